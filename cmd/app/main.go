@@ -13,15 +13,30 @@ import (
 	"github.com/bpva/ad-marketplace/internal/http/app"
 	"github.com/bpva/ad-marketplace/internal/http/dbgserver"
 	"github.com/bpva/ad-marketplace/internal/logx"
+	campaign_repo "github.com/bpva/ad-marketplace/internal/repository/campaign"
 	channel_repo "github.com/bpva/ad-marketplace/internal/repository/channel"
 	deal_repo "github.com/bpva/ad-marketplace/internal/repository/deal"
+	dispute_repo "github.com/bpva/ad-marketplace/internal/repository/dispute"
+	event_repo "github.com/bpva/ad-marketplace/internal/repository/event"
+	ledger_repo "github.com/bpva/ad-marketplace/internal/repository/ledger"
+	message_repo "github.com/bpva/ad-marketplace/internal/repository/message"
+	offer_repo "github.com/bpva/ad-marketplace/internal/repository/offer"
+	outbox_repo "github.com/bpva/ad-marketplace/internal/repository/outbox"
 	post_repo "github.com/bpva/ad-marketplace/internal/repository/post"
+	promotion_repo "github.com/bpva/ad-marketplace/internal/repository/promotion"
+	rating_repo "github.com/bpva/ad-marketplace/internal/repository/rating"
+	reschedule_repo "github.com/bpva/ad-marketplace/internal/repository/reschedule"
+	revision_repo "github.com/bpva/ad-marketplace/internal/repository/revision"
 	settings_repo "github.com/bpva/ad-marketplace/internal/repository/settings"
+	snapshot_repo "github.com/bpva/ad-marketplace/internal/repository/snapshot"
+	transfer_repo "github.com/bpva/ad-marketplace/internal/repository/transfer"
 	user_repo "github.com/bpva/ad-marketplace/internal/repository/user"
 	"github.com/bpva/ad-marketplace/internal/service/auth"
 	"github.com/bpva/ad-marketplace/internal/service/bot"
+	campaign_service "github.com/bpva/ad-marketplace/internal/service/campaign"
 	channel_service "github.com/bpva/ad-marketplace/internal/service/channel"
 	deal_service "github.com/bpva/ad-marketplace/internal/service/deal"
+	"github.com/bpva/ad-marketplace/internal/service/escrow"
 	post_service "github.com/bpva/ad-marketplace/internal/service/post"
 	"github.com/bpva/ad-marketplace/internal/service/stats"
 	"github.com/bpva/ad-marketplace/internal/service/tonrates"
@@ -81,6 +96,27 @@ func main() {
 
 	statsSvc := stats.New(mtprotoClient, channelRepo, log)
 
+	dealRepo := deal_repo.New(db)
+	transferRepo := transfer_repo.New(db)
+	outboxRepo := outbox_repo.New(db)
+	revisionRepo := revision_repo.New(db)
+	eventRepo := event_repo.New(db)
+	messageRepo := message_repo.New(db)
+	offerRepo := offer_repo.New(db)
+	rescheduleRepo := reschedule_repo.New(db)
+	disputeRepo := dispute_repo.New(db)
+	snapshotRepo := snapshot_repo.New(db)
+	ratingRepo := rating_repo.New(db)
+	promotionRepo := promotion_repo.New(db)
+	ledgerRepo := ledger_repo.New(db)
+	escrowWallet := escrow.NewWallet(cfg.TON.EscrowWalletAddress)
+	dealSvc := deal_service.New(
+		cfg.Deal,
+		dealRepo, channelRepo, postRepo, userRepo, transferRepo, outboxRepo, revisionRepo,
+		eventRepo, messageRepo, offerRepo, rescheduleRepo, disputeRepo, snapshotRepo,
+		ratingRepo, promotionRepo, ledgerRepo, db, escrowWallet, log,
+	)
+
 	botSvc := bot.New(
 		telebotClient,
 		cfg.Telegram,
@@ -90,6 +126,8 @@ func main() {
 		userRepo,
 		statsSvc,
 		postRepo,
+		messageRepo,
+		dealSvc,
 	)
 
 	if cfg.Env == "prod" {
@@ -101,14 +139,19 @@ func main() {
 		log.Warn("bot will not receive updates")
 	}
 
-	channelSvc := channel_service.New(channelRepo, userRepo, telebotClient, db, log)
+	channelSvc := channel_service.New(channelRepo, dealRepo, userRepo, telebotClient, db, log)
 	userSvc := user_service.New(userRepo, settingsRepo, log)
 	postSvc := post_service.New(postRepo, telebotClient, log)
 	tonRatesSvc := tonrates.New(log)
-	dealRepo := deal_repo.New(db)
-	dealSvc := deal_service.New(dealRepo, channelRepo, postRepo, userRepo, db, log)
+	campaignRepo := campaign_repo.New(db)
+	campaignSvc := campaign_service.New(
+		campaignRepo, dealRepo, channelRepo, postRepo, dealSvc, db, log,
+	)
 
-	a := app.New(cfg.HTTP, log, botSvc, authSvc, channelSvc, userSvc, postSvc, tonRatesSvc, dealSvc)
+	a := app.New(
+		cfg.HTTP, log, botSvc, authSvc, channelSvc, userSvc, postSvc, tonRatesSvc, dealSvc,
+		campaignSvc,
+	)
 
 	go func() {
 		if err := a.Serve(); err != nil {
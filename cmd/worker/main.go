@@ -8,8 +8,38 @@ import (
 	"syscall"
 
 	"github.com/bpva/ad-marketplace/internal/config"
+	"github.com/bpva/ad-marketplace/internal/gateway/mtproto"
+	"github.com/bpva/ad-marketplace/internal/gateway/telebot"
+	"github.com/bpva/ad-marketplace/internal/gateway/ton"
 	"github.com/bpva/ad-marketplace/internal/logx"
+	channel_repo "github.com/bpva/ad-marketplace/internal/repository/channel"
+	cursor_repo "github.com/bpva/ad-marketplace/internal/repository/cursor"
+	deal_repo "github.com/bpva/ad-marketplace/internal/repository/deal"
+	dispute_repo "github.com/bpva/ad-marketplace/internal/repository/dispute"
+	event_repo "github.com/bpva/ad-marketplace/internal/repository/event"
+	ledger_repo "github.com/bpva/ad-marketplace/internal/repository/ledger"
+	message_repo "github.com/bpva/ad-marketplace/internal/repository/message"
+	offer_repo "github.com/bpva/ad-marketplace/internal/repository/offer"
+	outbox_repo "github.com/bpva/ad-marketplace/internal/repository/outbox"
+	post_repo "github.com/bpva/ad-marketplace/internal/repository/post"
+	promotion_repo "github.com/bpva/ad-marketplace/internal/repository/promotion"
+	rating_repo "github.com/bpva/ad-marketplace/internal/repository/rating"
+	reschedule_repo "github.com/bpva/ad-marketplace/internal/repository/reschedule"
+	revision_repo "github.com/bpva/ad-marketplace/internal/repository/revision"
+	settings_repo "github.com/bpva/ad-marketplace/internal/repository/settings"
+	snapshot_repo "github.com/bpva/ad-marketplace/internal/repository/snapshot"
+	transfer_repo "github.com/bpva/ad-marketplace/internal/repository/transfer"
+	user_repo "github.com/bpva/ad-marketplace/internal/repository/user"
+	deal_service "github.com/bpva/ad-marketplace/internal/service/deal"
+	"github.com/bpva/ad-marketplace/internal/service/escrow"
+	"github.com/bpva/ad-marketplace/internal/service/messaging"
+	"github.com/bpva/ad-marketplace/internal/service/notification"
+	post_service "github.com/bpva/ad-marketplace/internal/service/post"
+	"github.com/bpva/ad-marketplace/internal/service/publisher"
+	"github.com/bpva/ad-marketplace/internal/service/sla"
+	"github.com/bpva/ad-marketplace/internal/service/verifier"
 	"github.com/bpva/ad-marketplace/internal/storage"
+	"github.com/bpva/ad-marketplace/internal/worker"
 )
 
 func main() {
@@ -35,9 +65,82 @@ func main() {
 	}
 	defer db.Close()
 
+	telebotClient, err := telebot.New(cfg.Telegram.BotToken, log)
+	if err != nil {
+		log.Error("failed to create telebot client", "error", err)
+		os.Exit(1)
+	}
+
+	mtprotoClient, err := mtproto.New(ctx, cfg.Telegram, log)
+	if err != nil {
+		log.Error("failed to create mtproto client", "error", err)
+		os.Exit(1)
+	}
+
+	tonClient, err := ton.New(cfg.TON, log)
+	if err != nil {
+		log.Error("failed to create ton client", "error", err)
+		os.Exit(1)
+	}
+
+	dealRepo := deal_repo.New(db)
+	channelRepo := channel_repo.New(db)
+	postRepo := post_repo.New(db)
+	userRepo := user_repo.New(db)
+	settingsRepo := settings_repo.New(db)
+
+	transferRepo := transfer_repo.New(db)
+	outboxRepo := outbox_repo.New(db)
+	revisionRepo := revision_repo.New(db)
+	eventRepo := event_repo.New(db)
+	messageRepo := message_repo.New(db)
+	offerRepo := offer_repo.New(db)
+	rescheduleRepo := reschedule_repo.New(db)
+	disputeRepo := dispute_repo.New(db)
+	snapshotRepo := snapshot_repo.New(db)
+	ratingRepo := rating_repo.New(db)
+	promotionRepo := promotion_repo.New(db)
+	ledgerRepo := ledger_repo.New(db)
+	cursorRepo := cursor_repo.New(db)
+	escrowWallet := escrow.NewWallet(cfg.TON.EscrowWalletAddress)
+	dealSvc := deal_service.New(
+		cfg.Deal,
+		dealRepo, channelRepo, postRepo, userRepo, transferRepo, outboxRepo, revisionRepo,
+		eventRepo, messageRepo, offerRepo, rescheduleRepo, disputeRepo, snapshotRepo,
+		ratingRepo, promotionRepo, ledgerRepo, db, escrowWallet, log,
+	)
+	notificationSvc := notification.New(userRepo, settingsRepo, telebotClient, log)
+	escrowSvc := escrow.New(
+		cfg.TON,
+		dealRepo, channelRepo, transferRepo, outboxRepo, cursorRepo, ledgerRepo,
+		dealSvc, notificationSvc, tonClient, db, log,
+	)
+	postSvc := post_service.New(postRepo, telebotClient, log)
+	publisherSvc := publisher.New(
+		dealRepo, channelRepo, postRepo, postSvc, telebotClient, telebotClient, dealSvc, db, log,
+	)
+	verifierSvc := verifier.New(dealRepo, channelRepo, snapshotRepo, mtprotoClient, dealSvc, log)
+	slaSvc := sla.New(cfg.Deal, dealRepo, revisionRepo, dealSvc, notificationSvc, log)
+	messagingSvc := messaging.New(
+		cfg.Telegram, messageRepo, dealRepo, channelRepo, userRepo, settingsRepo, telebotClient,
+		log,
+	)
+
+	w := worker.New(log)
+	w.Every("payments", cfg.TON.PollInterval, escrowSvc.CheckPayments)
+	w.Every("transfers", cfg.TON.PollInterval, escrowSvc.ProcessTransfers)
+	w.Every("publish", cfg.Worker.Interval, publisherSvc.PublishDue)
+	w.Every("pins", cfg.Worker.Interval, publisherSvc.UpdatePins)
+	w.Every("delete", cfg.Worker.Interval, publisherSvc.DeleteExpired)
+	w.Every("verify", cfg.Worker.VerifyInterval, verifierSvc.VerifyPosted)
+	w.Every("reviews", cfg.Worker.Interval, slaSvc.ExpireReviews)
+	w.Every("messages", cfg.Worker.Interval, messagingSvc.RelayMessages)
+	w.Every("marketplace", cfg.Worker.MarketplaceInterval, channelRepo.RefreshMV)
+	w.Every("ledger", cfg.Worker.LedgerInterval, escrowSvc.ReconcileLedger)
+
 	log.Info("worker started")
 
-	<-ctx.Done()
+	w.Run(ctx)
 	log.Info("shutdown signal received")
 
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
@@ -54,6 +54,171 @@ const docTemplate = `{
                 }
             }
         },
+        "/campaigns": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "campaigns"
+                ],
+                "summary": "List campaigns",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/CampaignsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "campaigns"
+                ],
+                "summary": "Create campaign",
+                "parameters": [
+                    {
+                        "description": "Campaign",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateCampaignRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/CampaignResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/campaigns/{campaignID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "campaigns"
+                ],
+                "summary": "Get campaign",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Campaign ID",
+                        "name": "campaignID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/CampaignResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/channels": {
             "get": {
                 "security": [
@@ -368,20 +533,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/channels/{TgChannelID}/categories": {
-            "patch": {
+        "/channels/{TgChannelID}/availability": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "channels"
                 ],
-                "summary": "Update channel categories",
+                "summary": "Get channel availability",
                 "parameters": [
                     {
                         "type": "integer",
@@ -391,18 +556,25 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Categories",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/UpdateCategoriesRequest"
-                        }
+                        "type": "string",
+                        "description": "First day, YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 14,
+                        "description": "Number of days",
+                        "name": "days",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ChannelAvailabilityResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -429,10 +601,8 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/channels/{TgChannelID}/listing": {
-            "patch": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -444,7 +614,7 @@ const docTemplate = `{
                 "tags": [
                     "channels"
                 ],
-                "summary": "Update channel listing",
+                "summary": "Update channel availability",
                 "parameters": [
                     {
                         "type": "integer",
@@ -454,12 +624,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Listing status",
+                        "description": "Inventory",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/UpdateListingRequest"
+                            "$ref": "#/definitions/UpdateAvailabilityRequest"
                         }
                     }
                 ],
@@ -490,30 +660,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
-                    },
-                    "422": {
-                        "description": "Unprocessable Entity",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/channels/{TgChannelID}/managers": {
-            "get": {
+        "/channels/{TgChannelID}/categories": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "produces": [
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
                     "channels"
                 ],
-                "summary": "Get channel managers",
+                "summary": "Update channel categories",
                 "parameters": [
                     {
                         "type": "integer",
@@ -521,14 +685,20 @@ const docTemplate = `{
                         "name": "TgChannelID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Categories",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateCategoriesRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/ChannelManagersResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -542,6 +712,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -549,8 +725,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/channels/{TgChannelID}/listing": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -562,7 +740,7 @@ const docTemplate = `{
                 "tags": [
                     "channels"
                 ],
-                "summary": "Add channel manager",
+                "summary": "Update channel listing",
                 "parameters": [
                     {
                         "type": "integer",
@@ -572,12 +750,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Manager telegram ID",
+                        "description": "Listing status",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/AddManagerRequest"
+                            "$ref": "#/definitions/UpdateListingRequest"
                         }
                     }
                 ],
@@ -602,21 +780,36 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/channels/{TgChannelID}/managers/{tgID}": {
-            "delete": {
+        "/channels/{TgChannelID}/managers": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "channels"
                 ],
-                "summary": "Remove channel manager",
+                "summary": "Get channel managers",
                 "parameters": [
                     {
                         "type": "integer",
@@ -624,9 +817,112 @@ const docTemplate = `{
                         "name": "TgChannelID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ChannelManagersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Add channel manager",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Telegram channel ID",
+                        "name": "TgChannelID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Manager telegram ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/AddManagerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/channels/{TgChannelID}/managers/{tgID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Remove channel manager",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Telegram channel ID",
+                        "name": "TgChannelID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
                         "description": "Manager telegram ID",
                         "name": "tgID",
                         "in": "path",
@@ -664,6 +960,69 @@ const docTemplate = `{
                 }
             }
         },
+        "/channels/{TgChannelID}/review-timeout": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "channels"
+                ],
+                "summary": "Update channel review timeout",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Telegram channel ID",
+                        "name": "TgChannelID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review timeout",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateReviewTimeoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/deals": {
             "get": {
                 "security": [
@@ -694,8 +1053,80 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
+                        "description": "Advertiser Telegram ID",
+                        "name": "advertiser_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated statuses",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Scheduled from (RFC 3339)",
+                        "name": "scheduled_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Scheduled to (RFC 3339)",
+                        "name": "scheduled_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ad format",
+                        "name": "format_type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "scheduled_at",
+                            "price"
+                        ],
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "Sort key",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Sort order",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count matching deals across all pages",
+                        "name": "include_total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Deprecated: page number, use cursor",
                         "name": "page",
                         "in": "query"
                     }
@@ -864,6 +1295,9 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
                     "deals"
                 ],
@@ -875,6 +1309,14 @@ const docTemplate = `{
                         "name": "dealID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Version being approved",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/ApproveRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -959,20 +1401,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/deals/{dealID}/reject": {
-            "post": {
+        "/deals/{dealID}/dispute": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "deals"
+                    "disputes"
                 ],
-                "summary": "Reject deal",
+                "summary": "Get deal dispute",
                 "parameters": [
                     {
                         "type": "string",
@@ -980,19 +1422,14 @@ const docTemplate = `{
                         "name": "dealID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Rejection reason",
-                        "name": "request",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/RejectRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/DisputeResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1021,7 +1458,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/deals/{dealID}/request-changes": {
+        "/deals/{dealID}/dispute/evidence": {
             "post": {
                 "security": [
                     {
@@ -1031,10 +1468,13 @@ const docTemplate = `{
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "deals"
+                    "disputes"
                 ],
-                "summary": "Request changes on deal",
+                "summary": "Submit dispute evidence",
                 "parameters": [
                     {
                         "type": "string",
@@ -1044,18 +1484,21 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Details",
+                        "description": "Evidence",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/RequestChangesRequest"
+                            "$ref": "#/definitions/SubmitEvidenceRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/DisputeEvidenceResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1084,25 +1527,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/me": {
-            "get": {
+        "/deals/{dealID}/dispute/resolve": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "produces": [
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "disputes"
                 ],
-                "summary": "Get current user",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                "summary": "Resolve deal dispute",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Deal ID",
+                        "name": "dealID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ruling",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/UserResponse"
+                            "$ref": "#/definitions/ResolveDisputeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
                         }
                     },
                     "401": {
@@ -1110,43 +1574,56 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/mp/channels": {
-            "post": {
+        "/deals/{dealID}/events": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "marketplace"
+                    "deals"
                 ],
-                "summary": "List marketplace channels",
+                "summary": "List deal events",
                 "parameters": [
                     {
-                        "description": "Search/sort/filter params",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/MarketplaceChannelsRequest"
-                        }
+                        "type": "string",
+                        "description": "Deal ID",
+                        "name": "dealID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/MarketplaceChannelsResponse"
+                            "$ref": "#/definitions/DealEventsResponse"
                         }
                     },
                     "400": {
@@ -1160,11 +1637,23 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/posts": {
+        "/deals/{dealID}/ledger": {
             "get": {
                 "security": [
                     {
@@ -1175,14 +1664,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "posts"
+                    "ledger"
+                ],
+                "summary": "Get deal ledger",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Deal ID",
+                        "name": "dealID",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List templates",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/TemplatesResponse"
+                            "$ref": "#/definitions/DealLedgerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
                         }
                     },
                     "401": {
@@ -1190,33 +1694,51 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/posts/{postID}/preview": {
-            "post": {
+        "/deals/{dealID}/messages": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "posts"
+                    "deals"
                 ],
-                "summary": "Send template preview to chat",
+                "summary": "List deal messages",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Post ID",
-                        "name": "postID",
+                        "description": "Deal ID",
+                        "name": "dealID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/DealMessagesResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1243,35 +1765,8 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/ton-rates": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "rates"
-                ],
-                "summary": "Get TON exchange rates",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/TonRatesResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/user/name": {
-            "patch": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -1280,24 +1775,37 @@ const docTemplate = `{
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "user"
+                    "deals"
                 ],
-                "summary": "Update user name",
+                "summary": "Post deal message",
                 "parameters": [
                     {
-                        "description": "New name",
+                        "type": "string",
+                        "description": "Deal ID",
+                        "name": "dealID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Message",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/UpdateNameRequest"
+                            "$ref": "#/definitions/PostMessageRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/DealMessageResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1310,11 +1818,23 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/user/profile": {
+        "/deals/{dealID}/offers": {
             "get": {
                 "security": [
                     {
@@ -1325,14 +1845,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "deals"
+                ],
+                "summary": "List deal offers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Deal ID",
+                        "name": "dealID",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get user profile",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ProfileResponse"
+                            "$ref": "#/definitions/DealOffersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
                         }
                     },
                     "401": {
@@ -1340,12 +1875,22 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/user/settings": {
-            "patch": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -1354,24 +1899,37 @@ const docTemplate = `{
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "user"
+                    "deals"
                 ],
-                "summary": "Update user settings",
+                "summary": "Counter deal offer",
                 "parameters": [
                     {
-                        "description": "Settings to update",
+                        "type": "string",
+                        "description": "Deal ID",
+                        "name": "dealID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Proposed terms",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/UpdateSettingsRequest"
+                            "$ref": "#/definitions/CounterOfferRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/DealOfferResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1384,12 +1942,81 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/user/wallet": {
-            "put": {
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/deals/{dealID}/offers/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "deals"
+                ],
+                "summary": "Accept deal offer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Deal ID",
+                        "name": "dealID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/deals/{dealID}/offers/decline": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -1399,17 +2026,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "deals"
                 ],
-                "summary": "Link TON wallet",
+                "summary": "Decline deal offer",
                 "parameters": [
                     {
-                        "description": "Wallet address",
+                        "type": "string",
+                        "description": "Deal ID",
+                        "name": "dealID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/LinkWalletRequest"
+                            "$ref": "#/definitions/DeclineOfferRequest"
                         }
                     }
                 ],
@@ -1428,116 +2061,1967 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/deals/{dealID}/ratings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deals"
+                ],
+                "summary": "List deal ratings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Deal ID",
+                        "name": "dealID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/DealRatingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "user"
+                    "deals"
+                ],
+                "summary": "Rate deal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Deal ID",
+                        "name": "dealID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rating",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RateDealRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/DealRatingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/deals/{dealID}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deals"
+                ],
+                "summary": "Reject deal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Deal ID",
+                        "name": "dealID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rejection reason",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/RejectRequest"
+                        }
+                    }
                 ],
-                "summary": "Unlink TON wallet",
                 "responses": {
                     "204": {
                         "description": "No Content"
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
-                    }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/deals/{dealID}/report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deals"
+                ],
+                "summary": "Get deal report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Deal ID",
+                        "name": "dealID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/DealReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/deals/{dealID}/request-changes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deals"
+                ],
+                "summary": "Request changes on deal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Deal ID",
+                        "name": "dealID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RequestChangesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/deals/{dealID}/reschedules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deals"
+                ],
+                "summary": "List deal reschedules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Deal ID",
+                        "name": "dealID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/DealReschedulesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deals"
+                ],
+                "summary": "Request deal reschedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Deal ID",
+                        "name": "dealID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New posting time",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RescheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/DealRescheduleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/deals/{dealID}/reschedules/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "deals"
+                ],
+                "summary": "Accept deal reschedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Deal ID",
+                        "name": "dealID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/deals/{dealID}/reschedules/decline": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deals"
+                ],
+                "summary": "Decline deal reschedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Deal ID",
+                        "name": "dealID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/DeclineRescheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/deals/{dealID}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deals"
+                ],
+                "summary": "List ad revisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Deal ID",
+                        "name": "dealID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/RevisionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deals"
+                ],
+                "summary": "Submit ad revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Deal ID",
+                        "name": "dealID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New creative",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SubmitRevisionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/RevisionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/deals/{dealID}/revisions/diff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deals"
+                ],
+                "summary": "Diff ad revisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Deal ID",
+                        "name": "dealID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Older version (default: to - 1)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Newer version (default: latest)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/RevisionDiffResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/disputes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "disputes"
+                ],
+                "summary": "List open disputes",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/DisputesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fees/channels/{TgChannelID}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fees"
+                ],
+                "summary": "Set channel platform fee",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Telegram channel ID",
+                        "name": "TgChannelID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Platform fee",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SetChannelFeeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fees/promotions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fees"
+                ],
+                "summary": "List fee promotions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PromotionsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fees"
+                ],
+                "summary": "Create fee promotion",
+                "parameters": [
+                    {
+                        "description": "Promotion",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreatePromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/PromotionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fees/promotions/{promotionID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "fees"
+                ],
+                "summary": "Delete fee promotion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promotion ID",
+                        "name": "promotionID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ledger/balances": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ledger"
+                ],
+                "summary": "Get ledger balances",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID, admins only",
+                        "name": "user_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/LedgerBalancesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Get current user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/UserResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/mp/channels": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "marketplace"
+                ],
+                "summary": "List marketplace channels",
+                "parameters": [
+                    {
+                        "description": "Search/sort/filter params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/MarketplaceChannelsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/MarketplaceChannelsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "List templates",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/TemplatesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{postID}/preview": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "posts"
+                ],
+                "summary": "Send template preview to chat",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ton-rates": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rates"
+                ],
+                "summary": "Get TON exchange rates",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/TonRatesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/name": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update user name",
+                "parameters": [
+                    {
+                        "description": "New name",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateNameRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get user profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ProfileResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update user settings",
+                "parameters": [
+                    {
+                        "description": "Settings to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateSettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/wallet": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Link TON wallet",
+                "parameters": [
+                    {
+                        "description": "Wallet address",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/LinkWalletRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Unlink TON wallet",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "AdFormat": {
+            "type": "object",
+            "properties": {
+                "auto_delete": {
+                    "type": "boolean"
+                },
+                "feed_hours": {
+                    "type": "integer"
+                },
+                "format_type": {
+                    "$ref": "#/definitions/AdFormatType"
+                },
+                "is_native": {
+                    "type": "boolean"
+                },
+                "price_nano_ton": {
+                    "type": "integer"
+                },
+                "top_hours": {
+                    "type": "integer"
+                }
+            }
+        },
+        "AdFormatResponse": {
+            "type": "object",
+            "properties": {
+                "auto_delete": {
+                    "type": "boolean"
+                },
+                "feed_hours": {
+                    "type": "integer"
+                },
+                "format_type": {
+                    "$ref": "#/definitions/AdFormatType"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_native": {
+                    "type": "boolean"
+                },
+                "price_nano_ton": {
+                    "type": "integer"
+                },
+                "top_hours": {
+                    "type": "integer"
+                }
+            }
+        },
+        "AdFormatType": {
+            "type": "string",
+            "enum": [
+                "post",
+                "repost",
+                "story"
+            ],
+            "x-enum-varnames": [
+                "AdFormatTypePost",
+                "AdFormatTypeRepost",
+                "AdFormatTypeStory"
+            ]
+        },
+        "AdFormatsResponse": {
+            "type": "object",
+            "properties": {
+                "ad_formats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/AdFormatResponse"
+                    }
+                }
+            }
+        },
+        "AddAdFormatRequest": {
+            "type": "object",
+            "required": [
+                "feed_hours",
+                "format_type",
+                "price_nano_ton",
+                "top_hours"
+            ],
+            "properties": {
+                "auto_delete": {
+                    "type": "boolean"
+                },
+                "feed_hours": {
+                    "type": "integer",
+                    "enum": [
+                        12,
+                        24
+                    ]
+                },
+                "format_type": {
+                    "$ref": "#/definitions/AdFormatType"
+                },
+                "is_native": {
+                    "type": "boolean"
+                },
+                "price_nano_ton": {
+                    "type": "integer"
+                },
+                "top_hours": {
+                    "type": "integer",
+                    "enum": [
+                        2,
+                        4
+                    ]
+                }
+            }
+        },
+        "AddManagerRequest": {
+            "type": "object",
+            "required": [
+                "telegram_id"
+            ],
+            "properties": {
+                "telegram_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ApproveRequest": {
+            "type": "object",
+            "properties": {
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "AuthRequest": {
+            "type": "object",
+            "properties": {
+                "init_data": {
+                    "type": "string"
+                }
+            }
+        },
+        "AuthResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/UserResponse"
+                }
+            }
+        },
+        "AvailabilityDay": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "boolean"
+                },
+                "booked": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                }
+            }
+        },
+        "BookedSlotResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "CampaignResponse": {
+            "type": "object",
+            "properties": {
+                "budget_nano_ton": {
+                    "type": "integer"
+                },
+                "committed_nano_ton": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/DealResponse"
+                    }
+                },
+                "deals_by_status": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "reach": {
+                    "type": "integer"
+                },
+                "spent_nano_ton": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/CampaignStatus"
+                },
+                "template_post_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "CampaignStatus": {
+            "type": "string",
+            "enum": [
+                "active",
+                "finished"
+            ],
+            "x-enum-varnames": [
+                "CampaignStatusActive",
+                "CampaignStatusFinished"
+            ]
+        },
+        "CampaignTarget": {
+            "type": "object",
+            "required": [
+                "channel_id",
+                "feed_hours",
+                "format_type",
+                "price_nano_ton",
+                "scheduled_at",
+                "top_hours"
+            ],
+            "properties": {
+                "channel_id": {
+                    "type": "integer"
+                },
+                "feed_hours": {
+                    "type": "integer"
+                },
+                "format_type": {
+                    "$ref": "#/definitions/AdFormatType"
+                },
+                "is_native": {
+                    "type": "boolean"
+                },
+                "offer": {
+                    "type": "boolean"
+                },
+                "price_nano_ton": {
+                    "type": "integer"
+                },
+                "scheduled_at": {
+                    "type": "string"
+                },
+                "top_hours": {
+                    "type": "integer"
+                }
+            }
+        },
+        "CampaignsResponse": {
+            "type": "object",
+            "properties": {
+                "campaigns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CampaignResponse"
+                    }
+                }
+            }
+        },
+        "CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "display_name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "ChannelAdmin": {
+            "type": "object",
+            "properties": {
+                "first_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "telegram_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "ChannelAdminsResponse": {
+            "type": "object",
+            "properties": {
+                "admins": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ChannelAdmin"
+                    }
+                }
+            }
+        },
+        "ChannelAvailabilityResponse": {
+            "type": "object",
+            "properties": {
+                "blackout_dates": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "booked": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/BookedSlotResponse"
+                    }
+                },
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/AvailabilityDay"
+                    }
+                },
+                "max_ads_per_day": {
+                    "type": "integer"
+                },
+                "posting_windows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PostingWindow"
+                    }
+                }
+            }
+        },
+        "ChannelManagersResponse": {
+            "type": "object",
+            "properties": {
+                "managers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ManagerResponse"
+                    }
+                }
+            }
+        },
+        "ChannelResponse": {
+            "type": "object",
+            "properties": {
+                "ad_formats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/AdFormatResponse"
+                    }
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CategoryResponse"
+                    }
+                },
+                "has_stats": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_listed": {
+                    "type": "boolean"
+                },
+                "payout_address": {
+                    "type": "string"
+                },
+                "photo_big_url": {
+                    "type": "string"
+                },
+                "photo_small_url": {
+                    "type": "string"
+                },
+                "review_timeout_hours": {
+                    "description": "nil means the platform default applies",
+                    "type": "integer"
+                },
+                "subscribers": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "ChannelRoleType": {
+            "type": "string",
+            "enum": [
+                "undefined",
+                "owner",
+                "manager"
+            ],
+            "x-enum-varnames": [
+                "ChannelRoleTypeUndefined",
+                "ChannelRoleTypeOwner",
+                "ChannelRoleTypeManager"
+            ]
+        },
+        "ChannelSortBy": {
+            "type": "string",
+            "enum": [
+                "subscribers",
+                "views",
+                "rating",
+                "completed_deals"
+            ],
+            "x-enum-varnames": [
+                "ChannelSortBySubscribers",
+                "ChannelSortByViews",
+                "ChannelSortByRating",
+                "ChannelSortByCompletedDeals"
+            ]
+        },
+        "ChannelWithRoleResponse": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "$ref": "#/definitions/ChannelResponse"
+                },
+                "role": {
+                    "$ref": "#/definitions/ChannelRoleType"
                 }
             }
-        }
-    },
-    "definitions": {
-        "AdFormat": {
+        },
+        "ChannelsResponse": {
             "type": "object",
             "properties": {
-                "feed_hours": {
-                    "type": "integer"
-                },
-                "format_type": {
-                    "$ref": "#/definitions/AdFormatType"
-                },
-                "is_native": {
-                    "type": "boolean"
-                },
-                "price_nano_ton": {
-                    "type": "integer"
-                },
-                "top_hours": {
-                    "type": "integer"
+                "channels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ChannelWithRoleResponse"
+                    }
                 }
             }
         },
-        "AdFormatResponse": {
+        "CounterOfferRequest": {
             "type": "object",
+            "required": [
+                "price_nano_ton",
+                "scheduled_at"
+            ],
             "properties": {
-                "feed_hours": {
-                    "type": "integer"
-                },
-                "format_type": {
-                    "$ref": "#/definitions/AdFormatType"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "is_native": {
-                    "type": "boolean"
-                },
                 "price_nano_ton": {
                     "type": "integer"
                 },
-                "top_hours": {
-                    "type": "integer"
+                "scheduled_at": {
+                    "type": "string"
                 }
             }
         },
-        "AdFormatType": {
-            "type": "string",
-            "enum": [
-                "post",
-                "repost",
-                "story"
-            ],
-            "x-enum-varnames": [
-                "AdFormatTypePost",
-                "AdFormatTypeRepost",
-                "AdFormatTypeStory"
-            ]
-        },
-        "AdFormatsResponse": {
+        "CreateCampaignRequest": {
             "type": "object",
+            "required": [
+                "budget_nano_ton",
+                "name",
+                "targets",
+                "template_post_id"
+            ],
             "properties": {
-                "ad_formats": {
+                "budget_nano_ton": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "targets": {
                     "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
                     "items": {
-                        "$ref": "#/definitions/AdFormatResponse"
+                        "$ref": "#/definitions/CampaignTarget"
                     }
+                },
+                "template_post_id": {
+                    "type": "string"
                 }
             }
         },
-        "AddAdFormatRequest": {
+        "CreateDealRequest": {
             "type": "object",
             "required": [
+                "channel_id",
                 "feed_hours",
                 "format_type",
                 "price_nano_ton",
+                "scheduled_at",
+                "template_post_id",
                 "top_hours"
             ],
             "properties": {
+                "channel_id": {
+                    "type": "integer"
+                },
                 "feed_hours": {
-                    "type": "integer",
-                    "enum": [
-                        12,
-                        24
-                    ]
+                    "type": "integer"
                 },
                 "format_type": {
                     "$ref": "#/definitions/AdFormatType"
@@ -1545,223 +4029,388 @@ const docTemplate = `{
                 "is_native": {
                     "type": "boolean"
                 },
+                "offer": {
+                    "description": "Offer proposes price_nano_ton and scheduled_at to the publisher instead\nof requiring the listed price; the deal starts out negotiating.",
+                    "type": "boolean"
+                },
                 "price_nano_ton": {
                     "type": "integer"
                 },
+                "scheduled_at": {
+                    "type": "string"
+                },
+                "template_post_id": {
+                    "type": "string"
+                },
                 "top_hours": {
-                    "type": "integer",
-                    "enum": [
-                        2,
-                        4
-                    ]
+                    "type": "integer"
                 }
             }
         },
-        "AddManagerRequest": {
+        "CreatePromotionRequest": {
             "type": "object",
             "required": [
-                "telegram_id"
+                "ends_at",
+                "starts_at"
             ],
             "properties": {
-                "telegram_id": {
+                "channel_id": {
                     "type": "integer"
-                }
-            }
-        },
-        "AuthRequest": {
-            "type": "object",
-            "properties": {
-                "init_data": {
-                    "type": "string"
-                }
-            }
-        },
-        "AuthResponse": {
-            "type": "object",
-            "properties": {
-                "token": {
-                    "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/UserResponse"
-                }
-            }
-        },
-        "CategoryResponse": {
-            "type": "object",
-            "properties": {
-                "display_name": {
+                "ends_at": {
                     "type": "string"
                 },
-                "slug": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "starts_at": {
                     "type": "string"
                 }
             }
         },
-        "ChannelAdmin": {
+        "DealDisputeStatus": {
+            "type": "string",
+            "enum": [
+                "open",
+                "resolved"
+            ],
+            "x-enum-varnames": [
+                "DealDisputeStatusOpen",
+                "DealDisputeStatusResolved"
+            ]
+        },
+        "DealEventResponse": {
             "type": "object",
             "properties": {
-                "first_name": {
+                "actor_name": {
                     "type": "string"
                 },
-                "last_name": {
+                "actor_role": {
+                    "$ref": "#/definitions/DealParty"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "telegram_id": {
-                    "type": "integer"
+                "from_status": {
+                    "$ref": "#/definitions/DealStatus"
                 },
-                "username": {
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "note": {
                     "type": "string"
+                },
+                "to_status": {
+                    "$ref": "#/definitions/DealStatus"
+                },
+                "type": {
+                    "$ref": "#/definitions/DealEventType"
                 }
             }
         },
-        "ChannelAdminsResponse": {
+        "DealEventType": {
+            "type": "string",
+            "enum": [
+                "created",
+                "offer_made",
+                "offer_accepted",
+                "offer_declined",
+                "reschedule_requested",
+                "rescheduled",
+                "reschedule_declined",
+                "payment_received",
+                "payment_expired",
+                "late_payment_refunded",
+                "revision_submitted",
+                "revision_approved",
+                "changes_requested",
+                "rejected",
+                "cancelled",
+                "review_expired",
+                "posted",
+                "publish_failed",
+                "completed",
+                "disputed",
+                "evidence_submitted",
+                "dispute_resolved",
+                "rated"
+            ],
+            "x-enum-varnames": [
+                "DealEventCreated",
+                "DealEventOfferMade",
+                "DealEventOfferAccepted",
+                "DealEventOfferDeclined",
+                "DealEventRescheduleRequested",
+                "DealEventRescheduled",
+                "DealEventRescheduleDeclined",
+                "DealEventPaymentReceived",
+                "DealEventPaymentExpired",
+                "DealEventLatePaymentRefunded",
+                "DealEventRevisionSubmitted",
+                "DealEventRevisionApproved",
+                "DealEventChangesRequested",
+                "DealEventRejected",
+                "DealEventCancelled",
+                "DealEventReviewExpired",
+                "DealEventPosted",
+                "DealEventPublishFailed",
+                "DealEventCompleted",
+                "DealEventDisputed",
+                "DealEventEvidenceSubmitted",
+                "DealEventDisputeResolved",
+                "DealEventRated"
+            ]
+        },
+        "DealEventsResponse": {
             "type": "object",
             "properties": {
-                "admins": {
+                "events": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/ChannelAdmin"
+                        "$ref": "#/definitions/DealEventResponse"
                     }
                 }
             }
         },
-        "ChannelManagersResponse": {
+        "DealLedgerResponse": {
             "type": "object",
             "properties": {
-                "managers": {
+                "entries": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/ManagerResponse"
+                        "$ref": "#/definitions/LedgerEntryResponse"
                     }
+                },
+                "escrow_balance_nano_ton": {
+                    "type": "integer"
                 }
             }
         },
-        "ChannelResponse": {
+        "DealMessageResponse": {
             "type": "object",
             "properties": {
-                "ad_formats": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/AdFormatResponse"
-                    }
+                "author_name": {
+                    "type": "string"
                 },
-                "categories": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/CategoryResponse"
-                    }
+                "author_role": {
+                    "$ref": "#/definitions/DealParty"
                 },
-                "has_stats": {
-                    "type": "boolean"
+                "created_at": {
+                    "type": "string"
                 },
                 "id": {
-                    "type": "integer"
-                },
-                "is_listed": {
-                    "type": "boolean"
+                    "type": "string"
                 },
-                "payout_address": {
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "DealMessagesResponse": {
+            "type": "object",
+            "properties": {
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/DealMessageResponse"
+                    }
+                }
+            }
+        },
+        "DealOfferResponse": {
+            "type": "object",
+            "properties": {
+                "author_name": {
                     "type": "string"
                 },
-                "photo_big_url": {
+                "author_role": {
+                    "$ref": "#/definitions/DealParty"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "photo_small_url": {
+                "id": {
                     "type": "string"
                 },
-                "subscribers": {
+                "price_nano_ton": {
                     "type": "integer"
                 },
-                "title": {
+                "responded_at": {
                     "type": "string"
                 },
-                "username": {
+                "scheduled_at": {
                     "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/DealOfferStatus"
                 }
             }
         },
-        "ChannelRoleType": {
+        "DealOfferStatus": {
             "type": "string",
             "enum": [
-                "undefined",
-                "owner",
-                "manager"
+                "pending",
+                "accepted",
+                "declined",
+                "countered"
             ],
             "x-enum-varnames": [
-                "ChannelRoleTypeUndefined",
-                "ChannelRoleTypeOwner",
-                "ChannelRoleTypeManager"
+                "DealOfferStatusPending",
+                "DealOfferStatusAccepted",
+                "DealOfferStatusDeclined",
+                "DealOfferStatusCountered"
             ]
         },
-        "ChannelSortBy": {
+        "DealOffersResponse": {
+            "type": "object",
+            "properties": {
+                "offers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/DealOfferResponse"
+                    }
+                }
+            }
+        },
+        "DealParty": {
             "type": "string",
             "enum": [
-                "subscribers",
-                "views"
+                "advertiser",
+                "publisher",
+                "arbiter"
             ],
             "x-enum-varnames": [
-                "ChannelSortBySubscribers",
-                "ChannelSortByViews"
+                "DealPartyAdvertiser",
+                "DealPartyPublisher",
+                "DealPartyArbiter"
             ]
         },
-        "ChannelWithRoleResponse": {
+        "DealRatingResponse": {
             "type": "object",
             "properties": {
-                "channel": {
-                    "$ref": "#/definitions/ChannelResponse"
+                "author_name": {
+                    "type": "string"
                 },
-                "role": {
-                    "$ref": "#/definitions/ChannelRoleType"
+                "author_role": {
+                    "$ref": "#/definitions/DealParty"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer"
                 }
             }
         },
-        "ChannelsResponse": {
+        "DealRatingsResponse": {
             "type": "object",
             "properties": {
-                "channels": {
+                "ratings": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/ChannelWithRoleResponse"
+                        "$ref": "#/definitions/DealRatingResponse"
                     }
                 }
             }
         },
-        "CreateDealRequest": {
+        "DealReportResponse": {
             "type": "object",
-            "required": [
-                "channel_id",
-                "feed_hours",
-                "format_type",
-                "price_nano_ton",
-                "scheduled_at",
-                "template_post_id",
-                "top_hours"
-            ],
             "properties": {
-                "channel_id": {
+                "channel_avg_daily_views_7d": {
                     "type": "integer"
                 },
-                "feed_hours": {
+                "cpm_nano_ton": {
                     "type": "integer"
                 },
-                "format_type": {
-                    "$ref": "#/definitions/AdFormatType"
+                "curve": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/DealViewPoint"
+                    }
                 },
-                "is_native": {
-                    "type": "boolean"
+                "deal_id": {
+                    "type": "string"
+                },
+                "feed_ends_at": {
+                    "type": "string"
+                },
+                "forwards": {
+                    "type": "integer"
+                },
+                "posted_at": {
+                    "type": "string"
                 },
                 "price_nano_ton": {
                     "type": "integer"
                 },
-                "scheduled_at": {
+                "reactions": {
+                    "type": "integer"
+                },
+                "views": {
+                    "type": "integer"
+                },
+                "views_to_channel_avg": {
+                    "type": "number"
+                }
+            }
+        },
+        "DealRescheduleResponse": {
+            "type": "object",
+            "properties": {
+                "author_name": {
                     "type": "string"
                 },
-                "template_post_id": {
+                "author_role": {
+                    "$ref": "#/definitions/DealParty"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "top_hours": {
-                    "type": "integer"
+                "id": {
+                    "type": "string"
+                },
+                "responded_at": {
+                    "type": "string"
+                },
+                "scheduled_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/DealRescheduleStatus"
+                }
+            }
+        },
+        "DealRescheduleStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "accepted",
+                "declined",
+                "superseded"
+            ],
+            "x-enum-varnames": [
+                "DealRescheduleStatusPending",
+                "DealRescheduleStatusAccepted",
+                "DealRescheduleStatusDeclined",
+                "DealRescheduleStatusSuperseded"
+            ]
+        },
+        "DealReschedulesResponse": {
+            "type": "object",
+            "properties": {
+                "reschedules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/DealRescheduleResponse"
+                    }
                 }
             }
         },
@@ -1771,12 +4420,24 @@ const docTemplate = `{
                 "ad": {
                     "$ref": "#/definitions/TemplateResponse"
                 },
+                "auto_delete": {
+                    "type": "boolean"
+                },
+                "campaign_id": {
+                    "type": "string"
+                },
                 "channel_id": {
                     "type": "integer"
                 },
                 "created_at": {
                     "type": "string"
                 },
+                "delete_error": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
                 "feed_hours": {
                     "type": "integer"
                 },
@@ -1789,12 +4450,37 @@ const docTemplate = `{
                 "is_native": {
                     "type": "boolean"
                 },
+                "payment": {
+                    "$ref": "#/definitions/PaymentInstructions"
+                },
+                "payout": {
+                    "$ref": "#/definitions/TransferResponse"
+                },
+                "payout_error": {
+                    "type": "string"
+                },
+                "pinned_at": {
+                    "type": "string"
+                },
+                "platform_fee_bps": {
+                    "description": "platform fee withheld from the payout, frozen when the deal was created",
+                    "type": "integer"
+                },
+                "posted_at": {
+                    "type": "string"
+                },
                 "price_nano_ton": {
                     "type": "integer"
                 },
+                "publish_error": {
+                    "type": "string"
+                },
                 "publisher_note": {
                     "type": "string"
                 },
+                "refund": {
+                    "$ref": "#/definitions/TransferResponse"
+                },
                 "scheduled_at": {
                     "type": "string"
                 },
@@ -1803,12 +4489,16 @@ const docTemplate = `{
                 },
                 "top_hours": {
                     "type": "integer"
+                },
+                "unpinned_at": {
+                    "type": "string"
                 }
             }
         },
         "DealStatus": {
             "type": "string",
             "enum": [
+                "negotiating",
                 "pending_payment",
                 "hold_failed",
                 "pending_review",
@@ -1816,11 +4506,14 @@ const docTemplate = `{
                 "approved",
                 "rejected",
                 "cancelled",
+                "publish_failed",
                 "posted",
                 "completed",
-                "dispute"
+                "dispute",
+                "resolved"
             ],
             "x-enum-varnames": [
+                "DealStatusNegotiating",
                 "DealStatusPendingPayment",
                 "DealStatusHoldFailed",
                 "DealStatusPendingReview",
@@ -1828,11 +4521,30 @@ const docTemplate = `{
                 "DealStatusApproved",
                 "DealStatusRejected",
                 "DealStatusCancelled",
+                "DealStatusPublishFailed",
                 "DealStatusPosted",
                 "DealStatusCompleted",
-                "DealStatusDispute"
+                "DealStatusDispute",
+                "DealStatusResolved"
             ]
         },
+        "DealViewPoint": {
+            "type": "object",
+            "properties": {
+                "forwards": {
+                    "type": "integer"
+                },
+                "reactions": {
+                    "type": "integer"
+                },
+                "taken_at": {
+                    "type": "string"
+                },
+                "views": {
+                    "type": "integer"
+                }
+            }
+        },
         "DealsResponse": {
             "type": "object",
             "properties": {
@@ -1842,8 +4554,117 @@ const docTemplate = `{
                         "$ref": "#/definitions/DealResponse"
                     }
                 },
+                "next_cursor": {
+                    "description": "pass as cursor to fetch the next page; absent on the last one",
+                    "type": "string"
+                },
                 "total": {
+                    "description": "deals matching the filters across all pages; only with include_total\nor page",
+                    "type": "integer"
+                }
+            }
+        },
+        "DeclineOfferRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "DeclineRescheduleRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "DisputeEvidenceResponse": {
+            "type": "object",
+            "properties": {
+                "author_name": {
+                    "type": "string"
+                },
+                "author_role": {
+                    "$ref": "#/definitions/DealParty"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "file_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "message_link": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "DisputeOutcome": {
+            "type": "string",
+            "enum": [
+                "release",
+                "refund",
+                "split"
+            ],
+            "x-enum-varnames": [
+                "DisputeOutcomeRelease",
+                "DisputeOutcomeRefund",
+                "DisputeOutcomeSplit"
+            ]
+        },
+        "DisputeResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deal": {
+                    "$ref": "#/definitions/DealResponse"
+                },
+                "evidence": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/DisputeEvidenceResponse"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "outcome": {
+                    "$ref": "#/definitions/DisputeOutcome"
+                },
+                "publisher_share_nano_ton": {
                     "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "resolution_note": {
+                    "type": "string"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/DealDisputeStatus"
+                }
+            }
+        },
+        "DisputesResponse": {
+            "type": "object",
+            "properties": {
+                "disputes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/DisputeResponse"
+                    }
                 }
             }
         },
@@ -1881,6 +4702,81 @@ const docTemplate = `{
                 }
             }
         },
+        "LedgerAccount": {
+            "type": "string",
+            "enum": [
+                "escrow",
+                "advertiser",
+                "publisher",
+                "platform"
+            ],
+            "x-enum-varnames": [
+                "LedgerAccountEscrow",
+                "LedgerAccountAdvertiser",
+                "LedgerAccountPublisher",
+                "LedgerAccountPlatform"
+            ]
+        },
+        "LedgerBalanceResponse": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "$ref": "#/definitions/LedgerAccount"
+                },
+                "amount_nano_ton": {
+                    "type": "integer"
+                }
+            }
+        },
+        "LedgerBalancesResponse": {
+            "type": "object",
+            "properties": {
+                "balances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/LedgerBalanceResponse"
+                    }
+                }
+            }
+        },
+        "LedgerEntryKind": {
+            "type": "string",
+            "enum": [
+                "hold",
+                "release",
+                "fee",
+                "refund"
+            ],
+            "x-enum-varnames": [
+                "LedgerEntryHold",
+                "LedgerEntryRelease",
+                "LedgerEntryFee",
+                "LedgerEntryRefund"
+            ]
+        },
+        "LedgerEntryResponse": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "$ref": "#/definitions/LedgerAccount"
+                },
+                "amount_nano_ton": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/LedgerEntryKind"
+                },
+                "txn_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
         "LinkWalletRequest": {
             "type": "object",
             "required": [
@@ -1942,6 +4838,9 @@ const docTemplate = `{
                         "$ref": "#/definitions/CategoryResponse"
                     }
                 },
+                "completed_deals": {
+                    "type": "integer"
+                },
                 "engagement_rate_30d": {
                     "type": "number"
                 },
@@ -1961,6 +4860,13 @@ const docTemplate = `{
                 "photo_small_url": {
                     "type": "string"
                 },
+                "rating": {
+                    "description": "1–5, from ratings left by advertisers; unset until the first one",
+                    "type": "number"
+                },
+                "rating_count": {
+                    "type": "integer"
+                },
                 "reactions_by_emotion": {
                     "description": "Keys are unicode emoji (\"👍\"), custom will be mapped to standard too",
                     "type": "object",
@@ -2047,6 +4953,44 @@ const docTemplate = `{
                 "value": {}
             }
         },
+        "MediaChange": {
+            "type": "object",
+            "properties": {
+                "changed_flags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "from": {
+                    "$ref": "#/definitions/PostMediaItem"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "$ref": "#/definitions/MediaChangeKind"
+                },
+                "to": {
+                    "$ref": "#/definitions/PostMediaItem"
+                }
+            }
+        },
+        "MediaChangeKind": {
+            "type": "string",
+            "enum": [
+                "added",
+                "removed",
+                "replaced",
+                "updated"
+            ],
+            "x-enum-varnames": [
+                "MediaAdded",
+                "MediaRemoved",
+                "MediaReplaced",
+                "MediaUpdated"
+            ]
+        },
         "MediaType": {
             "type": "string",
             "enum": [
@@ -2070,6 +5014,26 @@ const docTemplate = `{
                 "MediaTypeSticker"
             ]
         },
+        "PaymentInstructions": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "amount_nano_ton": {
+                    "type": "integer"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "deep_link": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                }
+            }
+        },
         "PostMediaItem": {
             "type": "object",
             "properties": {
@@ -2087,6 +5051,32 @@ const docTemplate = `{
                 }
             }
         },
+        "PostMessageRequest": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "PostingWindow": {
+            "type": "object",
+            "required": [
+                "end",
+                "start"
+            ],
+            "properties": {
+                "end": {
+                    "type": "string"
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
+        },
         "PreferredMode": {
             "type": "string",
             "enum": [
@@ -2127,6 +5117,57 @@ const docTemplate = `{
                 }
             }
         },
+        "PromotionResponse": {
+            "type": "object",
+            "properties": {
+                "channel_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "PromotionsResponse": {
+            "type": "object",
+            "properties": {
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PromotionResponse"
+                    }
+                }
+            }
+        },
+        "RateDealRequest": {
+            "type": "object",
+            "required": [
+                "rating"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                }
+            }
+        },
         "RejectRequest": {
             "type": "object",
             "properties": {
@@ -2146,6 +5187,114 @@ const docTemplate = `{
                 }
             }
         },
+        "RescheduleRequest": {
+            "type": "object",
+            "required": [
+                "scheduled_at"
+            ],
+            "properties": {
+                "scheduled_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "ResolveDisputeRequest": {
+            "type": "object",
+            "required": [
+                "outcome"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "outcome": {
+                    "enum": [
+                        "release",
+                        "refund",
+                        "split"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/DisputeOutcome"
+                        }
+                    ]
+                },
+                "publisher_share_nano_ton": {
+                    "type": "integer"
+                }
+            }
+        },
+        "RevisionDiffResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "integer"
+                },
+                "media": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/MediaChange"
+                    }
+                },
+                "text": {
+                    "$ref": "#/definitions/TextDiff"
+                },
+                "to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "RevisionResponse": {
+            "type": "object",
+            "properties": {
+                "ad": {
+                    "$ref": "#/definitions/TemplateResponse"
+                },
+                "advertiser_approved_at": {
+                    "type": "string"
+                },
+                "author_name": {
+                    "type": "string"
+                },
+                "author_role": {
+                    "$ref": "#/definitions/DealParty"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "publisher_approved_at": {
+                    "type": "string"
+                },
+                "publisher_note": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "RevisionsResponse": {
+            "type": "object",
+            "properties": {
+                "revisions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/RevisionResponse"
+                    }
+                }
+            }
+        },
+        "SetChannelFeeRequest": {
+            "type": "object",
+            "properties": {
+                "platform_fee_bps": {
+                    "description": "nil resets the channel to the platform default",
+                    "type": "integer",
+                    "maximum": 9999,
+                    "minimum": 0
+                }
+            }
+        },
         "SortOrder": {
             "type": "string",
             "enum": [
@@ -2157,6 +5306,42 @@ const docTemplate = `{
                 "SortOrderDesc"
             ]
         },
+        "SubmitEvidenceRequest": {
+            "type": "object",
+            "properties": {
+                "file_id": {
+                    "type": "string",
+                    "maxLength": 256,
+                    "minLength": 1
+                },
+                "message_link": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string",
+                    "maxLength": 4000,
+                    "minLength": 1
+                }
+            }
+        },
+        "SubmitRevisionRequest": {
+            "type": "object",
+            "properties": {
+                "entities": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "template_post_id": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string",
+                    "minLength": 1
+                }
+            }
+        },
         "TemplateResponse": {
             "type": "object",
             "properties": {
@@ -2197,6 +5382,79 @@ const docTemplate = `{
                 }
             }
         },
+        "TextDiff": {
+            "type": "object",
+            "properties": {
+                "added_entities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/TextEntity"
+                    }
+                },
+                "removed_entities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/TextEntity"
+                    }
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/TextSegment"
+                    }
+                }
+            }
+        },
+        "TextDiffOp": {
+            "type": "string",
+            "enum": [
+                "equal",
+                "insert",
+                "delete"
+            ],
+            "x-enum-varnames": [
+                "TextDiffEqual",
+                "TextDiffInsert",
+                "TextDiffDelete"
+            ]
+        },
+        "TextEntity": {
+            "type": "object",
+            "properties": {
+                "custom_emoji_id": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "length": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "TextSegment": {
+            "type": "object",
+            "properties": {
+                "op": {
+                    "$ref": "#/definitions/TextDiffOp"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
         "Theme": {
             "type": "string",
             "enum": [
@@ -2227,6 +5485,71 @@ const docTemplate = `{
                 }
             }
         },
+        "TransferResponse": {
+            "type": "object",
+            "properties": {
+                "amount_nano_ton": {
+                    "type": "integer"
+                },
+                "destination": {
+                    "type": "string"
+                },
+                "fee_nano_ton": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/TransferStatus"
+                },
+                "tx_hash": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "TransferStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "sent",
+                "confirmed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "TransferStatusPending",
+                "TransferStatusSent",
+                "TransferStatusConfirmed",
+                "TransferStatusFailed"
+            ]
+        },
+        "UpdateAvailabilityRequest": {
+            "type": "object",
+            "properties": {
+                "blackout_dates": {
+                    "description": "UTC dates in YYYY-MM-DD",
+                    "type": "array",
+                    "maxItems": 366,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "max_ads_per_day": {
+                    "description": "nil lifts the daily limit",
+                    "type": "integer",
+                    "maximum": 48,
+                    "minimum": 1
+                },
+                "posting_windows": {
+                    "description": "empty allows posting at any time of day",
+                    "type": "array",
+                    "maxItems": 24,
+                    "items": {
+                        "$ref": "#/definitions/PostingWindow"
+                    }
+                }
+            }
+        },
         "UpdateCategoriesRequest": {
             "type": "object",
             "properties": {
@@ -2257,6 +5580,17 @@ const docTemplate = `{
                 }
             }
         },
+        "UpdateReviewTimeoutRequest": {
+            "type": "object",
+            "properties": {
+                "hours": {
+                    "description": "nil resets the channel to the platform default",
+                    "type": "integer",
+                    "maximum": 168,
+                    "minimum": 1
+                }
+            }
+        },
         "UpdateSettingsRequest": {
             "type": "object",
             "properties": {
@@ -54,6 +54,214 @@ export interface paths {
     patch?: never;
     trace?: never;
   };
+  "/campaigns": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /** List campaigns */
+    get: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path?: never;
+        cookie?: never;
+      };
+      requestBody?: never;
+      responses: {
+        /** @description OK */
+        200: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["CampaignsResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    put?: never;
+    /** Create campaign */
+    post: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path?: never;
+        cookie?: never;
+      };
+      /** @description Campaign */
+      requestBody: {
+        content: {
+          "application/json": components["schemas"]["CreateCampaignRequest"];
+        };
+      };
+      responses: {
+        /** @description Created */
+        201: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["CampaignResponse"];
+          };
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Conflict */
+        409: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unprocessable Entity */
+        422: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/campaigns/{campaignID}": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /** Get campaign */
+    get: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Campaign ID */
+          campaignID: string;
+        };
+        cookie?: never;
+      };
+      requestBody?: never;
+      responses: {
+        /** @description OK */
+        200: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["CampaignResponse"];
+          };
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    put?: never;
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
   "/channels": {
     parameters: {
       query?: never;
@@ -452,23 +660,22 @@ export interface paths {
     patch?: never;
     trace?: never;
   };
-  "/channels/{TgChannelID}/categories": {
+  "/channels/{TgChannelID}/availability": {
     parameters: {
       query?: never;
       header?: never;
       path?: never;
       cookie?: never;
     };
-    get?: never;
-    put?: never;
-    post?: never;
-    delete?: never;
-    options?: never;
-    head?: never;
-    /** Update channel categories */
-    patch: {
+    /** Get channel availability */
+    get: {
       parameters: {
-        query?: never;
+        query?: {
+          /** @description First day, YYYY-MM-DD */
+          from?: string;
+          /** @description Number of days */
+          days?: number;
+        };
         header?: never;
         path: {
           /** @description Telegram channel ID */
@@ -476,19 +683,16 @@ export interface paths {
         };
         cookie?: never;
       };
-      /** @description Categories */
-      requestBody: {
-        content: {
-          "application/json": components["schemas"]["UpdateCategoriesRequest"];
-        };
-      };
+      requestBody?: never;
       responses: {
-        /** @description No Content */
-        204: {
+        /** @description OK */
+        200: {
           headers: {
             [name: string]: unknown;
           };
-          content?: never;
+          content: {
+            "application/json": components["schemas"]["ChannelAvailabilityResponse"];
+          };
         };
         /** @description Bad Request */
         400: {
@@ -496,7 +700,7 @@ export interface paths {
             [name: string]: unknown;
           };
           content: {
-            "*/*": components["schemas"]["ErrorResponse"];
+            "application/json": components["schemas"]["ErrorResponse"];
           };
         };
         /** @description Unauthorized */
@@ -505,7 +709,7 @@ export interface paths {
             [name: string]: unknown;
           };
           content: {
-            "*/*": components["schemas"]["ErrorResponse"];
+            "application/json": components["schemas"]["ErrorResponse"];
           };
         };
         /** @description Forbidden */
@@ -514,7 +718,7 @@ export interface paths {
             [name: string]: unknown;
           };
           content: {
-            "*/*": components["schemas"]["ErrorResponse"];
+            "application/json": components["schemas"]["ErrorResponse"];
           };
         };
         /** @description Not Found */
@@ -523,28 +727,13 @@ export interface paths {
             [name: string]: unknown;
           };
           content: {
-            "*/*": components["schemas"]["ErrorResponse"];
+            "application/json": components["schemas"]["ErrorResponse"];
           };
         };
       };
     };
-    trace?: never;
-  };
-  "/channels/{TgChannelID}/listing": {
-    parameters: {
-      query?: never;
-      header?: never;
-      path?: never;
-      cookie?: never;
-    };
-    get?: never;
-    put?: never;
-    post?: never;
-    delete?: never;
-    options?: never;
-    head?: never;
-    /** Update channel listing */
-    patch: {
+    /** Update channel availability */
+    put: {
       parameters: {
         query?: never;
         header?: never;
@@ -554,10 +743,10 @@ export interface paths {
         };
         cookie?: never;
       };
-      /** @description Listing status */
+      /** @description Inventory */
       requestBody: {
         content: {
-          "application/json": components["schemas"]["UpdateListingRequest"];
+          "application/json": components["schemas"]["UpdateAvailabilityRequest"];
         };
       };
       responses: {
@@ -604,11 +793,172 @@ export interface paths {
             "*/*": components["schemas"]["ErrorResponse"];
           };
         };
-        /** @description Unprocessable Entity */
-        422: {
-          headers: {
-            [name: string]: unknown;
-          };
+      };
+    };
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/channels/{TgChannelID}/categories": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    get?: never;
+    put?: never;
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    /** Update channel categories */
+    patch: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Telegram channel ID */
+          TgChannelID: number;
+        };
+        cookie?: never;
+      };
+      /** @description Categories */
+      requestBody: {
+        content: {
+          "application/json": components["schemas"]["UpdateCategoriesRequest"];
+        };
+      };
+      responses: {
+        /** @description No Content */
+        204: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content?: never;
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    trace?: never;
+  };
+  "/channels/{TgChannelID}/listing": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    get?: never;
+    put?: never;
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    /** Update channel listing */
+    patch: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Telegram channel ID */
+          TgChannelID: number;
+        };
+        cookie?: never;
+      };
+      /** @description Listing status */
+      requestBody: {
+        content: {
+          "application/json": components["schemas"]["UpdateListingRequest"];
+        };
+      };
+      responses: {
+        /** @description No Content */
+        204: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content?: never;
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unprocessable Entity */
+        422: {
+          headers: {
+            [name: string]: unknown;
+          };
           content: {
             "*/*": components["schemas"]["ErrorResponse"];
           };
@@ -811,6 +1161,84 @@ export interface paths {
     patch?: never;
     trace?: never;
   };
+  "/channels/{TgChannelID}/review-timeout": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    get?: never;
+    put?: never;
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    /** Update channel review timeout */
+    patch: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Telegram channel ID */
+          TgChannelID: number;
+        };
+        cookie?: never;
+      };
+      /** @description Review timeout */
+      requestBody: {
+        content: {
+          "application/json": components["schemas"]["UpdateReviewTimeoutRequest"];
+        };
+      };
+      responses: {
+        /** @description No Content */
+        204: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content?: never;
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    trace?: never;
+  };
   "/deals": {
     parameters: {
       query?: never;
@@ -826,7 +1254,33 @@ export interface paths {
           role?: string;
           /** @description Channel ID */
           channel_id?: number;
-          /** @description Page number */
+          /** @description Advertiser Telegram ID */
+          advertiser_id?: number;
+          /** @description Comma-separated statuses */
+          status?: string;
+          /** @description Scheduled from (RFC 3339) */
+          scheduled_from?: string;
+          /** @description Scheduled to (RFC 3339) */
+          scheduled_to?: string;
+          /** @description Ad format */
+          format_type?: string;
+          /**
+           * @description Sort key
+           * @enum {string}
+           */
+          sort_by?: "created_at" | "scheduled_at" | "price";
+          /**
+           * @description Sort order
+           * @enum {string}
+           */
+          sort_order?: "asc" | "desc";
+          /** @description next_cursor of the previous page */
+          cursor?: string;
+          /** @description Page size */
+          limit?: number;
+          /** @description Count matching deals across all pages */
+          include_total?: boolean;
+          /** @description Deprecated: page number, use cursor */
           page?: number;
         };
         header?: never;
@@ -1055,7 +1509,1961 @@ export interface paths {
         };
         cookie?: never;
       };
-      requestBody?: never;
+      /** @description Version being approved */
+      requestBody?: {
+        content: {
+          "application/json": components["schemas"]["ApproveRequest"];
+        };
+      };
+      responses: {
+        /** @description No Content */
+        204: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content?: never;
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/deals/{dealID}/cancel": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    get?: never;
+    put?: never;
+    /** Cancel deal */
+    post: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Deal ID */
+          dealID: string;
+        };
+        cookie?: never;
+      };
+      requestBody?: never;
+      responses: {
+        /** @description No Content */
+        204: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content?: never;
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/deals/{dealID}/dispute": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /** Get deal dispute */
+    get: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Deal ID */
+          dealID: string;
+        };
+        cookie?: never;
+      };
+      requestBody?: never;
+      responses: {
+        /** @description OK */
+        200: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["DisputeResponse"];
+          };
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    put?: never;
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/deals/{dealID}/dispute/evidence": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    get?: never;
+    put?: never;
+    /** Submit dispute evidence */
+    post: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Deal ID */
+          dealID: string;
+        };
+        cookie?: never;
+      };
+      /** @description Evidence */
+      requestBody: {
+        content: {
+          "application/json": components["schemas"]["SubmitEvidenceRequest"];
+        };
+      };
+      responses: {
+        /** @description Created */
+        201: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["DisputeEvidenceResponse"];
+          };
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/deals/{dealID}/dispute/resolve": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    get?: never;
+    put?: never;
+    /** Resolve deal dispute */
+    post: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Deal ID */
+          dealID: string;
+        };
+        cookie?: never;
+      };
+      /** @description Ruling */
+      requestBody: {
+        content: {
+          "application/json": components["schemas"]["ResolveDisputeRequest"];
+        };
+      };
+      responses: {
+        /** @description No Content */
+        204: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content?: never;
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Conflict */
+        409: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/deals/{dealID}/events": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /** List deal events */
+    get: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Deal ID */
+          dealID: string;
+        };
+        cookie?: never;
+      };
+      requestBody?: never;
+      responses: {
+        /** @description OK */
+        200: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["DealEventsResponse"];
+          };
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    put?: never;
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/deals/{dealID}/ledger": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /** Get deal ledger */
+    get: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Deal ID */
+          dealID: string;
+        };
+        cookie?: never;
+      };
+      requestBody?: never;
+      responses: {
+        /** @description OK */
+        200: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["DealLedgerResponse"];
+          };
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    put?: never;
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/deals/{dealID}/messages": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /** List deal messages */
+    get: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Deal ID */
+          dealID: string;
+        };
+        cookie?: never;
+      };
+      requestBody?: never;
+      responses: {
+        /** @description OK */
+        200: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["DealMessagesResponse"];
+          };
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    put?: never;
+    /** Post deal message */
+    post: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Deal ID */
+          dealID: string;
+        };
+        cookie?: never;
+      };
+      /** @description Message */
+      requestBody: {
+        content: {
+          "application/json": components["schemas"]["PostMessageRequest"];
+        };
+      };
+      responses: {
+        /** @description Created */
+        201: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["DealMessageResponse"];
+          };
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/deals/{dealID}/offers": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /** List deal offers */
+    get: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Deal ID */
+          dealID: string;
+        };
+        cookie?: never;
+      };
+      requestBody?: never;
+      responses: {
+        /** @description OK */
+        200: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["DealOffersResponse"];
+          };
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    put?: never;
+    /** Counter deal offer */
+    post: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Deal ID */
+          dealID: string;
+        };
+        cookie?: never;
+      };
+      /** @description Proposed terms */
+      requestBody: {
+        content: {
+          "application/json": components["schemas"]["CounterOfferRequest"];
+        };
+      };
+      responses: {
+        /** @description Created */
+        201: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["DealOfferResponse"];
+          };
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/deals/{dealID}/offers/accept": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    get?: never;
+    put?: never;
+    /** Accept deal offer */
+    post: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Deal ID */
+          dealID: string;
+        };
+        cookie?: never;
+      };
+      requestBody?: never;
+      responses: {
+        /** @description No Content */
+        204: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content?: never;
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unprocessable Entity */
+        422: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/deals/{dealID}/offers/decline": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    get?: never;
+    put?: never;
+    /** Decline deal offer */
+    post: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Deal ID */
+          dealID: string;
+        };
+        cookie?: never;
+      };
+      /** @description Reason */
+      requestBody?: {
+        content: {
+          "application/json": components["schemas"]["DeclineOfferRequest"];
+        };
+      };
+      responses: {
+        /** @description No Content */
+        204: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content?: never;
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/deals/{dealID}/ratings": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /** List deal ratings */
+    get: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Deal ID */
+          dealID: string;
+        };
+        cookie?: never;
+      };
+      requestBody?: never;
+      responses: {
+        /** @description OK */
+        200: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["DealRatingsResponse"];
+          };
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    put?: never;
+    /** Rate deal */
+    post: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Deal ID */
+          dealID: string;
+        };
+        cookie?: never;
+      };
+      /** @description Rating */
+      requestBody: {
+        content: {
+          "application/json": components["schemas"]["RateDealRequest"];
+        };
+      };
+      responses: {
+        /** @description Created */
+        201: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["DealRatingResponse"];
+          };
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Conflict */
+        409: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/deals/{dealID}/reject": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    get?: never;
+    put?: never;
+    /** Reject deal */
+    post: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Deal ID */
+          dealID: string;
+        };
+        cookie?: never;
+      };
+      /** @description Rejection reason */
+      requestBody?: {
+        content: {
+          "application/json": components["schemas"]["RejectRequest"];
+        };
+      };
+      responses: {
+        /** @description No Content */
+        204: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content?: never;
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/deals/{dealID}/report": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /** Get deal report */
+    get: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Deal ID */
+          dealID: string;
+        };
+        cookie?: never;
+      };
+      requestBody?: never;
+      responses: {
+        /** @description OK */
+        200: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["DealReportResponse"];
+          };
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Conflict */
+        409: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    put?: never;
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/deals/{dealID}/request-changes": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    get?: never;
+    put?: never;
+    /** Request changes on deal */
+    post: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Deal ID */
+          dealID: string;
+        };
+        cookie?: never;
+      };
+      /** @description Details */
+      requestBody: {
+        content: {
+          "application/json": components["schemas"]["RequestChangesRequest"];
+        };
+      };
+      responses: {
+        /** @description No Content */
+        204: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content?: never;
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/deals/{dealID}/reschedules": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /** List deal reschedules */
+    get: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Deal ID */
+          dealID: string;
+        };
+        cookie?: never;
+      };
+      requestBody?: never;
+      responses: {
+        /** @description OK */
+        200: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["DealReschedulesResponse"];
+          };
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    put?: never;
+    /** Request deal reschedule */
+    post: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Deal ID */
+          dealID: string;
+        };
+        cookie?: never;
+      };
+      /** @description New posting time */
+      requestBody: {
+        content: {
+          "application/json": components["schemas"]["RescheduleRequest"];
+        };
+      };
+      responses: {
+        /** @description Created */
+        201: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["DealRescheduleResponse"];
+          };
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Conflict */
+        409: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/deals/{dealID}/reschedules/accept": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    get?: never;
+    put?: never;
+    /** Accept deal reschedule */
+    post: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Deal ID */
+          dealID: string;
+        };
+        cookie?: never;
+      };
+      requestBody?: never;
+      responses: {
+        /** @description No Content */
+        204: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content?: never;
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Conflict */
+        409: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/deals/{dealID}/reschedules/decline": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    get?: never;
+    put?: never;
+    /** Decline deal reschedule */
+    post: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Deal ID */
+          dealID: string;
+        };
+        cookie?: never;
+      };
+      /** @description Reason */
+      requestBody?: {
+        content: {
+          "application/json": components["schemas"]["DeclineRescheduleRequest"];
+        };
+      };
+      responses: {
+        /** @description No Content */
+        204: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content?: never;
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "*/*": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/deals/{dealID}/revisions": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /** List ad revisions */
+    get: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Deal ID */
+          dealID: string;
+        };
+        cookie?: never;
+      };
+      requestBody?: never;
+      responses: {
+        /** @description OK */
+        200: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["RevisionsResponse"];
+          };
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    put?: never;
+    /** Submit ad revision */
+    post: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Deal ID */
+          dealID: string;
+        };
+        cookie?: never;
+      };
+      /** @description New creative */
+      requestBody: {
+        content: {
+          "application/json": components["schemas"]["SubmitRevisionRequest"];
+        };
+      };
+      responses: {
+        /** @description Created */
+        201: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["RevisionResponse"];
+          };
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/deals/{dealID}/revisions/diff": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /** Diff ad revisions */
+    get: {
+      parameters: {
+        query?: {
+          /** @description Older version (default: to - 1) */
+          from?: number;
+          /** @description Newer version (default: latest) */
+          to?: number;
+        };
+        header?: never;
+        path: {
+          /** @description Deal ID */
+          dealID: string;
+        };
+        cookie?: never;
+      };
+      requestBody?: never;
+      responses: {
+        /** @description OK */
+        200: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["RevisionDiffResponse"];
+          };
+        };
+        /** @description Bad Request */
+        400: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Not Found */
+        404: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    put?: never;
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/disputes": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    /** List open disputes */
+    get: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path?: never;
+        cookie?: never;
+      };
+      requestBody?: never;
+      responses: {
+        /** @description OK */
+        200: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["DisputesResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
+    put?: never;
+    post?: never;
+    delete?: never;
+    options?: never;
+    head?: never;
+    patch?: never;
+    trace?: never;
+  };
+  "/fees/channels/{TgChannelID}": {
+    parameters: {
+      query?: never;
+      header?: never;
+      path?: never;
+      cookie?: never;
+    };
+    get?: never;
+    /** Set channel platform fee */
+    put: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path: {
+          /** @description Telegram channel ID */
+          TgChannelID: number;
+        };
+        cookie?: never;
+      };
+      /** @description Platform fee */
+      requestBody: {
+        content: {
+          "application/json": components["schemas"]["SetChannelFeeRequest"];
+        };
+      };
       responses: {
         /** @description No Content */
         204: {
@@ -1102,40 +3510,83 @@ export interface paths {
         };
       };
     };
+    post?: never;
     delete?: never;
     options?: never;
     head?: never;
     patch?: never;
     trace?: never;
   };
-  "/deals/{dealID}/cancel": {
+  "/fees/promotions": {
     parameters: {
       query?: never;
       header?: never;
       path?: never;
       cookie?: never;
     };
-    get?: never;
+    /** List fee promotions */
+    get: {
+      parameters: {
+        query?: never;
+        header?: never;
+        path?: never;
+        cookie?: never;
+      };
+      requestBody?: never;
+      responses: {
+        /** @description OK */
+        200: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["PromotionsResponse"];
+          };
+        };
+        /** @description Unauthorized */
+        401: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+        /** @description Forbidden */
+        403: {
+          headers: {
+            [name: string]: unknown;
+          };
+          content: {
+            "application/json": components["schemas"]["ErrorResponse"];
+          };
+        };
+      };
+    };
     put?: never;
-    /** Cancel deal */
+    /** Create fee promotion */
     post: {
       parameters: {
         query?: never;
         header?: never;
-        path: {
-          /** @description Deal ID */
-          dealID: string;
-        };
+        path?: never;
         cookie?: never;
       };
-      requestBody?: never;
+      /** @description Promotion */
+      requestBody: {
+        content: {
+          "application/json": components["schemas"]["CreatePromotionRequest"];
+        };
+      };
       responses: {
-        /** @description No Content */
-        204: {
+        /** @description Created */
+        201: {
           headers: {
             [name: string]: unknown;
           };
-          content?: never;
+          content: {
+            "application/json": components["schemas"]["PromotionResponse"];
+          };
         };
         /** @description Bad Request */
         400: {
@@ -1143,7 +3594,7 @@ export interface paths {
             [name: string]: unknown;
           };
           content: {
-            "*/*": components["schemas"]["ErrorResponse"];
+            "application/json": components["schemas"]["ErrorResponse"];
           };
         };
         /** @description Unauthorized */
@@ -1152,7 +3603,7 @@ export interface paths {
             [name: string]: unknown;
           };
           content: {
-            "*/*": components["schemas"]["ErrorResponse"];
+            "application/json": components["schemas"]["ErrorResponse"];
           };
         };
         /** @description Forbidden */
@@ -1161,7 +3612,7 @@ export interface paths {
             [name: string]: unknown;
           };
           content: {
-            "*/*": components["schemas"]["ErrorResponse"];
+            "application/json": components["schemas"]["ErrorResponse"];
           };
         };
         /** @description Not Found */
@@ -1170,7 +3621,7 @@ export interface paths {
             [name: string]: unknown;
           };
           content: {
-            "*/*": components["schemas"]["ErrorResponse"];
+            "application/json": components["schemas"]["ErrorResponse"];
           };
         };
       };
@@ -1181,7 +3632,7 @@ export interface paths {
     patch?: never;
     trace?: never;
   };
-  "/deals/{dealID}/reject": {
+  "/fees/promotions/{promotionID}": {
     parameters: {
       query?: never;
       header?: never;
@@ -1190,23 +3641,19 @@ export interface paths {
     };
     get?: never;
     put?: never;
-    /** Reject deal */
-    post: {
+    post?: never;
+    /** Delete fee promotion */
+    delete: {
       parameters: {
         query?: never;
         header?: never;
         path: {
-          /** @description Deal ID */
-          dealID: string;
+          /** @description Promotion ID */
+          promotionID: string;
         };
         cookie?: never;
       };
-      /** @description Rejection reason */
-      requestBody?: {
-        content: {
-          "application/json": components["schemas"]["RejectRequest"];
-        };
-      };
+      requestBody?: never;
       responses: {
         /** @description No Content */
         204: {
@@ -1253,45 +3700,39 @@ export interface paths {
         };
       };
     };
-    delete?: never;
     options?: never;
     head?: never;
     patch?: never;
     trace?: never;
   };
-  "/deals/{dealID}/request-changes": {
+  "/ledger/balances": {
     parameters: {
       query?: never;
       header?: never;
       path?: never;
       cookie?: never;
     };
-    get?: never;
-    put?: never;
-    /** Request changes on deal */
-    post: {
+    /** Get ledger balances */
+    get: {
       parameters: {
-        query?: never;
-        header?: never;
-        path: {
-          /** @description Deal ID */
-          dealID: string;
+        query?: {
+          /** @description User ID, admins only */
+          user_id?: string;
         };
+        header?: never;
+        path?: never;
         cookie?: never;
       };
-      /** @description Details */
-      requestBody: {
-        content: {
-          "application/json": components["schemas"]["RequestChangesRequest"];
-        };
-      };
+      requestBody?: never;
       responses: {
-        /** @description No Content */
-        204: {
+        /** @description OK */
+        200: {
           headers: {
             [name: string]: unknown;
           };
-          content?: never;
+          content: {
+            "application/json": components["schemas"]["LedgerBalancesResponse"];
+          };
         };
         /** @description Bad Request */
         400: {
@@ -1299,7 +3740,7 @@ export interface paths {
             [name: string]: unknown;
           };
           content: {
-            "*/*": components["schemas"]["ErrorResponse"];
+            "application/json": components["schemas"]["ErrorResponse"];
           };
         };
         /** @description Unauthorized */
@@ -1308,7 +3749,7 @@ export interface paths {
             [name: string]: unknown;
           };
           content: {
-            "*/*": components["schemas"]["ErrorResponse"];
+            "application/json": components["schemas"]["ErrorResponse"];
           };
         };
         /** @description Forbidden */
@@ -1317,20 +3758,13 @@ export interface paths {
             [name: string]: unknown;
           };
           content: {
-            "*/*": components["schemas"]["ErrorResponse"];
-          };
-        };
-        /** @description Not Found */
-        404: {
-          headers: {
-            [name: string]: unknown;
-          };
-          content: {
-            "*/*": components["schemas"]["ErrorResponse"];
+            "application/json": components["schemas"]["ErrorResponse"];
           };
         };
       };
     };
+    put?: never;
+    post?: never;
     delete?: never;
     options?: never;
     head?: never;
@@ -1852,6 +4286,7 @@ export type webhooks = Record<string, never>;
 export interface components {
   schemas: {
     AdFormat: {
+      auto_delete?: boolean;
       feed_hours?: number;
       format_type?: components["schemas"]["AdFormatType"];
       is_native?: boolean;
@@ -1859,6 +4294,7 @@ export interface components {
       top_hours?: number;
     };
     AdFormatResponse: {
+      auto_delete?: boolean;
       feed_hours?: number;
       format_type?: components["schemas"]["AdFormatType"];
       id?: string;
@@ -1872,6 +4308,7 @@ export interface components {
       ad_formats?: components["schemas"]["AdFormatResponse"][];
     };
     AddAdFormatRequest: {
+      auto_delete?: boolean;
       /** @enum {integer} */
       feed_hours: 12 | 24;
       format_type: components["schemas"]["AdFormatType"];
@@ -1883,6 +4320,9 @@ export interface components {
     AddManagerRequest: {
       telegram_id: number;
     };
+    ApproveRequest: {
+      version?: number;
+    };
     AuthRequest: {
       init_data?: string;
     };
@@ -1890,6 +4330,45 @@ export interface components {
       token?: string;
       user?: components["schemas"]["UserResponse"];
     };
+    AvailabilityDay: {
+      available?: boolean;
+      booked?: number;
+      date?: string;
+    };
+    BookedSlotResponse: {
+      from?: string;
+      to?: string;
+    };
+    CampaignResponse: {
+      budget_nano_ton?: number;
+      committed_nano_ton?: number;
+      created_at?: string;
+      deals?: components["schemas"]["DealResponse"][];
+      deals_by_status?: {
+        [key: string]: number;
+      };
+      id?: string;
+      name?: string;
+      reach?: number;
+      spent_nano_ton?: number;
+      status?: components["schemas"]["CampaignStatus"];
+      template_post_id?: string;
+    };
+    /** @enum {string} */
+    CampaignStatus: "active" | "finished";
+    CampaignTarget: {
+      channel_id: number;
+      feed_hours: number;
+      format_type: components["schemas"]["AdFormatType"];
+      is_native?: boolean;
+      offer?: boolean;
+      price_nano_ton: number;
+      scheduled_at: string;
+      top_hours: number;
+    };
+    CampaignsResponse: {
+      campaigns?: components["schemas"]["CampaignResponse"][];
+    };
     CategoryResponse: {
       display_name?: string;
       slug?: string;
@@ -1903,6 +4382,13 @@ export interface components {
     ChannelAdminsResponse: {
       admins?: components["schemas"]["ChannelAdmin"][];
     };
+    ChannelAvailabilityResponse: {
+      blackout_dates?: string[];
+      booked?: components["schemas"]["BookedSlotResponse"][];
+      days?: components["schemas"]["AvailabilityDay"][];
+      max_ads_per_day?: number;
+      posting_windows?: components["schemas"]["PostingWindow"][];
+    };
     ChannelManagersResponse: {
       managers?: components["schemas"]["ManagerResponse"][];
     };
@@ -1915,6 +4401,8 @@ export interface components {
       payout_address?: string;
       photo_big_url?: string;
       photo_small_url?: string;
+      /** @description nil means the platform default applies */
+      review_timeout_hours?: number;
       subscribers?: number;
       title?: string;
       username?: string;
@@ -1922,7 +4410,7 @@ export interface components {
     /** @enum {string} */
     ChannelRoleType: "undefined" | "owner" | "manager";
     /** @enum {string} */
-    ChannelSortBy: "subscribers" | "views";
+    ChannelSortBy: "subscribers" | "views" | "rating" | "completed_deals";
     ChannelWithRoleResponse: {
       channel?: components["schemas"]["ChannelResponse"];
       role?: components["schemas"]["ChannelRoleType"];
@@ -1930,32 +4418,177 @@ export interface components {
     ChannelsResponse: {
       channels?: components["schemas"]["ChannelWithRoleResponse"][];
     };
+    CounterOfferRequest: {
+      price_nano_ton: number;
+      scheduled_at: string;
+    };
+    CreateCampaignRequest: {
+      budget_nano_ton: number;
+      name: string;
+      targets: components["schemas"]["CampaignTarget"][];
+      template_post_id: string;
+    };
     CreateDealRequest: {
       channel_id: number;
       feed_hours: number;
       format_type: components["schemas"]["AdFormatType"];
       is_native?: boolean;
+      /** @description Offer proposes price_nano_ton and scheduled_at to the publisher instead
+of requiring the listed price; the deal starts out negotiating. */
+      offer?: boolean;
       price_nano_ton: number;
       scheduled_at: string;
       template_post_id: string;
       top_hours: number;
     };
+    CreatePromotionRequest: {
+      channel_id?: number;
+      ends_at: string;
+      note?: string;
+      starts_at: string;
+    };
+    /** @enum {string} */
+    DealDisputeStatus: "open" | "resolved";
+    DealEventResponse: {
+      actor_name?: string;
+      actor_role?: components["schemas"]["DealParty"];
+      created_at?: string;
+      from_status?: components["schemas"]["DealStatus"];
+      metadata?: {
+        [key: string]: unknown;
+      };
+      note?: string;
+      to_status?: components["schemas"]["DealStatus"];
+      type?: components["schemas"]["DealEventType"];
+    };
+    /** @enum {string} */
+    DealEventType:
+      | "created"
+      | "offer_made"
+      | "offer_accepted"
+      | "offer_declined"
+      | "reschedule_requested"
+      | "rescheduled"
+      | "reschedule_declined"
+      | "payment_received"
+      | "payment_expired"
+      | "late_payment_refunded"
+      | "revision_submitted"
+      | "revision_approved"
+      | "changes_requested"
+      | "rejected"
+      | "cancelled"
+      | "review_expired"
+      | "posted"
+      | "publish_failed"
+      | "completed"
+      | "disputed"
+      | "evidence_submitted"
+      | "dispute_resolved"
+      | "rated";
+    DealEventsResponse: {
+      events?: components["schemas"]["DealEventResponse"][];
+    };
+    DealLedgerResponse: {
+      entries?: components["schemas"]["LedgerEntryResponse"][];
+      escrow_balance_nano_ton?: number;
+    };
+    DealMessageResponse: {
+      author_name?: string;
+      author_role?: components["schemas"]["DealParty"];
+      created_at?: string;
+      id?: string;
+      text?: string;
+    };
+    DealMessagesResponse: {
+      messages?: components["schemas"]["DealMessageResponse"][];
+    };
+    DealOfferResponse: {
+      author_name?: string;
+      author_role?: components["schemas"]["DealParty"];
+      created_at?: string;
+      id?: string;
+      price_nano_ton?: number;
+      responded_at?: string;
+      scheduled_at?: string;
+      status?: components["schemas"]["DealOfferStatus"];
+    };
+    /** @enum {string} */
+    DealOfferStatus: "pending" | "accepted" | "declined" | "countered";
+    DealOffersResponse: {
+      offers?: components["schemas"]["DealOfferResponse"][];
+    };
+    /** @enum {string} */
+    DealParty: "advertiser" | "publisher" | "arbiter";
+    DealRatingResponse: {
+      author_name?: string;
+      author_role?: components["schemas"]["DealParty"];
+      comment?: string;
+      created_at?: string;
+      id?: string;
+      rating?: number;
+    };
+    DealRatingsResponse: {
+      ratings?: components["schemas"]["DealRatingResponse"][];
+    };
+    DealReportResponse: {
+      channel_avg_daily_views_7d?: number;
+      cpm_nano_ton?: number;
+      curve?: components["schemas"]["DealViewPoint"][];
+      deal_id?: string;
+      feed_ends_at?: string;
+      forwards?: number;
+      posted_at?: string;
+      price_nano_ton?: number;
+      reactions?: number;
+      views?: number;
+      views_to_channel_avg?: number;
+    };
+    DealRescheduleResponse: {
+      author_name?: string;
+      author_role?: components["schemas"]["DealParty"];
+      created_at?: string;
+      id?: string;
+      responded_at?: string;
+      scheduled_at?: string;
+      status?: components["schemas"]["DealRescheduleStatus"];
+    };
+    /** @enum {string} */
+    DealRescheduleStatus: "pending" | "accepted" | "declined" | "superseded";
+    DealReschedulesResponse: {
+      reschedules?: components["schemas"]["DealRescheduleResponse"][];
+    };
     DealResponse: {
       ad?: components["schemas"]["TemplateResponse"];
+      auto_delete?: boolean;
+      campaign_id?: string;
       channel_id?: number;
       created_at?: string;
+      delete_error?: string;
+      deleted_at?: string;
       feed_hours?: number;
       format_type?: components["schemas"]["AdFormatType"];
       id?: string;
       is_native?: boolean;
+      payment?: components["schemas"]["PaymentInstructions"];
+      payout?: components["schemas"]["TransferResponse"];
+      payout_error?: string;
+      pinned_at?: string;
+      /** @description platform fee withheld from the payout, frozen when the deal was created */
+      platform_fee_bps?: number;
+      posted_at?: string;
       price_nano_ton?: number;
+      publish_error?: string;
       publisher_note?: string;
+      refund?: components["schemas"]["TransferResponse"];
       scheduled_at?: string;
       status?: components["schemas"]["DealStatus"];
       top_hours?: number;
+      unpinned_at?: string;
     };
     /** @enum {string} */
     DealStatus:
+      | "negotiating"
       | "pending_payment"
       | "hold_failed"
       | "pending_review"
@@ -1963,13 +4596,57 @@ export interface components {
       | "approved"
       | "rejected"
       | "cancelled"
+      | "publish_failed"
       | "posted"
       | "completed"
-      | "dispute";
+      | "dispute"
+      | "resolved";
+    DealViewPoint: {
+      forwards?: number;
+      reactions?: number;
+      taken_at?: string;
+      views?: number;
+    };
     DealsResponse: {
       deals?: components["schemas"]["DealResponse"][];
+      /** @description pass as cursor to fetch the next page; absent on the last one */
+      next_cursor?: string;
+      /** @description deals matching the filters across all pages; only with include_total
+or page */
       total?: number;
     };
+    DeclineOfferRequest: {
+      reason?: string;
+    };
+    DeclineRescheduleRequest: {
+      reason?: string;
+    };
+    DisputeEvidenceResponse: {
+      author_name?: string;
+      author_role?: components["schemas"]["DealParty"];
+      created_at?: string;
+      file_id?: string;
+      id?: string;
+      message_link?: string;
+      text?: string;
+    };
+    /** @enum {string} */
+    DisputeOutcome: "release" | "refund" | "split";
+    DisputeResponse: {
+      created_at?: string;
+      deal?: components["schemas"]["DealResponse"];
+      evidence?: components["schemas"]["DisputeEvidenceResponse"][];
+      id?: string;
+      outcome?: components["schemas"]["DisputeOutcome"];
+      publisher_share_nano_ton?: number;
+      reason?: string;
+      resolution_note?: string;
+      resolved_at?: string;
+      status?: components["schemas"]["DealDisputeStatus"];
+    };
+    DisputesResponse: {
+      disputes?: components["schemas"]["DisputeResponse"][];
+    };
     ErrorResponse: {
       details?: {
         [key: string]: unknown;
@@ -1982,6 +4659,25 @@ export interface components {
       language?: string;
       percentage?: number;
     };
+    /** @enum {string} */
+    LedgerAccount: "escrow" | "advertiser" | "publisher" | "platform";
+    LedgerBalanceResponse: {
+      account?: components["schemas"]["LedgerAccount"];
+      amount_nano_ton?: number;
+    };
+    LedgerBalancesResponse: {
+      balances?: components["schemas"]["LedgerBalanceResponse"][];
+    };
+    /** @enum {string} */
+    LedgerEntryKind: "hold" | "release" | "fee" | "refund";
+    LedgerEntryResponse: {
+      account?: components["schemas"]["LedgerAccount"];
+      amount_nano_ton?: number;
+      created_at?: string;
+      kind?: components["schemas"]["LedgerEntryKind"];
+      txn_id?: string;
+      user_id?: string;
+    };
     LinkWalletRequest: {
       address: string;
     };
@@ -2000,12 +4696,16 @@ export interface components {
       avg_interactions_30d?: number;
       avg_interactions_7d?: number;
       categories?: components["schemas"]["CategoryResponse"][];
+      completed_deals?: number;
       engagement_rate_30d?: number;
       engagement_rate_7d?: number;
       id?: number;
       /** @description ISO 639-1 codes: "en", "ru" */
       languages?: components["schemas"]["LanguageShare"][];
       photo_small_url?: string;
+      /** @description 1–5, from ratings left by advertisers; unset until the first one */
+      rating?: number;
+      rating_count?: number;
       /** @description Keys are unicode emoji ("👍"), custom will be mapped to standard too */
       reactions_by_emotion?: {
         [key: string]: number;
@@ -2037,6 +4737,15 @@ export interface components {
       name?: string;
       value?: unknown;
     };
+    MediaChange: {
+      changed_flags?: string[];
+      from?: components["schemas"]["PostMediaItem"];
+      index?: number;
+      kind?: components["schemas"]["MediaChangeKind"];
+      to?: components["schemas"]["PostMediaItem"];
+    };
+    /** @enum {string} */
+    MediaChangeKind: "added" | "removed" | "replaced" | "updated";
     /** @enum {string} */
     MediaType:
       | "photo"
@@ -2047,12 +4756,26 @@ export interface components {
       | "voice"
       | "video_note"
       | "sticker";
+    PaymentInstructions: {
+      address?: string;
+      amount_nano_ton?: number;
+      comment?: string;
+      deep_link?: string;
+      expires_at?: string;
+    };
     PostMediaItem: {
       has_media_spoiler?: boolean;
       media_type?: components["schemas"]["MediaType"];
       post_id?: string;
       show_caption_above_media?: boolean;
     };
+    PostMessageRequest: {
+      text: string;
+    };
+    PostingWindow: {
+      end: string;
+      start: string;
+    };
     /** @enum {string} */
     PreferredMode: "publisher" | "advertiser";
     ProfileResponse: {
@@ -2065,14 +4788,71 @@ export interface components {
       theme?: components["schemas"]["Theme"];
       wallet_address?: string;
     };
+    PromotionResponse: {
+      channel_id?: number;
+      created_at?: string;
+      ends_at?: string;
+      id?: string;
+      note?: string;
+      starts_at?: string;
+    };
+    PromotionsResponse: {
+      promotions?: components["schemas"]["PromotionResponse"][];
+    };
+    RateDealRequest: {
+      comment?: string;
+      rating: number;
+    };
     RejectRequest: {
       reason?: string;
     };
     RequestChangesRequest: {
       note: string;
     };
+    RescheduleRequest: {
+      scheduled_at: string;
+    };
+    ResolveDisputeRequest: {
+      note?: string;
+      /** @enum {string} */
+      outcome: "release" | "refund" | "split";
+      publisher_share_nano_ton?: number;
+    };
+    RevisionDiffResponse: {
+      from?: number;
+      media?: components["schemas"]["MediaChange"][];
+      text?: components["schemas"]["TextDiff"];
+      to?: number;
+    };
+    RevisionResponse: {
+      ad?: components["schemas"]["TemplateResponse"];
+      advertiser_approved_at?: string;
+      author_name?: string;
+      author_role?: components["schemas"]["DealParty"];
+      created_at?: string;
+      publisher_approved_at?: string;
+      publisher_note?: string;
+      version?: number;
+    };
+    RevisionsResponse: {
+      revisions?: components["schemas"]["RevisionResponse"][];
+    };
+    SetChannelFeeRequest: {
+      /** @description nil resets the channel to the platform default */
+      platform_fee_bps?: number;
+    };
     /** @enum {string} */
     SortOrder: "asc" | "desc";
+    SubmitEvidenceRequest: {
+      file_id?: string;
+      message_link?: string;
+      text?: string;
+    };
+    SubmitRevisionRequest: {
+      entities?: number[];
+      template_post_id?: string;
+      text?: string;
+    };
     TemplateResponse: {
       created_at?: string;
       entities?: number[];
@@ -2084,6 +4864,26 @@ export interface components {
     TemplatesResponse: {
       templates?: components["schemas"]["TemplateResponse"][];
     };
+    TextDiff: {
+      added_entities?: components["schemas"]["TextEntity"][];
+      removed_entities?: components["schemas"]["TextEntity"][];
+      segments?: components["schemas"]["TextSegment"][];
+    };
+    /** @enum {string} */
+    TextDiffOp: "equal" | "insert" | "delete";
+    TextEntity: {
+      custom_emoji_id?: string;
+      language?: string;
+      length?: number;
+      offset?: number;
+      text?: string;
+      type?: string;
+      url?: string;
+    };
+    TextSegment: {
+      op?: components["schemas"]["TextDiffOp"];
+      text?: string;
+    };
     /** @enum {string} */
     Theme: "light" | "dark" | "auto";
     TonRatesResponse: {
@@ -2092,6 +4892,24 @@ export interface components {
       rub?: number;
       usd?: number;
     };
+    TransferResponse: {
+      amount_nano_ton?: number;
+      destination?: string;
+      fee_nano_ton?: number;
+      status?: components["schemas"]["TransferStatus"];
+      tx_hash?: string;
+      updated_at?: string;
+    };
+    /** @enum {string} */
+    TransferStatus: "pending" | "sent" | "confirmed" | "failed";
+    UpdateAvailabilityRequest: {
+      /** @description UTC dates in YYYY-MM-DD */
+      blackout_dates?: string[];
+      /** @description nil lifts the daily limit */
+      max_ads_per_day?: number;
+      /** @description empty allows posting at any time of day */
+      posting_windows?: components["schemas"]["PostingWindow"][];
+    };
     UpdateCategoriesRequest: {
       categories?: string[];
     };
@@ -2101,6 +4919,10 @@ export interface components {
     UpdateNameRequest: {
       name: string;
     };
+    UpdateReviewTimeoutRequest: {
+      /** @description nil resets the channel to the platform default */
+      hours?: number;
+    };
     UpdateSettingsRequest: {
       language?: components["schemas"]["Language"];
       onboarding_finished?: boolean;
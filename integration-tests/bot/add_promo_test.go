@@ -163,6 +163,8 @@ func TestHandleAddPromo(t *testing.T) {
 				userRepo,
 				statsSvc,
 				postRepo,
+				messageRepo,
+				dealSvc,
 			)
 
 			for _, upd := range tt.updates {
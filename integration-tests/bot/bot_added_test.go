@@ -280,7 +280,10 @@ func TestHandleBotAdded(t *testing.T) {
 				MaxRetries: 1,
 			}
 
-			botSvc := bot.New(mock, cfg, log, testDB, channelRepo, userRepo, statsSvc, postRepo)
+			botSvc := bot.New(
+				mock, cfg, log, testDB, channelRepo, userRepo, statsSvc, postRepo,
+				messageRepo, dealSvc,
+			)
 
 			tt.setup(t, mock)
 
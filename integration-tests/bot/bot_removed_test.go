@@ -114,6 +114,8 @@ func TestHandleBotRemoved(t *testing.T) {
 				userRepo,
 				statsSvc,
 				postRepo,
+				messageRepo,
+				dealSvc,
 			)
 
 			tt.setup(t)
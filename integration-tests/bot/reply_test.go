@@ -0,0 +1,134 @@
+//go:build integration
+
+package bot_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	tele "gopkg.in/telebot.v4"
+
+	"github.com/bpva/ad-marketplace/internal/config"
+	"github.com/bpva/ad-marketplace/internal/entity"
+	"github.com/bpva/ad-marketplace/internal/service/bot"
+)
+
+const relayedTgMessageID = 42
+
+type replySetup struct {
+	advertiser *entity.User
+	publisher  *entity.User
+	deal       *entity.Deal
+}
+
+// setupReply creates a deal whose advertiser wrote a message that the bot
+// forwarded to the publisher.
+func setupReply(t *testing.T, ctx context.Context) *replySetup {
+	t.Helper()
+	require.NoError(t, testTools.TruncateAll(ctx))
+
+	advertiser, err := testTools.CreateUser(ctx, 6001001, "Advertiser")
+	require.NoError(t, err)
+	publisher, err := testTools.CreateUser(ctx, 6001002, "Publisher")
+	require.NoError(t, err)
+
+	channel, err := testTools.CreateChannel(ctx, -1006001001, "Reply Channel", nil)
+	require.NoError(t, err)
+	_, err = testTools.CreateChannelRole(ctx, channel.ID, publisher.ID, entity.ChannelRoleTypeOwner)
+	require.NoError(t, err)
+
+	deal, err := testTools.CreateDeal(
+		ctx,
+		channel.ID,
+		advertiser.ID,
+		entity.DealStatusPendingReview,
+		time.Now().Add(48*time.Hour),
+		entity.AdFormatTypePost,
+		false,
+		24,
+		4,
+		1_000_000_000,
+	)
+	require.NoError(t, err)
+
+	msg, err := testTools.CreateDealMessage(ctx, deal.ID, advertiser.ID, "Is Friday fine?")
+	require.NoError(t, err)
+	require.NoError(t, testTools.CreateMessageRelay(
+		ctx, publisher.TgID, relayedTgMessageID, msg.ID,
+	))
+
+	return &replySetup{advertiser: advertiser, publisher: publisher, deal: deal}
+}
+
+func newReplyBot(t *testing.T) interface{ HandleUpdate(tele.Update) } {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mock := bot.NewMockTelebotClient(ctrl)
+	mock.EXPECT().Handle(gomock.Any(), gomock.Any()).AnyTimes()
+
+	return bot.New(
+		mock,
+		config.Telegram{},
+		log,
+		testDB,
+		channelRepo,
+		userRepo,
+		statsSvc,
+		postRepo,
+		messageRepo,
+		dealSvc,
+	)
+}
+
+func createReplyUpdate(senderID int64, replyToID int, text string) tele.Update {
+	upd := createTextUpdate(senderID, text)
+	upd.Message.ReplyTo = &tele.Message{ID: replyToID}
+	return upd
+}
+
+func TestHandleReply(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reply lands in the deal thread", func(t *testing.T) {
+		s := setupReply(t, ctx)
+
+		newReplyBot(t).HandleUpdate(
+			createReplyUpdate(s.publisher.TgID, relayedTgMessageID, "Yes, Friday works"),
+		)
+
+		msgs, err := testTools.GetDealMessages(ctx, s.deal.ID)
+		require.NoError(t, err)
+		require.Len(t, msgs, 2)
+		assert.Equal(t, s.publisher.ID, msgs[1].AuthorID)
+		assert.Equal(t, "Yes, Friday works", msgs[1].Text)
+		assert.Nil(t, msgs[1].RelayedAt)
+	})
+
+	t.Run("reply to another message is ignored", func(t *testing.T) {
+		s := setupReply(t, ctx)
+
+		newReplyBot(t).HandleUpdate(
+			createReplyUpdate(s.publisher.TgID, relayedTgMessageID+1, "Yes, Friday works"),
+		)
+
+		msgs, err := testTools.GetDealMessages(ctx, s.deal.ID)
+		require.NoError(t, err)
+		assert.Len(t, msgs, 1)
+	})
+
+	t.Run("relay from another chat is ignored", func(t *testing.T) {
+		s := setupReply(t, ctx)
+
+		newReplyBot(t).HandleUpdate(
+			createReplyUpdate(s.advertiser.TgID, relayedTgMessageID, "Yes, Friday works"),
+		)
+
+		msgs, err := testTools.GetDealMessages(ctx, s.deal.ID)
+		require.NoError(t, err)
+		assert.Len(t, msgs, 1)
+	})
+}
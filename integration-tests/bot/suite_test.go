@@ -23,9 +23,24 @@ import (
 	"github.com/bpva/ad-marketplace/internal/dto"
 	"github.com/bpva/ad-marketplace/internal/entity"
 	channel_repo "github.com/bpva/ad-marketplace/internal/repository/channel"
+	deal_repo "github.com/bpva/ad-marketplace/internal/repository/deal"
+	dispute_repo "github.com/bpva/ad-marketplace/internal/repository/dispute"
+	event_repo "github.com/bpva/ad-marketplace/internal/repository/event"
+	ledger_repo "github.com/bpva/ad-marketplace/internal/repository/ledger"
+	message_repo "github.com/bpva/ad-marketplace/internal/repository/message"
+	offer_repo "github.com/bpva/ad-marketplace/internal/repository/offer"
+	outbox_repo "github.com/bpva/ad-marketplace/internal/repository/outbox"
 	post_repo "github.com/bpva/ad-marketplace/internal/repository/post"
+	promotion_repo "github.com/bpva/ad-marketplace/internal/repository/promotion"
+	rating_repo "github.com/bpva/ad-marketplace/internal/repository/rating"
+	reschedule_repo "github.com/bpva/ad-marketplace/internal/repository/reschedule"
+	revision_repo "github.com/bpva/ad-marketplace/internal/repository/revision"
+	snapshot_repo "github.com/bpva/ad-marketplace/internal/repository/snapshot"
+	transfer_repo "github.com/bpva/ad-marketplace/internal/repository/transfer"
 	user_repo "github.com/bpva/ad-marketplace/internal/repository/user"
 	"github.com/bpva/ad-marketplace/internal/service/bot"
+	deal_service "github.com/bpva/ad-marketplace/internal/service/deal"
+	"github.com/bpva/ad-marketplace/internal/service/escrow"
 	"github.com/bpva/ad-marketplace/internal/service/stats"
 	"github.com/bpva/ad-marketplace/internal/storage"
 	"github.com/bpva/ad-marketplace/migrations"
@@ -45,6 +60,8 @@ var (
 	userRepo    bot.UserRepository
 	postRepo    bot.PostRepository
 	statsSvc    bot.StatsFetcher
+	messageRepo bot.MessageRepository
+	dealSvc     bot.DealService
 	log         *slog.Logger
 )
 
@@ -104,6 +121,31 @@ func TestMain(m *testing.M) {
 	postRepo = post_repo.New(testDB)
 	log = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
 
+	msgRepo := message_repo.New(testDB)
+	messageRepo = msgRepo
+	dealSvc = deal_service.New(
+		config.Deal{PaymentTimeout: time.Hour},
+		deal_repo.New(testDB),
+		channel_repo.New(testDB),
+		post_repo.New(testDB),
+		user_repo.New(testDB),
+		transfer_repo.New(testDB),
+		outbox_repo.New(testDB),
+		revision_repo.New(testDB),
+		event_repo.New(testDB),
+		msgRepo,
+		offer_repo.New(testDB),
+		reschedule_repo.New(testDB),
+		dispute_repo.New(testDB),
+		snapshot_repo.New(testDB),
+		rating_repo.New(testDB),
+		promotion_repo.New(testDB),
+		ledger_repo.New(testDB),
+		testDB,
+		escrow.NewWallet("EQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqB2N"),
+		log,
+	)
+
 	ctrl := gomock.NewController(&testing.T{})
 	mockMTProto := stats.NewMockMTProtoClient(ctrl)
 	mockMTProto.EXPECT().
@@ -288,6 +288,42 @@ func TestHandleAddAdFormat(t *testing.T) {
 				assert.True(t, formats[0].IsNative)
 			},
 		},
+		{
+			name: "owner adds auto-delete ad format",
+			setup: func(t *testing.T) (string, int64, string) {
+				owner, err := testTools.CreateUser(ctx, 8002013, "Owner")
+				require.NoError(t, err)
+
+				ch, err := testTools.CreateChannel(
+					ctx,
+					-1008002013001,
+					"Auto Delete Channel",
+					nil,
+				)
+				require.NoError(t, err)
+				_, err = testTools.CreateChannelRole(
+					ctx,
+					ch.ID,
+					owner.ID,
+					entity.ChannelRoleTypeOwner,
+				)
+				require.NoError(t, err)
+
+				token, err := testTools.GenerateToken(owner)
+				require.NoError(t, err)
+				body := `{"format_type": "post", "feed_hours": 24, "top_hours": 2, "auto_delete": true, "price_nano_ton": 1000000000}`
+				return "Bearer " + token, ch.TgChannelID, body
+			},
+			expectedStatus: http.StatusNoContent,
+			check: func(t *testing.T) {
+				ch, err := testTools.GetChannelByTgID(ctx, -1008002013001)
+				require.NoError(t, err)
+				formats, err := testTools.GetAdFormatsByChannelID(ctx, ch.ID)
+				require.NoError(t, err)
+				require.Len(t, formats, 1)
+				assert.True(t, formats[0].AutoDelete)
+			},
+		},
 		{
 			name: "duplicate ad format conflict",
 			setup: func(t *testing.T) (string, int64, string) {
@@ -0,0 +1,144 @@
+//go:build integration
+
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+func availabilityRequest(
+	t *testing.T, method string, tgChannelID int64, query, token string, body any,
+) (int, []byte) {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(raw)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/channels/%d/availability%s", testServer.URL, tgChannelID, query)
+	req, err := http.NewRequest(method, url, reader)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, respBody
+}
+
+func bookViaAPI(t *testing.T, s *dealSetup, at time.Time) (int, []byte) {
+	t.Helper()
+
+	return dealRequest(t, http.MethodPost, "", s.advToken, dto.CreateDealRequest{
+		TgChannelID:    s.channel.TgChannelID,
+		FormatType:     entity.AdFormatTypePost,
+		FeedHours:      24,
+		TopHours:       4,
+		PriceNanoTON:   1000000000,
+		TemplatePostID: s.templatePost.ID.String(),
+		ScheduledAt:    at,
+	})
+}
+
+func TestHandleAvailability(t *testing.T) {
+	ctx := context.Background()
+	day := time.Now().UTC().Truncate(24 * time.Hour).Add(3 * 24 * time.Hour)
+
+	t.Run("inventory limits bookings", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		maxAds := 2
+		blackout := day.Add(24 * time.Hour)
+
+		code, body := availabilityRequest(t, http.MethodPut, s.channel.TgChannelID, "",
+			s.pubToken, dto.UpdateAvailabilityRequest{
+				MaxAdsPerDay:   &maxAds,
+				PostingWindows: []dto.PostingWindow{{Start: "08:00", End: "24:00"}},
+				BlackoutDates:  []string{blackout.Format(time.DateOnly)},
+			})
+		require.Equal(t, http.StatusNoContent, code, string(body))
+
+		code, body = bookViaAPI(t, s, day.Add(9*time.Hour))
+		require.Equal(t, http.StatusCreated, code, string(body))
+
+		// the first ad still holds the top of the feed
+		code, body = bookViaAPI(t, s, day.Add(11*time.Hour))
+		assert.Equal(t, http.StatusConflict, code, string(body))
+		assert.Contains(t, string(body), "slot_taken")
+
+		code, body = bookViaAPI(t, s, day.Add(13*time.Hour))
+		require.Equal(t, http.StatusCreated, code, string(body))
+
+		code, body = bookViaAPI(t, s, day.Add(18*time.Hour))
+		assert.Equal(t, http.StatusConflict, code, string(body))
+		assert.Contains(t, string(body), "slot_unavailable")
+
+		code, body = bookViaAPI(t, s, blackout.Add(12*time.Hour))
+		assert.Equal(t, http.StatusConflict, code, string(body))
+		assert.Contains(t, string(body), "slot_unavailable")
+
+		code, body = bookViaAPI(t, s, day.Add(2*24*time.Hour+6*time.Hour))
+		assert.Equal(t, http.StatusConflict, code, string(body))
+		assert.Contains(t, string(body), "slot_unavailable")
+
+		query := "?from=" + day.Format(time.DateOnly) + "&days=3"
+		code, body = availabilityRequest(t, http.MethodGet, s.channel.TgChannelID, query,
+			s.advToken, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+
+		var resp dto.ChannelAvailabilityResponse
+		require.NoError(t, json.Unmarshal(body, &resp))
+		require.NotNil(t, resp.MaxAdsPerDay)
+		assert.Equal(t, 2, *resp.MaxAdsPerDay)
+		assert.Equal(t, []dto.PostingWindow{{Start: "08:00", End: "24:00"}}, resp.PostingWindows)
+		assert.Equal(t, []string{blackout.Format(time.DateOnly)}, resp.BlackoutDates)
+		require.Len(t, resp.Booked, 2)
+		assert.True(t, day.Add(9*time.Hour).Equal(resp.Booked[0].From))
+		assert.True(t, day.Add(13*time.Hour).Equal(resp.Booked[0].To))
+		require.Len(t, resp.Days, 3)
+		assert.Equal(t, dto.AvailabilityDay{
+			Date: day.Format(time.DateOnly), Booked: 2, Available: false,
+		}, resp.Days[0])
+		assert.Equal(t, dto.AvailabilityDay{
+			Date: blackout.Format(time.DateOnly), Booked: 0, Available: false,
+		}, resp.Days[1])
+		assert.True(t, resp.Days[2].Available)
+	})
+
+	t.Run("invalid window", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+
+		code, _ := availabilityRequest(t, http.MethodPut, s.channel.TgChannelID, "",
+			s.pubToken, dto.UpdateAvailabilityRequest{
+				PostingWindows: []dto.PostingWindow{{Start: "18:00", End: "09:00"}},
+			})
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("advertiser cannot edit", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+
+		code, _ := availabilityRequest(t, http.MethodPut, s.channel.TgChannelID, "",
+			s.advToken, dto.UpdateAvailabilityRequest{})
+		assert.Equal(t, http.StatusForbidden, code)
+	})
+}
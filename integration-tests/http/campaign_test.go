@@ -0,0 +1,271 @@
+//go:build integration
+
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+func campaignRequest(
+	t *testing.T,
+	method, path, token string,
+	body any,
+) (int, []byte) {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequest(method, testServer.URL+"/api/v1/campaigns"+path, reader)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, respBody
+}
+
+// setupSecondChannel lists another channel of the setup's publisher with the
+// same ad format
+func setupSecondChannel(t *testing.T, ctx context.Context, s *dealSetup) *entity.Channel {
+	t.Helper()
+
+	channel, err := testTools.CreateChannel(ctx, -1004001002, "Second Channel", nil)
+	require.NoError(t, err)
+	require.NoError(t, testTools.UpdateChannelListing(ctx, channel.ID, true))
+	_, err = testTools.CreateChannelRole(
+		ctx, channel.ID, s.publisher.ID, entity.ChannelRoleTypeOwner,
+	)
+	require.NoError(t, err)
+	_, err = testTools.CreateAdFormat(
+		ctx, channel.ID, entity.AdFormatTypePost, false, 24, 4, 1000000000,
+	)
+	require.NoError(t, err)
+
+	return channel
+}
+
+func campaignTarget(tgChannelID int64, at time.Time) dto.CampaignTarget {
+	return dto.CampaignTarget{
+		TgChannelID:  tgChannelID,
+		FormatType:   entity.AdFormatTypePost,
+		FeedHours:    24,
+		TopHours:     4,
+		PriceNanoTON: 1000000000,
+		ScheduledAt:  at,
+	}
+}
+
+func TestHandleCreateCampaign(t *testing.T) {
+	ctx := context.Background()
+	at := time.Now().Add(48 * time.Hour)
+
+	t.Run("fans out into deals", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		second := setupSecondChannel(t, ctx, s)
+
+		code, body := campaignRequest(t, http.MethodPost, "", s.advToken, dto.CreateCampaignRequest{
+			Name:           "Launch",
+			TemplatePostID: s.templatePost.ID.String(),
+			BudgetNanoTON:  2000000000,
+			Targets: []dto.CampaignTarget{
+				campaignTarget(s.channel.TgChannelID, at),
+				campaignTarget(second.TgChannelID, at),
+			},
+		})
+		require.Equal(t, http.StatusCreated, code, string(body))
+
+		var resp dto.CampaignResponse
+		require.NoError(t, json.Unmarshal(body, &resp))
+		assert.Equal(t, "Launch", resp.Name)
+		assert.Equal(t, entity.CampaignStatusActive, resp.Status)
+		assert.Equal(t, 2, resp.DealsByStatus[entity.DealStatusPendingPayment])
+		require.Len(t, resp.Deals, 2)
+		for _, d := range resp.Deals {
+			require.NotNil(t, d.CampaignID)
+			assert.Equal(t, resp.ID, *d.CampaignID)
+			assert.NotNil(t, d.Payment)
+		}
+	})
+
+	t.Run("over budget", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		second := setupSecondChannel(t, ctx, s)
+
+		code, body := campaignRequest(t, http.MethodPost, "", s.advToken, dto.CreateCampaignRequest{
+			Name:           "Launch",
+			TemplatePostID: s.templatePost.ID.String(),
+			BudgetNanoTON:  1500000000,
+			Targets: []dto.CampaignTarget{
+				campaignTarget(s.channel.TgChannelID, at),
+				campaignTarget(second.TgChannelID, at),
+			},
+		})
+		require.Equal(t, http.StatusUnprocessableEntity, code)
+
+		var errResp dto.ErrorResponse
+		require.NoError(t, json.Unmarshal(body, &errResp))
+		assert.Equal(t, "budget_exceeded", errResp.ErrorCode)
+	})
+
+	t.Run("negotiated price is capped by the budget", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		second := setupSecondChannel(t, ctx, s)
+
+		offer := campaignTarget(s.channel.TgChannelID, at)
+		offer.PriceNanoTON = 500000000
+		offer.Offer = true
+		code, body := campaignRequest(t, http.MethodPost, "", s.advToken, dto.CreateCampaignRequest{
+			Name:           "Launch",
+			TemplatePostID: s.templatePost.ID.String(),
+			BudgetNanoTON:  2000000000,
+			Targets:        []dto.CampaignTarget{offer, campaignTarget(second.TgChannelID, at)},
+		})
+		require.Equal(t, http.StatusCreated, code, string(body))
+
+		var resp dto.CampaignResponse
+		require.NoError(t, json.Unmarshal(body, &resp))
+		var dealID string
+		for _, d := range resp.Deals {
+			if d.Status == entity.DealStatusNegotiating {
+				dealID = d.ID
+			}
+		}
+		require.NotEmpty(t, dealID)
+
+		code, body = dealRequest(t, http.MethodPost, "/"+dealID+"/offers", s.pubToken,
+			dto.CounterOfferRequest{PriceNanoTON: 1500000000, ScheduledAt: at})
+		require.Equal(t, http.StatusCreated, code, string(body))
+
+		code, body = dealRequest(t, http.MethodPost, "/"+dealID+"/offers/accept", s.advToken, nil)
+		require.Equal(t, http.StatusUnprocessableEntity, code, string(body))
+		var errResp dto.ErrorResponse
+		require.NoError(t, json.Unmarshal(body, &errResp))
+		assert.Equal(t, "budget_exceeded", errResp.ErrorCode)
+		assert.Equal(t, float64(1000000000), errResp.Details["budget_left_nano_ton"])
+
+		code, body = dealRequest(t, http.MethodPost, "/"+dealID+"/offers", s.advToken,
+			dto.CounterOfferRequest{PriceNanoTON: 1000000000, ScheduledAt: at})
+		require.Equal(t, http.StatusCreated, code, string(body))
+
+		code, body = dealRequest(t, http.MethodPost, "/"+dealID+"/offers/accept", s.pubToken, nil)
+		require.Equal(t, http.StatusNoContent, code, string(body))
+	})
+
+	t.Run("failed target rolls back the campaign", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+
+		code, body := campaignRequest(t, http.MethodPost, "", s.advToken, dto.CreateCampaignRequest{
+			Name:           "Launch",
+			TemplatePostID: s.templatePost.ID.String(),
+			BudgetNanoTON:  2000000000,
+			Targets: []dto.CampaignTarget{
+				campaignTarget(s.channel.TgChannelID, at),
+				campaignTarget(s.channel.TgChannelID, at.Add(time.Hour)),
+			},
+		})
+		require.Equal(t, http.StatusConflict, code)
+
+		var errResp dto.ErrorResponse
+		require.NoError(t, json.Unmarshal(body, &errResp))
+		assert.Equal(t, "slot_taken", errResp.ErrorCode)
+		assert.Equal(t, float64(1), errResp.Details["target"])
+
+		code, body = campaignRequest(t, http.MethodGet, "", s.advToken, nil)
+		require.Equal(t, http.StatusOK, code)
+		var list dto.CampaignsResponse
+		require.NoError(t, json.Unmarshal(body, &list))
+		assert.Empty(t, list.Campaigns)
+
+		code, body = dealRequest(t, http.MethodGet, "", s.advToken, nil)
+		require.Equal(t, http.StatusOK, code)
+		var deals dto.DealsResponse
+		require.NoError(t, json.Unmarshal(body, &deals))
+		assert.Empty(t, deals.Deals)
+	})
+}
+
+func TestHandleGetCampaign(t *testing.T) {
+	ctx := context.Background()
+	at := time.Now().Add(48 * time.Hour)
+
+	s := setupDeal(t, ctx)
+	second := setupSecondChannel(t, ctx, s)
+
+	code, body := campaignRequest(t, http.MethodPost, "", s.advToken, dto.CreateCampaignRequest{
+		Name:           "Launch",
+		TemplatePostID: s.templatePost.ID.String(),
+		BudgetNanoTON:  2000000000,
+		Targets: []dto.CampaignTarget{
+			campaignTarget(s.channel.TgChannelID, at),
+			campaignTarget(second.TgChannelID, at),
+		},
+	})
+	require.Equal(t, http.StatusCreated, code, string(body))
+
+	var created dto.CampaignResponse
+	require.NoError(t, json.Unmarshal(body, &created))
+
+	t.Run("aggregates deal statuses", func(t *testing.T) {
+		completed, err := uuid.Parse(created.Deals[0].ID)
+		require.NoError(t, err)
+		require.NoError(t, testTools.SetStatus(ctx, completed, entity.DealStatusCompleted))
+		approved, err := uuid.Parse(created.Deals[1].ID)
+		require.NoError(t, err)
+		require.NoError(t, testTools.SetStatus(ctx, approved, entity.DealStatusApproved))
+
+		code, body := campaignRequest(t, http.MethodGet, "/"+created.ID.String(), s.advToken, nil)
+		require.Equal(t, http.StatusOK, code)
+
+		var resp dto.CampaignResponse
+		require.NoError(t, json.Unmarshal(body, &resp))
+		assert.Equal(t, entity.CampaignStatusActive, resp.Status)
+		assert.Equal(t, int64(1000000000), resp.SpentNanoTON)
+		assert.Equal(t, int64(1000000000), resp.CommittedNanoTON)
+		assert.Len(t, resp.Deals, 2)
+
+		require.NoError(t, testTools.SetStatus(ctx, approved, entity.DealStatusCancelled))
+
+		code, body = campaignRequest(t, http.MethodGet, "", s.advToken, nil)
+		require.Equal(t, http.StatusOK, code)
+
+		var list dto.CampaignsResponse
+		require.NoError(t, json.Unmarshal(body, &list))
+		require.Len(t, list.Campaigns, 1)
+		assert.Equal(t, entity.CampaignStatusFinished, list.Campaigns[0].Status)
+		assert.Zero(t, list.Campaigns[0].CommittedNanoTON)
+		assert.Empty(t, list.Campaigns[0].Deals)
+	})
+
+	t.Run("other users are forbidden", func(t *testing.T) {
+		code, _ := campaignRequest(t, http.MethodGet, "/"+created.ID.String(), s.pubToken, nil)
+		assert.Equal(t, http.StatusForbidden, code)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		code, _ := campaignRequest(t, http.MethodGet, "/not-a-uuid", s.advToken, nil)
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
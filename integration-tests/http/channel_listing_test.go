@@ -289,3 +289,63 @@ func TestChannelResponseIncludesPayoutAddress(t *testing.T) {
 
 	assert.Equal(t, "EQDpayout_test_address", result["payout_address"])
 }
+
+func TestHandleUpdateReviewTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	owner, err := testTools.CreateUser(ctx, 7004001, "Owner")
+	require.NoError(t, err)
+	manager, err := testTools.CreateUser(ctx, 7004002, "Manager")
+	require.NoError(t, err)
+
+	ch, err := testTools.CreateChannel(ctx, -1007004001001, "SLA Channel", nil)
+	require.NoError(t, err)
+	_, err = testTools.CreateChannelRole(ctx, ch.ID, owner.ID, entity.ChannelRoleTypeOwner)
+	require.NoError(t, err)
+	_, err = testTools.CreateChannelRole(ctx, ch.ID, manager.ID, entity.ChannelRoleTypeManager)
+	require.NoError(t, err)
+
+	ownerToken, err := testTools.GenerateToken(owner)
+	require.NoError(t, err)
+	managerToken, err := testTools.GenerateToken(manager)
+	require.NoError(t, err)
+
+	patch := func(t *testing.T, token, body string) int {
+		t.Helper()
+		url := fmt.Sprintf("%s/api/v1/channels/%d/review-timeout", testServer.URL, ch.TgChannelID)
+		req, err := http.NewRequest(http.MethodPatch, url, bytes.NewBufferString(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	t.Run("owner sets timeout", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, patch(t, ownerToken, `{"hours": 6}`))
+
+		got, err := testTools.GetChannelByTgID(ctx, ch.TgChannelID)
+		require.NoError(t, err)
+		require.NotNil(t, got.ReviewTimeoutHours)
+		assert.Equal(t, 6, *got.ReviewTimeoutHours)
+	})
+
+	t.Run("owner resets to default", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, patch(t, ownerToken, `{"hours": null}`))
+
+		got, err := testTools.GetChannelByTgID(ctx, ch.TgChannelID)
+		require.NoError(t, err)
+		assert.Nil(t, got.ReviewTimeoutHours)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, patch(t, ownerToken, `{"hours": 0}`))
+	})
+
+	t.Run("manager forbidden", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, patch(t, managerToken, `{"hours": 6}`))
+	})
+}
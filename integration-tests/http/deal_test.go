@@ -133,6 +133,17 @@ func TestHandleCreateDeal(t *testing.T) {
 		assert.Equal(t, int64(1000000000), dealResp.PriceNanoTON)
 		assert.NotNil(t, dealResp.Ad)
 		assert.Equal(t, "Ad creative text", *dealResp.Ad.Text)
+
+		require.NotNil(t, dealResp.Payment)
+		assert.Equal(t, testEscrowAddress, dealResp.Payment.Address)
+		assert.Equal(t, int64(1000000000), dealResp.Payment.AmountNanoTON)
+		assert.NotEmpty(t, dealResp.Payment.Comment)
+		assert.Equal(t,
+			"ton://transfer/"+testEscrowAddress+
+				"?amount=1000000000&text="+dealResp.Payment.Comment,
+			dealResp.Payment.DeepLink)
+		require.NotNil(t, dealResp.Payment.ExpiresAt)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), *dealResp.Payment.ExpiresAt, time.Minute)
 	})
 
 	t.Run("price mismatch", func(t *testing.T) {
@@ -193,6 +204,35 @@ func TestHandleCreateDeal(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 	})
 
+	t.Run("scheduled before payment deadline", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+
+		body, _ := json.Marshal(dto.CreateDealRequest{
+			TgChannelID:    s.channel.TgChannelID,
+			FormatType:     entity.AdFormatTypePost,
+			FeedHours:      24,
+			TopHours:       4,
+			PriceNanoTON:   1000000000,
+			TemplatePostID: s.templatePost.ID.String(),
+			ScheduledAt:    time.Now().Add(30 * time.Minute),
+		})
+
+		req, err := http.NewRequest(
+			http.MethodPost,
+			testServer.URL+"/api/v1/deals",
+			bytes.NewReader(body),
+		)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", s.advToken)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
 	t.Run("template not owned", func(t *testing.T) {
 		s := setupDeal(t, ctx)
 
@@ -267,7 +307,7 @@ func TestHandleListDeals(t *testing.T) {
 
 		req, err := http.NewRequest(
 			http.MethodGet,
-			testServer.URL+"/api/v1/deals?role=advertiser",
+			testServer.URL+"/api/v1/deals?role=advertiser&include_total=true",
 			nil,
 		)
 		require.NoError(t, err)
@@ -284,7 +324,8 @@ func TestHandleListDeals(t *testing.T) {
 
 		var dealsResp dto.DealsResponse
 		require.NoError(t, json.Unmarshal(respBody, &dealsResp))
-		assert.Equal(t, 1, dealsResp.Total)
+		require.NotNil(t, dealsResp.Total)
+		assert.Equal(t, 1, *dealsResp.Total)
 		require.Len(t, dealsResp.Deals, 1)
 		assert.Equal(t, s.channel.TgChannelID, dealsResp.Deals[0].TgChannelID)
 	})
@@ -298,7 +339,7 @@ func TestHandleListDeals(t *testing.T) {
 		require.NoError(t, err)
 
 		url := fmt.Sprintf(
-			"%s/api/v1/deals?role=publisher&channel_id=%d",
+			"%s/api/v1/deals?role=publisher&channel_id=%d&page=1",
 			testServer.URL,
 			s.channel.TgChannelID,
 		)
@@ -317,7 +358,8 @@ func TestHandleListDeals(t *testing.T) {
 
 		var dealsResp dto.DealsResponse
 		require.NoError(t, json.Unmarshal(respBody, &dealsResp))
-		assert.Equal(t, 1, dealsResp.Total)
+		require.NotNil(t, dealsResp.Total)
+		assert.Equal(t, 1, *dealsResp.Total)
 		require.Len(t, dealsResp.Deals, 1)
 	})
 
@@ -339,9 +381,79 @@ func TestHandleListDeals(t *testing.T) {
 
 		var dealsResp dto.DealsResponse
 		require.NoError(t, json.Unmarshal(respBody, &dealsResp))
-		assert.Equal(t, 0, dealsResp.Total)
+		assert.Nil(t, dealsResp.Total)
 		assert.Empty(t, dealsResp.Deals)
 	})
+
+	t.Run("filters and keyset pages", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		day := time.Now().UTC().Truncate(24 * time.Hour).Add(48 * time.Hour)
+
+		statuses := []entity.DealStatus{
+			entity.DealStatusPendingReview,
+			entity.DealStatusApproved,
+			entity.DealStatusPendingReview,
+			entity.DealStatusCompleted,
+			entity.DealStatusPendingReview,
+		}
+		for i, status := range statuses {
+			_, err := testTools.CreateDeal(ctx, s.channel.ID, s.advertiser.ID,
+				status, day.Add(time.Duration(i)*24*time.Hour),
+				entity.AdFormatTypePost, false, 24, 4, 1000000000)
+			require.NoError(t, err)
+		}
+
+		list := func(t *testing.T, query string) dto.DealsResponse {
+			t.Helper()
+			path := fmt.Sprintf("?role=publisher&channel_id=%d&%s", s.channel.TgChannelID, query)
+			code, body := dealRequest(t, http.MethodGet, path, s.pubToken, nil)
+			require.Equal(t, http.StatusOK, code, string(body))
+			var resp dto.DealsResponse
+			require.NoError(t, json.Unmarshal(body, &resp))
+			return resp
+		}
+
+		query := "status=pending_review,approved&sort_by=scheduled_at&sort_order=asc&limit=2"
+		first := list(t, query+"&include_total=true")
+		require.NotNil(t, first.Total)
+		assert.Equal(t, 4, *first.Total)
+		require.Len(t, first.Deals, 2)
+		require.NotEmpty(t, first.NextCursor)
+		assert.True(t, first.Deals[0].ScheduledAt.Equal(day))
+		assert.Equal(t, entity.DealStatusApproved, first.Deals[1].Status)
+
+		second := list(t, query+"&cursor="+first.NextCursor)
+		assert.Nil(t, second.Total)
+		require.Len(t, second.Deals, 2)
+		assert.Empty(t, second.NextCursor)
+		assert.True(t, second.Deals[0].ScheduledAt.Equal(day.Add(48*time.Hour)))
+		assert.True(t, second.Deals[1].ScheduledAt.Equal(day.Add(96*time.Hour)))
+
+		from := day.Add(24 * time.Hour).Format(time.RFC3339)
+		to := day.Add(72 * time.Hour).Format(time.RFC3339)
+		ranged := list(t, "scheduled_from="+from+"&scheduled_to="+to+
+			fmt.Sprintf("&advertiser_id=%d", s.advertiser.TgID))
+		require.Len(t, ranged.Deals, 2)
+		assert.Equal(t, entity.DealStatusPendingReview, ranged.Deals[0].Status)
+
+		other := list(t, "advertiser_id=1")
+		assert.Empty(t, other.Deals)
+
+		paged := list(t, query+"&page=2")
+		require.NotNil(t, paged.Total)
+		assert.Equal(t, 4, *paged.Total)
+		require.Len(t, paged.Deals, 2)
+		assert.Equal(t, second.Deals[0].ID, paged.Deals[0].ID)
+
+		for _, bad := range []string{
+			"?sort_by=title",
+			"?status=approved,paid",
+			"?page=2&cursor=" + first.NextCursor,
+		} {
+			code, _ := dealRequest(t, http.MethodGet, bad, s.advToken, nil)
+			assert.Equal(t, http.StatusBadRequest, code, bad)
+		}
+	})
 }
 
 func TestHandleGetDeal(t *testing.T) {
@@ -459,6 +571,7 @@ func TestHandleApproveDeal(t *testing.T) {
 			entity.DealStatusPendingReview, time.Now().Add(48*time.Hour),
 			entity.AdFormatTypePost, false, 24, 4, 1000000000)
 		require.NoError(t, err)
+		require.NoError(t, testTools.CreateRevision(ctx, deal.ID, s.advertiser.ID, 1))
 
 		req, err := http.NewRequest(
 			http.MethodPost,
@@ -473,6 +586,10 @@ func TestHandleApproveDeal(t *testing.T) {
 		defer resp.Body.Close()
 
 		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		updated, err := testTools.GetDeal(ctx, deal.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.DealStatusApproved, updated.Status)
 	})
 
 	t.Run("invalid transition", func(t *testing.T) {
@@ -498,13 +615,14 @@ func TestHandleApproveDeal(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 	})
 
-	t.Run("forbidden for advertiser", func(t *testing.T) {
+	t.Run("advertiser already confirmed", func(t *testing.T) {
 		s := setupDeal(t, ctx)
 
 		deal, err := testTools.CreateDeal(ctx, s.channel.ID, s.advertiser.ID,
 			entity.DealStatusPendingReview, time.Now().Add(48*time.Hour),
 			entity.AdFormatTypePost, false, 24, 4, 1000000000)
 		require.NoError(t, err)
+		require.NoError(t, testTools.CreateRevision(ctx, deal.ID, s.advertiser.ID, 1))
 
 		req, err := http.NewRequest(
 			http.MethodPost,
@@ -518,7 +636,7 @@ func TestHandleApproveDeal(t *testing.T) {
 		require.NoError(t, err)
 		defer resp.Body.Close()
 
-		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 	})
 }
 
@@ -550,6 +668,36 @@ func TestHandleRejectDeal(t *testing.T) {
 		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
 	})
 
+	t.Run("paid deal queues refund", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+
+		deal, err := testTools.CreateDeal(ctx, s.channel.ID, s.advertiser.ID,
+			entity.DealStatusPendingReview, time.Now().Add(48*time.Hour),
+			entity.AdFormatTypePost, false, 24, 4, 1000000000)
+		require.NoError(t, err)
+		require.NoError(t, testTools.SetPaid(ctx, deal.ID, "payment-tx"))
+
+		req, err := http.NewRequest(
+			http.MethodPost,
+			testServer.URL+"/api/v1/deals/"+deal.ID.String()+"/reject",
+			nil,
+		)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", s.pubToken)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		msgs, err := testTools.GetOutboxMessages(ctx, deal.ID)
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+		assert.Equal(t, entity.OutboxEventRefund, msgs[0].Event)
+		assert.Nil(t, msgs[0].ProcessedAt)
+	})
+
 	t.Run("without reason", func(t *testing.T) {
 		s := setupDeal(t, ctx)
 
@@ -608,6 +756,8 @@ func TestHandleRequestChanges(t *testing.T) {
 			entity.AdFormatTypePost, false, 24, 4, 1000000000)
 		require.NoError(t, err)
 
+		require.NoError(t, testTools.CreateRevision(ctx, deal.ID, s.advertiser.ID, 1))
+
 		body, _ := json.Marshal(dto.RequestChangesRequest{Note: "please fix the text"})
 		req, err := http.NewRequest(
 			http.MethodPost,
@@ -675,6 +825,43 @@ func TestHandleCancelDeal(t *testing.T) {
 		defer resp.Body.Close()
 
 		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		msgs, err := testTools.GetOutboxMessages(ctx, deal.ID)
+		require.NoError(t, err)
+		assert.Empty(t, msgs)
+	})
+
+	t.Run("paid deal queues refund", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+
+		deal, err := testTools.CreateDeal(ctx, s.channel.ID, s.advertiser.ID,
+			entity.DealStatusPendingReview, time.Now().Add(48*time.Hour),
+			entity.AdFormatTypePost, false, 24, 4, 1000000000)
+		require.NoError(t, err)
+		require.NoError(t, testTools.SetPaid(ctx, deal.ID, "payment-tx"))
+
+		req, err := http.NewRequest(
+			http.MethodPost,
+			testServer.URL+"/api/v1/deals/"+deal.ID.String()+"/cancel",
+			nil,
+		)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", s.advToken)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		got, err := testTools.GetDeal(ctx, deal.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.DealStatusCancelled, got.Status)
+
+		msgs, err := testTools.GetOutboxMessages(ctx, deal.ID)
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+		assert.Equal(t, entity.OutboxEventRefund, msgs[0].Event)
 	})
 
 	t.Run("invalid transition after approval", func(t *testing.T) {
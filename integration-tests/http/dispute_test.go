@@ -0,0 +1,165 @@
+//go:build integration
+
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+func listDisputes(t *testing.T, token string) (int, []byte) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, testServer.URL+"/api/v1/disputes", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, body
+}
+
+func TestHandleDisputes(t *testing.T) {
+	ctx := context.Background()
+
+	disputedDeal := func(t *testing.T, s *dealSetup) string {
+		t.Helper()
+		deal, err := testTools.CreateDeal(ctx, s.channel.ID, s.advertiser.ID,
+			entity.DealStatusDispute, time.Now().Add(-2*time.Hour),
+			entity.AdFormatTypePost, false, 24, 4, 1000000000)
+		require.NoError(t, err)
+		require.NoError(t, testTools.SetPaid(ctx, deal.ID, "tx-hash"))
+		require.NoError(t, testTools.CreateDispute(ctx, deal.ID, "post deleted early"))
+		return deal.ID.String()
+	}
+
+	arbiterToken := func(t *testing.T) string {
+		t.Helper()
+		arbiter, err := testTools.CreateUser(ctx, testArbiterTgID, "Arbiter")
+		require.NoError(t, err)
+		token, err := testTools.GenerateToken(arbiter)
+		require.NoError(t, err)
+		return "Bearer " + token
+	}
+
+	t.Run("evidence then split", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		dealID := disputedDeal(t, s)
+		path := "/" + dealID
+		arbToken := arbiterToken(t)
+
+		text := "the post was gone after an hour"
+		code, body := dealRequest(t, http.MethodPost, path+"/dispute/evidence", s.advToken,
+			dto.SubmitEvidenceRequest{Text: &text})
+		require.Equal(t, http.StatusCreated, code, string(body))
+
+		var evidence dto.DisputeEvidenceResponse
+		require.NoError(t, json.Unmarshal(body, &evidence))
+		assert.Equal(t, entity.DealPartyAdvertiser, evidence.AuthorRole)
+
+		link := "https://t.me/dealchannel/42"
+		code, body = dealRequest(t, http.MethodPost, path+"/dispute/evidence", s.pubToken,
+			dto.SubmitEvidenceRequest{MessageLink: &link})
+		require.Equal(t, http.StatusCreated, code, string(body))
+
+		code, body = listDisputes(t, arbToken)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var list dto.DisputesResponse
+		require.NoError(t, json.Unmarshal(body, &list))
+		require.Len(t, list.Disputes, 1)
+		assert.Equal(t, dealID, list.Disputes[0].Deal.ID)
+
+		share := int64(400000000)
+		code, _ = dealRequest(t, http.MethodPost, path+"/dispute/resolve", s.advToken,
+			dto.ResolveDisputeRequest{Outcome: entity.DisputeOutcomeRefund})
+		assert.Equal(t, http.StatusForbidden, code)
+
+		code, body = dealRequest(t, http.MethodPost, path+"/dispute/resolve", arbToken,
+			dto.ResolveDisputeRequest{
+				Outcome:               entity.DisputeOutcomeSplit,
+				PublisherShareNanoTON: &share,
+			})
+		require.Equal(t, http.StatusNoContent, code, string(body))
+
+		code, body = dealRequest(t, http.MethodGet, path+"/dispute", s.pubToken, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var dispute dto.DisputeResponse
+		require.NoError(t, json.Unmarshal(body, &dispute))
+		assert.Equal(t, entity.DealDisputeStatusResolved, dispute.Status)
+		require.NotNil(t, dispute.Outcome)
+		assert.Equal(t, entity.DisputeOutcomeSplit, *dispute.Outcome)
+		require.Len(t, dispute.Evidence, 2)
+		assert.Equal(t, entity.DealPartyPublisher, dispute.Evidence[1].AuthorRole)
+
+		deal, err := testTools.GetDeal(ctx, uuid.MustParse(dispute.Deal.ID))
+		require.NoError(t, err)
+		assert.Equal(t, entity.DealStatusResolved, deal.Status)
+		require.NotNil(t, deal.PublisherShareNanoTON)
+		assert.Equal(t, share, *deal.PublisherShareNanoTON)
+
+		outbox, err := testTools.GetOutboxMessages(ctx, deal.ID)
+		require.NoError(t, err)
+		require.Len(t, outbox, 1)
+		assert.Equal(t, entity.OutboxEventRefund, outbox[0].Event)
+
+		events, err := testTools.GetDealEvents(ctx, deal.ID)
+		require.NoError(t, err)
+		require.Len(t, events, 3)
+		assert.Equal(t, entity.DealEventEvidenceSubmitted, events[0].Type)
+		assert.Equal(t, entity.DealEventDisputeResolved, events[2].Type)
+
+		code, _ = dealRequest(t, http.MethodPost, path+"/dispute/evidence", s.advToken,
+			dto.SubmitEvidenceRequest{Text: &text})
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("full release", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		path := "/" + disputedDeal(t, s)
+
+		code, body := dealRequest(t, http.MethodPost, path+"/dispute/resolve", arbiterToken(t),
+			dto.ResolveDisputeRequest{Outcome: entity.DisputeOutcomeRelease})
+		require.Equal(t, http.StatusNoContent, code, string(body))
+
+		code, body = dealRequest(t, http.MethodGet, path, s.advToken, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var resp dto.DealResponse
+		require.NoError(t, json.Unmarshal(body, &resp))
+		assert.Equal(t, entity.DealStatusResolved, resp.Status)
+
+		outbox, err := testTools.GetOutboxMessages(ctx, uuid.MustParse(resp.ID))
+		require.NoError(t, err)
+		assert.Empty(t, outbox)
+	})
+
+	t.Run("empty evidence rejected", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		path := "/" + disputedDeal(t, s)
+
+		code, _ := dealRequest(t, http.MethodPost, path+"/dispute/evidence", s.advToken,
+			dto.SubmitEvidenceRequest{})
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("list forbidden to parties", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+
+		code, _ := listDisputes(t, s.advToken)
+		assert.Equal(t, http.StatusForbidden, code)
+	})
+}
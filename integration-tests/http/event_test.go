@@ -0,0 +1,74 @@
+//go:build integration
+
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+func TestHandleListDealEvents(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("records each step", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		dealID := createDealViaAPI(t, ctx, s, "fix it")
+
+		text := "Fixed"
+		code, _ := dealRequest(t, http.MethodPost, "/"+dealID+"/revisions", s.advToken,
+			dto.SubmitRevisionRequest{Text: &text})
+		require.Equal(t, http.StatusCreated, code)
+
+		code, _ = dealRequest(t, http.MethodPost, "/"+dealID+"/approve", s.pubToken, nil)
+		require.Equal(t, http.StatusNoContent, code)
+
+		code, body := dealRequest(t, http.MethodGet, "/"+dealID+"/events", s.advToken, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+
+		var resp dto.DealEventsResponse
+		require.NoError(t, json.Unmarshal(body, &resp))
+		require.Len(t, resp.Events, 4)
+
+		created := resp.Events[0]
+		assert.Equal(t, entity.DealEventCreated, created.Type)
+		assert.Equal(t, entity.DealPartyAdvertiser, created.ActorRole)
+		assert.Equal(t, "Advertiser", created.ActorName)
+		assert.Nil(t, created.FromStatus)
+		assert.Equal(t, entity.DealStatusPendingPayment, created.ToStatus)
+
+		changes := resp.Events[1]
+		assert.Equal(t, entity.DealEventChangesRequested, changes.Type)
+		assert.Equal(t, entity.DealPartyPublisher, changes.ActorRole)
+		require.NotNil(t, changes.Note)
+		assert.Equal(t, "fix it", *changes.Note)
+
+		revision := resp.Events[2]
+		assert.Equal(t, entity.DealEventRevisionSubmitted, revision.Type)
+		assert.Equal(t, float64(2), revision.Metadata["version"])
+
+		approved := resp.Events[3]
+		assert.Equal(t, entity.DealEventRevisionApproved, approved.Type)
+		assert.Equal(t, entity.DealStatusApproved, approved.ToStatus)
+	})
+
+	t.Run("stranger", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		dealID := createDealViaAPI(t, ctx, s, "fix it")
+
+		stranger, err := testTools.CreateUser(ctx, 4001004, "Stranger")
+		require.NoError(t, err)
+		token, err := testTools.GenerateToken(stranger)
+		require.NoError(t, err)
+
+		code, _ := dealRequest(t, http.MethodGet, "/"+dealID+"/events", "Bearer "+token, nil)
+		assert.Equal(t, http.StatusForbidden, code)
+	})
+}
@@ -0,0 +1,146 @@
+//go:build integration
+
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+func feeRequest(t *testing.T, method, path, token string, body any) (int, []byte) {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequest(method, testServer.URL+"/api/v1/fees"+path, reader)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, respBody
+}
+
+func TestHandleFees(t *testing.T) {
+	ctx := context.Background()
+	day := time.Now().UTC().Truncate(24 * time.Hour).Add(3 * 24 * time.Hour)
+
+	adminToken := func(t *testing.T) string {
+		t.Helper()
+		admin, err := testTools.CreateUser(ctx, testAdminTgID, "Admin")
+		require.NoError(t, err)
+		token, err := testTools.GenerateToken(admin)
+		require.NoError(t, err)
+		return "Bearer " + token
+	}
+
+	bookedFee := func(t *testing.T, s *dealSetup, at time.Time) int64 {
+		t.Helper()
+		code, body := bookViaAPI(t, s, at)
+		require.Equal(t, http.StatusCreated, code, string(body))
+		var deal dto.DealResponse
+		require.NoError(t, json.Unmarshal(body, &deal))
+		return deal.PlatformFeeBPS
+	}
+
+	t.Run("default, channel override and promotion", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		token := adminToken(t)
+		channelPath := fmt.Sprintf("/channels/%d", s.channel.TgChannelID)
+
+		assert.Equal(t, int64(testPlatformFeeBPS), bookedFee(t, s, day))
+
+		code, body := feeRequest(t, http.MethodPut, channelPath, token,
+			dto.SetChannelFeeRequest{PlatformFeeBPS: ptrInt64(100)})
+		require.Equal(t, http.StatusNoContent, code, string(body))
+		assert.Equal(t, int64(100), bookedFee(t, s, day.Add(48*time.Hour)))
+
+		code, body = feeRequest(t, http.MethodPost, "/promotions", token,
+			dto.CreatePromotionRequest{
+				TgChannelID: &s.channel.TgChannelID,
+				StartsAt:    time.Now().Add(-time.Hour),
+				EndsAt:      time.Now().Add(time.Hour),
+			})
+		require.Equal(t, http.StatusCreated, code, string(body))
+		var promo dto.PromotionResponse
+		require.NoError(t, json.Unmarshal(body, &promo))
+		assert.Equal(t, int64(0), bookedFee(t, s, day.Add(96*time.Hour)))
+
+		code, body = feeRequest(t, http.MethodGet, "/promotions", token, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var list dto.PromotionsResponse
+		require.NoError(t, json.Unmarshal(body, &list))
+		require.Len(t, list.Promotions, 1)
+		assert.Equal(t, promo.ID, list.Promotions[0].ID)
+
+		code, body = feeRequest(t, http.MethodDelete, "/promotions/"+promo.ID.String(), token, nil)
+		require.Equal(t, http.StatusNoContent, code, string(body))
+		assert.Equal(t, int64(100), bookedFee(t, s, day.Add(144*time.Hour)))
+	})
+
+	t.Run("fee must leave a payout", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		path := fmt.Sprintf("/channels/%d", s.channel.TgChannelID)
+
+		code, _ := feeRequest(t, http.MethodPut, path, adminToken(t),
+			dto.SetChannelFeeRequest{PlatformFeeBPS: ptrInt64(10000)})
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("non-admin cannot configure fees", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		path := fmt.Sprintf("/channels/%d", s.channel.TgChannelID)
+
+		code, _ := feeRequest(t, http.MethodPut, path, s.pubToken,
+			dto.SetChannelFeeRequest{PlatformFeeBPS: ptrInt64(0)})
+		assert.Equal(t, http.StatusForbidden, code)
+
+		code, _ = feeRequest(t, http.MethodGet, "/promotions", s.advToken, nil)
+		assert.Equal(t, http.StatusForbidden, code)
+	})
+
+	t.Run("deal ledger", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		deal, err := testTools.CreateDeal(ctx, s.channel.ID, s.advertiser.ID,
+			entity.DealStatusCompleted, time.Now().Add(-48*time.Hour),
+			entity.AdFormatTypePost, false, 24, 4, 1000000000)
+		require.NoError(t, err)
+		path := "/" + deal.ID.String() + "/ledger"
+
+		code, body := dealRequest(t, http.MethodGet, path, s.pubToken, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var ledger dto.DealLedgerResponse
+		require.NoError(t, json.Unmarshal(body, &ledger))
+		assert.Empty(t, ledger.Entries)
+		assert.Equal(t, int64(0), ledger.EscrowBalanceNanoTON)
+
+		outsider, err := testTools.CreateUser(ctx, 4001011, "Outsider")
+		require.NoError(t, err)
+		token, err := testTools.GenerateToken(outsider)
+		require.NoError(t, err)
+		code, _ = dealRequest(t, http.MethodGet, path, "Bearer "+token, nil)
+		assert.Equal(t, http.StatusForbidden, code)
+	})
+}
@@ -0,0 +1,86 @@
+//go:build integration
+
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+func TestHandleDealMessages(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("thread between both parties", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		dealID := createDealViaAPI(t, ctx, s, "fix it")
+
+		code, body := dealRequest(t, http.MethodPost, "/"+dealID+"/messages", s.advToken,
+			dto.PostMessageRequest{Text: "Which part should change?"})
+		require.Equal(t, http.StatusCreated, code, string(body))
+
+		var posted dto.DealMessageResponse
+		require.NoError(t, json.Unmarshal(body, &posted))
+		assert.Equal(t, entity.DealPartyAdvertiser, posted.AuthorRole)
+		assert.Equal(t, "Advertiser", posted.AuthorName)
+		assert.Equal(t, "Which part should change?", posted.Text)
+
+		code, body = dealRequest(t, http.MethodPost, "/"+dealID+"/messages", s.pubToken,
+			dto.PostMessageRequest{Text: "The link in the last line"})
+		require.Equal(t, http.StatusCreated, code, string(body))
+
+		code, body = dealRequest(t, http.MethodGet, "/"+dealID+"/messages", s.pubToken, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+
+		var resp dto.DealMessagesResponse
+		require.NoError(t, json.Unmarshal(body, &resp))
+		require.Len(t, resp.Messages, 2)
+		assert.Equal(t, posted.ID, resp.Messages[0].ID)
+		assert.Equal(t, entity.DealPartyPublisher, resp.Messages[1].AuthorRole)
+		assert.Equal(t, "Publisher", resp.Messages[1].AuthorName)
+		assert.Equal(t, "The link in the last line", resp.Messages[1].Text)
+	})
+
+	t.Run("empty text", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		dealID := createDealViaAPI(t, ctx, s, "fix it")
+
+		code, _ := dealRequest(t, http.MethodPost, "/"+dealID+"/messages", s.advToken,
+			dto.PostMessageRequest{Text: "   "})
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("too long", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		dealID := createDealViaAPI(t, ctx, s, "fix it")
+
+		code, _ := dealRequest(t, http.MethodPost, "/"+dealID+"/messages", s.advToken,
+			dto.PostMessageRequest{Text: strings.Repeat("a", 3501)})
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("stranger", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		dealID := createDealViaAPI(t, ctx, s, "fix it")
+
+		stranger, err := testTools.CreateUser(ctx, 4001004, "Stranger")
+		require.NoError(t, err)
+		token, err := testTools.GenerateToken(stranger)
+		require.NoError(t, err)
+
+		code, _ := dealRequest(t, http.MethodPost, "/"+dealID+"/messages", "Bearer "+token,
+			dto.PostMessageRequest{Text: "hi"})
+		assert.Equal(t, http.StatusForbidden, code)
+
+		code, _ = dealRequest(t, http.MethodGet, "/"+dealID+"/messages", "Bearer "+token, nil)
+		assert.Equal(t, http.StatusForbidden, code)
+	})
+}
@@ -0,0 +1,120 @@
+//go:build integration
+
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+func createOfferViaAPI(t *testing.T, s *dealSetup, price int64) dto.DealResponse {
+	t.Helper()
+
+	code, body := dealRequest(t, http.MethodPost, "", s.advToken, dto.CreateDealRequest{
+		TgChannelID:    s.channel.TgChannelID,
+		FormatType:     entity.AdFormatTypePost,
+		FeedHours:      24,
+		TopHours:       4,
+		PriceNanoTON:   price,
+		TemplatePostID: s.templatePost.ID.String(),
+		ScheduledAt:    time.Now().Add(48 * time.Hour),
+		Offer:          true,
+	})
+	require.Equal(t, http.StatusCreated, code, string(body))
+
+	var deal dto.DealResponse
+	require.NoError(t, json.Unmarshal(body, &deal))
+	return deal
+}
+
+func TestHandleOffers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("counter and accept", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		deal := createOfferViaAPI(t, s, 300000000)
+		assert.Equal(t, entity.DealStatusNegotiating, deal.Status)
+		assert.Nil(t, deal.Payment)
+
+		scheduledAt := time.Now().Add(72 * time.Hour).Truncate(time.Second)
+		code, body := dealRequest(t, http.MethodPost, "/"+deal.ID+"/offers", s.pubToken,
+			dto.CounterOfferRequest{PriceNanoTON: 800000000, ScheduledAt: scheduledAt})
+		require.Equal(t, http.StatusCreated, code, string(body))
+
+		var counter dto.DealOfferResponse
+		require.NoError(t, json.Unmarshal(body, &counter))
+		assert.Equal(t, entity.DealPartyPublisher, counter.AuthorRole)
+		assert.Equal(t, entity.DealOfferStatusPending, counter.Status)
+
+		code, body = dealRequest(t, http.MethodPost, "/"+deal.ID+"/offers/accept", s.advToken, nil)
+		require.Equal(t, http.StatusNoContent, code, string(body))
+
+		code, body = dealRequest(t, http.MethodGet, "/"+deal.ID, s.advToken, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var accepted dto.DealResponse
+		require.NoError(t, json.Unmarshal(body, &accepted))
+		assert.Equal(t, entity.DealStatusPendingPayment, accepted.Status)
+		assert.Equal(t, int64(800000000), accepted.PriceNanoTON)
+		assert.True(t, scheduledAt.Equal(accepted.ScheduledAt))
+		require.NotNil(t, accepted.Payment)
+		assert.Equal(t, int64(800000000), accepted.Payment.AmountNanoTON)
+
+		code, body = dealRequest(t, http.MethodGet, "/"+deal.ID+"/offers", s.pubToken, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var resp dto.DealOffersResponse
+		require.NoError(t, json.Unmarshal(body, &resp))
+		require.Len(t, resp.Offers, 2)
+		assert.Equal(t, entity.DealPartyAdvertiser, resp.Offers[0].AuthorRole)
+		assert.Equal(t, entity.DealOfferStatusCountered, resp.Offers[0].Status)
+		assert.Equal(t, counter.ID, resp.Offers[1].ID)
+		assert.Equal(t, entity.DealOfferStatusAccepted, resp.Offers[1].Status)
+	})
+
+	t.Run("cannot accept own offer", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		deal := createOfferViaAPI(t, s, 300000000)
+
+		code, _ := dealRequest(t, http.MethodPost, "/"+deal.ID+"/offers/accept", s.advToken, nil)
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("publisher declines", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		deal := createOfferViaAPI(t, s, 300000000)
+
+		reason := "below my rate"
+		code, body := dealRequest(t, http.MethodPost, "/"+deal.ID+"/offers/decline", s.pubToken,
+			dto.DeclineOfferRequest{Reason: &reason})
+		require.Equal(t, http.StatusNoContent, code, string(body))
+
+		code, body = dealRequest(t, http.MethodGet, "/"+deal.ID, s.advToken, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var declined dto.DealResponse
+		require.NoError(t, json.Unmarshal(body, &declined))
+		assert.Equal(t, entity.DealStatusRejected, declined.Status)
+		require.NotNil(t, declined.PublisherNote)
+		assert.Equal(t, reason, *declined.PublisherNote)
+	})
+
+	t.Run("stranger", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		deal := createOfferViaAPI(t, s, 300000000)
+
+		stranger, err := testTools.CreateUser(ctx, 4001005, "Stranger")
+		require.NoError(t, err)
+		token, err := testTools.GenerateToken(stranger)
+		require.NoError(t, err)
+
+		code, _ := dealRequest(t, http.MethodGet, "/"+deal.ID+"/offers", "Bearer "+token, nil)
+		assert.Equal(t, http.StatusForbidden, code)
+	})
+}
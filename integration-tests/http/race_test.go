@@ -0,0 +1,135 @@
+//go:build integration
+
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+const raceRounds = 10
+
+// race sends both requests at once and returns their status codes
+func race(t *testing.T, a, b func() int) (int, int) {
+	t.Helper()
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	var codeA, codeB int
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		<-start
+		codeA = a()
+	}()
+	go func() {
+		defer wg.Done()
+		<-start
+		codeB = b()
+	}()
+	close(start)
+	wg.Wait()
+
+	return codeA, codeB
+}
+
+func TestDealTransitionRaces(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("approve against cancel", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+
+		for range raceRounds {
+			deal, err := testTools.CreateDeal(ctx, s.channel.ID, s.advertiser.ID,
+				entity.DealStatusPendingReview, time.Now().Add(48*time.Hour),
+				entity.AdFormatTypePost, false, 24, 4, 1000000000)
+			require.NoError(t, err)
+			require.NoError(t, testTools.SetPaid(ctx, deal.ID, "tx-"+deal.ID.String()))
+			require.NoError(t, testTools.CreateRevision(ctx, deal.ID, s.advertiser.ID, 1))
+			path := "/" + deal.ID.String()
+
+			approve, cancel := race(t,
+				func() int {
+					code, _ := dealRequest(t, http.MethodPost, path+"/approve", s.pubToken, nil)
+					return code
+				},
+				func() int {
+					code, _ := dealRequest(t, http.MethodPost, path+"/cancel", s.advToken, nil)
+					return code
+				},
+			)
+
+			got, err := testTools.GetDeal(ctx, deal.ID)
+			require.NoError(t, err)
+			msgs, err := testTools.GetOutboxMessages(ctx, deal.ID)
+			require.NoError(t, err)
+			events, err := testTools.GetDealEvents(ctx, deal.ID)
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+
+			if approve == http.StatusNoContent {
+				assert.Equal(t, http.StatusBadRequest, cancel)
+				assert.Equal(t, entity.DealStatusApproved, got.Status)
+				assert.Empty(t, msgs)
+				assert.Equal(t, entity.DealEventRevisionApproved, events[0].Type)
+			} else {
+				assert.Equal(t, http.StatusBadRequest, approve)
+				assert.Equal(t, http.StatusNoContent, cancel)
+				assert.Equal(t, entity.DealStatusCancelled, got.Status)
+				assert.Len(t, msgs, 1)
+				assert.Equal(t, entity.DealEventCancelled, events[0].Type)
+			}
+		}
+	})
+
+	t.Run("reject against request changes", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+
+		for range raceRounds {
+			deal, err := testTools.CreateDeal(ctx, s.channel.ID, s.advertiser.ID,
+				entity.DealStatusPendingReview, time.Now().Add(48*time.Hour),
+				entity.AdFormatTypePost, false, 24, 4, 1000000000)
+			require.NoError(t, err)
+			require.NoError(t, testTools.CreateRevision(ctx, deal.ID, s.advertiser.ID, 1))
+			path := "/" + deal.ID.String()
+
+			reject, changes := race(t,
+				func() int {
+					code, _ := dealRequest(t, http.MethodPost, path+"/reject", s.pubToken, nil)
+					return code
+				},
+				func() int {
+					code, _ := dealRequest(t, http.MethodPost, path+"/request-changes",
+						s.pubToken, dto.RequestChangesRequest{Note: "shorter"})
+					return code
+				},
+			)
+
+			got, err := testTools.GetDeal(ctx, deal.ID)
+			require.NoError(t, err)
+			events, err := testTools.GetDealEvents(ctx, deal.ID)
+			require.NoError(t, err)
+
+			require.Len(t, events, 1)
+			if reject == http.StatusNoContent {
+				assert.Equal(t, http.StatusBadRequest, changes)
+				assert.Equal(t, entity.DealStatusRejected, got.Status)
+				assert.Equal(t, entity.DealEventRejected, events[0].Type)
+			} else {
+				assert.Equal(t, http.StatusBadRequest, reject)
+				assert.Equal(t, http.StatusNoContent, changes)
+				assert.Equal(t, entity.DealStatusChangesRequested, got.Status)
+				assert.Equal(t, entity.DealEventChangesRequested, events[0].Type)
+			}
+		}
+	})
+}
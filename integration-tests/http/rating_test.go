@@ -0,0 +1,113 @@
+//go:build integration
+
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+func marketplaceChannels(
+	t *testing.T, token string, req dto.MarketplaceChannelsRequest,
+) dto.MarketplaceChannelsResponse {
+	t.Helper()
+
+	raw, err := json.Marshal(req)
+	require.NoError(t, err)
+	httpReq, err := http.NewRequest(
+		http.MethodPost, testServer.URL+"/api/v1/mp/channels", bytes.NewReader(raw),
+	)
+	require.NoError(t, err)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", token)
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	var channels dto.MarketplaceChannelsResponse
+	require.NoError(t, json.Unmarshal(body, &channels))
+	return channels
+}
+
+func TestHandleRatings(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("both sides rate once", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		deal, err := testTools.CreateDeal(ctx, s.channel.ID, s.advertiser.ID,
+			entity.DealStatusCompleted, time.Now().Add(-48*time.Hour),
+			entity.AdFormatTypePost, false, 24, 4, 1000000000)
+		require.NoError(t, err)
+		path := "/" + deal.ID.String() + "/ratings"
+
+		comment := "great channel"
+		code, body := dealRequest(t, http.MethodPost, path, s.advToken,
+			dto.RateDealRequest{Rating: 5, Comment: &comment})
+		require.Equal(t, http.StatusCreated, code, string(body))
+
+		var rated dto.DealRatingResponse
+		require.NoError(t, json.Unmarshal(body, &rated))
+		assert.Equal(t, entity.DealPartyAdvertiser, rated.AuthorRole)
+
+		code, _ = dealRequest(t, http.MethodPost, path, s.advToken,
+			dto.RateDealRequest{Rating: 1})
+		assert.Equal(t, http.StatusConflict, code)
+
+		code, body = dealRequest(t, http.MethodPost, path, s.pubToken,
+			dto.RateDealRequest{Rating: 4})
+		require.Equal(t, http.StatusCreated, code, string(body))
+
+		code, body = dealRequest(t, http.MethodGet, path, s.pubToken, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var list dto.DealRatingsResponse
+		require.NoError(t, json.Unmarshal(body, &list))
+		assert.Len(t, list.Ratings, 2)
+
+		require.NoError(t, testTools.RefreshMarketplace(ctx))
+
+		channels := marketplaceChannels(t, s.advToken, dto.MarketplaceChannelsRequest{
+			Filters: []dto.MarketplaceFilter{{Name: "min_rating", Value: 4.5}},
+			SortBy:  entity.ChannelSortByRating,
+		})
+		require.Len(t, channels.Channels, 1)
+		ch := channels.Channels[0]
+		require.NotNil(t, ch.Rating)
+		// only the advertiser's rating counts towards the channel
+		assert.InDelta(t, 5.0, *ch.Rating, 1e-9)
+		assert.Equal(t, 1, ch.RatingCount)
+		assert.Equal(t, 1, ch.CompletedDeals)
+
+		channels = marketplaceChannels(t, s.advToken, dto.MarketplaceChannelsRequest{
+			Filters: []dto.MarketplaceFilter{{Name: "min_completed_deals", Value: 2}},
+		})
+		assert.Empty(t, channels.Channels)
+	})
+
+	t.Run("deal not finished", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		deal, err := testTools.CreateDeal(ctx, s.channel.ID, s.advertiser.ID,
+			entity.DealStatusPosted, time.Now().Add(-time.Hour),
+			entity.AdFormatTypePost, false, 24, 4, 1000000000)
+		require.NoError(t, err)
+
+		code, _ := dealRequest(t, http.MethodPost, "/"+deal.ID.String()+"/ratings", s.advToken,
+			dto.RateDealRequest{Rating: 3})
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
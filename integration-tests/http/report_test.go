@@ -0,0 +1,69 @@
+//go:build integration
+
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+func TestHandleDealReport(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("posted deal", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		postedAt := time.Now().Add(-3 * time.Hour).Truncate(time.Second)
+		deal, err := testTools.CreateDeal(ctx, s.channel.ID, s.advertiser.ID,
+			entity.DealStatusPosted, postedAt,
+			entity.AdFormatTypePost, false, 24, 4, 1000000000)
+		require.NoError(t, err)
+		require.NoError(t, testTools.SetPosted(ctx, deal.ID, []int64{10}, postedAt))
+
+		for i, views := range []int64{800, 1600, 2000} {
+			require.NoError(t, testTools.CreateViewSnapshot(ctx, entity.DealViewSnapshot{
+				DealID:    deal.ID,
+				TakenAt:   postedAt.Add(time.Duration(i+1) * time.Hour),
+				Views:     views,
+				Forwards:  int64(i),
+				Reactions: int64(2 * i),
+			}))
+		}
+
+		code, body := dealRequest(t, http.MethodGet, "/"+deal.ID.String()+"/report",
+			s.pubToken, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+
+		var report dto.DealReportResponse
+		require.NoError(t, json.Unmarshal(body, &report))
+		assert.True(t, postedAt.Equal(report.PostedAt))
+		assert.True(t, postedAt.Add(24*time.Hour).Equal(report.FeedEndsAt))
+		require.Len(t, report.Curve, 3)
+		assert.Equal(t, int64(800), report.Curve[0].Views)
+		assert.Equal(t, int64(2000), report.Views)
+		assert.Equal(t, int64(4), report.Reactions)
+		require.NotNil(t, report.CPMNanoTON)
+		assert.Equal(t, int64(500000000), *report.CPMNanoTON)
+		assert.Nil(t, report.ViewsToChannelAvg)
+	})
+
+	t.Run("not posted yet", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		deal, err := testTools.CreateDeal(ctx, s.channel.ID, s.advertiser.ID,
+			entity.DealStatusApproved, time.Now().Add(24*time.Hour),
+			entity.AdFormatTypePost, false, 24, 4, 1000000000)
+		require.NoError(t, err)
+
+		code, _ := dealRequest(t, http.MethodGet, "/"+deal.ID.String()+"/report",
+			s.advToken, nil)
+		assert.Equal(t, http.StatusConflict, code)
+	})
+}
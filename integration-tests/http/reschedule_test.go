@@ -0,0 +1,119 @@
+//go:build integration
+
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+func TestHandleReschedules(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("approved deal moved", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		deal, err := testTools.CreateDeal(ctx, s.channel.ID, s.advertiser.ID,
+			entity.DealStatusApproved, time.Now().Add(48*time.Hour),
+			entity.AdFormatTypePost, false, 24, 4, 1000000000)
+		require.NoError(t, err)
+		path := "/" + deal.ID.String()
+
+		at := time.Now().Add(96 * time.Hour).Truncate(time.Second)
+		code, body := dealRequest(t, http.MethodPost, path+"/reschedules", s.pubToken,
+			dto.RescheduleRequest{ScheduledAt: at})
+		require.Equal(t, http.StatusCreated, code, string(body))
+
+		var proposed dto.DealRescheduleResponse
+		require.NoError(t, json.Unmarshal(body, &proposed))
+		assert.Equal(t, entity.DealPartyPublisher, proposed.AuthorRole)
+		assert.Equal(t, entity.DealRescheduleStatusPending, proposed.Status)
+
+		code, _ = dealRequest(t, http.MethodPost, path+"/reschedules/accept", s.pubToken, nil)
+		assert.Equal(t, http.StatusBadRequest, code)
+
+		code, body = dealRequest(t, http.MethodPost, path+"/reschedules/accept", s.advToken, nil)
+		require.Equal(t, http.StatusNoContent, code, string(body))
+
+		updated, err := testTools.GetDeal(ctx, deal.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.DealStatusApproved, updated.Status)
+		assert.True(t, at.Equal(updated.ScheduledAt))
+
+		code, body = dealRequest(t, http.MethodGet, path+"/reschedules", s.advToken, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var resp dto.DealReschedulesResponse
+		require.NoError(t, json.Unmarshal(body, &resp))
+		require.Len(t, resp.Reschedules, 1)
+		assert.Equal(t, entity.DealRescheduleStatusAccepted, resp.Reschedules[0].Status)
+
+		events, err := testTools.GetDealEvents(ctx, deal.ID)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, entity.DealEventRescheduleRequested, events[0].Type)
+		assert.Equal(t, entity.DealEventRescheduled, events[1].Type)
+	})
+
+	t.Run("declined keeps time", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		scheduledAt := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+		deal, err := testTools.CreateDeal(ctx, s.channel.ID, s.advertiser.ID,
+			entity.DealStatusPendingReview, scheduledAt,
+			entity.AdFormatTypePost, false, 24, 4, 1000000000)
+		require.NoError(t, err)
+		path := "/" + deal.ID.String()
+
+		code, body := dealRequest(t, http.MethodPost, path+"/reschedules", s.advToken,
+			dto.RescheduleRequest{ScheduledAt: time.Now().Add(96 * time.Hour)})
+		require.Equal(t, http.StatusCreated, code, string(body))
+
+		code, body = dealRequest(t, http.MethodPost, path+"/reschedules/decline", s.pubToken, nil)
+		require.Equal(t, http.StatusNoContent, code, string(body))
+
+		updated, err := testTools.GetDeal(ctx, deal.ID)
+		require.NoError(t, err)
+		assert.True(t, scheduledAt.Equal(updated.ScheduledAt))
+	})
+
+	t.Run("slot taken", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		deal, err := testTools.CreateDeal(ctx, s.channel.ID, s.advertiser.ID,
+			entity.DealStatusApproved, time.Now().Add(48*time.Hour),
+			entity.AdFormatTypePost, false, 24, 4, 1000000000)
+		require.NoError(t, err)
+		other := time.Now().Add(96 * time.Hour)
+		_, err = testTools.CreateDeal(ctx, s.channel.ID, s.advertiser.ID,
+			entity.DealStatusApproved, other,
+			entity.AdFormatTypePost, false, 24, 4, 1000000000)
+		require.NoError(t, err)
+
+		code, body := dealRequest(t, http.MethodPost, "/"+deal.ID.String()+"/reschedules",
+			s.advToken, dto.RescheduleRequest{ScheduledAt: other.Add(-2 * time.Hour)})
+		assert.Equal(t, http.StatusConflict, code, string(body))
+	})
+
+	t.Run("stranger", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		deal, err := testTools.CreateDeal(ctx, s.channel.ID, s.advertiser.ID,
+			entity.DealStatusApproved, time.Now().Add(48*time.Hour),
+			entity.AdFormatTypePost, false, 24, 4, 1000000000)
+		require.NoError(t, err)
+
+		stranger, err := testTools.CreateUser(ctx, 4001006, "Stranger")
+		require.NoError(t, err)
+		token, err := testTools.GenerateToken(stranger)
+		require.NoError(t, err)
+
+		code, _ := dealRequest(t, http.MethodPost, "/"+deal.ID.String()+"/reschedules",
+			"Bearer "+token, dto.RescheduleRequest{ScheduledAt: time.Now().Add(96 * time.Hour)})
+		assert.Equal(t, http.StatusForbidden, code)
+	})
+}
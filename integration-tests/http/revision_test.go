@@ -0,0 +1,275 @@
+//go:build integration
+
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+// dealRequest sends body as JSON to a deal endpoint and returns the status
+// code and the response body
+func dealRequest(
+	t *testing.T,
+	method, path, token string,
+	body any,
+) (int, []byte) {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequest(method, testServer.URL+"/api/v1/deals"+path, reader)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", token)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, respBody
+}
+
+// createDealViaAPI creates a deal from the setup's template and moves it to
+// changes_requested with the given publisher note
+func createDealViaAPI(t *testing.T, ctx context.Context, s *dealSetup, note string) string {
+	t.Helper()
+
+	code, body := dealRequest(t, http.MethodPost, "", s.advToken, dto.CreateDealRequest{
+		TgChannelID:    s.channel.TgChannelID,
+		FormatType:     entity.AdFormatTypePost,
+		FeedHours:      24,
+		TopHours:       4,
+		PriceNanoTON:   1000000000,
+		TemplatePostID: s.templatePost.ID.String(),
+		ScheduledAt:    time.Now().Add(48 * time.Hour),
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	var deal dto.DealResponse
+	require.NoError(t, json.Unmarshal(body, &deal))
+
+	id, err := uuid.Parse(deal.ID)
+	require.NoError(t, err)
+	require.NoError(t, testTools.SetStatus(ctx, id, entity.DealStatusPendingReview))
+
+	code, _ = dealRequest(t, http.MethodPost, "/"+deal.ID+"/request-changes", s.pubToken,
+		dto.RequestChangesRequest{Note: note})
+	require.Equal(t, http.StatusNoContent, code)
+
+	return deal.ID
+}
+
+func TestHandleSubmitRevision(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("new text keeps history", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		dealID := createDealViaAPI(t, ctx, s, "please shorten the text")
+
+		text := "Shorter text"
+		code, body := dealRequest(t, http.MethodPost, "/"+dealID+"/revisions", s.advToken,
+			dto.SubmitRevisionRequest{Text: &text})
+		require.Equal(t, http.StatusCreated, code, string(body))
+
+		var rev dto.RevisionResponse
+		require.NoError(t, json.Unmarshal(body, &rev))
+		assert.Equal(t, 2, rev.Version)
+		assert.Equal(t, entity.DealPartyAdvertiser, rev.AuthorRole)
+		assert.NotNil(t, rev.AdvertiserApprovedAt)
+		assert.Nil(t, rev.PublisherApprovedAt)
+		assert.Equal(t, "Advertiser", rev.AuthorName)
+		require.NotNil(t, rev.PublisherNote)
+		assert.Equal(t, "please shorten the text", *rev.PublisherNote)
+		require.NotNil(t, rev.Ad.Text)
+		assert.Equal(t, text, *rev.Ad.Text)
+
+		code, body = dealRequest(t, http.MethodGet, "/"+dealID, s.pubToken, nil)
+		require.Equal(t, http.StatusOK, code)
+		var deal dto.DealResponse
+		require.NoError(t, json.Unmarshal(body, &deal))
+		assert.Equal(t, entity.DealStatusPendingReview, deal.Status)
+		assert.Equal(t, text, *deal.Ad.Text)
+
+		code, body = dealRequest(t, http.MethodGet, "/"+dealID+"/revisions", s.pubToken, nil)
+		require.Equal(t, http.StatusOK, code)
+
+		var history dto.RevisionsResponse
+		require.NoError(t, json.Unmarshal(body, &history))
+		require.Len(t, history.Revisions, 2)
+		assert.Equal(t, 1, history.Revisions[0].Version)
+		assert.Nil(t, history.Revisions[0].PublisherNote)
+		assert.Equal(t, "Ad creative text", *history.Revisions[0].Ad.Text)
+		assert.Equal(t, 2, history.Revisions[1].Version)
+		assert.Equal(t, "please shorten the text", *history.Revisions[1].PublisherNote)
+		assert.Equal(t, text, *history.Revisions[1].Ad.Text)
+	})
+
+	t.Run("from template", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		dealID := createDealViaAPI(t, ctx, s, "use the other creative")
+
+		other := "Other creative"
+		tmpl, err := testTools.CreatePost(ctx, entity.PostTypeTemplate, s.advertiser.ID,
+			nil, nil, &other, nil, nil, nil)
+		require.NoError(t, err)
+
+		templateID := tmpl.ID.String()
+		code, body := dealRequest(t, http.MethodPost, "/"+dealID+"/revisions", s.advToken,
+			dto.SubmitRevisionRequest{TemplatePostID: &templateID})
+		require.Equal(t, http.StatusCreated, code, string(body))
+
+		var rev dto.RevisionResponse
+		require.NoError(t, json.Unmarshal(body, &rev))
+		assert.Equal(t, 2, rev.Version)
+		assert.Equal(t, other, *rev.Ad.Text)
+	})
+
+	t.Run("deal already approved", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		dealID := createDealViaAPI(t, ctx, s, "fix it")
+		require.NoError(t, testTools.SetStatus(ctx, uuid.MustParse(dealID),
+			entity.DealStatusApproved))
+
+		text := "late edit"
+		code, _ := dealRequest(t, http.MethodPost, "/"+dealID+"/revisions", s.advToken,
+			dto.SubmitRevisionRequest{Text: &text})
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("both template and text", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		dealID := createDealViaAPI(t, ctx, s, "fix it")
+
+		text := "text"
+		templateID := s.templatePost.ID.String()
+		code, _ := dealRequest(t, http.MethodPost, "/"+dealID+"/revisions", s.advToken,
+			dto.SubmitRevisionRequest{TemplatePostID: &templateID, Text: &text})
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("publisher edit confirmed by advertiser", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		dealID := createDealViaAPI(t, ctx, s, "fix it")
+
+		text := "Edited by the channel"
+		code, body := dealRequest(t, http.MethodPost, "/"+dealID+"/revisions", s.pubToken,
+			dto.SubmitRevisionRequest{Text: &text})
+		require.Equal(t, http.StatusCreated, code, string(body))
+
+		var rev dto.RevisionResponse
+		require.NoError(t, json.Unmarshal(body, &rev))
+		assert.Equal(t, entity.DealPartyPublisher, rev.AuthorRole)
+		assert.Nil(t, rev.AdvertiserApprovedAt)
+		assert.NotNil(t, rev.PublisherApprovedAt)
+
+		code, _ = dealRequest(t, http.MethodPost, "/"+dealID+"/approve", s.pubToken, nil)
+		assert.Equal(t, http.StatusBadRequest, code)
+
+		code, _ = dealRequest(t, http.MethodPost, "/"+dealID+"/approve", s.advToken,
+			dto.ApproveRequest{Version: &rev.Version})
+		require.Equal(t, http.StatusNoContent, code)
+
+		deal, err := testTools.GetDeal(ctx, uuid.MustParse(dealID))
+		require.NoError(t, err)
+		assert.Equal(t, entity.DealStatusApproved, deal.Status)
+	})
+
+	t.Run("stale version", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		dealID := createDealViaAPI(t, ctx, s, "fix it")
+
+		text := "Edited by the channel"
+		code, _ := dealRequest(t, http.MethodPost, "/"+dealID+"/revisions", s.pubToken,
+			dto.SubmitRevisionRequest{Text: &text})
+		require.Equal(t, http.StatusCreated, code)
+
+		version := 1
+		code, _ = dealRequest(t, http.MethodPost, "/"+dealID+"/approve", s.advToken,
+			dto.ApproveRequest{Version: &version})
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+func TestHandleListRevisions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("stranger", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		dealID := createDealViaAPI(t, ctx, s, "fix it")
+
+		stranger, err := testTools.CreateUser(ctx, 4001003, "Stranger")
+		require.NoError(t, err)
+		token, err := testTools.GenerateToken(stranger)
+		require.NoError(t, err)
+
+		code, _ := dealRequest(t, http.MethodGet, "/"+dealID+"/revisions", "Bearer "+token, nil)
+		assert.Equal(t, http.StatusForbidden, code)
+	})
+}
+
+func TestHandleDiffRevisions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("latest against previous", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		dealID := createDealViaAPI(t, ctx, s, "make it punchier")
+
+		text := "Ad creative text, now punchier"
+		code, _ := dealRequest(t, http.MethodPost, "/"+dealID+"/revisions", s.advToken,
+			dto.SubmitRevisionRequest{Text: &text})
+		require.Equal(t, http.StatusCreated, code)
+
+		code, body := dealRequest(t, http.MethodGet, "/"+dealID+"/revisions/diff", s.pubToken, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+
+		var diff dto.RevisionDiffResponse
+		require.NoError(t, json.Unmarshal(body, &diff))
+		assert.Equal(t, 1, diff.From)
+		assert.Equal(t, 2, diff.To)
+		require.NotNil(t, diff.Text)
+		assert.Equal(t, []dto.TextSegment{
+			{Op: dto.TextDiffEqual, Text: "Ad creative "},
+			{Op: dto.TextDiffDelete, Text: "text"},
+			{Op: dto.TextDiffInsert, Text: "text, now punchier"},
+		}, diff.Text.Segments)
+		assert.Empty(t, diff.Media)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		dealID := createDealViaAPI(t, ctx, s, "fix it")
+
+		code, _ := dealRequest(t, http.MethodGet, "/"+dealID+"/revisions/diff?from=1&to=7",
+			s.pubToken, nil)
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		s := setupDeal(t, ctx)
+		dealID := createDealViaAPI(t, ctx, s, "fix it")
+
+		code, _ := dealRequest(t, http.MethodGet, "/"+dealID+"/revisions/diff?to=latest",
+			s.pubToken, nil)
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
@@ -23,15 +23,30 @@ import (
 	"github.com/bpva/ad-marketplace/internal/dto"
 	"github.com/bpva/ad-marketplace/internal/entity"
 	"github.com/bpva/ad-marketplace/internal/http/app"
+	campaign_repo "github.com/bpva/ad-marketplace/internal/repository/campaign"
 	channel_repo "github.com/bpva/ad-marketplace/internal/repository/channel"
 	deal_repo "github.com/bpva/ad-marketplace/internal/repository/deal"
+	dispute_repo "github.com/bpva/ad-marketplace/internal/repository/dispute"
+	event_repo "github.com/bpva/ad-marketplace/internal/repository/event"
+	ledger_repo "github.com/bpva/ad-marketplace/internal/repository/ledger"
+	message_repo "github.com/bpva/ad-marketplace/internal/repository/message"
+	offer_repo "github.com/bpva/ad-marketplace/internal/repository/offer"
+	outbox_repo "github.com/bpva/ad-marketplace/internal/repository/outbox"
 	post_repo "github.com/bpva/ad-marketplace/internal/repository/post"
+	promotion_repo "github.com/bpva/ad-marketplace/internal/repository/promotion"
+	rating_repo "github.com/bpva/ad-marketplace/internal/repository/rating"
+	reschedule_repo "github.com/bpva/ad-marketplace/internal/repository/reschedule"
+	revision_repo "github.com/bpva/ad-marketplace/internal/repository/revision"
 	settings_repo "github.com/bpva/ad-marketplace/internal/repository/settings"
+	snapshot_repo "github.com/bpva/ad-marketplace/internal/repository/snapshot"
+	transfer_repo "github.com/bpva/ad-marketplace/internal/repository/transfer"
 	user_repo "github.com/bpva/ad-marketplace/internal/repository/user"
 	"github.com/bpva/ad-marketplace/internal/service/auth"
 	"github.com/bpva/ad-marketplace/internal/service/bot"
+	campaign_service "github.com/bpva/ad-marketplace/internal/service/campaign"
 	channel_service "github.com/bpva/ad-marketplace/internal/service/channel"
 	deal_service "github.com/bpva/ad-marketplace/internal/service/deal"
+	"github.com/bpva/ad-marketplace/internal/service/escrow"
 	post_service "github.com/bpva/ad-marketplace/internal/service/post"
 	"github.com/bpva/ad-marketplace/internal/service/stats"
 	"github.com/bpva/ad-marketplace/internal/service/tonrates"
@@ -55,6 +70,16 @@ var (
 const (
 	testJWTSecret = "test-jwt-secret-32-bytes-long!!"
 	testBotToken  = "123456:ABC-DEF1234ghIkl-zyx57W2v1u123ew11"
+
+	testEscrowAddress = "EQEscrow000"
+
+	// tg id of the user allowed to rule on disputes
+	testArbiterTgID = 4001007
+
+	// tg id of the user allowed to configure platform fees
+	testAdminTgID = 4001010
+
+	testPlatformFeeBPS = 250
 )
 
 func TestMain(m *testing.M) {
@@ -185,6 +210,56 @@ func setupTestServer(testDB db) *httptest.Server {
 		AnyTimes()
 	statsSvc := stats.New(mockMTProto, channelRepo, log)
 
+	dealRepo := deal_repo.New(testDB)
+	channelSvc := channel_service.New(channelRepo, dealRepo, userRepo, telebotMock, testDB, log)
+	userSvc := user_service.New(userRepo, settingsRepo, log)
+	postRepo := post_repo.New(testDB)
+	postSvc := post_service.New(postRepo, telebotMock, log)
+	tonRatesSvc := tonrates.New(log)
+	transferRepo := transfer_repo.New(testDB)
+	outboxRepo := outbox_repo.New(testDB)
+	revisionRepo := revision_repo.New(testDB)
+	eventRepo := event_repo.New(testDB)
+	messageRepo := message_repo.New(testDB)
+	offerRepo := offer_repo.New(testDB)
+	rescheduleRepo := reschedule_repo.New(testDB)
+	disputeRepo := dispute_repo.New(testDB)
+	snapshotRepo := snapshot_repo.New(testDB)
+	ratingRepo := rating_repo.New(testDB)
+	promotionRepo := promotion_repo.New(testDB)
+	ledgerRepo := ledger_repo.New(testDB)
+	escrowWallet := escrow.NewWallet(testEscrowAddress)
+	dealSvc := deal_service.New(
+		config.Deal{
+			PaymentTimeout: time.Hour,
+			Arbiters:       []int64{testArbiterTgID},
+			Admins:         []int64{testAdminTgID},
+			PlatformFeeBPS: testPlatformFeeBPS,
+		},
+		dealRepo,
+		channelRepo,
+		postRepo,
+		userRepo,
+		transferRepo,
+		outboxRepo,
+		revisionRepo,
+		eventRepo,
+		messageRepo,
+		offerRepo,
+		rescheduleRepo,
+		disputeRepo,
+		snapshotRepo,
+		ratingRepo,
+		promotionRepo,
+		ledgerRepo,
+		testDB,
+		escrowWallet,
+		log,
+	)
+	campaignRepo := campaign_repo.New(testDB)
+	campaignSvc := campaign_service.New(
+		campaignRepo, dealRepo, channelRepo, postRepo, dealSvc, testDB, log,
+	)
 	botSvc := bot.New(
 		telebotMock,
 		config.Telegram{},
@@ -193,17 +268,15 @@ func setupTestServer(testDB db) *httptest.Server {
 		channelRepo,
 		userRepo,
 		statsSvc,
-		post_repo.New(testDB),
+		postRepo,
+		messageRepo,
+		dealSvc,
 	)
-	channelSvc := channel_service.New(channelRepo, userRepo, telebotMock, testDB, log)
-	userSvc := user_service.New(userRepo, settingsRepo, log)
-	postRepo := post_repo.New(testDB)
-	postSvc := post_service.New(postRepo, telebotMock, log)
-	tonRatesSvc := tonrates.New(log)
-	dealRepo := deal_repo.New(testDB)
-	dealSvc := deal_service.New(dealRepo, channelRepo, postRepo, userRepo, testDB, log)
 
-	a := app.New(httpCfg, log, botSvc, authSvc, channelSvc, userSvc, postSvc, tonRatesSvc, dealSvc)
+	a := app.New(
+		httpCfg, log, botSvc, authSvc, channelSvc, userSvc, postSvc, tonRatesSvc, dealSvc,
+		campaignSvc,
+	)
 	return httptest.NewServer(a.Handler())
 }
 
@@ -12,5 +12,15 @@ func (t *Tools) Truncate(ctx context.Context, tables ...string) error {
 }
 
 func (t *Tools) TruncateAll(ctx context.Context) error {
-	return t.Truncate(ctx, "deals", "posts", "channel_roles", "channels", "users")
+	return t.Truncate(ctx,
+		"escrow_cursors", "deal_message_relays", "deal_messages", "deal_offers",
+		"deal_reschedules", "dispute_evidence", "deal_disputes", "deal_events", "ad_revisions",
+		"deal_view_snapshots", "deal_ratings", "ledger_entries", "outbox", "transfers",
+		"deals", "fee_promotions", "campaigns", "posts", "channel_inventory", "channel_roles",
+		"channels", "users")
+}
+
+func (t *Tools) RefreshMarketplace(ctx context.Context) error {
+	_, err := t.pool.Exec(ctx, "REFRESH MATERIALIZED VIEW channel_marketplace")
+	return err
 }
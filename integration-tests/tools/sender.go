@@ -0,0 +1,58 @@
+package tools
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	tele "gopkg.in/telebot.v4"
+)
+
+// FakeSender records bot messages instead of sending them to Telegram.
+type FakeSender struct {
+	mu   sync.Mutex
+	sent []SentMessage
+}
+
+type SentMessage struct {
+	ChatID int64
+	Text   string
+	Markup *tele.ReplyMarkup
+}
+
+func NewFakeSender() *FakeSender {
+	return &FakeSender{}
+}
+
+func (f *FakeSender) Send(to tele.Recipient, what any, opts ...any) (*tele.Message, error) {
+	chatID, err := strconv.ParseInt(to.Recipient(), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse recipient: %w", err)
+	}
+
+	msg := SentMessage{ChatID: chatID, Text: fmt.Sprint(what)}
+	for _, opt := range opts {
+		if markup, ok := opt.(*tele.ReplyMarkup); ok {
+			msg.Markup = markup
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, msg)
+
+	return &tele.Message{ID: len(f.sent)}, nil
+}
+
+// Sent returns the messages sent so far.
+func (f *FakeSender) Sent() []SentMessage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]SentMessage{}, f.sent...)
+}
+
+func (f *FakeSender) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = nil
+}
@@ -0,0 +1,197 @@
+package tools
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FakeTONCenter is a minimal toncenter v2 API served over httptest.
+type FakeTONCenter struct {
+	*httptest.Server
+
+	mu    sync.Mutex
+	txs   map[string][]fakeTransaction
+	seqno map[string]int64
+	bocs  []string
+	lt    int64
+}
+
+type fakeTransaction struct {
+	UTime         int64 `json:"utime"`
+	TransactionID struct {
+		LT   string `json:"lt"`
+		Hash string `json:"hash"`
+	} `json:"transaction_id"`
+	InMsg   *fakeMessage  `json:"in_msg"`
+	OutMsgs []fakeMessage `json:"out_msgs"`
+}
+
+type fakeMessage struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	Value       string `json:"value"`
+	Message     string `json:"message"`
+}
+
+func NewFakeTONCenter() *FakeTONCenter {
+	f := &FakeTONCenter{
+		txs:   make(map[string][]fakeTransaction),
+		seqno: make(map[string]int64),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /getTransactions", f.handleGetTransactions)
+	mux.HandleFunc("GET /getWalletInformation", f.handleGetWalletInformation)
+	mux.HandleFunc("POST /sendBoc", f.handleSendBoc)
+	f.Server = httptest.NewServer(mux)
+
+	return f
+}
+
+// AddIncoming records a transfer to address and returns its hash.
+func (f *FakeTONCenter) AddIncoming(
+	address, source string,
+	valueNanoTON int64,
+	comment string,
+	at time.Time,
+) string {
+	tx := newFakeTransaction(at)
+	tx.InMsg = &fakeMessage{
+		Source:      source,
+		Destination: address,
+		Value:       strconv.FormatInt(valueNanoTON, 10),
+		Message:     comment,
+	}
+	return f.add(address, tx)
+}
+
+// AddOutgoing records a transfer executed by the wallet at address, bumping
+// its seqno, and returns the transaction hash.
+func (f *FakeTONCenter) AddOutgoing(
+	address, destination string,
+	valueNanoTON int64,
+	comment string,
+	at time.Time,
+) string {
+	tx := newFakeTransaction(at)
+	tx.OutMsgs = []fakeMessage{{
+		Source:      address,
+		Destination: destination,
+		Value:       strconv.FormatInt(valueNanoTON, 10),
+		Message:     comment,
+	}}
+
+	f.mu.Lock()
+	f.seqno[address]++
+	f.mu.Unlock()
+
+	return f.add(address, tx)
+}
+
+// SentBOCs returns the base64 messages received via sendBoc.
+func (f *FakeTONCenter) SentBOCs() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string{}, f.bocs...)
+}
+
+func newFakeTransaction(at time.Time) fakeTransaction {
+	var tx fakeTransaction
+	tx.UTime = at.Unix()
+	tx.TransactionID.Hash = uuid.NewString()
+	return tx
+}
+
+func (f *FakeTONCenter) add(address string, tx fakeTransaction) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// logical time only grows, whatever timestamp the test picked
+	f.lt++
+	tx.TransactionID.LT = strconv.FormatInt(f.lt, 10)
+
+	// toncenter returns newest first
+	f.txs[address] = append([]fakeTransaction{tx}, f.txs[address]...)
+
+	return tx.TransactionID.Hash
+}
+
+func (f *FakeTONCenter) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.txs = make(map[string][]fakeTransaction)
+	f.seqno = make(map[string]int64)
+	f.bocs = nil
+}
+
+func (f *FakeTONCenter) handleGetTransactions(w http.ResponseWriter, r *http.Request) {
+	address := r.URL.Query().Get("address")
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 10
+	}
+
+	f.mu.Lock()
+	txs := f.txs[address]
+	// like toncenter, a page requested by lt/hash starts at that transaction
+	if hash := r.URL.Query().Get("hash"); hash != "" {
+		start := len(txs)
+		for i := range txs {
+			if txs[i].TransactionID.Hash == hash {
+				start = i
+				break
+			}
+		}
+		txs = txs[start:]
+	}
+	if len(txs) > limit {
+		txs = txs[:limit]
+	}
+	result := append([]fakeTransaction{}, txs...)
+	f.mu.Unlock()
+
+	writeResult(w, result)
+}
+
+func (f *FakeTONCenter) handleGetWalletInformation(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	seqno := f.seqno[r.URL.Query().Get("address")]
+	f.mu.Unlock()
+
+	writeResult(w, map[string]any{
+		"wallet":        true,
+		"seqno":         seqno,
+		"account_state": "active",
+	})
+}
+
+func (f *FakeTONCenter) handleSendBoc(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		BOC string `json:"boc"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]any{"ok": false, "error": err.Error()})
+		return
+	}
+
+	f.mu.Lock()
+	f.bocs = append(f.bocs, req.BOC)
+	f.mu.Unlock()
+
+	writeResult(w, map[string]any{"@type": "ok"})
+}
+
+func writeResult(w http.ResponseWriter, result any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"ok":     true,
+		"result": result,
+	})
+}
@@ -134,7 +134,8 @@ func (t *Tools) GetChannelByTgID(
 ) (*entity.Channel, error) {
 	var channel entity.Channel
 	err := t.pool.QueryRow(ctx, `
-		SELECT id, telegram_channel_id, title, username, is_listed, created_at
+		SELECT id, telegram_channel_id, title, username, is_listed, created_at,
+			review_timeout_hours
 		FROM channels
 		WHERE telegram_channel_id = $1
 	`, tgChannelID).Scan(
@@ -144,6 +145,7 @@ func (t *Tools) GetChannelByTgID(
 		&channel.Username,
 		&channel.IsListed,
 		&channel.CreatedAt,
+		&channel.ReviewTimeoutHours,
 	)
 	if err != nil {
 		return nil, err
@@ -342,6 +344,16 @@ func (t *Tools) CreateAdFormat(
 	return &af, nil
 }
 
+const dealColumns = `
+	id, channel_id, advertiser_id, campaign_id, status, scheduled_at,
+	publisher_note, escrow_wallet_address, escrow_memo, advertiser_wallet_address,
+	payout_wallet_address, format_type, is_native, feed_hours,
+	top_hours, price_nano_ton, publisher_share_nano_ton, platform_fee_bps, posted_message_ids,
+	payment_expires_at, paid_at, payment_tx_hash, payer_address,
+	posted_at, publish_attempts, publish_error, publishing_at,
+	pinned_at, unpinned_at, auto_delete, deleted_at, delete_error,
+	release_tx_hash, refund_tx_hash, payout_error, status_changed_at, created_at, updated_at`
+
 func (t *Tools) CreateDeal(
 	ctx context.Context,
 	channelID, advertiserID uuid.UUID,
@@ -363,14 +375,8 @@ func (t *Tools) CreateDeal(
 			format_type, is_native, feed_hours, top_hours, price_nano_ton
 		)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-		RETURNING
-			id, channel_id, advertiser_id, status, scheduled_at,
-			publisher_note, escrow_wallet_address, advertiser_wallet_address,
-			payout_wallet_address, format_type, is_native, feed_hours,
-			top_hours, price_nano_ton, posted_message_ids,
-			paid_at, payment_tx_hash, posted_at, release_tx_hash,
-			refund_tx_hash, created_at, updated_at
-	`, id, channelID, advertiserID, status, scheduledAt,
+		RETURNING `+dealColumns,
+		id, channelID, advertiserID, status, scheduledAt,
 		formatType, isNative, feedHours, topHours, priceNanoTON)
 	if err != nil {
 		return nil, err
@@ -384,13 +390,369 @@ func (t *Tools) CreateDeal(
 	return &d, nil
 }
 
+// CreateRevision records an ad version of the deal as submitted by the
+// advertiser, who confirms it by submitting it
+func (t *Tools) CreateRevision(
+	ctx context.Context,
+	dealID, authorID uuid.UUID,
+	version int,
+) error {
+	_, err := t.pool.Exec(ctx, `
+		INSERT INTO ad_revisions (deal_id, version, author_id, advertiser_approved_at)
+		VALUES ($1, $2, $3, NOW())
+	`, dealID, version, authorID)
+	return err
+}
+
+// CreatePublisherRevision records an ad version of the deal edited by the
+// publisher, who confirms it by submitting it
+func (t *Tools) CreatePublisherRevision(
+	ctx context.Context,
+	dealID, authorID uuid.UUID,
+	version int,
+) error {
+	_, err := t.pool.Exec(ctx, `
+		INSERT INTO ad_revisions (deal_id, version, author_id, publisher_approved_at)
+		VALUES ($1, $2, $3, NOW())
+	`, dealID, version, authorID)
+	return err
+}
+
+func (t *Tools) GetDealEvents(ctx context.Context, dealID uuid.UUID) ([]entity.DealEvent, error) {
+	rows, err := t.pool.Query(ctx, `
+		SELECT id, deal_id, type, actor_id, from_status, to_status, note, metadata, created_at
+		FROM deal_events
+		WHERE deal_id = $1
+		ORDER BY created_at, id
+	`, dealID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[entity.DealEvent])
+}
+
+func (t *Tools) CreateDealMessage(
+	ctx context.Context,
+	dealID, authorID uuid.UUID,
+	text string,
+) (*entity.DealMessage, error) {
+	rows, err := t.pool.Query(ctx, `
+		INSERT INTO deal_messages (id, deal_id, author_id, text)
+		VALUES (gen_random_uuid(), $1, $2, $3)
+		RETURNING id, deal_id, author_id, text, created_at, relayed_at
+	`, dealID, authorID, text)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.DealMessage])
+	if err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+func (t *Tools) GetDealMessages(
+	ctx context.Context,
+	dealID uuid.UUID,
+) ([]entity.DealMessage, error) {
+	rows, err := t.pool.Query(ctx, `
+		SELECT id, deal_id, author_id, text, created_at, relayed_at
+		FROM deal_messages
+		WHERE deal_id = $1
+		ORDER BY created_at, id
+	`, dealID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[entity.DealMessage])
+}
+
+func (t *Tools) CreateMessageRelay(
+	ctx context.Context,
+	chatID int64,
+	tgMessageID int,
+	messageID uuid.UUID,
+) error {
+	_, err := t.pool.Exec(ctx, `
+		INSERT INTO deal_message_relays (chat_id, tg_message_id, message_id)
+		VALUES ($1, $2, $3)
+	`, chatID, tgMessageID, messageID)
+	return err
+}
+
+func (t *Tools) GetMessageRelays(
+	ctx context.Context,
+	messageID uuid.UUID,
+) ([]entity.DealMessageRelay, error) {
+	rows, err := t.pool.Query(ctx, `
+		SELECT chat_id, tg_message_id, message_id
+		FROM deal_message_relays
+		WHERE message_id = $1
+		ORDER BY chat_id
+	`, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[entity.DealMessageRelay])
+}
+
+func (t *Tools) GetDeal(ctx context.Context, id uuid.UUID) (*entity.Deal, error) {
+	rows, err := t.pool.Query(ctx, `SELECT `+dealColumns+` FROM deals WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Deal])
+	if err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+func (t *Tools) SetEscrowDeposit(
+	ctx context.Context,
+	dealID uuid.UUID,
+	address string,
+	memo *string,
+) error {
+	_, err := t.pool.Exec(ctx, `
+		UPDATE deals SET escrow_wallet_address = $2, escrow_memo = $3 WHERE id = $1
+	`, dealID, address, memo)
+	return err
+}
+
+func (t *Tools) SetPayoutWallet(ctx context.Context, dealID uuid.UUID, address string) error {
+	_, err := t.pool.Exec(ctx, `
+		UPDATE deals SET payout_wallet_address = $2 WHERE id = $1
+	`, dealID, address)
+	return err
+}
+
+func (t *Tools) SetAdvertiserWallet(ctx context.Context, dealID uuid.UUID, address string) error {
+	_, err := t.pool.Exec(ctx, `
+		UPDATE deals SET advertiser_wallet_address = $2 WHERE id = $1
+	`, dealID, address)
+	return err
+}
+
+func (t *Tools) SetPlatformFee(ctx context.Context, dealID uuid.UUID, bps int64) error {
+	_, err := t.pool.Exec(ctx, `
+		UPDATE deals SET platform_fee_bps = $2 WHERE id = $1
+	`, dealID, bps)
+	return err
+}
+
+func (t *Tools) SetPaymentExpiresAt(
+	ctx context.Context,
+	dealID uuid.UUID,
+	expiresAt time.Time,
+) error {
+	_, err := t.pool.Exec(ctx, `
+		UPDATE deals SET payment_expires_at = $2 WHERE id = $1
+	`, dealID, expiresAt)
+	return err
+}
+
+func (t *Tools) SetStatus(ctx context.Context, dealID uuid.UUID, status entity.DealStatus) error {
+	_, err := t.pool.Exec(ctx, `
+		UPDATE deals SET status = $2 WHERE id = $1
+	`, dealID, status)
+	return err
+}
+
+func (t *Tools) CreateDispute(ctx context.Context, dealID uuid.UUID, reason string) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+	_, err = t.pool.Exec(ctx, `
+		INSERT INTO deal_disputes (id, deal_id, reason) VALUES ($1, $2, $3)
+	`, id, dealID, reason)
+	return err
+}
+
+func (t *Tools) SetStatusChangedAt(
+	ctx context.Context,
+	dealID uuid.UUID,
+	changedAt time.Time,
+) error {
+	_, err := t.pool.Exec(ctx, `
+		UPDATE deals SET status_changed_at = $2 WHERE id = $1
+	`, dealID, changedAt)
+	return err
+}
+
+func (t *Tools) SetChannelReviewTimeout(
+	ctx context.Context,
+	channelID uuid.UUID,
+	hours int,
+) error {
+	_, err := t.pool.Exec(ctx, `
+		UPDATE channels SET review_timeout_hours = $2 WHERE id = $1
+	`, channelID, hours)
+	return err
+}
+
+func (t *Tools) SetPaid(ctx context.Context, dealID uuid.UUID, txHash string) error {
+	_, err := t.pool.Exec(ctx, `
+		UPDATE deals SET payment_tx_hash = $2, paid_at = NOW() WHERE id = $1
+	`, dealID, txHash)
+	return err
+}
+
+func (t *Tools) CreateOutboxMessage(
+	ctx context.Context,
+	dealID uuid.UUID,
+	event entity.OutboxEvent,
+) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+	_, err = t.pool.Exec(ctx, `
+		INSERT INTO outbox (id, deal_id, event) VALUES ($1, $2, $3)
+	`, id, dealID, event)
+	return err
+}
+
+func (t *Tools) GetOutboxMessages(
+	ctx context.Context,
+	dealID uuid.UUID,
+) ([]entity.OutboxMessage, error) {
+	rows, err := t.pool.Query(ctx, `
+		SELECT id, deal_id, event, created_at, processed_at
+		FROM outbox
+		WHERE deal_id = $1
+		ORDER BY created_at ASC
+	`, dealID)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[entity.OutboxMessage])
+}
+
+func (t *Tools) SetPosted(
+	ctx context.Context,
+	dealID uuid.UUID,
+	messageIDs []int64,
+	postedAt time.Time,
+) error {
+	_, err := t.pool.Exec(ctx, `
+		UPDATE deals SET posted_message_ids = $2, posted_at = $3 WHERE id = $1
+	`, dealID, messageIDs, postedAt)
+	return err
+}
+
+func (t *Tools) SetPublishingAt(ctx context.Context, dealID uuid.UUID, at time.Time) error {
+	_, err := t.pool.Exec(ctx, `UPDATE deals SET publishing_at = $2 WHERE id = $1`, dealID, at)
+	return err
+}
+
+func (t *Tools) CreateViewSnapshot(ctx context.Context, snap entity.DealViewSnapshot) error {
+	_, err := t.pool.Exec(ctx, `
+		INSERT INTO deal_view_snapshots (deal_id, taken_at, views, forwards, reactions)
+		VALUES ($1, $2, $3, $4, $5)
+	`, snap.DealID, snap.TakenAt, snap.Views, snap.Forwards, snap.Reactions)
+	return err
+}
+
+func (t *Tools) GetViewSnapshots(
+	ctx context.Context,
+	dealID uuid.UUID,
+) ([]entity.DealViewSnapshot, error) {
+	rows, err := t.pool.Query(ctx, `
+		SELECT deal_id, taken_at, views, forwards, reactions
+		FROM deal_view_snapshots
+		WHERE deal_id = $1
+		ORDER BY taken_at ASC
+	`, dealID)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[entity.DealViewSnapshot])
+}
+
+func (t *Tools) SetPinned(ctx context.Context, dealID uuid.UUID, pinnedAt time.Time) error {
+	_, err := t.pool.Exec(ctx, `UPDATE deals SET pinned_at = $2 WHERE id = $1`, dealID, pinnedAt)
+	return err
+}
+
+func (t *Tools) SetAutoDelete(ctx context.Context, dealID uuid.UUID) error {
+	_, err := t.pool.Exec(ctx, `UPDATE deals SET auto_delete = TRUE WHERE id = $1`, dealID)
+	return err
+}
+
+func (t *Tools) GetTransfers(ctx context.Context, dealID uuid.UUID) ([]entity.Transfer, error) {
+	rows, err := t.pool.Query(ctx, `
+		SELECT id, deal_id, kind, destination, amount_nano_ton, fee_nano_ton, comment,
+			status, seqno, valid_until, tx_hash, attempts, last_error,
+			created_at, updated_at
+		FROM transfers
+		WHERE deal_id = $1
+		ORDER BY created_at ASC
+	`, dealID)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[entity.Transfer])
+}
+
+func (t *Tools) GetLedgerEntries(
+	ctx context.Context,
+	dealID uuid.UUID,
+) ([]entity.LedgerEntry, error) {
+	rows, err := t.pool.Query(ctx, `
+		SELECT id, txn_id, deal_id, kind, account, user_id, amount_nano_ton, created_at
+		FROM ledger_entries
+		WHERE deal_id = $1
+		ORDER BY created_at ASC, kind ASC, account ASC
+	`, dealID)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[entity.LedgerEntry])
+}
+
+// ExpireTransfer moves valid_until of a sent transfer far enough into the past
+// for the worker to treat it as expired.
+func (t *Tools) CreateTransfer(
+	ctx context.Context,
+	dealID uuid.UUID,
+	kind entity.TransferKind,
+	destination string,
+	amountNanoTON int64,
+	comment string,
+) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+	_, err = t.pool.Exec(ctx, `
+		INSERT INTO transfers (id, deal_id, kind, destination, amount_nano_ton, comment)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, id, dealID, kind, destination, amountNanoTON, comment)
+	return err
+}
+
+func (t *Tools) ExpireTransfer(ctx context.Context, id uuid.UUID) error {
+	_, err := t.pool.Exec(ctx, `
+		UPDATE transfers SET valid_until = NOW() - INTERVAL '1 hour' WHERE id = $1
+	`, id)
+	return err
+}
+
 func (t *Tools) GetAdFormatsByChannelID(
 	ctx context.Context,
 	channelID uuid.UUID,
 ) ([]entity.ChannelAdFormat, error) {
 	rows, err := t.pool.Query(ctx, `
 		SELECT id, channel_id, format_type, is_native, feed_hours, top_hours,
-			price_nano_ton, created_at
+			auto_delete, price_nano_ton, created_at
 		FROM channel_ad_formats
 		WHERE channel_id = $1
 	`, channelID)
@@ -404,7 +766,7 @@ func (t *Tools) GetAdFormatsByChannelID(
 		var af entity.ChannelAdFormat
 		if err := rows.Scan(
 			&af.ID, &af.ChannelID, &af.FormatType, &af.IsNative,
-			&af.FeedHours, &af.TopHours, &af.PriceNanoTON, &af.CreatedAt,
+			&af.FeedHours, &af.TopHours, &af.AutoDelete, &af.PriceNanoTON, &af.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,102 @@
+//go:build integration
+
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tele "gopkg.in/telebot.v4"
+
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+func setupAutoDelete(
+	t *testing.T,
+	ctx context.Context,
+	status entity.DealStatus,
+	postedAt time.Time,
+) *publishSetup {
+	t.Helper()
+	s := setupLive(t, ctx, status, postedAt)
+	require.NoError(t, testTools.SetAutoDelete(ctx, s.deal.ID))
+	return s
+}
+
+func TestDeleteExpired_DeletesCompletedAd(t *testing.T) {
+	ctx := context.Background()
+	s := setupAutoDelete(t, ctx, entity.DealStatusCompleted, time.Now().Add(-25*time.Hour))
+
+	s.deleter.EXPECT().DeleteMessages(s.channel.TgChannelID, []int64{10, 11}).Return(nil)
+
+	require.NoError(t, s.svc.DeleteExpired(ctx))
+	require.NoError(t, s.svc.DeleteExpired(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusCompleted, got.Status)
+	require.NotNil(t, got.DeletedAt)
+	assert.Nil(t, got.DeleteError)
+}
+
+func TestDeleteExpired_SkipsDealsWithoutAutoDelete(t *testing.T) {
+	ctx := context.Background()
+	s := setupLive(t, ctx, entity.DealStatusCompleted, time.Now().Add(-25*time.Hour))
+
+	require.NoError(t, s.svc.DeleteExpired(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.DeletedAt)
+}
+
+func TestDeleteExpired_WaitsForVerification(t *testing.T) {
+	ctx := context.Background()
+	s := setupAutoDelete(t, ctx, entity.DealStatusPosted, time.Now().Add(-25*time.Hour))
+
+	require.NoError(t, s.svc.DeleteExpired(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.DeletedAt)
+}
+
+func TestDeleteExpired_RetriesTransientFailure(t *testing.T) {
+	ctx := context.Background()
+	s := setupAutoDelete(t, ctx, entity.DealStatusCompleted, time.Now().Add(-25*time.Hour))
+
+	s.deleter.EXPECT().
+		DeleteMessages(s.channel.TgChannelID, []int64{10, 11}).
+		Return(errors.New("connection reset"))
+
+	require.NoError(t, s.svc.DeleteExpired(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.DeletedAt)
+	assert.Nil(t, got.DeleteError)
+}
+
+func TestDeleteExpired_GivesUpWhenBotCannotDelete(t *testing.T) {
+	ctx := context.Background()
+	s := setupAutoDelete(t, ctx, entity.DealStatusCompleted, time.Now().Add(-25*time.Hour))
+
+	s.deleter.EXPECT().
+		DeleteMessages(s.channel.TgChannelID, []int64{10, 11}).
+		Return(tele.ErrNoRightsToDelete).
+		Times(1)
+
+	require.NoError(t, s.svc.DeleteExpired(ctx))
+	require.NoError(t, s.svc.DeleteExpired(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusCompleted, got.Status)
+	assert.Nil(t, got.DeletedAt)
+	require.NotNil(t, got.DeleteError)
+	assert.Contains(t, *got.DeleteError, "can't be deleted")
+}
@@ -0,0 +1,111 @@
+//go:build integration
+
+package worker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+type messageSetup struct {
+	*paymentSetup
+	owner   *entity.User
+	manager *entity.User
+	deal    *entity.Deal
+}
+
+func setupMessages(t *testing.T, ctx context.Context) *messageSetup {
+	t.Helper()
+	s := setupPayments(t, ctx)
+
+	owner, err := testTools.CreateUser(ctx, 5001002, "Owner")
+	require.NoError(t, err)
+	_, err = testTools.CreateChannelRole(ctx, s.channel.ID, owner.ID, entity.ChannelRoleTypeOwner)
+	require.NoError(t, err)
+
+	manager, err := testTools.CreateUser(ctx, 5001003, "Manager")
+	require.NoError(t, err)
+	_, err = testTools.CreateChannelRole(
+		ctx, s.channel.ID, manager.ID, entity.ChannelRoleTypeManager,
+	)
+	require.NoError(t, err)
+
+	deal, err := testTools.CreateDeal(
+		ctx,
+		s.channel.ID,
+		s.advertiser.ID,
+		entity.DealStatusPendingReview,
+		time.Now().Add(48*time.Hour),
+		entity.AdFormatTypePost,
+		false,
+		24,
+		4,
+		dealPrice,
+	)
+	require.NoError(t, err)
+
+	return &messageSetup{paymentSetup: s, owner: owner, manager: manager, deal: deal}
+}
+
+func TestRelayMessages_AdvertiserToChannelTeam(t *testing.T) {
+	ctx := context.Background()
+	s := setupMessages(t, ctx)
+
+	msg, err := testTools.CreateDealMessage(ctx, s.deal.ID, s.advertiser.ID, "Is Friday fine?")
+	require.NoError(t, err)
+
+	require.NoError(t, messagingSvc.RelayMessages(ctx))
+
+	sent := testSender.Sent()
+	require.Len(t, sent, 2)
+	chats := []int64{sent[0].ChatID, sent[1].ChatID}
+	assert.ElementsMatch(t, []int64{s.owner.TgID, s.manager.TgID}, chats)
+	assert.Contains(t, sent[0].Text, "Advertiser")
+	assert.Contains(t, sent[0].Text, "Is Friday fine?")
+
+	require.NotNil(t, sent[0].Markup)
+	require.Len(t, sent[0].Markup.InlineKeyboard, 1)
+	btn := sent[0].Markup.InlineKeyboard[0][0]
+	require.NotNil(t, btn.WebApp)
+	assert.Equal(t, miniAppURL+"/deals/"+s.deal.ID.String(), btn.WebApp.URL)
+
+	relays, err := testTools.GetMessageRelays(ctx, msg.ID)
+	require.NoError(t, err)
+	assert.Len(t, relays, 2)
+
+	msgs, err := testTools.GetDealMessages(ctx, s.deal.ID)
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	assert.NotNil(t, msgs[0].RelayedAt)
+
+	// relayed messages are not sent again
+	require.NoError(t, messagingSvc.RelayMessages(ctx))
+	assert.Len(t, testSender.Sent(), 2)
+}
+
+func TestRelayMessages_PublisherToAdvertiser(t *testing.T) {
+	ctx := context.Background()
+	s := setupMessages(t, ctx)
+
+	msg, err := testTools.CreateDealMessage(ctx, s.deal.ID, s.manager.ID, "Yes, Friday works")
+	require.NoError(t, err)
+
+	require.NoError(t, messagingSvc.RelayMessages(ctx))
+
+	sent := testSender.Sent()
+	require.Len(t, sent, 1)
+	assert.Equal(t, s.advertiser.TgID, sent[0].ChatID)
+	assert.Contains(t, sent[0].Text, "Manager")
+	assert.Contains(t, sent[0].Text, "Yes, Friday works")
+
+	relays, err := testTools.GetMessageRelays(ctx, msg.ID)
+	require.NoError(t, err)
+	require.Len(t, relays, 1)
+	assert.Equal(t, s.advertiser.TgID, relays[0].ChatID)
+}
@@ -0,0 +1,408 @@
+//go:build integration
+
+package worker_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+const (
+	advertiserAddress = "UQAdvertiser000"
+	dealPrice         = int64(1000000000)
+)
+
+type paymentSetup struct {
+	advertiser *entity.User
+	channel    *entity.Channel
+}
+
+func setupPayments(t *testing.T, ctx context.Context) *paymentSetup {
+	t.Helper()
+	require.NoError(t, testTools.TruncateAll(ctx))
+	testTONCenter.Reset()
+	testSender.Reset()
+
+	advertiser, err := testTools.CreateUser(ctx, 5001001, "Advertiser")
+	require.NoError(t, err)
+
+	channel, err := testTools.CreateChannel(ctx, -1005001001, "Worker Channel", nil)
+	require.NoError(t, err)
+
+	return &paymentSetup{advertiser: advertiser, channel: channel}
+}
+
+func (s *paymentSetup) createDeal(t *testing.T, ctx context.Context, memo *string) *entity.Deal {
+	t.Helper()
+
+	deal, err := testTools.CreateDeal(
+		ctx,
+		s.channel.ID,
+		s.advertiser.ID,
+		entity.DealStatusPendingPayment,
+		time.Now().Add(48*time.Hour),
+		entity.AdFormatTypePost,
+		false,
+		24,
+		4,
+		dealPrice,
+	)
+	require.NoError(t, err)
+	require.NoError(t, testTools.SetEscrowDeposit(ctx, deal.ID, escrowAddress, memo))
+
+	return deal
+}
+
+func setupPendingPaymentDeal(t *testing.T, ctx context.Context) *entity.Deal {
+	t.Helper()
+	return setupPayments(t, ctx).createDeal(t, ctx, nil)
+}
+
+func TestCheckPayments_ConfirmsPaidDeal(t *testing.T) {
+	ctx := context.Background()
+	deal := setupPendingPaymentDeal(t, ctx)
+
+	paidAt := time.Now().Add(time.Minute)
+	hash := testTONCenter.AddIncoming(escrowAddress, advertiserAddress, dealPrice, "", paidAt)
+
+	require.NoError(t, escrowSvc.CheckPayments(ctx))
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPendingReview, got.Status)
+	require.NotNil(t, got.PaymentTxHash)
+	assert.Equal(t, hash, *got.PaymentTxHash)
+	require.NotNil(t, got.PaidAt)
+	assert.WithinDuration(t, paidAt, *got.PaidAt, time.Second)
+
+	events, err := testTools.GetDealEvents(ctx, deal.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, entity.DealEventPaymentReceived, events[0].Type)
+	assert.Nil(t, events[0].ActorID)
+	assert.Equal(t, entity.DealStatusPendingReview, events[0].ToStatus)
+	assert.Equal(t, hash, events[0].Metadata["tx_hash"])
+}
+
+func TestCheckPayments_IgnoresUnderpayment(t *testing.T) {
+	ctx := context.Background()
+	deal := setupPendingPaymentDeal(t, ctx)
+
+	testTONCenter.AddIncoming(
+		escrowAddress, advertiserAddress, dealPrice-1, "", time.Now().Add(time.Minute),
+	)
+
+	require.NoError(t, escrowSvc.CheckPayments(ctx))
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPendingPayment, got.Status)
+	assert.Nil(t, got.PaymentTxHash)
+}
+
+func TestCheckPayments_ReturnsUnderpaymentWithMemo(t *testing.T) {
+	ctx := context.Background()
+	s := setupPayments(t, ctx)
+	memo := "MEMOFFFFFF"
+	deal := s.createDeal(t, ctx, &memo)
+
+	hash := testTONCenter.AddIncoming(
+		escrowAddress, payerAddress, dealPrice/2, memo, time.Now().Add(time.Minute),
+	)
+
+	require.NoError(t, escrowSvc.CheckPayments(ctx))
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPendingPayment, got.Status)
+	assert.Nil(t, got.PaymentTxHash)
+
+	transfers, err := testTools.GetTransfers(ctx, deal.ID)
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	assert.Equal(t, entity.TransferKindReturn, transfers[0].Kind)
+	assert.Equal(t, payerAddress, transfers[0].Destination)
+	assert.Equal(t, dealPrice/2, transfers[0].AmountNanoTON)
+	assert.Contains(t, transfers[0].Comment, hash)
+
+	sent := testSender.Sent()
+	require.Len(t, sent, 1)
+	assert.Equal(t, s.advertiser.TgID, sent[0].ChatID)
+}
+
+func TestCheckPayments_ReturnsOverpaidExcess(t *testing.T) {
+	ctx := context.Background()
+	s := setupPayments(t, ctx)
+	memo := "MEMOGGGGGG"
+	deal := s.createDeal(t, ctx, &memo)
+
+	excess := dealPrice / 2
+	hash := testTONCenter.AddIncoming(
+		escrowAddress, payerAddress, dealPrice+excess, memo, time.Now().Add(time.Minute),
+	)
+
+	require.NoError(t, escrowSvc.CheckPayments(ctx))
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPendingReview, got.Status)
+	require.NotNil(t, got.PaymentTxHash)
+	assert.Equal(t, hash, *got.PaymentTxHash)
+
+	transfers, err := testTools.GetTransfers(ctx, deal.ID)
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	assert.Equal(t, entity.TransferKindReturn, transfers[0].Kind)
+	assert.Equal(t, payerAddress, transfers[0].Destination)
+	assert.Equal(t, excess, transfers[0].AmountNanoTON)
+}
+
+func TestCheckPayments_ReturnsSecondPayment(t *testing.T) {
+	ctx := context.Background()
+	s := setupPayments(t, ctx)
+	memo := "MEMOHHHHHH"
+	deal := s.createDeal(t, ctx, &memo)
+
+	first := testTONCenter.AddIncoming(
+		escrowAddress, payerAddress, dealPrice, memo, time.Now().Add(time.Minute),
+	)
+	second := testTONCenter.AddIncoming(
+		escrowAddress, payerAddress, dealPrice, memo, time.Now().Add(2*time.Minute),
+	)
+
+	require.NoError(t, escrowSvc.CheckPayments(ctx))
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.PaymentTxHash)
+	assert.Equal(t, first, *got.PaymentTxHash)
+
+	transfers, err := testTools.GetTransfers(ctx, deal.ID)
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	assert.Equal(t, entity.TransferKindReturn, transfers[0].Kind)
+	assert.Equal(t, dealPrice, transfers[0].AmountNanoTON)
+	assert.Contains(t, transfers[0].Comment, second)
+}
+
+func TestCheckPayments_IgnoresTransfersBeforeDeal(t *testing.T) {
+	ctx := context.Background()
+	deal := setupPendingPaymentDeal(t, ctx)
+
+	testTONCenter.AddIncoming(
+		escrowAddress, advertiserAddress, dealPrice, "", time.Now().Add(-time.Hour),
+	)
+
+	require.NoError(t, escrowSvc.CheckPayments(ctx))
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPendingPayment, got.Status)
+}
+
+func TestCheckPayments_Idempotent(t *testing.T) {
+	ctx := context.Background()
+	deal := setupPendingPaymentDeal(t, ctx)
+
+	hash := testTONCenter.AddIncoming(
+		escrowAddress, advertiserAddress, dealPrice, "", time.Now().Add(time.Minute),
+	)
+
+	require.NoError(t, escrowSvc.CheckPayments(ctx))
+	require.NoError(t, escrowSvc.CheckPayments(ctx))
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPendingReview, got.Status)
+	require.NotNil(t, got.PaymentTxHash)
+	assert.Equal(t, hash, *got.PaymentTxHash)
+}
+
+func TestCheckPayments_MatchesMemoOnSharedWallet(t *testing.T) {
+	ctx := context.Background()
+	s := setupPayments(t, ctx)
+
+	memoA, memoB := "MEMOAAAAAA", "MEMOBBBBBB"
+	dealA := s.createDeal(t, ctx, &memoA)
+	dealB := s.createDeal(t, ctx, &memoB)
+
+	testTONCenter.AddIncoming(
+		escrowAddress, advertiserAddress, dealPrice, "WRONGMEMO1", time.Now().Add(time.Minute),
+	)
+	hash := testTONCenter.AddIncoming(
+		escrowAddress, advertiserAddress, dealPrice, memoB, time.Now().Add(time.Minute),
+	)
+
+	require.NoError(t, escrowSvc.CheckPayments(ctx))
+
+	gotA, err := testTools.GetDeal(ctx, dealA.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPendingPayment, gotA.Status)
+	assert.Nil(t, gotA.PaymentTxHash)
+
+	gotB, err := testTools.GetDeal(ctx, dealB.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPendingReview, gotB.Status)
+	require.NotNil(t, gotB.PaymentTxHash)
+	assert.Equal(t, hash, *gotB.PaymentTxHash)
+}
+
+func TestCheckPayments_FindsPaymentBeyondFirstPage(t *testing.T) {
+	ctx := context.Background()
+	s := setupPayments(t, ctx)
+	memo := "MEMOCCCCCC"
+	deal := s.createDeal(t, ctx, &memo)
+
+	hash := testTONCenter.AddIncoming(
+		escrowAddress, advertiserAddress, dealPrice, memo, time.Now().Add(time.Minute),
+	)
+	addSpam(150)
+
+	require.NoError(t, escrowSvc.CheckPayments(ctx))
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPendingReview, got.Status)
+	require.NotNil(t, got.PaymentTxHash)
+	assert.Equal(t, hash, *got.PaymentTxHash)
+}
+
+func TestCheckPayments_ResumesFromCursor(t *testing.T) {
+	ctx := context.Background()
+	s := setupPayments(t, ctx)
+	memo := "MEMODDDDDD"
+	deal := s.createDeal(t, ctx, &memo)
+
+	addSpam(1)
+	require.NoError(t, escrowSvc.CheckPayments(ctx))
+
+	hash := testTONCenter.AddIncoming(
+		escrowAddress, advertiserAddress, dealPrice, memo, time.Now().Add(time.Minute),
+	)
+	addSpam(250)
+	require.NoError(t, escrowSvc.CheckPayments(ctx))
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPendingReview, got.Status)
+	require.NotNil(t, got.PaymentTxHash)
+	assert.Equal(t, hash, *got.PaymentTxHash)
+}
+
+func TestCheckPayments_RefundsLatePayment(t *testing.T) {
+	for _, status := range []entity.DealStatus{
+		entity.DealStatusCancelled,
+		entity.DealStatusHoldFailed,
+	} {
+		t.Run(string(status), func(t *testing.T) {
+			ctx := context.Background()
+			s := setupPayments(t, ctx)
+			memo := "MEMOEEEEEE"
+			deal, err := testTools.CreateDeal(
+				ctx,
+				s.channel.ID,
+				s.advertiser.ID,
+				status,
+				time.Now().Add(48*time.Hour),
+				entity.AdFormatTypePost,
+				false,
+				24,
+				4,
+				dealPrice,
+			)
+			require.NoError(t, err)
+			require.NoError(t, testTools.SetEscrowDeposit(ctx, deal.ID, escrowAddress, &memo))
+
+			hash := testTONCenter.AddIncoming(
+				escrowAddress, payerAddress, dealPrice, memo, time.Now().Add(time.Minute),
+			)
+			require.NoError(t, escrowSvc.CheckPayments(ctx))
+
+			got, err := testTools.GetDeal(ctx, deal.ID)
+			require.NoError(t, err)
+			assert.Equal(t, status, got.Status)
+			require.NotNil(t, got.PaymentTxHash)
+			assert.Equal(t, hash, *got.PaymentTxHash)
+
+			msgs, err := testTools.GetOutboxMessages(ctx, deal.ID)
+			require.NoError(t, err)
+			require.Len(t, msgs, 1)
+			assert.Equal(t, entity.OutboxEventRefund, msgs[0].Event)
+
+			sent := testSender.Sent()
+			require.Len(t, sent, 1)
+			assert.Equal(t, s.advertiser.TgID, sent[0].ChatID)
+
+			require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+			transfers, err := testTools.GetTransfers(ctx, deal.ID)
+			require.NoError(t, err)
+			require.Len(t, transfers, 1)
+			assert.Equal(t, entity.TransferKindRefund, transfers[0].Kind)
+			assert.Equal(t, payerAddress, transfers[0].Destination)
+		})
+	}
+}
+
+// addSpam records unrelated dust transfers to the escrow wallet.
+func addSpam(n int) {
+	at := time.Now().Add(time.Minute)
+	for i := range n {
+		testTONCenter.AddIncoming(
+			escrowAddress, advertiserAddress, 1, fmt.Sprintf("spam %d", i), at,
+		)
+	}
+}
+
+func TestCheckPayments_ExpiresOverdueDeal(t *testing.T) {
+	ctx := context.Background()
+	s := setupPayments(t, ctx)
+	deal := s.createDeal(t, ctx, nil)
+	require.NoError(t, testTools.SetPaymentExpiresAt(ctx, deal.ID, time.Now().Add(-time.Minute)))
+
+	require.NoError(t, escrowSvc.CheckPayments(ctx))
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusHoldFailed, got.Status)
+
+	sent := testSender.Sent()
+	require.Len(t, sent, 1)
+	assert.Equal(t, s.advertiser.TgID, sent[0].ChatID)
+	assert.Contains(t, sent[0].Text, deal.ID.String())
+}
+
+func TestCheckPayments_PaymentWinsOverDeadline(t *testing.T) {
+	ctx := context.Background()
+	deal := setupPendingPaymentDeal(t, ctx)
+	require.NoError(t, testTools.SetPaymentExpiresAt(ctx, deal.ID, time.Now().Add(-time.Minute)))
+
+	testTONCenter.AddIncoming(
+		escrowAddress, advertiserAddress, dealPrice, "", time.Now().Add(time.Minute),
+	)
+
+	require.NoError(t, escrowSvc.CheckPayments(ctx))
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPendingReview, got.Status)
+	assert.Empty(t, testSender.Sent())
+}
+
+func TestCheckPayments_KeepsDealBeforeDeadline(t *testing.T) {
+	ctx := context.Background()
+	deal := setupPendingPaymentDeal(t, ctx)
+	require.NoError(t, testTools.SetPaymentExpiresAt(ctx, deal.ID, time.Now().Add(time.Hour)))
+
+	require.NoError(t, escrowSvc.CheckPayments(ctx))
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPendingPayment, got.Status)
+}
@@ -0,0 +1,234 @@
+//go:build integration
+
+package worker_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+const publisherAddress = "0:1111111111111111111111111111111111111111111111111111111111111111"
+
+func setupCompletedDeal(t *testing.T, ctx context.Context, payoutWallet string) *entity.Deal {
+	t.Helper()
+	s := setupPayments(t, ctx)
+
+	deal, err := testTools.CreateDeal(
+		ctx,
+		s.channel.ID,
+		s.advertiser.ID,
+		entity.DealStatusCompleted,
+		time.Now().Add(-48*time.Hour),
+		entity.AdFormatTypePost,
+		false,
+		24,
+		4,
+		dealPrice,
+	)
+	require.NoError(t, err)
+	require.NoError(t, testTools.SetEscrowDeposit(ctx, deal.ID, escrowAddress, nil))
+	require.NoError(t, testTools.SetPlatformFee(ctx, deal.ID, platformFee))
+	if payoutWallet != "" {
+		require.NoError(t, testTools.SetPayoutWallet(ctx, deal.ID, payoutWallet))
+	}
+
+	return deal
+}
+
+func getPayout(t *testing.T, ctx context.Context, deal *entity.Deal) entity.Transfer {
+	t.Helper()
+	transfers, err := testTools.GetTransfers(ctx, deal.ID)
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	require.Equal(t, entity.TransferKindPayout, transfers[0].Kind)
+	return transfers[0]
+}
+
+func TestProcessTransfers_PaysOutCompletedDeal(t *testing.T) {
+	ctx := context.Background()
+	deal := setupCompletedDeal(t, ctx, publisherAddress)
+
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+
+	payout := getPayout(t, ctx, deal)
+	fee := dealPrice * platformFee / 10000
+	assert.Equal(t, entity.TransferStatusSent, payout.Status)
+	assert.Equal(t, publisherAddress, payout.Destination)
+	assert.Equal(t, dealPrice-fee, payout.AmountNanoTON)
+	assert.Equal(t, fee, payout.FeeNanoTON)
+	assert.Equal(t, fmt.Sprintf("Payout for deal %s", deal.ID), payout.Comment)
+	require.NotNil(t, payout.Seqno)
+	assert.Equal(t, int64(0), *payout.Seqno)
+	assert.Len(t, testTONCenter.SentBOCs(), 1)
+
+	hash := testTONCenter.AddOutgoing(
+		escrowAddress, publisherAddress, payout.AmountNanoTON, payout.Comment, time.Now(),
+	)
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+
+	payout = getPayout(t, ctx, deal)
+	assert.Equal(t, entity.TransferStatusConfirmed, payout.Status)
+	require.NotNil(t, payout.TxHash)
+	assert.Equal(t, hash, *payout.TxHash)
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.ReleaseTxHash)
+	assert.Equal(t, hash, *got.ReleaseTxHash)
+
+	entries, err := testTools.GetLedgerEntries(ctx, deal.ID)
+	require.NoError(t, err)
+	balances := map[entity.LedgerAccount]int64{}
+	for _, e := range entries {
+		balances[e.Account] += e.AmountNanoTON
+	}
+	assert.Len(t, entries, 4)
+	assert.Equal(t, dealPrice-fee, balances[entity.LedgerAccountPublisher])
+	assert.Equal(t, fee, balances[entity.LedgerAccountPlatform])
+	assert.Equal(t, -dealPrice, balances[entity.LedgerAccountEscrow])
+}
+
+func TestProcessTransfers_SettlesPayoutTakenByFee(t *testing.T) {
+	ctx := context.Background()
+	deal := setupCompletedDeal(t, ctx, publisherAddress)
+	require.NoError(t, testTools.SetPlatformFee(ctx, deal.ID, 10000))
+
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+
+	payout := getPayout(t, ctx, deal)
+	assert.Equal(t, entity.TransferStatusConfirmed, payout.Status)
+	assert.Zero(t, payout.AmountNanoTON)
+	assert.Equal(t, dealPrice, payout.FeeNanoTON)
+	assert.Empty(t, testTONCenter.SentBOCs())
+
+	entries, err := testTools.GetLedgerEntries(ctx, deal.ID)
+	require.NoError(t, err)
+	balances := map[entity.LedgerAccount]int64{}
+	for _, e := range entries {
+		balances[e.Account] += e.AmountNanoTON
+	}
+	assert.Len(t, entries, 2)
+	assert.Equal(t, dealPrice, balances[entity.LedgerAccountPlatform])
+	assert.Equal(t, -dealPrice, balances[entity.LedgerAccountEscrow])
+
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+	getPayout(t, ctx, deal)
+}
+
+func TestProcessTransfers_ConfirmsTransferBeyondFirstPage(t *testing.T) {
+	ctx := context.Background()
+	deal := setupCompletedDeal(t, ctx, publisherAddress)
+
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+	payout := getPayout(t, ctx, deal)
+
+	hash := testTONCenter.AddOutgoing(
+		escrowAddress, publisherAddress, payout.AmountNanoTON, payout.Comment, time.Now(),
+	)
+	addSpam(150)
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+
+	payout = getPayout(t, ctx, deal)
+	assert.Equal(t, entity.TransferStatusConfirmed, payout.Status)
+	require.NotNil(t, payout.TxHash)
+	assert.Equal(t, hash, *payout.TxHash)
+}
+
+func TestProcessTransfers_DoesNotResendInFlight(t *testing.T) {
+	ctx := context.Background()
+	deal := setupCompletedDeal(t, ctx, publisherAddress)
+
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+
+	payout := getPayout(t, ctx, deal)
+	assert.Equal(t, entity.TransferStatusSent, payout.Status)
+	assert.Equal(t, 1, payout.Attempts)
+	assert.Len(t, testTONCenter.SentBOCs(), 1)
+}
+
+func TestProcessTransfers_ResendsExpiredUnappliedMessage(t *testing.T) {
+	ctx := context.Background()
+	deal := setupCompletedDeal(t, ctx, publisherAddress)
+
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+	payout := getPayout(t, ctx, deal)
+	require.NoError(t, testTools.ExpireTransfer(ctx, payout.ID))
+
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+
+	payout = getPayout(t, ctx, deal)
+	assert.Equal(t, entity.TransferStatusSent, payout.Status)
+	assert.Equal(t, 2, payout.Attempts)
+	assert.Len(t, testTONCenter.SentBOCs(), 2)
+}
+
+func TestProcessTransfers_ParksTransferWithUnknownOutcome(t *testing.T) {
+	ctx := context.Background()
+	deal := setupCompletedDeal(t, ctx, publisherAddress)
+
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+	payout := getPayout(t, ctx, deal)
+	require.NoError(t, testTools.ExpireTransfer(ctx, payout.ID))
+
+	testTONCenter.AddOutgoing(escrowAddress, publisherAddress, 1, "unrelated", time.Now())
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+
+	payout = getPayout(t, ctx, deal)
+	assert.Equal(t, entity.TransferStatusFailed, payout.Status)
+	require.NotNil(t, payout.LastError)
+	assert.Len(t, testTONCenter.SentBOCs(), 1)
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.ReleaseTxHash)
+}
+
+func TestProcessTransfers_SkipsDealWithoutPayoutWallet(t *testing.T) {
+	ctx := context.Background()
+	deal := setupCompletedDeal(t, ctx, "")
+
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+
+	transfers, err := testTools.GetTransfers(ctx, deal.ID)
+	require.NoError(t, err)
+	assert.Empty(t, transfers)
+	assert.Empty(t, testTONCenter.SentBOCs())
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.PayoutError)
+	assert.Contains(t, *got.PayoutError, "payout wallet")
+}
+
+func TestProcessTransfers_PaysOutToWalletLinkedLater(t *testing.T) {
+	ctx := context.Background()
+	deal := setupCompletedDeal(t, ctx, "")
+
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+
+	owner, err := testTools.CreateUser(ctx, 5001002, "Owner")
+	require.NoError(t, err)
+	_, err = testTools.CreateChannelRole(ctx, deal.ChannelID, owner.ID, entity.ChannelRoleTypeOwner)
+	require.NoError(t, err)
+	require.NoError(t, testTools.SetWalletAddress(ctx, owner.ID, publisherAddress))
+
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+
+	payout := getPayout(t, ctx, deal)
+	assert.Equal(t, publisherAddress, payout.Destination)
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.PayoutWalletAddress)
+	assert.Equal(t, publisherAddress, *got.PayoutWalletAddress)
+	assert.Nil(t, got.PayoutError)
+}
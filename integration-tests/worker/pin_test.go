@@ -0,0 +1,91 @@
+//go:build integration
+
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tele "gopkg.in/telebot.v4"
+
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+func TestUpdatePins_PinsPostedAd(t *testing.T) {
+	ctx := context.Background()
+	s := setupLive(t, ctx, entity.DealStatusPosted, time.Now().Add(-time.Minute))
+
+	s.pinner.EXPECT().Pin(s.channel.TgChannelID, 10).Return(nil)
+
+	require.NoError(t, s.svc.UpdatePins(ctx))
+	require.NoError(t, s.svc.UpdatePins(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.PinnedAt)
+	assert.WithinDuration(t, time.Now(), *got.PinnedAt, time.Minute)
+	assert.Nil(t, got.UnpinnedAt)
+}
+
+func TestUpdatePins_UnpinsAfterTopHours(t *testing.T) {
+	ctx := context.Background()
+	s := setupLive(t, ctx, entity.DealStatusPosted, time.Now().Add(-5*time.Hour))
+	require.NoError(t, testTools.SetPinned(ctx, s.deal.ID, time.Now().Add(-5*time.Hour)))
+
+	s.pinner.EXPECT().Unpin(s.channel.TgChannelID, 10).Return(nil)
+
+	require.NoError(t, s.svc.UpdatePins(ctx))
+	require.NoError(t, s.svc.UpdatePins(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.UnpinnedAt)
+	assert.Equal(t, entity.DealStatusPosted, got.Status)
+}
+
+func TestUpdatePins_KeepsPinWithinTopHours(t *testing.T) {
+	ctx := context.Background()
+	s := setupLive(t, ctx, entity.DealStatusPosted, time.Now().Add(-time.Hour))
+	require.NoError(t, testTools.SetPinned(ctx, s.deal.ID, time.Now().Add(-time.Hour)))
+
+	require.NoError(t, s.svc.UpdatePins(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.UnpinnedAt)
+}
+
+func TestUpdatePins_RetriesTransientFailure(t *testing.T) {
+	ctx := context.Background()
+	s := setupLive(t, ctx, entity.DealStatusPosted, time.Now().Add(-time.Minute))
+
+	s.pinner.EXPECT().Pin(s.channel.TgChannelID, 10).Return(errors.New("connection reset"))
+
+	require.NoError(t, s.svc.UpdatePins(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPosted, got.Status)
+	assert.Nil(t, got.PinnedAt)
+}
+
+func TestUpdatePins_DisputesWhenBotCannotPin(t *testing.T) {
+	ctx := context.Background()
+	s := setupLive(t, ctx, entity.DealStatusPosted, time.Now().Add(-time.Minute))
+
+	s.pinner.EXPECT().
+		Pin(s.channel.TgChannelID, 10).
+		Return(tele.NewError(403, "Forbidden: not enough rights to pin a message"))
+
+	require.NoError(t, s.svc.UpdatePins(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusDispute, got.Status)
+	require.NotNil(t, got.PublisherNote)
+	assert.Contains(t, *got.PublisherNote, "could not be pinned")
+}
@@ -0,0 +1,329 @@
+//go:build integration
+
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	tele "gopkg.in/telebot.v4"
+
+	"github.com/bpva/ad-marketplace/internal/entity"
+	"github.com/bpva/ad-marketplace/internal/service/bot"
+	"github.com/bpva/ad-marketplace/internal/service/publisher"
+)
+
+type publishSetup struct {
+	channel *entity.Channel
+	deal    *entity.Deal
+	bot     *bot.MockTelebotClient
+	pinner  *publisher.MockPinner
+	deleter *publisher.MockDeleter
+	svc     publisherService
+}
+
+func setupPublish(t *testing.T, ctx context.Context, scheduledAt time.Time) *publishSetup {
+	t.Helper()
+	s := setupPayments(t, ctx)
+
+	deal, err := testTools.CreateDeal(
+		ctx,
+		s.channel.ID,
+		s.advertiser.ID,
+		entity.DealStatusApproved,
+		scheduledAt,
+		entity.AdFormatTypePost,
+		false,
+		24,
+		4,
+		dealPrice,
+	)
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	botMock := bot.NewMockTelebotClient(ctrl)
+	pinner := publisher.NewMockPinner(ctrl)
+	deleter := publisher.NewMockDeleter(ctrl)
+
+	return &publishSetup{
+		channel: s.channel,
+		deal:    deal,
+		bot:     botMock,
+		pinner:  pinner,
+		deleter: deleter,
+		svc:     newPublisher(botMock, pinner, deleter),
+	}
+}
+
+// setupLive returns a deal whose ad went live at postedAt as messages 10 and 11
+func setupLive(
+	t *testing.T,
+	ctx context.Context,
+	status entity.DealStatus,
+	postedAt time.Time,
+) *publishSetup {
+	t.Helper()
+	s := setupPayments(t, ctx)
+
+	deal, err := testTools.CreateDeal(
+		ctx,
+		s.channel.ID,
+		s.advertiser.ID,
+		status,
+		postedAt,
+		entity.AdFormatTypePost,
+		false,
+		24,
+		4,
+		dealPrice,
+	)
+	require.NoError(t, err)
+	require.NoError(t, testTools.SetPosted(ctx, deal.ID, []int64{10, 11}, postedAt))
+
+	ctrl := gomock.NewController(t)
+	pinner := publisher.NewMockPinner(ctrl)
+	deleter := publisher.NewMockDeleter(ctrl)
+
+	return &publishSetup{
+		channel: s.channel,
+		deal:    deal,
+		pinner:  pinner,
+		deleter: deleter,
+		svc:     newPublisher(bot.NewMockTelebotClient(ctrl), pinner, deleter),
+	}
+}
+
+func (s *publishSetup) addTextAd(t *testing.T, ctx context.Context, version int, text string) {
+	t.Helper()
+	_, err := testTools.CreatePost(ctx, entity.PostTypeAd, s.deal.ID, &version,
+		nil, &text, nil, nil, nil)
+	require.NoError(t, err)
+}
+
+func TestPublishDue_PostsLatestAdVersion(t *testing.T) {
+	ctx := context.Background()
+	s := setupPublish(t, ctx, time.Now().Add(-time.Minute))
+	s.addTextAd(t, ctx, 1, "first draft")
+	s.addTextAd(t, ctx, 2, "final text")
+
+	s.bot.EXPECT().
+		Send(tele.ChatID(s.channel.TgChannelID), "final text").
+		Return(&tele.Message{ID: 42}, nil)
+
+	require.NoError(t, s.svc.PublishDue(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPosted, got.Status)
+	assert.Equal(t, []int64{42}, got.PostedMessageIDs)
+	require.NotNil(t, got.PostedAt)
+	assert.WithinDuration(t, time.Now(), *got.PostedAt, time.Minute)
+	assert.Nil(t, got.PublishError)
+}
+
+func TestPublishDue_PostsAlbum(t *testing.T) {
+	ctx := context.Background()
+	s := setupPublish(t, ctx, time.Now().Add(-time.Minute))
+
+	version := 1
+	group := "album-1"
+	photo := entity.MediaTypePhoto
+	caption := "album caption"
+	for i, fileID := range []string{"file-1", "file-2"} {
+		var text *string
+		if i == 0 {
+			text = &caption
+		}
+		_, err := testTools.CreatePost(ctx, entity.PostTypeAd, s.deal.ID, &version,
+			&group, text, nil, &photo, &fileID)
+		require.NoError(t, err)
+	}
+
+	s.bot.EXPECT().
+		SendAlbum(tele.ChatID(s.channel.TgChannelID), gomock.Len(2)).
+		Return([]tele.Message{{ID: 7}, {ID: 8}}, nil)
+
+	require.NoError(t, s.svc.PublishDue(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPosted, got.Status)
+	assert.Equal(t, []int64{7, 8}, got.PostedMessageIDs)
+}
+
+func TestPublishDue_SkipsDealsNotYetDue(t *testing.T) {
+	ctx := context.Background()
+	s := setupPublish(t, ctx, time.Now().Add(time.Hour))
+	s.addTextAd(t, ctx, 1, "later")
+
+	require.NoError(t, s.svc.PublishDue(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusApproved, got.Status)
+}
+
+func TestPublishDue_GivesUpWhenBotCannotPost(t *testing.T) {
+	ctx := context.Background()
+	s := setupPublish(t, ctx, time.Now().Add(-time.Minute))
+	s.addTextAd(t, ctx, 1, "text")
+	require.NoError(t, testTools.SetPaid(ctx, s.deal.ID, "paymenttx"))
+
+	s.bot.EXPECT().
+		Send(gomock.Any(), gomock.Any()).
+		Return(nil, tele.ErrNoRightsToSend).
+		Times(1)
+
+	require.NoError(t, s.svc.PublishDue(ctx))
+	require.NoError(t, s.svc.PublishDue(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPublishFailed, got.Status)
+	require.NotNil(t, got.PublishError)
+	assert.Contains(t, *got.PublishError, "no rights to send")
+	require.NotNil(t, got.PublisherNote)
+	assert.Contains(t, *got.PublisherNote, "no rights to send")
+
+	msgs, err := testTools.GetOutboxMessages(ctx, s.deal.ID)
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, entity.OutboxEventRefund, msgs[0].Event)
+}
+
+func TestPublishDue_GivesUpAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	s := setupPublish(t, ctx, time.Now().Add(-time.Minute))
+	s.addTextAd(t, ctx, 1, "text")
+	require.NoError(t, testTools.SetPaid(ctx, s.deal.ID, "paymenttx"))
+
+	s.bot.EXPECT().
+		Send(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("connection reset by peer")).
+		Times(5)
+
+	for range 6 {
+		require.NoError(t, s.svc.PublishDue(ctx))
+	}
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPublishFailed, got.Status)
+	assert.Equal(t, 5, got.PublishAttempts)
+
+	msgs, err := testTools.GetOutboxMessages(ctx, s.deal.ID)
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, entity.OutboxEventRefund, msgs[0].Event)
+}
+
+func TestPublishDue_RetriesTransientFailure(t *testing.T) {
+	ctx := context.Background()
+	s := setupPublish(t, ctx, time.Now().Add(-time.Minute))
+	s.addTextAd(t, ctx, 1, "text")
+
+	gomock.InOrder(
+		s.bot.EXPECT().
+			Send(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("connection reset by peer")),
+		s.bot.EXPECT().
+			Send(gomock.Any(), gomock.Any()).
+			Return(&tele.Message{ID: 5}, nil),
+	)
+
+	require.NoError(t, s.svc.PublishDue(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusApproved, got.Status)
+	assert.Equal(t, 1, got.PublishAttempts)
+	require.NotNil(t, got.PublishError)
+
+	require.NoError(t, s.svc.PublishDue(ctx))
+
+	got, err = testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPosted, got.Status)
+	assert.Nil(t, got.PublishError)
+}
+
+func TestPublishDue_SkipsDealClaimedByAnotherWorker(t *testing.T) {
+	ctx := context.Background()
+	s := setupPublish(t, ctx, time.Now().Add(-time.Minute))
+	s.addTextAd(t, ctx, 1, "text")
+	require.NoError(t, testTools.SetPublishingAt(ctx, s.deal.ID, time.Now()))
+
+	require.NoError(t, s.svc.PublishDue(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusApproved, got.Status)
+	assert.Equal(t, 0, got.PublishAttempts)
+}
+
+func TestPublishDue_RetriesStaleClaim(t *testing.T) {
+	ctx := context.Background()
+	s := setupPublish(t, ctx, time.Now().Add(-time.Hour))
+	s.addTextAd(t, ctx, 1, "text")
+	require.NoError(t, testTools.SetPublishingAt(ctx, s.deal.ID, time.Now().Add(-time.Hour)))
+
+	s.bot.EXPECT().
+		Send(gomock.Any(), gomock.Any()).
+		Return(&tele.Message{ID: 7}, nil)
+
+	require.NoError(t, s.svc.PublishDue(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPosted, got.Status)
+}
+
+func TestPublishDue_KeepsPostedDealsWhenAnotherFails(t *testing.T) {
+	ctx := context.Background()
+	s := setupPublish(t, ctx, time.Now().Add(-2*time.Minute))
+	s.addTextAd(t, ctx, 1, "first")
+
+	other, err := testTools.CreateDeal(
+		ctx,
+		s.channel.ID,
+		s.deal.AdvertiserID,
+		entity.DealStatusApproved,
+		time.Now().Add(-time.Minute),
+		entity.AdFormatTypePost,
+		false,
+		24,
+		4,
+		dealPrice,
+	)
+	require.NoError(t, err)
+	version, text := 1, "second"
+	_, err = testTools.CreatePost(ctx, entity.PostTypeAd, other.ID, &version,
+		nil, &text, nil, nil, nil)
+	require.NoError(t, err)
+
+	gomock.InOrder(
+		s.bot.EXPECT().
+			Send(gomock.Any(), "first").
+			Return(&tele.Message{ID: 8}, nil),
+		s.bot.EXPECT().
+			Send(gomock.Any(), "second").
+			Return(nil, errors.New("connection reset by peer")),
+	)
+
+	require.NoError(t, s.svc.PublishDue(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPosted, got.Status)
+
+	got, err = testTools.GetDeal(ctx, other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusApproved, got.Status)
+	assert.Equal(t, 1, got.PublishAttempts)
+	assert.Nil(t, got.PublishingAt)
+}
@@ -0,0 +1,191 @@
+//go:build integration
+
+package worker_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+const (
+	refundAddress = "0:2222222222222222222222222222222222222222222222222222222222222222"
+	payerAddress  = "0:3333333333333333333333333333333333333333333333333333333333333333"
+)
+
+func setupRefundedDeal(t *testing.T, ctx context.Context, advertiserWallet string) *entity.Deal {
+	t.Helper()
+	s := setupPayments(t, ctx)
+
+	deal, err := testTools.CreateDeal(
+		ctx,
+		s.channel.ID,
+		s.advertiser.ID,
+		entity.DealStatusRejected,
+		time.Now().Add(48*time.Hour),
+		entity.AdFormatTypePost,
+		false,
+		24,
+		4,
+		dealPrice,
+	)
+	require.NoError(t, err)
+	require.NoError(t, testTools.SetEscrowDeposit(ctx, deal.ID, escrowAddress, nil))
+	require.NoError(t, testTools.SetPaid(ctx, deal.ID, "payment-tx"))
+	if advertiserWallet != "" {
+		require.NoError(t, testTools.SetAdvertiserWallet(ctx, deal.ID, advertiserWallet))
+	}
+	require.NoError(t, testTools.CreateOutboxMessage(ctx, deal.ID, entity.OutboxEventRefund))
+
+	return deal
+}
+
+func TestProcessTransfers_RefundsFromOutbox(t *testing.T) {
+	ctx := context.Background()
+	deal := setupRefundedDeal(t, ctx, refundAddress)
+
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+
+	msgs, err := testTools.GetOutboxMessages(ctx, deal.ID)
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	assert.NotNil(t, msgs[0].ProcessedAt)
+
+	transfers, err := testTools.GetTransfers(ctx, deal.ID)
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	refund := transfers[0]
+	assert.Equal(t, entity.TransferKindRefund, refund.Kind)
+	assert.Equal(t, entity.TransferStatusSent, refund.Status)
+	assert.Equal(t, refundAddress, refund.Destination)
+	assert.Equal(t, dealPrice, refund.AmountNanoTON)
+	assert.Equal(t, int64(0), refund.FeeNanoTON)
+	assert.Equal(t, fmt.Sprintf("Refund for deal %s", deal.ID), refund.Comment)
+	assert.Len(t, testTONCenter.SentBOCs(), 1)
+
+	hash := testTONCenter.AddOutgoing(
+		escrowAddress, refundAddress, refund.AmountNanoTON, refund.Comment, time.Now(),
+	)
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.RefundTxHash)
+	assert.Equal(t, hash, *got.RefundTxHash)
+	assert.Nil(t, got.ReleaseTxHash)
+
+	transfers, err = testTools.GetTransfers(ctx, deal.ID)
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	assert.Equal(t, entity.TransferStatusConfirmed, transfers[0].Status)
+}
+
+func TestProcessTransfers_RefundIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	deal := setupRefundedDeal(t, ctx, refundAddress)
+
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+
+	transfers, err := testTools.GetTransfers(ctx, deal.ID)
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	assert.Equal(t, 1, transfers[0].Attempts)
+	assert.Len(t, testTONCenter.SentBOCs(), 1)
+}
+
+func TestProcessTransfers_RefundWaitsForAdvertiserWallet(t *testing.T) {
+	ctx := context.Background()
+	deal := setupRefundedDeal(t, ctx, "")
+
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+
+	msgs, err := testTools.GetOutboxMessages(ctx, deal.ID)
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	assert.Nil(t, msgs[0].ProcessedAt)
+
+	transfers, err := testTools.GetTransfers(ctx, deal.ID)
+	require.NoError(t, err)
+	assert.Empty(t, transfers)
+	assert.Empty(t, testTONCenter.SentBOCs())
+
+	require.NoError(t, testTools.SetAdvertiserWallet(ctx, deal.ID, refundAddress))
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+
+	transfers, err = testTools.GetTransfers(ctx, deal.ID)
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	assert.Equal(t, entity.TransferKindRefund, transfers[0].Kind)
+}
+
+func TestProcessTransfers_FailedRefundDoesNotBlockOthers(t *testing.T) {
+	ctx := context.Background()
+	broken := setupRefundedDeal(t, ctx, refundAddress)
+	// a refund row already exists, so queueing the event violates (deal_id, kind)
+	require.NoError(t, testTools.CreateTransfer(
+		ctx, broken.ID, entity.TransferKindRefund, refundAddress, dealPrice, "stale refund",
+	))
+
+	deal, err := testTools.CreateDeal(
+		ctx,
+		broken.ChannelID,
+		broken.AdvertiserID,
+		entity.DealStatusRejected,
+		time.Now().Add(48*time.Hour),
+		entity.AdFormatTypePost,
+		false,
+		24,
+		4,
+		dealPrice,
+	)
+	require.NoError(t, err)
+	require.NoError(t, testTools.SetPaid(ctx, deal.ID, "payment-tx-2"))
+	require.NoError(t, testTools.SetAdvertiserWallet(ctx, deal.ID, refundAddress))
+	require.NoError(t, testTools.CreateOutboxMessage(ctx, deal.ID, entity.OutboxEventRefund))
+
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+
+	msgs, err := testTools.GetOutboxMessages(ctx, broken.ID)
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	assert.Nil(t, msgs[0].ProcessedAt)
+
+	transfers, err := testTools.GetTransfers(ctx, deal.ID)
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	assert.Equal(t, entity.TransferKindRefund, transfers[0].Kind)
+}
+
+func TestProcessTransfers_RefundsToPayer(t *testing.T) {
+	ctx := context.Background()
+	deal := setupPendingPaymentDeal(t, ctx)
+	testTONCenter.AddIncoming(
+		escrowAddress, payerAddress, dealPrice, "", time.Now().Add(time.Minute),
+	)
+	require.NoError(t, escrowSvc.CheckPayments(ctx))
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	require.Equal(t, entity.DealStatusPendingReview, got.Status)
+	require.NotNil(t, got.PayerAddress)
+	assert.Equal(t, payerAddress, *got.PayerAddress)
+	assert.Nil(t, got.AdvertiserWalletAddress)
+
+	require.NoError(t, testTools.SetStatusChangedAt(ctx, deal.ID, time.Now().Add(-25*time.Hour)))
+	require.NoError(t, slaSvc.ExpireReviews(ctx))
+	require.NoError(t, escrowSvc.ProcessTransfers(ctx))
+
+	transfers, err := testTools.GetTransfers(ctx, deal.ID)
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	assert.Equal(t, entity.TransferKindRefund, transfers[0].Kind)
+	assert.Equal(t, payerAddress, transfers[0].Destination)
+}
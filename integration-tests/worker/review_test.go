@@ -0,0 +1,163 @@
+//go:build integration
+
+package worker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+func setupReviewDeal(
+	t *testing.T,
+	ctx context.Context,
+	status entity.DealStatus,
+	scheduledAt time.Time,
+) (*paymentSetup, *entity.Deal) {
+	t.Helper()
+	s := setupPayments(t, ctx)
+
+	deal, err := testTools.CreateDeal(
+		ctx,
+		s.channel.ID,
+		s.advertiser.ID,
+		status,
+		scheduledAt,
+		entity.AdFormatTypePost,
+		false,
+		24,
+		4,
+		dealPrice,
+	)
+	require.NoError(t, err)
+	require.NoError(t, testTools.SetEscrowDeposit(ctx, deal.ID, escrowAddress, nil))
+	require.NoError(t, testTools.SetPaid(ctx, deal.ID, "payment-tx"))
+
+	return s, deal
+}
+
+func TestExpireReviews_CancelsStaleReview(t *testing.T) {
+	ctx := context.Background()
+	s, deal := setupReviewDeal(
+		t, ctx, entity.DealStatusPendingReview, time.Now().Add(72*time.Hour),
+	)
+	require.NoError(t, testTools.SetStatusChangedAt(ctx, deal.ID, time.Now().Add(-25*time.Hour)))
+
+	require.NoError(t, slaSvc.ExpireReviews(ctx))
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusCancelled, got.Status)
+	require.NotNil(t, got.PublisherNote)
+	assert.Equal(t, "publisher did not review the ad in time", *got.PublisherNote)
+
+	msgs, err := testTools.GetOutboxMessages(ctx, deal.ID)
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, entity.OutboxEventRefund, msgs[0].Event)
+
+	sent := testSender.Sent()
+	require.Len(t, sent, 1)
+	assert.Equal(t, s.advertiser.TgID, sent[0].ChatID)
+	assert.Contains(t, sent[0].Text, deal.ID.String())
+}
+
+func TestExpireReviews_CancelsStaleChangesRequest(t *testing.T) {
+	ctx := context.Background()
+	_, deal := setupReviewDeal(
+		t, ctx, entity.DealStatusChangesRequested, time.Now().Add(72*time.Hour),
+	)
+	require.NoError(t, testTools.SetStatusChangedAt(ctx, deal.ID, time.Now().Add(-25*time.Hour)))
+
+	require.NoError(t, slaSvc.ExpireReviews(ctx))
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusCancelled, got.Status)
+	require.NotNil(t, got.PublisherNote)
+	assert.Equal(t, "requested changes were not submitted in time", *got.PublisherNote)
+}
+
+func TestExpireReviews_BlamesAdvertiserForUnconfirmedEdit(t *testing.T) {
+	ctx := context.Background()
+	s, deal := setupReviewDeal(
+		t, ctx, entity.DealStatusPendingReview, time.Now().Add(72*time.Hour),
+	)
+	publisher, err := testTools.CreateUser(ctx, 5001002, "Publisher")
+	require.NoError(t, err)
+	require.NoError(t, testTools.CreateRevision(ctx, deal.ID, s.advertiser.ID, 1))
+	require.NoError(t, testTools.CreatePublisherRevision(ctx, deal.ID, publisher.ID, 2))
+	require.NoError(t, testTools.SetStatusChangedAt(ctx, deal.ID, time.Now().Add(-25*time.Hour)))
+
+	require.NoError(t, slaSvc.ExpireReviews(ctx))
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusCancelled, got.Status)
+	require.NotNil(t, got.PublisherNote)
+	assert.Equal(t,
+		"advertiser did not confirm the publisher's changes in time", *got.PublisherNote)
+}
+
+func TestExpireReviews_CancelsReviewCloseToSlot(t *testing.T) {
+	ctx := context.Background()
+	_, deal := setupReviewDeal(
+		t, ctx, entity.DealStatusPendingReview, time.Now().Add(30*time.Minute),
+	)
+
+	require.NoError(t, slaSvc.ExpireReviews(ctx))
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusCancelled, got.Status)
+	require.NotNil(t, got.PublisherNote)
+	assert.Equal(t, "review was not finished before the scheduled time", *got.PublisherNote)
+}
+
+func TestExpireReviews_KeepsFreshReview(t *testing.T) {
+	ctx := context.Background()
+	_, deal := setupReviewDeal(
+		t, ctx, entity.DealStatusPendingReview, time.Now().Add(72*time.Hour),
+	)
+	require.NoError(t, testTools.SetStatusChangedAt(ctx, deal.ID, time.Now().Add(-23*time.Hour)))
+
+	require.NoError(t, slaSvc.ExpireReviews(ctx))
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPendingReview, got.Status)
+	assert.Empty(t, testSender.Sent())
+}
+
+func TestExpireReviews_UsesChannelTimeout(t *testing.T) {
+	ctx := context.Background()
+	s, deal := setupReviewDeal(
+		t, ctx, entity.DealStatusPendingReview, time.Now().Add(72*time.Hour),
+	)
+	require.NoError(t, testTools.SetChannelReviewTimeout(ctx, s.channel.ID, 6))
+	require.NoError(t, testTools.SetStatusChangedAt(ctx, deal.ID, time.Now().Add(-7*time.Hour)))
+
+	require.NoError(t, slaSvc.ExpireReviews(ctx))
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusCancelled, got.Status)
+}
+
+func TestExpireReviews_IgnoresApprovedDeal(t *testing.T) {
+	ctx := context.Background()
+	_, deal := setupReviewDeal(
+		t, ctx, entity.DealStatusApproved, time.Now().Add(30*time.Minute),
+	)
+
+	require.NoError(t, slaSvc.ExpireReviews(ctx))
+
+	got, err := testTools.GetDeal(ctx, deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusApproved, got.Status)
+}
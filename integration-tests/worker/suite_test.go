@@ -0,0 +1,269 @@
+//go:build integration
+
+package worker_test
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/bpva/ad-marketplace/integration-tests/tools"
+	"github.com/bpva/ad-marketplace/internal/config"
+	"github.com/bpva/ad-marketplace/internal/gateway/ton"
+	channel_repo "github.com/bpva/ad-marketplace/internal/repository/channel"
+	cursor_repo "github.com/bpva/ad-marketplace/internal/repository/cursor"
+	deal_repo "github.com/bpva/ad-marketplace/internal/repository/deal"
+	dispute_repo "github.com/bpva/ad-marketplace/internal/repository/dispute"
+	event_repo "github.com/bpva/ad-marketplace/internal/repository/event"
+	ledger_repo "github.com/bpva/ad-marketplace/internal/repository/ledger"
+	message_repo "github.com/bpva/ad-marketplace/internal/repository/message"
+	offer_repo "github.com/bpva/ad-marketplace/internal/repository/offer"
+	outbox_repo "github.com/bpva/ad-marketplace/internal/repository/outbox"
+	post_repo "github.com/bpva/ad-marketplace/internal/repository/post"
+	promotion_repo "github.com/bpva/ad-marketplace/internal/repository/promotion"
+	rating_repo "github.com/bpva/ad-marketplace/internal/repository/rating"
+	reschedule_repo "github.com/bpva/ad-marketplace/internal/repository/reschedule"
+	revision_repo "github.com/bpva/ad-marketplace/internal/repository/revision"
+	settings_repo "github.com/bpva/ad-marketplace/internal/repository/settings"
+	snapshot_repo "github.com/bpva/ad-marketplace/internal/repository/snapshot"
+	transfer_repo "github.com/bpva/ad-marketplace/internal/repository/transfer"
+	user_repo "github.com/bpva/ad-marketplace/internal/repository/user"
+	deal_service "github.com/bpva/ad-marketplace/internal/service/deal"
+	"github.com/bpva/ad-marketplace/internal/service/escrow"
+	"github.com/bpva/ad-marketplace/internal/service/messaging"
+	"github.com/bpva/ad-marketplace/internal/service/notification"
+	post_service "github.com/bpva/ad-marketplace/internal/service/post"
+	"github.com/bpva/ad-marketplace/internal/service/publisher"
+	"github.com/bpva/ad-marketplace/internal/service/sla"
+	"github.com/bpva/ad-marketplace/internal/service/verifier"
+	"github.com/bpva/ad-marketplace/internal/storage"
+	"github.com/bpva/ad-marketplace/migrations"
+)
+
+type publisherService interface {
+	PublishDue(ctx context.Context) error
+	UpdatePins(ctx context.Context) error
+	DeleteExpired(ctx context.Context) error
+}
+
+type verifierService interface {
+	VerifyPosted(ctx context.Context) error
+}
+
+type slaService interface {
+	ExpireReviews(ctx context.Context) error
+}
+
+type messagingService interface {
+	RelayMessages(ctx context.Context) error
+}
+
+type escrowService interface {
+	CheckPayments(ctx context.Context) error
+	ProcessTransfers(ctx context.Context) error
+}
+
+const (
+	escrowAddress = "EQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqB2N"
+	escrowSeed    = "0000000000000000000000000000000000000000000000000000000000000001"
+	platformFee   = 500
+	miniAppURL    = "https://app.example.com"
+)
+
+var (
+	testPool      *pgxpool.Pool
+	testTools     *tools.Tools
+	testTONCenter *tools.FakeTONCenter
+	testSender    *tools.FakeSender
+	escrowSvc     escrowService
+	slaSvc        slaService
+	messagingSvc  messagingService
+	// publisher is built per test around its own telebot mock
+	newPublisher func(
+		bot post_service.TelebotClient,
+		pinner publisher.Pinner,
+		deleter publisher.Deleter,
+	) publisherService
+	newVerifier func(tg verifier.TelegramClient) verifierService
+)
+
+func TestMain(m *testing.M) {
+	ctx := context.Background()
+
+	pgContainer, err := postgres.Run(ctx,
+		"postgres:16-alpine",
+		postgres.WithDatabase("test_db"),
+		postgres.WithUsername("test"),
+		postgres.WithPassword("test"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2),
+		),
+	)
+	if err != nil {
+		slog.Error("failed to start postgres container", "error", err)
+		os.Exit(1)
+	}
+
+	host, err := pgContainer.Host(ctx)
+	if err != nil {
+		slog.Error("failed to get host", "error", err)
+		os.Exit(1)
+	}
+
+	port, err := pgContainer.MappedPort(ctx, "5432/tcp")
+	if err != nil {
+		slog.Error("failed to get port", "error", err)
+		os.Exit(1)
+	}
+
+	pgCfg := config.Postgres{
+		Host:     host,
+		Port:     port.Port(),
+		User:     "test",
+		Password: "test",
+		DB:       "test_db",
+	}
+
+	if err := migrations.Run(storage.URL(pgCfg)); err != nil {
+		slog.Error("failed to run migrations", "error", err)
+		os.Exit(1)
+	}
+
+	testDB, err := storage.New(ctx, pgCfg)
+	if err != nil {
+		slog.Error("failed to create storage", "error", err)
+		os.Exit(1)
+	}
+
+	connStr, err := pgContainer.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		slog.Error("failed to get connection string", "error", err)
+		os.Exit(1)
+	}
+
+	testPool, err = pgxpool.New(ctx, connStr)
+	if err != nil {
+		slog.Error("failed to create pool", "error", err)
+		os.Exit(1)
+	}
+
+	testTools = tools.New(testPool, "unused-jwt-secret")
+	testTONCenter = tools.NewFakeTONCenter()
+	testSender = tools.NewFakeSender()
+
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	tonCfg := config.TON{
+		Provider:            "toncenter",
+		Network:             "testnet",
+		APIURL:              testTONCenter.URL,
+		EscrowWalletAddress: escrowAddress,
+		EscrowWalletSeed:    escrowSeed,
+	}
+	tonClient, err := ton.New(tonCfg, log)
+	if err != nil {
+		slog.Error("failed to create ton client", "error", err)
+		os.Exit(1)
+	}
+
+	dealRepo := deal_repo.New(testDB)
+	channelRepo := channel_repo.New(testDB)
+	postRepo := post_repo.New(testDB)
+	transferRepo := transfer_repo.New(testDB)
+	outboxRepo := outbox_repo.New(testDB)
+	revisionRepo := revision_repo.New(testDB)
+	eventRepo := event_repo.New(testDB)
+	messageRepo := message_repo.New(testDB)
+	offerRepo := offer_repo.New(testDB)
+	rescheduleRepo := reschedule_repo.New(testDB)
+	disputeRepo := dispute_repo.New(testDB)
+	snapshotRepo := snapshot_repo.New(testDB)
+	ratingRepo := rating_repo.New(testDB)
+	promotionRepo := promotion_repo.New(testDB)
+	ledgerRepo := ledger_repo.New(testDB)
+	cursorRepo := cursor_repo.New(testDB)
+	userRepo := user_repo.New(testDB)
+	dealCfg := config.Deal{
+		PaymentTimeout: time.Hour,
+		ReviewTimeout:  24 * time.Hour,
+		ReviewCutoff:   time.Hour,
+		PlatformFeeBPS: platformFee,
+	}
+	dealSvc := deal_service.New(
+		dealCfg,
+		dealRepo,
+		channelRepo,
+		postRepo,
+		userRepo,
+		transferRepo,
+		outboxRepo,
+		revisionRepo,
+		eventRepo,
+		messageRepo,
+		offerRepo,
+		rescheduleRepo,
+		disputeRepo,
+		snapshotRepo,
+		ratingRepo,
+		promotionRepo,
+		ledgerRepo,
+		testDB,
+		escrow.NewWallet(escrowAddress),
+		log,
+	)
+	settingsRepo := settings_repo.New(testDB)
+	notificationSvc := notification.New(userRepo, settingsRepo, testSender, log)
+	escrowSvc = escrow.New(
+		tonCfg,
+		dealRepo,
+		channelRepo,
+		transferRepo,
+		outboxRepo,
+		cursorRepo,
+		ledgerRepo,
+		dealSvc,
+		notificationSvc,
+		tonClient,
+		testDB,
+		log,
+	)
+	slaSvc = sla.New(dealCfg, dealRepo, revisionRepo, dealSvc, notificationSvc, log)
+	messagingSvc = messaging.New(
+		config.Telegram{MiniAppURL: miniAppURL},
+		messageRepo,
+		dealRepo,
+		channelRepo,
+		userRepo,
+		settingsRepo,
+		testSender,
+		log,
+	)
+	newPublisher = func(
+		bot post_service.TelebotClient,
+		pinner publisher.Pinner,
+		deleter publisher.Deleter,
+	) publisherService {
+		postSvc := post_service.New(postRepo, bot, log)
+		return publisher.New(
+			dealRepo, channelRepo, postRepo, postSvc, pinner, deleter, dealSvc, testDB, log,
+		)
+	}
+	newVerifier = func(tg verifier.TelegramClient) verifierService {
+		return verifier.New(dealRepo, channelRepo, snapshotRepo, tg, dealSvc, log)
+	}
+
+	code := m.Run()
+
+	testTONCenter.Close()
+	testPool.Close()
+	_ = pgContainer.Terminate(ctx)
+
+	os.Exit(code)
+}
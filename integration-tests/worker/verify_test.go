@@ -0,0 +1,224 @@
+//go:build integration
+
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+	"github.com/bpva/ad-marketplace/internal/gateway/mtproto"
+	"github.com/bpva/ad-marketplace/internal/service/verifier"
+)
+
+type verifySetup struct {
+	channel *entity.Channel
+	deal    *entity.Deal
+	tg      *verifier.MockTelegramClient
+	svc     verifierService
+}
+
+func setupVerify(t *testing.T, ctx context.Context, postedAt time.Time) *verifySetup {
+	t.Helper()
+	s := setupPayments(t, ctx)
+
+	deal, err := testTools.CreateDeal(
+		ctx,
+		s.channel.ID,
+		s.advertiser.ID,
+		entity.DealStatusPosted,
+		postedAt,
+		entity.AdFormatTypePost,
+		false,
+		24,
+		4,
+		dealPrice,
+	)
+	require.NoError(t, err)
+	require.NoError(t, testTools.SetPosted(ctx, deal.ID, []int64{10, 11}, postedAt))
+
+	mock := verifier.NewMockTelegramClient(gomock.NewController(t))
+
+	return &verifySetup{
+		channel: s.channel,
+		deal:    deal,
+		tg:      mock,
+		svc:     newVerifier(mock),
+	}
+}
+
+func (s *verifySetup) expectMessages(msgs ...dto.ChannelMessage) {
+	s.tg.EXPECT().
+		GetChannelMessages(gomock.Any(), mtproto.BotAPIToMTProto(s.channel.TgChannelID),
+			[]int64{10, 11}).
+		Return(msgs, nil)
+}
+
+func TestVerifyPosted_KeepsDealWithinWindow(t *testing.T) {
+	ctx := context.Background()
+	s := setupVerify(t, ctx, time.Now().Add(-time.Hour))
+	s.expectMessages(
+		dto.ChannelMessage{ID: 10, Exists: true},
+		dto.ChannelMessage{ID: 11, Exists: true},
+	)
+
+	require.NoError(t, s.svc.VerifyPosted(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPosted, got.Status)
+}
+
+func TestVerifyPosted_StoresViewSnapshot(t *testing.T) {
+	ctx := context.Background()
+	s := setupVerify(t, ctx, time.Now().Add(-time.Hour))
+	s.expectMessages(
+		dto.ChannelMessage{ID: 10, Exists: true, Views: 1200, Forwards: 2, Reactions: 7},
+		dto.ChannelMessage{ID: 11, Exists: true, Views: 1180, Forwards: 1},
+	)
+
+	require.NoError(t, s.svc.VerifyPosted(ctx))
+
+	snaps, err := testTools.GetViewSnapshots(ctx, s.deal.ID)
+	require.NoError(t, err)
+	require.Len(t, snaps, 1)
+	assert.Equal(t, int64(1200), snaps[0].Views)
+	assert.Equal(t, int64(3), snaps[0].Forwards)
+	assert.Equal(t, int64(7), snaps[0].Reactions)
+}
+
+func TestVerifyPosted_CompletesAfterWindow(t *testing.T) {
+	ctx := context.Background()
+	s := setupVerify(t, ctx, time.Now().Add(-25*time.Hour))
+	s.expectMessages(
+		dto.ChannelMessage{ID: 10, Exists: true},
+		dto.ChannelMessage{ID: 11, Exists: true},
+	)
+
+	require.NoError(t, s.svc.VerifyPosted(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusCompleted, got.Status)
+}
+
+func TestVerifyPosted_DisputesDeletedMessage(t *testing.T) {
+	ctx := context.Background()
+	s := setupVerify(t, ctx, time.Now().Add(-time.Hour))
+	s.expectMessages(
+		dto.ChannelMessage{ID: 10, Exists: true},
+		dto.ChannelMessage{ID: 11},
+	)
+
+	require.NoError(t, s.svc.VerifyPosted(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusDispute, got.Status)
+	require.NotNil(t, got.PublisherNote)
+	assert.Contains(t, *got.PublisherNote, "message 11 was deleted")
+}
+
+func TestVerifyPosted_DisputesEditedMessage(t *testing.T) {
+	ctx := context.Background()
+	s := setupVerify(t, ctx, time.Now().Add(-25*time.Hour))
+	editedAt := time.Now().Add(-20 * time.Hour)
+	s.expectMessages(
+		dto.ChannelMessage{ID: 10, Exists: true, EditedAt: &editedAt},
+		dto.ChannelMessage{ID: 11, Exists: true},
+	)
+
+	require.NoError(t, s.svc.VerifyPosted(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusDispute, got.Status)
+	require.NotNil(t, got.PublisherNote)
+	assert.Contains(t, *got.PublisherNote, "message 10 was edited")
+}
+
+func TestVerifyPosted_CompletesWhenDeletedAfterWindow(t *testing.T) {
+	ctx := context.Background()
+	s := setupVerify(t, ctx, time.Now().Add(-25*time.Hour))
+	s.expectMessages(
+		dto.ChannelMessage{ID: 10},
+		dto.ChannelMessage{ID: 11},
+	)
+
+	require.NoError(t, s.svc.VerifyPosted(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusCompleted, got.Status)
+}
+
+func TestVerifyPosted_CompletesWhenEditedAfterWindow(t *testing.T) {
+	ctx := context.Background()
+	s := setupVerify(t, ctx, time.Now().Add(-25*time.Hour))
+	editedAt := time.Now().Add(-30 * time.Minute)
+	s.expectMessages(
+		dto.ChannelMessage{ID: 10, Exists: true, EditedAt: &editedAt},
+		dto.ChannelMessage{ID: 11, Exists: true},
+	)
+
+	require.NoError(t, s.svc.VerifyPosted(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusCompleted, got.Status)
+}
+
+func TestVerifyPosted_LeavesDealWhenChannelUnreadable(t *testing.T) {
+	ctx := context.Background()
+	s := setupVerify(t, ctx, time.Now().Add(-25*time.Hour))
+	s.tg.EXPECT().
+		GetChannelMessages(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("CHANNEL_PRIVATE"))
+
+	require.NoError(t, s.svc.VerifyPosted(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPosted, got.Status)
+}
+
+func TestVerifyPosted_DisputesEarlyUnpin(t *testing.T) {
+	ctx := context.Background()
+	s := setupVerify(t, ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, testTools.SetPinned(ctx, s.deal.ID, time.Now().Add(-time.Hour)))
+	s.expectMessages(
+		dto.ChannelMessage{ID: 10, Exists: true},
+		dto.ChannelMessage{ID: 11, Exists: true},
+	)
+
+	require.NoError(t, s.svc.VerifyPosted(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusDispute, got.Status)
+	require.NotNil(t, got.PublisherNote)
+	assert.Contains(t, *got.PublisherNote, "message 10 was unpinned")
+}
+
+func TestVerifyPosted_AllowsUnpinAfterTopHours(t *testing.T) {
+	ctx := context.Background()
+	s := setupVerify(t, ctx, time.Now().Add(-5*time.Hour))
+	require.NoError(t, testTools.SetPinned(ctx, s.deal.ID, time.Now().Add(-5*time.Hour)))
+	s.expectMessages(
+		dto.ChannelMessage{ID: 10, Exists: true},
+		dto.ChannelMessage{ID: 11, Exists: true},
+	)
+
+	require.NoError(t, s.svc.VerifyPosted(ctx))
+
+	got, err := testTools.GetDeal(ctx, s.deal.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPosted, got.Status)
+}
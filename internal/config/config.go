@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
@@ -12,6 +13,8 @@ type Config struct {
 	Postgres Postgres `yaml:"postgres"`
 	Telegram Telegram `yaml:"telegram"`
 	TON      TON      `yaml:"ton"`
+	Worker   Worker   `yaml:"worker"`
+	Deal     Deal     `yaml:"deal"`
 	JWT      JWT      `yaml:"jwt"`
 	Logger   Logger   `yaml:"logger"`
 }
@@ -49,11 +52,48 @@ type Telegram struct {
 }
 
 type TON struct {
-	Provider string `yaml:"provider" env:"TON_PROVIDER" env-default:"toncenter"`
-	Network  string `yaml:"network" env:"TON_NETWORK" env-default:"testnet"`
-	APIKey   string `yaml:"api_key" env:"TON_API_KEY"`
+	Provider     string        `yaml:"provider" env:"TON_PROVIDER" env-default:"toncenter"`
+	Network      string        `yaml:"network" env:"TON_NETWORK" env-default:"testnet"`
+	APIKey       string        `yaml:"api_key" env:"TON_API_KEY"`
+	APIURL       string        `yaml:"api_url" env:"TON_API_URL"`
+	PollInterval time.Duration `yaml:"poll_interval" env:"TON_POLL_INTERVAL" env-default:"15s"`
+
+	EscrowWalletAddress string `yaml:"escrow_wallet_address" env:"TON_ESCROW_WALLET_ADDRESS"`
+	// hex-encoded ed25519 seed of the escrow wallet (v4r2); required to send payouts
+	EscrowWalletSeed string `yaml:"escrow_wallet_seed" env:"TON_ESCROW_WALLET_SEED"`
+}
+
+type Worker struct {
+	// how often deal lifecycle jobs (publishing etc.) run
+	Interval time.Duration `yaml:"interval" env:"WORKER_INTERVAL" env-default:"30s"`
+	// how often posted ads are checked against the channel
+	VerifyInterval time.Duration `yaml:"verify_interval" env:"WORKER_VERIFY_INTERVAL" env-default:"5m"`
+	// how often channel ratings and deal counts on the marketplace are recomputed
+	MarketplaceInterval time.Duration `yaml:"marketplace_interval" env:"WORKER_MARKETPLACE_INTERVAL" env-default:"10m"`
+	// how often the ledger is reconciled against payments and transfers
+	LedgerInterval time.Duration `yaml:"ledger_interval" env:"WORKER_LEDGER_INTERVAL" env-default:"1h"`
 }
 
+type Deal struct {
+	// how long the advertiser has to fund a new deal before it is dropped
+	PaymentTimeout time.Duration `yaml:"payment_timeout" env:"DEAL_PAYMENT_TIMEOUT" env-default:"2h"`
+	// how long a deal may wait on the other party during review
+	ReviewTimeout time.Duration `yaml:"review_timeout" env:"DEAL_REVIEW_TIMEOUT" env-default:"24h"`
+	// a deal still under review this close to its slot is cancelled
+	ReviewCutoff time.Duration `yaml:"review_cutoff" env:"DEAL_REVIEW_CUTOFF" env-default:"1h"`
+	// telegram ids of the platform's arbiters, who settle disputes
+	Arbiters []int64 `yaml:"arbiters" env:"DEAL_ARBITERS" env-separator:","`
+	// platform commission in basis points, frozen on each deal when it is created;
+	// channel overrides and promotions take precedence; at most MaxPlatformFeeBPS
+	PlatformFeeBPS int64 `yaml:"platform_fee_bps" env:"DEAL_PLATFORM_FEE_BPS" env-default:"0"`
+	// telegram ids of the platform staff who manage fees and audit the ledger
+	Admins []int64 `yaml:"admins" env:"DEAL_ADMINS" env-separator:","`
+}
+
+// MaxPlatformFeeBPS keeps the platform fee below the whole payout, so the
+// publisher of a deal always receives something.
+const MaxPlatformFeeBPS = 9999
+
 type JWT struct {
 	Secret string `env:"JWT_SECRET" env-required:"true"`
 }
@@ -63,5 +103,9 @@ func Load() (*Config, error) {
 	if err := cleanenv.ReadConfig("config/config.yaml", &cfg); err != nil {
 		return nil, err
 	}
+	if fee := cfg.Deal.PlatformFeeBPS; fee < 0 || fee > MaxPlatformFeeBPS {
+		return nil, fmt.Errorf("DEAL_PLATFORM_FEE_BPS must be between 0 and %d, got %d",
+			MaxPlatformFeeBPS, fee)
+	}
 	return &cfg, nil
 }
@@ -0,0 +1,81 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+type CreateCampaignRequest struct {
+	Name           string           `json:"name" validate:"required,max=128"`
+	TemplatePostID string           `json:"template_post_id" validate:"required,uuid"`
+	BudgetNanoTON  int64            `json:"budget_nano_ton" validate:"required,gt=0"`
+	Targets        []CampaignTarget `json:"targets" validate:"required,min=1,max=50,dive"`
+}
+
+// CampaignTarget is one channel and format the campaign's template is placed
+// in; each target becomes a deal.
+type CampaignTarget struct {
+	TgChannelID  int64               `json:"channel_id" validate:"required"`
+	FormatType   entity.AdFormatType `json:"format_type" validate:"required"`
+	IsNative     bool                `json:"is_native"`
+	FeedHours    int                 `json:"feed_hours" validate:"required,gt=0"`
+	TopHours     int                 `json:"top_hours" validate:"required,gt=0"`
+	PriceNanoTON int64               `json:"price_nano_ton" validate:"required,gt=0"`
+	ScheduledAt  time.Time           `json:"scheduled_at" validate:"required"`
+	Offer        bool                `json:"offer"`
+}
+
+type CampaignItem struct {
+	entity.Campaign
+	Status        entity.CampaignStatus
+	DealsByStatus map[entity.DealStatus]int
+	// paid and held in escrow
+	CommittedNanoTON int64
+	// released to publishers
+	SpentNanoTON int64
+	// subscribers of the channels the ad went out in
+	Reach int64
+	Deals []DealListItem
+}
+
+type CampaignResponse struct {
+	ID               uuid.UUID                 `json:"id"`
+	Name             string                    `json:"name"`
+	TemplatePostID   uuid.UUID                 `json:"template_post_id"`
+	BudgetNanoTON    int64                     `json:"budget_nano_ton"`
+	Status           entity.CampaignStatus     `json:"status"`
+	DealsByStatus    map[entity.DealStatus]int `json:"deals_by_status"`
+	CommittedNanoTON int64                     `json:"committed_nano_ton"`
+	SpentNanoTON     int64                     `json:"spent_nano_ton"`
+	Reach            int64                     `json:"reach"`
+	Deals            []DealResponse            `json:"deals,omitempty"`
+	CreatedAt        time.Time                 `json:"created_at"`
+}
+
+type CampaignsResponse struct {
+	Campaigns []CampaignResponse `json:"campaigns"`
+}
+
+func CampaignResponseFrom(item *CampaignItem) CampaignResponse {
+	resp := CampaignResponse{
+		ID:               item.ID,
+		Name:             item.Name,
+		TemplatePostID:   item.TemplatePostID,
+		BudgetNanoTON:    item.BudgetNanoTON,
+		Status:           item.Status,
+		DealsByStatus:    item.DealsByStatus,
+		CommittedNanoTON: item.CommittedNanoTON,
+		SpentNanoTON:     item.SpentNanoTON,
+		Reach:            item.Reach,
+		CreatedAt:        item.CreatedAt,
+	}
+
+	for i := range item.Deals {
+		resp.Deals = append(resp.Deals, DealListResponseFrom(item.Deals[i]))
+	}
+
+	return resp
+}
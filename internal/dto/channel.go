@@ -28,6 +28,8 @@ type ChannelResponse struct {
 	Categories    []CategoryResponse `json:"categories"`
 	HasStats      bool               `json:"has_stats"`
 	PayoutAddress *string            `json:"payout_address,omitempty"`
+	// nil means the platform default applies
+	ReviewTimeoutHours *int `json:"review_timeout_hours,omitempty"`
 }
 
 type ChannelWithRoleResponse struct {
@@ -70,11 +72,17 @@ type UpdateListingRequest struct {
 	IsListed bool `json:"is_listed"`
 }
 
+type UpdateReviewTimeoutRequest struct {
+	// nil resets the channel to the platform default
+	Hours *int `json:"hours" validate:"omitempty,min=1,max=168"`
+}
+
 type AddAdFormatRequest struct {
 	FormatType   entity.AdFormatType `json:"format_type" validate:"required"`
 	IsNative     bool                `json:"is_native"`
 	FeedHours    int                 `json:"feed_hours" validate:"required,oneof=12 24"`
 	TopHours     int                 `json:"top_hours" validate:"required,oneof=2 4"`
+	AutoDelete   bool                `json:"auto_delete"`
 	PriceNanoTON int64               `json:"price_nano_ton" validate:"required,gt=0"`
 }
 
@@ -84,6 +92,7 @@ type AdFormatResponse struct {
 	IsNative     bool                `json:"is_native"`
 	FeedHours    int                 `json:"feed_hours"`
 	TopHours     int                 `json:"top_hours"`
+	AutoDelete   bool                `json:"auto_delete"`
 	PriceNanoTON int64               `json:"price_nano_ton"`
 }
 
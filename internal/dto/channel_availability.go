@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+// PostingWindow is a daily span of UTC time in HH:MM, end exclusive. "24:00"
+// ends a window at midnight.
+type PostingWindow struct {
+	Start string `json:"start" validate:"required"`
+	End   string `json:"end" validate:"required"`
+}
+
+type UpdateAvailabilityRequest struct {
+	// nil lifts the daily limit
+	MaxAdsPerDay *int `json:"max_ads_per_day" validate:"omitempty,min=1,max=48"`
+	// empty allows posting at any time of day
+	PostingWindows []PostingWindow `json:"posting_windows" validate:"max=24,dive"`
+	// UTC dates in YYYY-MM-DD
+	BlackoutDates []string `json:"blackout_dates" validate:"max=366,dive,datetime=2006-01-02"`
+}
+
+type BookedSlotResponse struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+type AvailabilityDay struct {
+	Date      string `json:"date"`
+	Booked    int    `json:"booked"`
+	Available bool   `json:"available"`
+}
+
+type ChannelAvailabilityResponse struct {
+	MaxAdsPerDay   *int                 `json:"max_ads_per_day,omitempty"`
+	PostingWindows []PostingWindow      `json:"posting_windows"`
+	BlackoutDates  []string             `json:"blackout_dates"`
+	Booked         []BookedSlotResponse `json:"booked"`
+	Days           []AvailabilityDay    `json:"days"`
+}
@@ -1,9 +1,14 @@
 package dto
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"net/url"
+	"strconv"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/bpva/ad-marketplace/internal/entity"
 )
 
@@ -16,6 +21,15 @@ type CreateDealRequest struct {
 	PriceNanoTON   int64               `json:"price_nano_ton" validate:"required,gt=0"`
 	TemplatePostID string              `json:"template_post_id" validate:"required,uuid"`
 	ScheduledAt    time.Time           `json:"scheduled_at" validate:"required"`
+	// Offer proposes price_nano_ton and scheduled_at to the publisher instead
+	// of requiring the listed price; the deal starts out negotiating.
+	Offer bool `json:"offer"`
+}
+
+// ApproveRequest optionally names the version being approved; approval
+// fails if a newer one has been submitted since.
+type ApproveRequest struct {
+	Version *int `json:"version,omitempty"`
 }
 
 type RejectRequest struct {
@@ -29,6 +43,7 @@ type RequestChangesRequest struct {
 type DealResponse struct {
 	ID            string              `json:"id"`
 	TgChannelID   int64               `json:"channel_id"`
+	CampaignID    *uuid.UUID          `json:"campaign_id,omitempty"`
 	Status        entity.DealStatus   `json:"status"`
 	ScheduledAt   time.Time           `json:"scheduled_at"`
 	PublisherNote *string             `json:"publisher_note,omitempty"`
@@ -36,14 +51,67 @@ type DealResponse struct {
 	IsNative      bool                `json:"is_native"`
 	FeedHours     int                 `json:"feed_hours"`
 	TopHours      int                 `json:"top_hours"`
+	AutoDelete    bool                `json:"auto_delete"`
 	PriceNanoTON  int64               `json:"price_nano_ton"`
-	Ad            *TemplateResponse   `json:"ad,omitempty"`
-	CreatedAt     time.Time           `json:"created_at"`
+	// platform fee withheld from the payout, frozen when the deal was created
+	PlatformFeeBPS int64                `json:"platform_fee_bps"`
+	Payment        *PaymentInstructions `json:"payment,omitempty"`
+	PostedAt       *time.Time           `json:"posted_at,omitempty"`
+	PublishError   *string              `json:"publish_error,omitempty"`
+	PinnedAt       *time.Time           `json:"pinned_at,omitempty"`
+	UnpinnedAt     *time.Time           `json:"unpinned_at,omitempty"`
+	DeletedAt      *time.Time           `json:"deleted_at,omitempty"`
+	DeleteError    *string              `json:"delete_error,omitempty"`
+	Payout         *TransferResponse    `json:"payout,omitempty"`
+	PayoutError    *string              `json:"payout_error,omitempty"`
+	Refund         *TransferResponse    `json:"refund,omitempty"`
+	Ad             *TemplateResponse    `json:"ad,omitempty"`
+	CreatedAt      time.Time            `json:"created_at"`
+}
+
+// PaymentInstructions is returned while the deal awaits the advertiser's transfer.
+type PaymentInstructions struct {
+	Address       string     `json:"address"`
+	AmountNanoTON int64      `json:"amount_nano_ton"`
+	Comment       string     `json:"comment,omitempty"`
+	DeepLink      string     `json:"deep_link"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+}
+
+// TransferResponse describes the movement of escrowed funds out of the platform wallet.
+type TransferResponse struct {
+	Status        entity.TransferStatus `json:"status"`
+	Destination   string                `json:"destination"`
+	AmountNanoTON int64                 `json:"amount_nano_ton"`
+	FeeNanoTON    int64                 `json:"fee_nano_ton"`
+	TxHash        *string               `json:"tx_hash,omitempty"`
+	UpdatedAt     time.Time             `json:"updated_at"`
 }
 
 type DealsResponse struct {
 	Deals []DealResponse `json:"deals"`
-	Total int            `json:"total"`
+	// deals matching the filters across all pages; only with include_total
+	// or page
+	Total *int `json:"total,omitempty"`
+	// pass as cursor to fetch the next page; absent on the last one
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+func EncodeDealCursor(c entity.DealCursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeDealCursor(s string) (*entity.DealCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c entity.DealCursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
 }
 
 type DealListItem struct {
@@ -51,19 +119,35 @@ type DealListItem struct {
 	TgChannelID int64
 }
 
-func DealResponseFrom(deal *entity.Deal, posts []entity.Post, tgChannelID int64) DealResponse {
+func DealResponseFrom(
+	deal *entity.Deal,
+	posts []entity.Post,
+	tgChannelID int64,
+	transfers []entity.Transfer,
+) DealResponse {
 	resp := DealResponse{
-		ID:            deal.ID.String(),
-		TgChannelID:   tgChannelID,
-		Status:        deal.Status,
-		ScheduledAt:   deal.ScheduledAt,
-		PublisherNote: deal.PublisherNote,
-		FormatType:    deal.FormatType,
-		IsNative:      deal.IsNative,
-		FeedHours:     deal.FeedHours,
-		TopHours:      deal.TopHours,
-		PriceNanoTON:  deal.PriceNanoTON,
-		CreatedAt:     deal.CreatedAt,
+		ID:             deal.ID.String(),
+		TgChannelID:    tgChannelID,
+		CampaignID:     deal.CampaignID,
+		Status:         deal.Status,
+		ScheduledAt:    deal.ScheduledAt,
+		PublisherNote:  deal.PublisherNote,
+		FormatType:     deal.FormatType,
+		IsNative:       deal.IsNative,
+		FeedHours:      deal.FeedHours,
+		TopHours:       deal.TopHours,
+		AutoDelete:     deal.AutoDelete,
+		PriceNanoTON:   deal.PriceNanoTON,
+		PlatformFeeBPS: deal.PlatformFeeBPS,
+		Payment:        paymentInstructionsFrom(deal),
+		PostedAt:       deal.PostedAt,
+		PublishError:   deal.PublishError,
+		PinnedAt:       deal.PinnedAt,
+		UnpinnedAt:     deal.UnpinnedAt,
+		DeletedAt:      deal.DeletedAt,
+		DeleteError:    deal.DeleteError,
+		PayoutError:    deal.PayoutError,
+		CreatedAt:      deal.CreatedAt,
 	}
 
 	if len(posts) > 0 {
@@ -71,25 +155,78 @@ func DealResponseFrom(deal *entity.Deal, posts []entity.Post, tgChannelID int64)
 		resp.Ad = &ad
 	}
 
+	for i := range transfers {
+		t := &transfers[i]
+		switch t.Kind {
+		case entity.TransferKindPayout:
+			resp.Payout = transferResponseFrom(t)
+		case entity.TransferKindRefund:
+			resp.Refund = transferResponseFrom(t)
+		}
+	}
+
 	return resp
 }
 
 func DealListResponseFrom(item DealListItem) DealResponse {
 	return DealResponse{
-		ID:            item.ID.String(),
-		TgChannelID:   item.TgChannelID,
-		Status:        item.Status,
-		ScheduledAt:   item.ScheduledAt,
-		PublisherNote: item.PublisherNote,
-		FormatType:    item.FormatType,
-		IsNative:      item.IsNative,
-		FeedHours:     item.FeedHours,
-		TopHours:      item.TopHours,
-		PriceNanoTON:  item.PriceNanoTON,
-		CreatedAt:     item.CreatedAt,
+		ID:             item.ID.String(),
+		TgChannelID:    item.TgChannelID,
+		CampaignID:     item.CampaignID,
+		Status:         item.Status,
+		ScheduledAt:    item.ScheduledAt,
+		PublisherNote:  item.PublisherNote,
+		FormatType:     item.FormatType,
+		IsNative:       item.IsNative,
+		FeedHours:      item.FeedHours,
+		TopHours:       item.TopHours,
+		AutoDelete:     item.AutoDelete,
+		PriceNanoTON:   item.PriceNanoTON,
+		PlatformFeeBPS: item.PlatformFeeBPS,
+		Payment:        paymentInstructionsFrom(&item.Deal),
+		PostedAt:       item.PostedAt,
+		PublishError:   item.PublishError,
+		PinnedAt:       item.PinnedAt,
+		UnpinnedAt:     item.UnpinnedAt,
+		DeletedAt:      item.DeletedAt,
+		DeleteError:    item.DeleteError,
+		CreatedAt:      item.CreatedAt,
 	}
 }
 
+func transferResponseFrom(t *entity.Transfer) *TransferResponse {
+	return &TransferResponse{
+		Status:        t.Status,
+		Destination:   t.Destination,
+		AmountNanoTON: t.AmountNanoTON,
+		FeeNanoTON:    t.FeeNanoTON,
+		TxHash:        t.TxHash,
+		UpdatedAt:     t.UpdatedAt,
+	}
+}
+
+func paymentInstructionsFrom(deal *entity.Deal) *PaymentInstructions {
+	if deal.Status != entity.DealStatusPendingPayment || deal.EscrowWalletAddress == nil {
+		return nil
+	}
+
+	p := &PaymentInstructions{
+		Address:       *deal.EscrowWalletAddress,
+		AmountNanoTON: deal.PriceNanoTON,
+		ExpiresAt:     deal.PaymentExpiresAt,
+	}
+
+	q := url.Values{}
+	q.Set("amount", strconv.FormatInt(deal.PriceNanoTON, 10))
+	if deal.EscrowMemo != nil {
+		p.Comment = *deal.EscrowMemo
+		q.Set("text", *deal.EscrowMemo)
+	}
+	p.DeepLink = "ton://transfer/" + p.Address + "?" + q.Encode()
+
+	return p
+}
+
 func buildAdResponse(posts []entity.Post) TemplateResponse {
 	resp := TemplateResponse{
 		ID:        posts[0].ID.String(),
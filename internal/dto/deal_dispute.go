@@ -0,0 +1,107 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+// SubmitEvidenceRequest carries a statement, a screenshot uploaded to
+// Telegram, a link to a Telegram message, or any mix of them.
+type SubmitEvidenceRequest struct {
+	Text        *string `json:"text,omitempty" validate:"omitempty,min=1,max=4000"`
+	FileID      *string `json:"file_id,omitempty" validate:"omitempty,min=1,max=256"`
+	MessageLink *string `json:"message_link,omitempty" validate:"omitempty,url"`
+}
+
+func (r SubmitEvidenceRequest) Valid() error {
+	if r.Text == nil && r.FileID == nil && r.MessageLink == nil {
+		return errors.New("one of text, file_id and message_link is required")
+	}
+	if r.MessageLink != nil && !strings.HasPrefix(*r.MessageLink, "https://t.me/") {
+		return errors.New("message_link must be a https://t.me/ link")
+	}
+	return nil
+}
+
+// ResolveDisputeRequest is an arbiter's ruling. PublisherShareNanoTON is
+// required for a split and ignored otherwise.
+type ResolveDisputeRequest struct {
+	Outcome               entity.DisputeOutcome `json:"outcome" validate:"required,oneof=release refund split"`
+	PublisherShareNanoTON *int64                `json:"publisher_share_nano_ton,omitempty"`
+	Note                  *string               `json:"note,omitempty"`
+}
+
+type DisputeEvidenceItem struct {
+	entity.DisputeEvidence
+	AuthorName  string
+	AuthorParty entity.DealParty
+}
+
+type DisputeItem struct {
+	entity.DealDispute
+	Deal     DealListItem
+	Evidence []DisputeEvidenceItem
+}
+
+type DisputeEvidenceResponse struct {
+	ID          uuid.UUID        `json:"id"`
+	AuthorRole  entity.DealParty `json:"author_role"`
+	AuthorName  string           `json:"author_name,omitempty"`
+	Text        *string          `json:"text,omitempty"`
+	FileID      *string          `json:"file_id,omitempty"`
+	MessageLink *string          `json:"message_link,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
+type DisputeResponse struct {
+	ID                    uuid.UUID                 `json:"id"`
+	Status                entity.DealDisputeStatus  `json:"status"`
+	Reason                string                    `json:"reason"`
+	Outcome               *entity.DisputeOutcome    `json:"outcome,omitempty"`
+	PublisherShareNanoTON *int64                    `json:"publisher_share_nano_ton,omitempty"`
+	ResolutionNote        *string                   `json:"resolution_note,omitempty"`
+	Deal                  DealResponse              `json:"deal"`
+	Evidence              []DisputeEvidenceResponse `json:"evidence"`
+	CreatedAt             time.Time                 `json:"created_at"`
+	ResolvedAt            *time.Time                `json:"resolved_at,omitempty"`
+}
+
+type DisputesResponse struct {
+	Disputes []DisputeResponse `json:"disputes"`
+}
+
+func DisputeEvidenceResponseFrom(item DisputeEvidenceItem) DisputeEvidenceResponse {
+	return DisputeEvidenceResponse{
+		ID:          item.ID,
+		AuthorRole:  item.AuthorParty,
+		AuthorName:  item.AuthorName,
+		Text:        item.Text,
+		FileID:      item.FileID,
+		MessageLink: item.MessageLink,
+		CreatedAt:   item.CreatedAt,
+	}
+}
+
+func DisputeResponseFrom(item *DisputeItem) DisputeResponse {
+	resp := DisputeResponse{
+		ID:                    item.ID,
+		Status:                item.Status,
+		Reason:                item.Reason,
+		Outcome:               item.Outcome,
+		PublisherShareNanoTON: item.Deal.PublisherShareNanoTON,
+		ResolutionNote:        item.ResolutionNote,
+		Deal:                  DealListResponseFrom(item.Deal),
+		Evidence:              make([]DisputeEvidenceResponse, len(item.Evidence)),
+		CreatedAt:             item.CreatedAt,
+		ResolvedAt:            item.ResolvedAt,
+	}
+	for i := range item.Evidence {
+		resp.Evidence[i] = DisputeEvidenceResponseFrom(item.Evidence[i])
+	}
+	return resp
+}
@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+type DealEventItem struct {
+	entity.DealEvent
+	ActorName  string
+	ActorParty entity.DealParty
+}
+
+// DealEventResponse is an entry in a deal's timeline. The actor is omitted
+// for changes made by the system.
+type DealEventResponse struct {
+	Type       entity.DealEventType `json:"type"`
+	ActorRole  entity.DealParty     `json:"actor_role,omitempty"`
+	ActorName  string               `json:"actor_name,omitempty"`
+	FromStatus *entity.DealStatus   `json:"from_status,omitempty"`
+	ToStatus   entity.DealStatus    `json:"to_status"`
+	Note       *string              `json:"note,omitempty"`
+	Metadata   map[string]any       `json:"metadata,omitempty"`
+	CreatedAt  time.Time            `json:"created_at"`
+}
+
+type DealEventsResponse struct {
+	Events []DealEventResponse `json:"events"`
+}
+
+func DealEventResponseFrom(item DealEventItem) DealEventResponse {
+	return DealEventResponse{
+		Type:       item.Type,
+		ActorRole:  item.ActorParty,
+		ActorName:  item.ActorName,
+		FromStatus: item.FromStatus,
+		ToStatus:   item.ToStatus,
+		Note:       item.Note,
+		Metadata:   item.Metadata,
+		CreatedAt:  item.CreatedAt,
+	}
+}
@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+type PostMessageRequest struct {
+	Text string `json:"text" validate:"required"`
+}
+
+type DealMessageItem struct {
+	entity.DealMessage
+	AuthorName  string
+	AuthorParty entity.DealParty
+}
+
+type DealMessageResponse struct {
+	ID         uuid.UUID        `json:"id"`
+	AuthorRole entity.DealParty `json:"author_role"`
+	AuthorName string           `json:"author_name"`
+	Text       string           `json:"text"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+type DealMessagesResponse struct {
+	Messages []DealMessageResponse `json:"messages"`
+}
+
+func DealMessageResponseFrom(item DealMessageItem) DealMessageResponse {
+	return DealMessageResponse{
+		ID:         item.ID,
+		AuthorRole: item.AuthorParty,
+		AuthorName: item.AuthorName,
+		Text:       item.Text,
+		CreatedAt:  item.CreatedAt,
+	}
+}
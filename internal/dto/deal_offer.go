@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+type CounterOfferRequest struct {
+	PriceNanoTON int64     `json:"price_nano_ton" validate:"required,gt=0"`
+	ScheduledAt  time.Time `json:"scheduled_at" validate:"required"`
+}
+
+type DeclineOfferRequest struct {
+	Reason *string `json:"reason,omitempty"`
+}
+
+type DealOfferItem struct {
+	entity.DealOffer
+	AuthorName  string
+	AuthorParty entity.DealParty
+}
+
+type DealOfferResponse struct {
+	ID           uuid.UUID              `json:"id"`
+	AuthorRole   entity.DealParty       `json:"author_role"`
+	AuthorName   string                 `json:"author_name,omitempty"`
+	PriceNanoTON int64                  `json:"price_nano_ton"`
+	ScheduledAt  time.Time              `json:"scheduled_at"`
+	Status       entity.DealOfferStatus `json:"status"`
+	CreatedAt    time.Time              `json:"created_at"`
+	RespondedAt  *time.Time             `json:"responded_at,omitempty"`
+}
+
+type DealOffersResponse struct {
+	Offers []DealOfferResponse `json:"offers"`
+}
+
+func DealOfferResponseFrom(item DealOfferItem) DealOfferResponse {
+	return DealOfferResponse{
+		ID:           item.ID,
+		AuthorRole:   item.AuthorParty,
+		AuthorName:   item.AuthorName,
+		PriceNanoTON: item.PriceNanoTON,
+		ScheduledAt:  item.ScheduledAt,
+		Status:       item.Status,
+		CreatedAt:    item.CreatedAt,
+		RespondedAt:  item.RespondedAt,
+	}
+}
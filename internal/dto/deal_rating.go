@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+type RateDealRequest struct {
+	Rating  int     `json:"rating" validate:"required,min=1,max=5"`
+	Comment *string `json:"comment,omitempty" validate:"omitempty,max=2000"`
+}
+
+type DealRatingItem struct {
+	entity.DealRating
+	AuthorName string
+}
+
+type DealRatingResponse struct {
+	ID         uuid.UUID        `json:"id"`
+	AuthorRole entity.DealParty `json:"author_role"`
+	AuthorName string           `json:"author_name,omitempty"`
+	Rating     int              `json:"rating"`
+	Comment    *string          `json:"comment,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+type DealRatingsResponse struct {
+	Ratings []DealRatingResponse `json:"ratings"`
+}
+
+func DealRatingResponseFrom(item DealRatingItem) DealRatingResponse {
+	return DealRatingResponse{
+		ID:         item.ID,
+		AuthorRole: item.Party,
+		AuthorName: item.AuthorName,
+		Rating:     item.Rating,
+		Comment:    item.Comment,
+		CreatedAt:  item.CreatedAt,
+	}
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+type DealViewPoint struct {
+	TakenAt   time.Time `json:"taken_at"`
+	Views     int64     `json:"views"`
+	Forwards  int64     `json:"forwards"`
+	Reactions int64     `json:"reactions"`
+}
+
+// DealReportResponse shows how a posted ad performed. The totals are those
+// of the latest snapshot. CPM is the price per thousand views, unset until
+// the ad has been seen; the channel comparison is unset while the channel
+// has no week of stats or is not listed.
+type DealReportResponse struct {
+	DealID                 string          `json:"deal_id"`
+	PostedAt               time.Time       `json:"posted_at"`
+	FeedEndsAt             time.Time       `json:"feed_ends_at"`
+	PriceNanoTON           int64           `json:"price_nano_ton"`
+	Views                  int64           `json:"views"`
+	Forwards               int64           `json:"forwards"`
+	Reactions              int64           `json:"reactions"`
+	CPMNanoTON             *int64          `json:"cpm_nano_ton,omitempty"`
+	ChannelAvgDailyViews7d *int            `json:"channel_avg_daily_views_7d,omitempty"`
+	ViewsToChannelAvg      *float64        `json:"views_to_channel_avg,omitempty"`
+	Curve                  []DealViewPoint `json:"curve"`
+}
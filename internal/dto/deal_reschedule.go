@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+type RescheduleRequest struct {
+	ScheduledAt time.Time `json:"scheduled_at" validate:"required"`
+}
+
+type DeclineRescheduleRequest struct {
+	Reason *string `json:"reason,omitempty"`
+}
+
+type DealRescheduleItem struct {
+	entity.DealReschedule
+	AuthorName  string
+	AuthorParty entity.DealParty
+}
+
+type DealRescheduleResponse struct {
+	ID          uuid.UUID                   `json:"id"`
+	AuthorRole  entity.DealParty            `json:"author_role"`
+	AuthorName  string                      `json:"author_name,omitempty"`
+	ScheduledAt time.Time                   `json:"scheduled_at"`
+	Status      entity.DealRescheduleStatus `json:"status"`
+	CreatedAt   time.Time                   `json:"created_at"`
+	RespondedAt *time.Time                  `json:"responded_at,omitempty"`
+}
+
+type DealReschedulesResponse struct {
+	Reschedules []DealRescheduleResponse `json:"reschedules"`
+}
+
+func DealRescheduleResponseFrom(item DealRescheduleItem) DealRescheduleResponse {
+	return DealRescheduleResponse{
+		ID:          item.ID,
+		AuthorRole:  item.AuthorParty,
+		AuthorName:  item.AuthorName,
+		ScheduledAt: item.ScheduledAt,
+		Status:      item.Status,
+		CreatedAt:   item.CreatedAt,
+		RespondedAt: item.RespondedAt,
+	}
+}
@@ -33,6 +33,9 @@ var (
 	ErrInvalidTransition    = new(http.StatusBadRequest, "invalid_transition")
 	ErrInvalidDealID        = new(http.StatusBadRequest, "invalid_deal_id")
 	ErrInvalidRole          = new(http.StatusBadRequest, "invalid_role")
+	ErrInvalidCampaignID    = new(http.StatusBadRequest, "invalid_campaign_id")
+	ErrInvalidPromotionID   = new(http.StatusBadRequest, "invalid_promotion_id")
+	ErrInvalidUserID        = new(http.StatusBadRequest, "invalid_user_id")
 
 	// 401 Unauthorized
 	ErrUnauthorized = new(http.StatusUnauthorized, "unauthorized")
@@ -46,10 +49,15 @@ var (
 	// 422 Unprocessable Entity
 	ErrNoPayoutMethod   = new(http.StatusUnprocessableEntity, "no_payout_method")
 	ErrChannelNotListed = new(http.StatusUnprocessableEntity, "channel_not_listed")
+	ErrBudgetExceeded   = new(http.StatusUnprocessableEntity, "budget_exceeded")
 
 	// 409 Conflict
-	ErrAdFormatExists = new(http.StatusConflict, "ad_format_exists")
-	ErrPriceMismatch  = new(http.StatusConflict, "price_mismatch")
+	ErrAdFormatExists  = new(http.StatusConflict, "ad_format_exists")
+	ErrPriceMismatch   = new(http.StatusConflict, "price_mismatch")
+	ErrSlotTaken       = new(http.StatusConflict, "slot_taken")
+	ErrSlotUnavailable = new(http.StatusConflict, "slot_unavailable")
+	ErrDealNotPosted   = new(http.StatusConflict, "deal_not_posted")
+	ErrAlreadyRated    = new(http.StatusConflict, "already_rated")
 
 	// 500 Internal Server Error
 	ErrInternalError = new(http.StatusInternalServerError, "internal_error")
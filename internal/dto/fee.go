@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+type SetChannelFeeRequest struct {
+	// nil resets the channel to the platform default
+	PlatformFeeBPS *int64 `json:"platform_fee_bps" validate:"omitempty,min=0,max=9999"`
+}
+
+// CreatePromotionRequest waives the platform fee on deals created between
+// starts_at and ends_at; without channel_id it applies to every channel.
+type CreatePromotionRequest struct {
+	TgChannelID *int64    `json:"channel_id,omitempty"`
+	StartsAt    time.Time `json:"starts_at" validate:"required"`
+	EndsAt      time.Time `json:"ends_at" validate:"required"`
+	Note        *string   `json:"note,omitempty" validate:"omitempty,max=500"`
+}
+
+type PromotionResponse struct {
+	ID          uuid.UUID `json:"id"`
+	TgChannelID *int64    `json:"channel_id,omitempty"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	Note        *string   `json:"note,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type PromotionsResponse struct {
+	Promotions []PromotionResponse `json:"promotions"`
+}
+
+func PromotionResponseFrom(p *entity.FeePromotion) PromotionResponse {
+	return PromotionResponse{
+		ID:          p.ID,
+		TgChannelID: p.TgChannelID,
+		StartsAt:    p.StartsAt,
+		EndsAt:      p.EndsAt,
+		Note:        p.Note,
+		CreatedAt:   p.CreatedAt,
+	}
+}
@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+type LedgerEntryResponse struct {
+	TxnID         uuid.UUID              `json:"txn_id"`
+	Kind          entity.LedgerEntryKind `json:"kind"`
+	Account       entity.LedgerAccount   `json:"account"`
+	UserID        *uuid.UUID             `json:"user_id,omitempty"`
+	AmountNanoTON int64                  `json:"amount_nano_ton"`
+	CreatedAt     time.Time              `json:"created_at"`
+}
+
+// DealLedgerResponse lists the ledger entries of a deal; the escrow balance
+// is what the escrow wallet still holds for it.
+type DealLedgerResponse struct {
+	Entries              []LedgerEntryResponse `json:"entries"`
+	EscrowBalanceNanoTON int64                 `json:"escrow_balance_nano_ton"`
+}
+
+type LedgerBalanceResponse struct {
+	Account       entity.LedgerAccount `json:"account"`
+	AmountNanoTON int64                `json:"amount_nano_ton"`
+}
+
+type LedgerBalancesResponse struct {
+	Balances []LedgerBalanceResponse `json:"balances"`
+}
+
+func DealLedgerResponseFrom(entries []entity.LedgerEntry) DealLedgerResponse {
+	resp := DealLedgerResponse{Entries: make([]LedgerEntryResponse, len(entries))}
+	for i := range entries {
+		e := &entries[i]
+		resp.Entries[i] = LedgerEntryResponse{
+			TxnID:         e.TxnID,
+			Kind:          e.Kind,
+			Account:       e.Account,
+			UserID:        e.UserID,
+			AmountNanoTON: e.AmountNanoTON,
+			CreatedAt:     e.CreatedAt,
+		}
+		if e.Account == entity.LedgerAccountEscrow {
+			resp.EscrowBalanceNanoTON += e.AmountNanoTON
+		}
+	}
+	return resp
+}
+
+func LedgerBalancesResponseFrom(balances []entity.LedgerBalance) LedgerBalancesResponse {
+	resp := LedgerBalancesResponse{Balances: make([]LedgerBalanceResponse, len(balances))}
+	for i := range balances {
+		resp.Balances[i] = LedgerBalanceResponse{
+			Account:       balances[i].Account,
+			AmountNanoTON: balances[i].AmountNanoTON,
+		}
+	}
+	return resp
+}
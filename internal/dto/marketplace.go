@@ -41,6 +41,10 @@ type MarketplaceChannel struct {
 	AvgInteractions30d      *int               `json:"avg_interactions_30d,omitempty"`
 	EngagementRate7d        *float64           `json:"engagement_rate_7d,omitempty"`
 	EngagementRate30d       *float64           `json:"engagement_rate_30d,omitempty"`
+	// 1–5, from ratings left by advertisers; unset until the first one
+	Rating         *float64 `json:"rating,omitempty"`
+	RatingCount    int      `json:"rating_count"`
+	CompletedDeals int      `json:"completed_deals"`
 }
 
 type AdFormat struct {
@@ -49,6 +53,7 @@ type AdFormat struct {
 	IsNative     bool                `json:"is_native"`
 	FeedHours    int                 `json:"feed_hours"`
 	TopHours     int                 `json:"top_hours"`
+	AutoDelete   bool                `json:"auto_delete"`
 	PriceNanoTON int64               `json:"price_nano_ton"`
 }
 
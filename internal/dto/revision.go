@@ -0,0 +1,129 @@
+package dto
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+// SubmitRevisionRequest carries a new creative: a template to copy, or new
+// text for the current creative that keeps its media.
+type SubmitRevisionRequest struct {
+	TemplatePostID *string         `json:"template_post_id,omitempty" validate:"omitempty,uuid"`
+	Text           *string         `json:"text,omitempty" validate:"omitempty,min=1"`
+	Entities       json.RawMessage `json:"entities,omitempty"`
+}
+
+func (r SubmitRevisionRequest) Valid() error {
+	if (r.TemplatePostID == nil) == (r.Text == nil) {
+		return errors.New("exactly one of template_post_id and text is required")
+	}
+	if r.TemplatePostID != nil && len(r.Entities) > 0 {
+		return errors.New("entities require text")
+	}
+	return nil
+}
+
+type RevisionItem struct {
+	entity.AdRevision
+	AuthorName  string
+	AuthorParty entity.DealParty
+	Posts       []entity.Post
+}
+
+// RevisionResponse is one ad version. The approval times tell which sides
+// have confirmed it; the author's side confirms it by submitting it.
+type RevisionResponse struct {
+	Version              int              `json:"version"`
+	AuthorRole           entity.DealParty `json:"author_role"`
+	AuthorName           string           `json:"author_name,omitempty"`
+	PublisherNote        *string          `json:"publisher_note,omitempty"`
+	Ad                   TemplateResponse `json:"ad"`
+	AdvertiserApprovedAt *time.Time       `json:"advertiser_approved_at,omitempty"`
+	PublisherApprovedAt  *time.Time       `json:"publisher_approved_at,omitempty"`
+	CreatedAt            time.Time        `json:"created_at"`
+}
+
+type RevisionsResponse struct {
+	Revisions []RevisionResponse `json:"revisions"`
+}
+
+func RevisionResponseFrom(item RevisionItem) RevisionResponse {
+	resp := RevisionResponse{
+		Version:              item.Version,
+		AuthorRole:           item.AuthorParty,
+		AuthorName:           item.AuthorName,
+		PublisherNote:        item.PublisherNote,
+		AdvertiserApprovedAt: item.AdvertiserApprovedAt,
+		PublisherApprovedAt:  item.PublisherApprovedAt,
+		CreatedAt:            item.CreatedAt,
+	}
+	if len(item.Posts) > 0 {
+		resp.Ad = buildAdResponse(item.Posts)
+	}
+	return resp
+}
+
+// RevisionDiffResponse lists what changed from one ad version to another.
+// Text is omitted when neither the text nor its formatting changed; media
+// lists only the items that differ, by position in the album.
+type RevisionDiffResponse struct {
+	From  int           `json:"from"`
+	To    int           `json:"to"`
+	Text  *TextDiff     `json:"text,omitempty"`
+	Media []MediaChange `json:"media,omitempty"`
+}
+
+type TextDiff struct {
+	Segments        []TextSegment `json:"segments"`
+	AddedEntities   []TextEntity  `json:"added_entities,omitempty"`
+	RemovedEntities []TextEntity  `json:"removed_entities,omitempty"`
+}
+
+type TextDiffOp string
+
+const (
+	TextDiffEqual  TextDiffOp = "equal"
+	TextDiffInsert TextDiffOp = "insert"
+	TextDiffDelete TextDiffOp = "delete"
+)
+
+// TextSegment is a run of text that is kept, inserted or deleted; the equal
+// and delete segments in order give the old text, equal and insert the new.
+type TextSegment struct {
+	Op   TextDiffOp `json:"op"`
+	Text string     `json:"text"`
+}
+
+// TextEntity is Telegram formatting; offset and length are in UTF-16 code
+// units of the version it belongs to, text is the part it covers.
+type TextEntity struct {
+	Type          string `json:"type"`
+	Offset        int    `json:"offset"`
+	Length        int    `json:"length"`
+	Text          string `json:"text"`
+	URL           string `json:"url,omitempty"`
+	Language      string `json:"language,omitempty"`
+	CustomEmojiID string `json:"custom_emoji_id,omitempty"`
+}
+
+type MediaChangeKind string
+
+const (
+	MediaAdded    MediaChangeKind = "added"
+	MediaRemoved  MediaChangeKind = "removed"
+	MediaReplaced MediaChangeKind = "replaced"
+	MediaUpdated  MediaChangeKind = "updated"
+)
+
+// MediaChange describes one album position. Updated means the same file with
+// different display flags, named in changed_flags.
+type MediaChange struct {
+	Index        int             `json:"index"`
+	Kind         MediaChangeKind `json:"kind"`
+	From         *PostMediaItem  `json:"from,omitempty"`
+	To           *PostMediaItem  `json:"to,omitempty"`
+	ChangedFlags []string        `json:"changed_flags,omitempty"`
+}
@@ -1,8 +1,22 @@
 package dto
 
+import "time"
+
 type TgUser struct {
 	ID        int64  `json:"id"`
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name"`
 	Username  string `json:"username"`
 }
+
+// ChannelMessage is the state of a channel message as seen by the bot.
+// Reactions is the total over all emoji.
+type ChannelMessage struct {
+	ID        int64
+	Exists    bool
+	Pinned    bool
+	EditedAt  *time.Time
+	Views     int64
+	Forwards  int64
+	Reactions int64
+}
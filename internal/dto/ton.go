@@ -0,0 +1,39 @@
+package dto
+
+import "time"
+
+type TONTransaction struct {
+	Hash string
+	LT   string
+	Time time.Time
+	// In is nil for transactions initiated by the wallet owner
+	In  *TONMessage
+	Out []TONMessage
+}
+
+// TONTxID locates a transaction in an account's history.
+type TONTxID struct {
+	LT   string
+	Hash string
+}
+
+type TONMessage struct {
+	Source       string
+	Destination  string
+	ValueNanoTON int64
+	Comment      string
+}
+
+// TONTransfer is an outgoing transfer from the platform escrow wallet.
+type TONTransfer struct {
+	Destination   string
+	AmountNanoTON int64
+	Comment       string
+}
+
+// EscrowDeposit tells the advertiser where to send funds for a deal.
+// The memo must be sent as the transfer comment so the payment can be matched.
+type EscrowDeposit struct {
+	Address string
+	Memo    string
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Campaign groups the deals an advertiser placed from one template in a
+// single request.
+type Campaign struct {
+	ID             uuid.UUID `db:"id"`
+	AdvertiserID   uuid.UUID `db:"advertiser_id"`
+	Name           string    `db:"name"`
+	TemplatePostID uuid.UUID `db:"template_post_id"`
+	BudgetNanoTON  int64     `db:"budget_nano_ton"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+// CampaignDealTotal sums up a campaign's deals in one status. Released is
+// what their publishers get, less than the price for deals settled by an
+// arbiter. Subscribers is the current audience of their channels.
+type CampaignDealTotal struct {
+	CampaignID      uuid.UUID  `db:"campaign_id"`
+	Status          DealStatus `db:"status"`
+	Deals           int        `db:"deals"`
+	PriceNanoTON    int64      `db:"price_nano_ton"`
+	ReleasedNanoTON int64      `db:"released_nano_ton"`
+	Subscribers     int64      `db:"subscribers"`
+}
+
+// CampaignStatus is derived from the campaign's deals.
+type CampaignStatus string
+
+const (
+	// At least one deal is still in progress
+	CampaignStatusActive CampaignStatus = "active"
+	// Every deal has reached a final status
+	CampaignStatusFinished CampaignStatus = "finished"
+)
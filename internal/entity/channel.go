@@ -17,6 +17,10 @@ type Channel struct {
 	PhotoBigFileID   *string    `db:"photo_big_file_id"`
 	CreatedAt        time.Time  `db:"created_at"`
 	DeletedAt        *time.Time `db:"deleted_at"`
+	// overrides the platform review timeout when set
+	ReviewTimeoutHours *int `db:"review_timeout_hours"`
+	// overrides the platform fee when set
+	PlatformFeeBPS *int64 `db:"platform_fee_bps"`
 }
 
 type MVChannel struct {
@@ -47,6 +51,10 @@ type MVChannel struct {
 	AvgInteractions30d      *int              `db:"avg_interactions_30d"`
 	EngagementRate7d        *float64          `db:"engagement_rate_7d"`
 	EngagementRate30d       *float64          `db:"engagement_rate_30d"`
+	// average of the ratings advertisers left on the channel's deals
+	Rating         *float64 `db:"rating"`
+	RatingCount    int      `db:"rating_count"`
+	CompletedDeals int      `db:"completed_deals"`
 }
 
 type LanguageShare struct {
@@ -103,6 +111,7 @@ type ChannelAdFormat struct {
 	IsNative     bool         `db:"is_native" json:"is_native"`
 	FeedHours    int          `db:"feed_hours" json:"feed_hours"`
 	TopHours     int          `db:"top_hours" json:"top_hours"`
+	AutoDelete   bool         `db:"auto_delete" json:"auto_delete"`
 	PriceNanoTON int64        `db:"price_nano_ton" json:"price_nano_ton"`
 	CreatedAt    time.Time    `db:"created_at" json:"created_at"`
 }
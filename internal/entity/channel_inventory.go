@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostingWindow is a daily span of time, in minutes since midnight UTC,
+// during which a channel takes ads. End is exclusive.
+type PostingWindow struct {
+	StartMinute int `json:"start_minute"`
+	EndMinute   int `json:"end_minute"`
+}
+
+// ChannelInventory is what a channel is willing to sell: on which days, at
+// which hours and how many ads a day. Zero values put no limit.
+type ChannelInventory struct {
+	ChannelID      uuid.UUID       `db:"channel_id"`
+	MaxAdsPerDay   *int            `db:"max_ads_per_day"`
+	PostingWindows []PostingWindow `db:"posting_windows"`
+	BlackoutDates  []time.Time     `db:"blackout_dates"`
+}
+
+// BookedSlot is the time a live deal holds the top of its channel's feed.
+type BookedSlot struct {
+	From time.Time `db:"from"`
+	To   time.Time `db:"to"`
+}
+
+// IsBlackedOut reports whether t falls on a day the channel takes no ads.
+func (inv *ChannelInventory) IsBlackedOut(t time.Time) bool {
+	day := t.UTC().Truncate(24 * time.Hour)
+	return slices.ContainsFunc(inv.BlackoutDates, func(d time.Time) bool {
+		return d.UTC().Truncate(24 * time.Hour).Equal(day)
+	})
+}
+
+// InPostingWindow reports whether an ad may be posted at t. Every time is
+// allowed when the channel set no windows.
+func (inv *ChannelInventory) InPostingWindow(t time.Time) bool {
+	if len(inv.PostingWindows) == 0 {
+		return true
+	}
+	t = t.UTC()
+	minute := t.Hour()*60 + t.Minute()
+	return slices.ContainsFunc(inv.PostingWindows, func(w PostingWindow) bool {
+		return minute >= w.StartMinute && minute < w.EndMinute
+	})
+}
@@ -10,6 +10,8 @@ import (
 type DealStatus string
 
 const (
+	// Advertiser offered terms other than the listing; awaiting agreement
+	DealStatusNegotiating DealStatus = "negotiating"
 	// Awaiting advertiser's TON payment to escrow wallet
 	DealStatusPendingPayment DealStatus = "pending_payment"
 	// Payment never arrived or failed on-chain
@@ -24,14 +26,35 @@ const (
 	DealStatusRejected DealStatus = "rejected"
 	// Advertiser cancelled before approval; funds returned
 	DealStatusCancelled DealStatus = "cancelled"
+	// Ad could not be posted to the channel; funds returned
+	DealStatusPublishFailed DealStatus = "publish_failed"
 	// Ad posted to channel; verification window active
 	DealStatusPosted DealStatus = "posted"
 	// Verification passed; funds released to publisher
 	DealStatusCompleted DealStatus = "completed"
 	// Post deleted/modified during verification; requires resolution
 	DealStatusDispute DealStatus = "dispute"
+	// Arbiter settled the dispute; escrow is paid out and refunded per the ruling
+	DealStatusResolved DealStatus = "resolved"
 )
 
+// DealStatuses lists every deal status in lifecycle order.
+var DealStatuses = []DealStatus{
+	DealStatusNegotiating,
+	DealStatusPendingPayment,
+	DealStatusHoldFailed,
+	DealStatusPendingReview,
+	DealStatusChangesRequested,
+	DealStatusApproved,
+	DealStatusRejected,
+	DealStatusCancelled,
+	DealStatusPublishFailed,
+	DealStatusPosted,
+	DealStatusCompleted,
+	DealStatusDispute,
+	DealStatusResolved,
+}
+
 func (s *DealStatus) Scan(src any) error {
 	switch v := src.(type) {
 	case string:
@@ -46,14 +69,34 @@ func (s *DealStatus) Scan(src any) error {
 	return nil
 }
 
+// DealParty is a side of a deal: the advertiser, or the channel's owner and
+// managers acting as the publisher.
+type DealParty string
+
+const (
+	DealPartyAdvertiser DealParty = "advertiser"
+	DealPartyPublisher  DealParty = "publisher"
+	// not a side of the deal: a platform arbiter deciding its dispute
+	DealPartyArbiter DealParty = "arbiter"
+)
+
+func (p DealParty) Other() DealParty {
+	if p == DealPartyAdvertiser {
+		return DealPartyPublisher
+	}
+	return DealPartyAdvertiser
+}
+
 type Deal struct {
 	ID                      uuid.UUID    `db:"id"`
 	ChannelID               uuid.UUID    `db:"channel_id"`
 	AdvertiserID            uuid.UUID    `db:"advertiser_id"`
+	CampaignID              *uuid.UUID   `db:"campaign_id"`
 	Status                  DealStatus   `db:"status"`
 	ScheduledAt             time.Time    `db:"scheduled_at"`
 	PublisherNote           *string      `db:"publisher_note"`
 	EscrowWalletAddress     *string      `db:"escrow_wallet_address"`
+	EscrowMemo              *string      `db:"escrow_memo"`
 	AdvertiserWalletAddress *string      `db:"advertiser_wallet_address"`
 	PayoutWalletAddress     *string      `db:"payout_wallet_address"`
 	FormatType              AdFormatType `db:"format_type"`
@@ -61,12 +104,32 @@ type Deal struct {
 	FeedHours               int          `db:"feed_hours"`
 	TopHours                int          `db:"top_hours"`
 	PriceNanoTON            int64        `db:"price_nano_ton"`
+	PublisherShareNanoTON   *int64       `db:"publisher_share_nano_ton"`
+	PlatformFeeBPS          int64        `db:"platform_fee_bps"`
 	PostedMessageIDs        []int64      `db:"posted_message_ids"`
+	PaymentExpiresAt        *time.Time   `db:"payment_expires_at"`
 	PaidAt                  *time.Time   `db:"paid_at"`
 	PaymentTxHash           *string      `db:"payment_tx_hash"`
+	PayerAddress            *string      `db:"payer_address"`
 	PostedAt                *time.Time   `db:"posted_at"`
+	PublishAttempts         int          `db:"publish_attempts"`
+	PublishError            *string      `db:"publish_error"`
+	PublishingAt            *time.Time   `db:"publishing_at"`
+	PinnedAt                *time.Time   `db:"pinned_at"`
+	UnpinnedAt              *time.Time   `db:"unpinned_at"`
+	AutoDelete              bool         `db:"auto_delete"`
+	DeletedAt               *time.Time   `db:"deleted_at"`
+	DeleteError             *string      `db:"delete_error"`
 	ReleaseTxHash           *string      `db:"release_tx_hash"`
 	RefundTxHash            *string      `db:"refund_tx_hash"`
+	PayoutError             *string      `db:"payout_error"`
+	StatusChangedAt         time.Time    `db:"status_changed_at"`
 	CreatedAt               time.Time    `db:"created_at"`
 	UpdatedAt               time.Time    `db:"updated_at"`
 }
+
+// ChannelDeal is a deal listed together with the telegram id of its channel.
+type ChannelDeal struct {
+	Deal
+	TgChannelID int64 `db:"telegram_channel_id"`
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DealDisputeStatus string
+
+const (
+	// Awaiting the arbiter's decision; both parties may add evidence
+	DealDisputeStatusOpen DealDisputeStatus = "open"
+	// The arbiter decided how the escrowed funds are split
+	DealDisputeStatusResolved DealDisputeStatus = "resolved"
+)
+
+type DisputeOutcome string
+
+const (
+	// The whole price goes to the publisher
+	DisputeOutcomeRelease DisputeOutcome = "release"
+	// The whole price goes back to the advertiser
+	DisputeOutcomeRefund DisputeOutcome = "refund"
+	// The publisher gets part of the price, the advertiser the rest
+	DisputeOutcomeSplit DisputeOutcome = "split"
+)
+
+// DealDispute is opened when a posted ad breaks its terms and is settled by
+// a platform arbiter. A deal has at most one.
+type DealDispute struct {
+	ID             uuid.UUID         `db:"id"`
+	DealID         uuid.UUID         `db:"deal_id"`
+	Reason         string            `db:"reason"`
+	Status         DealDisputeStatus `db:"status"`
+	Outcome        *DisputeOutcome   `db:"outcome"`
+	ArbiterID      *uuid.UUID        `db:"arbiter_id"`
+	ResolutionNote *string           `db:"resolution_note"`
+	CreatedAt      time.Time         `db:"created_at"`
+	ResolvedAt     *time.Time        `db:"resolved_at"`
+}
+
+// DisputeEvidence is a statement, a screenshot (a Telegram file ID) or a
+// link to a Telegram message submitted by one side of a dispute. At least
+// one of them is set.
+type DisputeEvidence struct {
+	ID          uuid.UUID `db:"id"`
+	DisputeID   uuid.UUID `db:"dispute_id"`
+	AuthorID    uuid.UUID `db:"author_id"`
+	Text        *string   `db:"text"`
+	FileID      *string   `db:"file_id"`
+	MessageLink *string   `db:"message_link"`
+	CreatedAt   time.Time `db:"created_at"`
+}
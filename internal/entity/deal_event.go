@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DealEventType string
+
+const (
+	// Advertiser created the deal
+	DealEventCreated DealEventType = "created"
+	// A party proposed price and schedule terms
+	DealEventOfferMade DealEventType = "offer_made"
+	// The latest offer was accepted and its terms frozen onto the deal
+	DealEventOfferAccepted DealEventType = "offer_accepted"
+	// The latest offer was declined, closing the deal
+	DealEventOfferDeclined DealEventType = "offer_declined"
+	// A party proposed a new posting time
+	DealEventRescheduleRequested DealEventType = "reschedule_requested"
+	// The deal was moved to the proposed posting time
+	DealEventRescheduled DealEventType = "rescheduled"
+	// The proposed posting time was turned down; the deal keeps its time
+	DealEventRescheduleDeclined DealEventType = "reschedule_declined"
+	// Escrow payment landed on-chain
+	DealEventPaymentReceived DealEventType = "payment_received"
+	// No payment before the deadline
+	DealEventPaymentExpired DealEventType = "payment_expired"
+	// Payment landed after the deal was closed and was refunded
+	DealEventLatePaymentRefunded DealEventType = "late_payment_refunded"
+	// A party submitted a new ad version
+	DealEventRevisionSubmitted DealEventType = "revision_submitted"
+	// A party confirmed the latest ad version
+	DealEventRevisionApproved DealEventType = "revision_approved"
+	// A party sent the latest ad version back for edits
+	DealEventChangesRequested DealEventType = "changes_requested"
+	// Publisher turned the deal down
+	DealEventRejected DealEventType = "rejected"
+	// Advertiser cancelled the deal
+	DealEventCancelled DealEventType = "cancelled"
+	// Review took longer than the channel allows
+	DealEventReviewExpired DealEventType = "review_expired"
+	// Ad went live in the channel
+	DealEventPosted DealEventType = "posted"
+	// Ad could not be posted
+	DealEventPublishFailed DealEventType = "publish_failed"
+	// Ad stayed up for the whole feed window
+	DealEventCompleted DealEventType = "completed"
+	// Ad was removed or modified before the feed window ended
+	DealEventDisputed DealEventType = "disputed"
+	// A party added evidence to the dispute
+	DealEventEvidenceSubmitted DealEventType = "evidence_submitted"
+	// An arbiter decided the dispute
+	DealEventDisputeResolved DealEventType = "dispute_resolved"
+	// A party rated the other side once the deal was over
+	DealEventRated DealEventType = "rated"
+)
+
+// DealEvent is an entry in a deal's timeline, written in the same transaction
+// as the change it records. ActorID is nil for changes made by the system;
+// FromStatus is nil for the deal's creation, and equals ToStatus when the
+// status did not change.
+type DealEvent struct {
+	ID         uuid.UUID      `db:"id"`
+	DealID     uuid.UUID      `db:"deal_id"`
+	Type       DealEventType  `db:"type"`
+	ActorID    *uuid.UUID     `db:"actor_id"`
+	FromStatus *DealStatus    `db:"from_status"`
+	ToStatus   DealStatus     `db:"to_status"`
+	Note       *string        `db:"note"`
+	Metadata   map[string]any `db:"metadata"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DealMessage is part of the negotiation thread between a deal's advertiser
+// and the channel's team.
+type DealMessage struct {
+	ID        uuid.UUID `db:"id"`
+	DealID    uuid.UUID `db:"deal_id"`
+	AuthorID  uuid.UUID `db:"author_id"`
+	Text      string    `db:"text"`
+	CreatedAt time.Time `db:"created_at"`
+	// set once the bot forwarded the message to the counterparty
+	RelayedAt *time.Time `db:"relayed_at"`
+}
+
+// DealMessageRelay is a bot message that forwarded a deal message to a
+// user's chat. Replies to it are posted back into the deal's thread.
+type DealMessageRelay struct {
+	ChatID      int64     `db:"chat_id"`
+	TgMessageID int       `db:"tg_message_id"`
+	MessageID   uuid.UUID `db:"message_id"`
+}
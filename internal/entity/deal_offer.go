@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DealOfferStatus string
+
+const (
+	// Awaiting the other party's answer
+	DealOfferStatusPending DealOfferStatus = "pending"
+	// Terms were frozen onto the deal
+	DealOfferStatusAccepted DealOfferStatus = "accepted"
+	// Turned down, closing the deal
+	DealOfferStatusDeclined DealOfferStatus = "declined"
+	// Replaced by a counter-offer
+	DealOfferStatusCountered DealOfferStatus = "countered"
+)
+
+// DealOffer is a set of terms proposed by one side of a deal under
+// negotiation. Only the latest offer can be pending.
+type DealOffer struct {
+	ID           uuid.UUID       `db:"id"`
+	DealID       uuid.UUID       `db:"deal_id"`
+	AuthorID     uuid.UUID       `db:"author_id"`
+	PriceNanoTON int64           `db:"price_nano_ton"`
+	ScheduledAt  time.Time       `db:"scheduled_at"`
+	Status       DealOfferStatus `db:"status"`
+	CreatedAt    time.Time       `db:"created_at"`
+	RespondedAt  *time.Time      `db:"responded_at"`
+}
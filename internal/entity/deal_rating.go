@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DealRating is one side's 1–5 verdict on the other once the deal is over.
+// Party is the side of the author; ratings left by advertisers make up the
+// channel's rating on the marketplace.
+type DealRating struct {
+	ID        uuid.UUID `db:"id"`
+	DealID    uuid.UUID `db:"deal_id"`
+	AuthorID  uuid.UUID `db:"author_id"`
+	Party     DealParty `db:"party"`
+	Rating    int       `db:"rating"`
+	Comment   *string   `db:"comment"`
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DealRescheduleStatus string
+
+const (
+	// Awaiting the other party's answer
+	DealRescheduleStatusPending DealRescheduleStatus = "pending"
+	// The deal was moved to the proposed time
+	DealRescheduleStatusAccepted DealRescheduleStatus = "accepted"
+	// Turned down; the deal keeps its time
+	DealRescheduleStatusDeclined DealRescheduleStatus = "declined"
+	// Replaced by a newer proposal from either side
+	DealRescheduleStatusSuperseded DealRescheduleStatus = "superseded"
+)
+
+// DealReschedule is a proposal from one side of a deal to move its posting
+// time. Only the latest proposal can be pending.
+type DealReschedule struct {
+	ID          uuid.UUID            `db:"id"`
+	DealID      uuid.UUID            `db:"deal_id"`
+	AuthorID    uuid.UUID            `db:"author_id"`
+	ScheduledAt time.Time            `db:"scheduled_at"`
+	Status      DealRescheduleStatus `db:"status"`
+	CreatedAt   time.Time            `db:"created_at"`
+	RespondedAt *time.Time           `db:"responded_at"`
+}
@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DealViewSnapshot is how the posted ad was doing at one point of its feed
+// window. Views are those of its most seen message, forwards and reactions
+// add up over all of them.
+type DealViewSnapshot struct {
+	DealID    uuid.UUID `db:"deal_id"`
+	TakenAt   time.Time `db:"taken_at"`
+	Views     int64     `db:"views"`
+	Forwards  int64     `db:"forwards"`
+	Reactions int64     `db:"reactions"`
+}
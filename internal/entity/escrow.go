@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// EscrowCursor is the last transaction of an escrow address whose incoming
+// payments have been processed; the payment watcher resumes after it.
+type EscrowCursor struct {
+	Address   string    `db:"address"`
+	LT        string    `db:"lt"`
+	Hash      string    `db:"hash"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
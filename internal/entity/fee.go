@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FeePromotion waives the platform fee on deals created between StartsAt and
+// EndsAt, on one channel or, with no ChannelID, on every channel.
+type FeePromotion struct {
+	ID        uuid.UUID  `db:"id"`
+	ChannelID *uuid.UUID `db:"channel_id"`
+	// telegram id of ChannelID, joined in from channels
+	TgChannelID *int64    `db:"telegram_channel_id"`
+	StartsAt    time.Time `db:"starts_at"`
+	EndsAt      time.Time `db:"ends_at"`
+	Note        *string   `db:"note"`
+	CreatedBy   uuid.UUID `db:"created_by"`
+	CreatedAt   time.Time `db:"created_at"`
+}
@@ -2,8 +2,10 @@ package entity
 
 import (
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
 )
 
 type SortOrder string
@@ -16,8 +18,18 @@ const (
 type ChannelSortBy string
 
 const (
-	ChannelSortBySubscribers ChannelSortBy = "subscribers"
-	ChannelSortByViews       ChannelSortBy = "views"
+	ChannelSortBySubscribers    ChannelSortBy = "subscribers"
+	ChannelSortByViews          ChannelSortBy = "views"
+	ChannelSortByRating         ChannelSortBy = "rating"
+	ChannelSortByCompletedDeals ChannelSortBy = "completed_deals"
+)
+
+type DealSortBy string
+
+const (
+	DealSortByCreatedAt   DealSortBy = "created_at"
+	DealSortByScheduledAt DealSortBy = "scheduled_at"
+	DealSortByPrice       DealSortBy = "price"
 )
 
 type Filter struct {
@@ -51,11 +63,35 @@ func (f Filter) ToSql() (string, []any, error) { //nolint:revive
 		sql := "EXISTS (SELECT 1 FROM jsonb_array_elements(categories)" +
 			" cat WHERE cat->>'slug' = ANY(?))"
 		return sql, []any{slugs}, nil
+	case "min_rating":
+		v, ok := number(f.Value)
+		if !ok {
+			return "", nil, fmt.Errorf("filter %s: not a number: %v", f.Name, f.Value)
+		}
+		return "rating >= ?", []any{v}, nil
+	case "min_completed_deals":
+		v, ok := number(f.Value)
+		if !ok {
+			return "", nil, fmt.Errorf("filter %s: not a number: %v", f.Name, f.Value)
+		}
+		return "completed_deals >= ?", []any{v}, nil
 	default:
 		return "", nil, fmt.Errorf("unknown filter: %s", f.Name)
 	}
 }
 
+// number reads a filter value, which comes from JSON as a float64.
+func number(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
 var _ sq.Sqlizer = Filter{}
 
 type ChannelSort struct {
@@ -69,9 +105,81 @@ func (s ChannelSort) OrderByClause() string {
 		dir = "DESC"
 	}
 
-	if s.By == ChannelSortByViews {
+	switch s.By {
+	case ChannelSortByViews:
 		return fmt.Sprintf("avg_daily_views_7d %s NULLS LAST", dir)
+	case ChannelSortByRating:
+		return fmt.Sprintf("rating %s NULLS LAST", dir)
+	case ChannelSortByCompletedDeals:
+		return fmt.Sprintf("completed_deals %s", dir)
 	}
 
 	return fmt.Sprintf("subscribers %s NULLS LAST", dir)
 }
+
+type DealSort struct {
+	By    DealSortBy
+	Order SortOrder
+}
+
+// Column is the deals column the list is ordered by; id breaks ties.
+func (s DealSort) Column() string {
+	switch s.By {
+	case DealSortByScheduledAt:
+		return "scheduled_at"
+	case DealSortByPrice:
+		return "price_nano_ton"
+	}
+	return "created_at"
+}
+
+// DealCursor is the sort key of the last deal of a page; the next page
+// starts right after it.
+type DealCursor struct {
+	CreatedAt    time.Time `json:"c"`
+	ScheduledAt  time.Time `json:"s"`
+	PriceNanoTON int64     `json:"p"`
+	ID           uuid.UUID `json:"i"`
+}
+
+func DealCursorFrom(d *Deal) DealCursor {
+	return DealCursor{
+		CreatedAt:    d.CreatedAt,
+		ScheduledAt:  d.ScheduledAt,
+		PriceNanoTON: d.PriceNanoTON,
+		ID:           d.ID,
+	}
+}
+
+// Value is the cursor's key in the column the list is sorted by.
+func (c DealCursor) Value(sort DealSort) any {
+	switch sort.By {
+	case DealSortByScheduledAt:
+		return c.ScheduledAt
+	case DealSortByPrice:
+		return c.PriceNanoTON
+	}
+	return c.CreatedAt
+}
+
+// DealFilter narrows a deal list and selects one page of it; unset fields
+// don't filter.
+type DealFilter struct {
+	Statuses      []DealStatus
+	ScheduledFrom *time.Time
+	ScheduledTo   *time.Time
+	FormatType    *AdFormatType
+	// the counterparty: the channel in an advertiser's list, the advertiser's
+	// telegram id in a channel's list
+	TgChannelID    *int64
+	AdvertiserTgID *int64
+	Sort           DealSort
+	After          *DealCursor
+	// skips deals from the start of the list; only for clients still paging
+	// by page number, cursors are cheaper
+	Offset int
+	Limit  int
+	// also count the matching deals across all pages, at the cost of a
+	// second query
+	WithTotal bool
+}
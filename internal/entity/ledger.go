@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LedgerAccount string
+
+const (
+	// Funds the escrow wallet holds for the deal
+	LedgerAccountEscrow LedgerAccount = "escrow"
+	// The advertiser who paid for the deal
+	LedgerAccountAdvertiser LedgerAccount = "advertiser"
+	// The owner of the channel the deal ran on
+	LedgerAccountPublisher LedgerAccount = "publisher"
+	// Platform commission; stays on the escrow wallet
+	LedgerAccountPlatform LedgerAccount = "platform"
+)
+
+type LedgerEntryKind string
+
+const (
+	// Advertiser's payment reached escrow
+	LedgerEntryHold LedgerEntryKind = "hold"
+	// Publisher's share left escrow
+	LedgerEntryRelease LedgerEntryKind = "release"
+	// Platform fee taken from the publisher's share
+	LedgerEntryFee LedgerEntryKind = "fee"
+	// Escrowed funds went back to the advertiser
+	LedgerEntryRefund LedgerEntryKind = "refund"
+)
+
+// LedgerEntry is one leg of a double-entry posting. A positive amount moves
+// funds into the account, a negative one out of it; the legs sharing a TxnID
+// always sum to zero. UserID is set on advertiser and publisher legs.
+type LedgerEntry struct {
+	ID            uuid.UUID       `db:"id"`
+	TxnID         uuid.UUID       `db:"txn_id"`
+	DealID        uuid.UUID       `db:"deal_id"`
+	Kind          LedgerEntryKind `db:"kind"`
+	Account       LedgerAccount   `db:"account"`
+	UserID        *uuid.UUID      `db:"user_id"`
+	AmountNanoTON int64           `db:"amount_nano_ton"`
+	CreatedAt     time.Time       `db:"created_at"`
+}
+
+// LedgerBalance is the sum of a user's entries on one account.
+type LedgerBalance struct {
+	Account       LedgerAccount `db:"account"`
+	AmountNanoTON int64         `db:"amount_nano_ton"`
+}
+
+// LedgerDiscrepancy is a deal whose escrow balance in the ledger differs from
+// what its payment and confirmed transfers leave on the wallet.
+type LedgerDiscrepancy struct {
+	DealID          uuid.UUID `db:"deal_id"`
+	LedgerNanoTON   int64     `db:"ledger_nano_ton"`
+	ExpectedNanoTON int64     `db:"expected_nano_ton"`
+}
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OutboxEvent string
+
+const (
+	// Escrowed funds must be returned to the advertiser
+	OutboxEventRefund OutboxEvent = "refund"
+)
+
+// OutboxMessage is written in the same transaction as the deal change that
+// caused it and consumed by the worker.
+type OutboxMessage struct {
+	ID          uuid.UUID   `db:"id"`
+	DealID      uuid.UUID   `db:"deal_id"`
+	Event       OutboxEvent `db:"event"`
+	CreatedAt   time.Time   `db:"created_at"`
+	ProcessedAt *time.Time  `db:"processed_at"`
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AdRevision is one version of a deal's ad creative. PublisherNote is the
+// change request, from either side, that prompted it; the first version has
+// none. A version is agreed once both parties approved it; its author
+// approves it on creation.
+type AdRevision struct {
+	DealID               uuid.UUID  `db:"deal_id"`
+	Version              int        `db:"version"`
+	AuthorID             uuid.UUID  `db:"author_id"`
+	PublisherNote        *string    `db:"publisher_note"`
+	AdvertiserApprovedAt *time.Time `db:"advertiser_approved_at"`
+	PublisherApprovedAt  *time.Time `db:"publisher_approved_at"`
+	CreatedAt            time.Time  `db:"created_at"`
+}
+
+func (r *AdRevision) ApprovedBy(party DealParty) bool {
+	if party == DealPartyAdvertiser {
+		return r.AdvertiserApprovedAt != nil
+	}
+	return r.PublisherApprovedAt != nil
+}
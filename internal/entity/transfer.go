@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TransferKind string
+
+const (
+	// Release of escrowed funds to the publisher
+	TransferKindPayout TransferKind = "payout"
+	// Return of escrowed funds to the advertiser
+	TransferKindRefund TransferKind = "refund"
+	// Return of an incoming transfer, or the part of it, that did not pay for
+	// the deal; the funds were never held for it
+	TransferKindReturn TransferKind = "return"
+)
+
+type TransferStatus string
+
+const (
+	// Queued; not broadcast yet, or a previous attempt provably expired
+	TransferStatusPending TransferStatus = "pending"
+	// Broadcast with Seqno/ValidUntil; awaiting the on-chain transaction
+	TransferStatusSent TransferStatus = "sent"
+	// Found on-chain; TxHash is set
+	TransferStatusConfirmed TransferStatus = "confirmed"
+	// Outcome unknown; requires manual reconciliation, never retried
+	TransferStatusFailed TransferStatus = "failed"
+)
+
+type Transfer struct {
+	ID            uuid.UUID      `db:"id"`
+	DealID        uuid.UUID      `db:"deal_id"`
+	Kind          TransferKind   `db:"kind"`
+	Destination   string         `db:"destination"`
+	AmountNanoTON int64          `db:"amount_nano_ton"`
+	FeeNanoTON    int64          `db:"fee_nano_ton"`
+	Comment       string         `db:"comment"`
+	Status        TransferStatus `db:"status"`
+	Seqno         *int64         `db:"seqno"`
+	ValidUntil    *time.Time     `db:"valid_until"`
+	TxHash        *string        `db:"tx_hash"`
+	Attempts      int            `db:"attempts"`
+	LastError     *string        `db:"last_error"`
+	CreatedAt     time.Time      `db:"created_at"`
+	UpdatedAt     time.Time      `db:"updated_at"`
+}
@@ -0,0 +1,78 @@
+package mtproto
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gotd/td/tg"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+)
+
+// GetChannelMessages looks up messages of a channel the bot administers,
+// along with their view, forward and reaction counts. Deleted messages are
+// returned with Exists unset.
+func (c *gateway) GetChannelMessages(
+	ctx context.Context,
+	channelID int64,
+	ids []int64,
+) ([]dto.ChannelMessage, error) {
+	accessHash, err := c.resolveChannel(ctx, c.api, channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	input := make([]tg.InputMessageClass, len(ids))
+	for i, id := range ids {
+		input[i] = &tg.InputMessageID{ID: int(id)}
+	}
+
+	res, err := c.api.ChannelsGetMessages(ctx, &tg.ChannelsGetMessagesRequest{
+		Channel: &tg.InputChannel{ChannelID: channelID, AccessHash: accessHash},
+		ID:      input,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get channel messages: %w", err)
+	}
+
+	modified, ok := res.AsModified()
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type: %T", res)
+	}
+
+	found := make(map[int64]*tg.Message, len(ids))
+	for _, m := range modified.GetMessages() {
+		if msg, ok := m.(*tg.Message); ok {
+			found[int64(msg.ID)] = msg
+		}
+	}
+
+	result := make([]dto.ChannelMessage, len(ids))
+	for i, id := range ids {
+		result[i].ID = id
+		msg, ok := found[id]
+		if !ok {
+			continue
+		}
+		result[i].Exists = true
+		result[i].Pinned = msg.Pinned
+		if ts, ok := msg.GetEditDate(); ok {
+			editedAt := time.Unix(int64(ts), 0)
+			result[i].EditedAt = &editedAt
+		}
+		if v, ok := msg.GetViews(); ok {
+			result[i].Views = int64(v)
+		}
+		if v, ok := msg.GetForwards(); ok {
+			result[i].Forwards = int64(v)
+		}
+		if reactions, ok := msg.GetReactions(); ok {
+			for _, r := range reactions.Results {
+				result[i].Reactions += int64(r.Count)
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"strconv"
 
 	tele "gopkg.in/telebot.v4"
 
@@ -85,6 +86,25 @@ func (c *Client) SendAlbum(to tele.Recipient, a tele.Album, opts ...any) ([]tele
 	return c.bot.SendAlbum(to, a, opts...)
 }
 
+// Pin silently pins a message in a channel.
+func (c *Client) Pin(chatID int64, messageID int) error {
+	msg := tele.StoredMessage{ChatID: chatID, MessageID: strconv.Itoa(messageID)}
+	return c.bot.Pin(msg, tele.Silent)
+}
+
+func (c *Client) Unpin(chatID int64, messageID int) error {
+	return c.bot.Unpin(&tele.Chat{ID: chatID}, messageID)
+}
+
+// DeleteMessages deletes messages from a channel, skipping those already gone.
+func (c *Client) DeleteMessages(chatID int64, messageIDs []int64) error {
+	msgs := make([]tele.Editable, len(messageIDs))
+	for i, id := range messageIDs {
+		msgs[i] = tele.StoredMessage{ChatID: chatID, MessageID: strconv.FormatInt(id, 10)}
+	}
+	return c.bot.DeleteMany(msgs)
+}
+
 func (c *Client) AdminsOf(channelID int64) ([]dto.ChannelAdmin, error) {
 	members, err := c.bot.AdminsOf(&tele.Chat{ID: channelID})
 	if err != nil {
@@ -0,0 +1,86 @@
+package ton
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	flagBounceable    = 0x11
+	flagNonBounceable = 0x51
+	flagTestOnly      = 0x80
+)
+
+type address struct {
+	workchain  int8
+	hash       [32]byte
+	bounceable bool
+}
+
+// parseAddress accepts both raw ("0:<hex>") and user-friendly base64 forms.
+func parseAddress(s string) (*address, error) {
+	if wc, h, ok := strings.Cut(s, ":"); ok {
+		return parseRawAddress(wc, h)
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		b, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(s, "="))
+	}
+	if err != nil || len(b) != 36 {
+		return nil, fmt.Errorf("invalid address %q", s)
+	}
+
+	if crc16(b[:34]) != binary.BigEndian.Uint16(b[34:]) {
+		return nil, fmt.Errorf("invalid address %q: checksum mismatch", s)
+	}
+
+	a := &address{workchain: int8(b[1])}
+	switch b[0] &^ flagTestOnly {
+	case flagBounceable:
+		a.bounceable = true
+	case flagNonBounceable:
+	default:
+		return nil, fmt.Errorf("invalid address %q: unknown flags", s)
+	}
+	copy(a.hash[:], b[2:34])
+
+	return a, nil
+}
+
+func parseRawAddress(wc, h string) (*address, error) {
+	workchain, err := strconv.ParseInt(wc, 10, 8)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workchain %q", wc)
+	}
+
+	b, err := hex.DecodeString(h)
+	if err != nil || len(b) != 32 {
+		return nil, fmt.Errorf("invalid address hash %q", h)
+	}
+
+	a := &address{workchain: int8(workchain), bounceable: true}
+	copy(a.hash[:], b)
+
+	return a, nil
+}
+
+// crc16 is CRC-16/XMODEM as used by user-friendly TON addresses.
+func crc16(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for range 8 {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
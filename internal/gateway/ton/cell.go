@@ -0,0 +1,226 @@
+package ton
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+const (
+	maxCellBits = 1023
+	maxCellRefs = 4
+)
+
+var (
+	errCellOverflow = errors.New("cell overflow")
+	errTooManyRefs  = errors.New("too many cell refs")
+)
+
+// cell is an ordinary (non-exotic) TVM cell. Only what is needed to build and
+// serialize wallet messages is implemented.
+type cell struct {
+	data []byte
+	bits int
+	refs []*cell
+}
+
+type builder struct {
+	c   cell
+	err error
+}
+
+func newBuilder() *builder {
+	return &builder{}
+}
+
+func (b *builder) storeBit(v bool) *builder {
+	if b.err != nil {
+		return b
+	}
+	if b.c.bits >= maxCellBits {
+		b.err = errCellOverflow
+		return b
+	}
+	if b.c.bits%8 == 0 {
+		b.c.data = append(b.c.data, 0)
+	}
+	if v {
+		b.c.data[b.c.bits/8] |= 0x80 >> (b.c.bits % 8)
+	}
+	b.c.bits++
+	return b
+}
+
+func (b *builder) storeUint(v uint64, n int) *builder {
+	for i := n - 1; i >= 0; i-- {
+		b.storeBit(v>>i&1 == 1)
+	}
+	return b
+}
+
+func (b *builder) storeBytes(p []byte) *builder {
+	for _, v := range p {
+		b.storeUint(uint64(v), 8)
+	}
+	return b
+}
+
+// storeCoins writes a Grams value (VarUInteger 16).
+func (b *builder) storeCoins(nano int64) *builder {
+	if nano < 0 {
+		b.err = errors.New("negative coins value")
+		return b
+	}
+	n := 0
+	if nano > 0 {
+		n = byteLen(uint64(nano))
+	}
+	b.storeUint(uint64(n), 4)
+	return b.storeUint(uint64(nano), 8*n)
+}
+
+// storeAddress writes addr_std without anycast; nil writes addr_none.
+func (b *builder) storeAddress(a *address) *builder {
+	if a == nil {
+		return b.storeUint(0, 2)
+	}
+	b.storeUint(0b100, 3)
+	b.storeUint(uint64(uint8(a.workchain)), 8)
+	return b.storeBytes(a.hash[:])
+}
+
+func (b *builder) storeRef(c *cell) *builder {
+	if b.err != nil {
+		return b
+	}
+	if len(b.c.refs) >= maxCellRefs {
+		b.err = errTooManyRefs
+		return b
+	}
+	b.c.refs = append(b.c.refs, c)
+	return b
+}
+
+func (b *builder) remainingBits() int {
+	return maxCellBits - b.c.bits
+}
+
+func (b *builder) end() (*cell, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	c := b.c
+	return &c, nil
+}
+
+func (c *cell) descriptors() []byte {
+	fullBytes := c.bits / 8
+	return []byte{
+		byte(len(c.refs)),
+		byte(fullBytes + (c.bits+7)/8),
+	}
+}
+
+// paddedData appends the completion tag when data is not byte-aligned.
+func (c *cell) paddedData() []byte {
+	data := append([]byte{}, c.data...)
+	if c.bits%8 != 0 {
+		data[len(data)-1] |= 0x80 >> (c.bits % 8)
+	}
+	return data
+}
+
+func (c *cell) depth() uint16 {
+	var d uint16
+	for _, r := range c.refs {
+		if rd := r.depth() + 1; rd > d {
+			d = rd
+		}
+	}
+	return d
+}
+
+// hash returns the representation hash of the cell.
+func (c *cell) hash() []byte {
+	h := sha256.New()
+	h.Write(c.descriptors())
+	h.Write(c.paddedData())
+	for _, r := range c.refs {
+		_ = binary.Write(h, binary.BigEndian, r.depth())
+	}
+	for _, r := range c.refs {
+		h.Write(r.hash())
+	}
+	return h.Sum(nil)
+}
+
+// toBOC serializes the cell tree as a bag of cells with a single root,
+// without index and checksum.
+func (c *cell) toBOC() []byte {
+	order := topoSort(c)
+	index := make(map[*cell]int, len(order))
+	for i, cc := range order {
+		index[cc] = i
+	}
+
+	sizeBytes := byteLen(uint64(len(order)))
+
+	var payload []byte
+	for _, cc := range order {
+		payload = append(payload, cc.descriptors()...)
+		payload = append(payload, cc.paddedData()...)
+		for _, r := range cc.refs {
+			payload = appendUint(payload, uint64(index[r]), sizeBytes)
+		}
+	}
+	offBytes := byteLen(uint64(len(payload)))
+
+	boc := []byte{0xb5, 0xee, 0x9c, 0x72}
+	boc = append(boc, byte(sizeBytes), byte(offBytes))
+	boc = appendUint(boc, uint64(len(order)), sizeBytes)
+	boc = appendUint(boc, 1, sizeBytes)
+	boc = appendUint(boc, 0, sizeBytes)
+	boc = appendUint(boc, uint64(len(payload)), offBytes)
+	boc = appendUint(boc, 0, sizeBytes)
+	return append(boc, payload...)
+}
+
+// topoSort orders cells so that every parent precedes its children.
+func topoSort(root *cell) []*cell {
+	var post []*cell
+	visited := make(map[*cell]bool)
+	var visit func(c *cell)
+	visit = func(c *cell) {
+		if visited[c] {
+			return
+		}
+		visited[c] = true
+		for _, r := range c.refs {
+			visit(r)
+		}
+		post = append(post, c)
+	}
+	visit(root)
+
+	order := make([]*cell, len(post))
+	for i, c := range post {
+		order[len(post)-1-i] = c
+	}
+	return order
+}
+
+func byteLen(v uint64) int {
+	n := (bits.Len64(v) + 7) / 8
+	if n == 0 {
+		return 1
+	}
+	return n
+}
+
+func appendUint(p []byte, v uint64, n int) []byte {
+	for i := n - 1; i >= 0; i-- {
+		p = append(p, byte(v>>(8*i)))
+	}
+	return p
+}
@@ -0,0 +1,276 @@
+package ton
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/bpva/ad-marketplace/internal/config"
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/logx"
+)
+
+const (
+	mainnetURL = "https://toncenter.com/api/v2"
+	testnetURL = "https://testnet.toncenter.com/api/v2"
+)
+
+type Client struct {
+	baseURL string
+	apiKey  string
+	http    *http.Client
+	log     *slog.Logger
+
+	// escrow wallet signing key; nil when the process only reads the chain
+	wallet *address
+	key    ed25519.PrivateKey
+}
+
+func New(cfg config.TON, log *slog.Logger) (*Client, error) {
+	if cfg.Provider != "toncenter" {
+		return nil, fmt.Errorf("unsupported TON provider %q", cfg.Provider)
+	}
+
+	baseURL := cfg.APIURL
+	if baseURL == "" {
+		switch cfg.Network {
+		case "mainnet":
+			baseURL = mainnetURL
+		case "testnet":
+			baseURL = testnetURL
+		default:
+			return nil, fmt.Errorf("unsupported TON network %q", cfg.Network)
+		}
+	}
+
+	c := &Client{
+		baseURL: baseURL,
+		apiKey:  cfg.APIKey,
+		http:    &http.Client{Timeout: 10 * time.Second},
+		log:     log.With(logx.Service("TONCenter")),
+	}
+
+	if cfg.EscrowWalletSeed != "" {
+		seed, err := hex.DecodeString(cfg.EscrowWalletSeed)
+		if err != nil || len(seed) != ed25519.SeedSize {
+			return nil, errors.New("escrow wallet seed must be 32 hex-encoded bytes")
+		}
+		c.wallet, err = parseAddress(cfg.EscrowWalletAddress)
+		if err != nil {
+			return nil, fmt.Errorf("parse escrow wallet address: %w", err)
+		}
+		c.key = ed25519.NewKeyFromSeed(seed)
+	}
+
+	return c, nil
+}
+
+type apiResponse struct {
+	OK     bool            `json:"ok"`
+	Result json.RawMessage `json:"result"`
+	Error  string          `json:"error"`
+	Code   int             `json:"code"`
+}
+
+type rawTransaction struct {
+	UTime         int64 `json:"utime"`
+	TransactionID struct {
+		LT   string `json:"lt"`
+		Hash string `json:"hash"`
+	} `json:"transaction_id"`
+	InMsg   *rawMessage  `json:"in_msg"`
+	OutMsgs []rawMessage `json:"out_msgs"`
+}
+
+type rawMessage struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	Value       string `json:"value"`
+	Message     string `json:"message"`
+}
+
+// GetTransactions returns up to limit transactions of address, newest first.
+// The page starts with the latest transaction, or right after before if set.
+func (c *Client) GetTransactions(
+	ctx context.Context,
+	address string,
+	limit int,
+	before *dto.TONTxID,
+) ([]dto.TONTransaction, error) {
+	q := url.Values{}
+	q.Set("address", address)
+	q.Set("limit", strconv.Itoa(limit))
+	q.Set("archival", "true")
+	if before != nil {
+		// toncenter starts the page at the given transaction itself
+		q.Set("limit", strconv.Itoa(limit+1))
+		q.Set("lt", before.LT)
+		q.Set("hash", before.Hash)
+	}
+
+	var raw []rawTransaction
+	if err := c.get(ctx, "/getTransactions", q, &raw); err != nil {
+		return nil, fmt.Errorf("get transactions: %w", err)
+	}
+	if before != nil && len(raw) > 0 && raw[0].TransactionID.Hash == before.Hash {
+		raw = raw[1:]
+	}
+	if len(raw) > limit {
+		raw = raw[:limit]
+	}
+
+	txs := make([]dto.TONTransaction, 0, len(raw))
+	for _, t := range raw {
+		tx := dto.TONTransaction{
+			Hash: t.TransactionID.Hash,
+			LT:   t.TransactionID.LT,
+			Time: time.Unix(t.UTime, 0),
+		}
+		// external messages (wallet's own outgoing requests) have no source
+		if t.InMsg != nil && t.InMsg.Source != "" {
+			msg, err := t.InMsg.toDTO()
+			if err != nil {
+				c.log.Warn("skip transaction with invalid value",
+					"hash", t.TransactionID.Hash,
+					"error", err)
+				continue
+			}
+			tx.In = &msg
+		}
+		for _, m := range t.OutMsgs {
+			msg, err := m.toDTO()
+			if err != nil {
+				c.log.Warn("skip outgoing message with invalid value",
+					"hash", t.TransactionID.Hash,
+					"error", err)
+				continue
+			}
+			tx.Out = append(tx.Out, msg)
+		}
+		txs = append(txs, tx)
+	}
+
+	return txs, nil
+}
+
+func (m *rawMessage) toDTO() (dto.TONMessage, error) {
+	value, err := strconv.ParseInt(m.Value, 10, 64)
+	if err != nil {
+		return dto.TONMessage{}, fmt.Errorf("parse value %q: %w", m.Value, err)
+	}
+	return dto.TONMessage{
+		Source:       m.Source,
+		Destination:  m.Destination,
+		ValueNanoTON: value,
+		Comment:      m.Message,
+	}, nil
+}
+
+// GetSeqno returns the current seqno of a wallet contract.
+func (c *Client) GetSeqno(ctx context.Context, address string) (int64, error) {
+	q := url.Values{}
+	q.Set("address", address)
+
+	var info struct {
+		Wallet       bool   `json:"wallet"`
+		Seqno        int64  `json:"seqno"`
+		AccountState string `json:"account_state"`
+	}
+	if err := c.get(ctx, "/getWalletInformation", q, &info); err != nil {
+		return 0, fmt.Errorf("get wallet information: %w", err)
+	}
+	if !info.Wallet || info.AccountState != "active" {
+		return 0, fmt.Errorf("wallet %s is not active (state %q)", address, info.AccountState)
+	}
+
+	return info.Seqno, nil
+}
+
+// SendTransfers signs the transfers with the escrow wallet key and broadcasts
+// them as a single external message. The message is rejected by the wallet
+// once seqno is used or validUntil has passed, so it can never execute twice.
+func (c *Client) SendTransfers(
+	ctx context.Context,
+	seqno int64,
+	validUntil time.Time,
+	transfers []dto.TONTransfer,
+) error {
+	if c.key == nil {
+		return errors.New("escrow wallet key is not configured")
+	}
+
+	msg, err := buildTransfer(c.key, c.wallet, seqno, validUntil, transfers)
+	if err != nil {
+		return fmt.Errorf("build transfer: %w", err)
+	}
+
+	req := map[string]string{"boc": base64.StdEncoding.EncodeToString(msg.toBOC())}
+	if err := c.post(ctx, "/sendBoc", req, nil); err != nil {
+		return fmt.Errorf("send boc: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) get(ctx context.Context, path string, q url.Values, result any) error {
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodGet, c.baseURL+path+"?"+q.Encode(), nil,
+	)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	return c.do(req, result)
+}
+
+func (c *Client) post(ctx context.Context, path string, body, result any) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(b),
+	)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.do(req, result)
+}
+
+func (c *Client) do(req *http.Request, result any) error {
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("do request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var body apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("decode response (status %d): %w", resp.StatusCode, err)
+	}
+	if !body.OK {
+		return fmt.Errorf("toncenter error %d: %s", body.Code, body.Error)
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(body.Result, result); err != nil {
+		return fmt.Errorf("decode result: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package ton
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+)
+
+func TestCellHash_Empty(t *testing.T) {
+	c, err := newBuilder().end()
+	require.NoError(t, err)
+	assert.Equal(t,
+		"96a296d224f285c67bee93c30f8a309157f0daa35dc5b87e410b78630a09cfc7",
+		hex.EncodeToString(c.hash()))
+}
+
+func TestParseAddress(t *testing.T) {
+	a, err := parseAddress("EQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqB2N")
+	require.NoError(t, err)
+	assert.Equal(t, int8(0), a.workchain)
+	assert.True(t, a.bounceable)
+	assert.Equal(t,
+		"83dfd552e63729b472fcbcc8c45ebcc6691702558b68ec7527e1ba403a0f31a8",
+		hex.EncodeToString(a.hash[:]))
+}
+
+func TestCellBOC_Empty(t *testing.T) {
+	c, err := newBuilder().end()
+	require.NoError(t, err)
+	assert.Equal(t, "b5ee9c72010101010002000000", hex.EncodeToString(c.toBOC()))
+}
+
+func TestBuildTransfer_SignsBody(t *testing.T) {
+	key := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	wallet, err := parseAddress("EQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqB2N")
+	require.NoError(t, err)
+
+	ext, err := buildTransfer(key, wallet, 7, time.Unix(1700000000, 0), []dto.TONTransfer{
+		{Destination: "0:" + strings.Repeat("ab", 32), AmountNanoTON: 1500000000, Comment: "payout"},
+	})
+	require.NoError(t, err)
+	require.Len(t, ext.refs, 1)
+
+	signed := ext.refs[0]
+	require.Len(t, signed.refs, 1)
+
+	unsigned, err := newBuilder().storeBytes(signed.data[64:]).storeRef(signed.refs[0]).end()
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(key.Public().(ed25519.PublicKey), unsigned.hash(), signed.data[:64]))
+
+	// subwallet id, valid_until, seqno
+	assert.Equal(t, []byte{0x29, 0xa9, 0xa3, 0x17}, unsigned.data[0:4])
+	assert.Equal(t, []byte{0x65, 0x53, 0xf1, 0x00}, unsigned.data[4:8])
+	assert.Equal(t, []byte{0, 0, 0, 7}, unsigned.data[8:12])
+}
+
+func TestBuildTransfer_TooManyMessages(t *testing.T) {
+	key := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	wallet, err := parseAddress("EQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqB2N")
+	require.NoError(t, err)
+
+	transfers := make([]dto.TONTransfer, maxWalletMessages+1)
+	_, err = buildTransfer(key, wallet, 1, time.Now(), transfers)
+	require.Error(t, err)
+}
+
+// Vector cross-checked with an independent TL-B decoder: wallet v4r2 body
+// (subwallet 698983191, valid_until 1700000000, seqno 7), one mode 3 message
+// to 0:abab..ab carrying 1.5 TON with the "payout" comment, non-bounceable.
+func TestBuildTransfer_GoldenBOC(t *testing.T) {
+	key := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	wallet, err := parseAddress("EQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqB2N")
+	require.NoError(t, err)
+
+	ext, err := buildTransfer(key, wallet, 7, time.Unix(1700000000, 0), []dto.TONTransfer{{
+		Destination:   "0:" + strings.Repeat("ab", 32),
+		AmountNanoTON: 1500000000,
+		Comment:       "payout",
+	}})
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		"b5ee9c720101030100b7000145880107bfaaa5cc6e5368e5f9799188bd798cd22e04ab16d1d8ea4f"+
+			"c37480741e63500c01019c0b7e859d40bb3b71c891d92525e69ca35fed6eed0d90b0174a4f50648f"+
+			"fef2389924bdcb26f3ee91afe69b2aa9ff462341d6b485ab077c36c5b69fa36fca170e29a9a31765"+
+			"53f10000000007000302007c420055d5d5d5d5d5d5d5d5d5d5d5d5d5d5d5d5d5d5d5d5d5d5d5d5d5"+
+			"d5d5d5d5d5d5a2cb41780000000000000000000000000000000000007061796f7574",
+		hex.EncodeToString(ext.toBOC()))
+	assert.Equal(t,
+		"fc5434d6e8f115865c5f7810d751e4fa90d13dd6cb678e49a9254f90361d3218",
+		hex.EncodeToString(ext.hash()))
+}
+
+func TestBuildInternalMessage_NonBounceable(t *testing.T) {
+	for _, dest := range []string{
+		"EQCD39VS5jcptHL8vMjEXrzGaRcCVYto7HUn4bpAOg8xqB2N",
+		"0:" + strings.Repeat("ab", 32),
+	} {
+		msg, err := buildInternalMessage(dto.TONTransfer{Destination: dest, AmountNanoTON: 1})
+		require.NoError(t, err)
+		// int_msg_info$0 ihr_disabled:1 bounce:0 bounced:0
+		assert.Equal(t, byte(0b0100), msg.data[0]>>4, dest)
+	}
+}
@@ -0,0 +1,120 @@
+package ton
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+)
+
+const (
+	// default subwallet id of wallet v4r2 in the basechain
+	walletV4SubwalletID = 698983191
+	// pay transfer fees separately, ignore action errors
+	walletSendMode = 3
+	// wallet v4 accepts at most four outgoing messages per external message
+	maxWalletMessages = 4
+)
+
+// buildTransfer builds and signs a wallet v4r2 external message carrying
+// the given transfers.
+func buildTransfer(
+	key ed25519.PrivateKey,
+	wallet *address,
+	seqno int64,
+	validUntil time.Time,
+	transfers []dto.TONTransfer,
+) (*cell, error) {
+	if len(transfers) == 0 || len(transfers) > maxWalletMessages {
+		return nil, fmt.Errorf("wallet can send 1 to %d messages, got %d",
+			maxWalletMessages, len(transfers))
+	}
+
+	body := newBuilder().
+		storeUint(walletV4SubwalletID, 32).
+		storeUint(uint64(validUntil.Unix()), 32).
+		storeUint(uint64(seqno), 32).
+		storeUint(0, 8)
+	for _, t := range transfers {
+		msg, err := buildInternalMessage(t)
+		if err != nil {
+			return nil, fmt.Errorf("build message to %s: %w", t.Destination, err)
+		}
+		body.storeUint(walletSendMode, 8).storeRef(msg)
+	}
+	unsigned, err := body.end()
+	if err != nil {
+		return nil, fmt.Errorf("build body: %w", err)
+	}
+
+	signed := newBuilder().storeBytes(ed25519.Sign(key, unsigned.hash()))
+	signed.storeBytes(unsigned.data[:unsigned.bits/8])
+	for _, r := range unsigned.refs {
+		signed.storeRef(r)
+	}
+	signedBody, err := signed.end()
+	if err != nil {
+		return nil, fmt.Errorf("sign body: %w", err)
+	}
+
+	// ext_in_msg_info$10 src:addr_none dest import_fee:0, no state init, body in ref
+	ext, err := newBuilder().
+		storeUint(0b10, 2).
+		storeAddress(nil).
+		storeAddress(wallet).
+		storeCoins(0).
+		storeBit(false).
+		storeBit(true).
+		storeRef(signedBody).
+		end()
+	if err != nil {
+		return nil, fmt.Errorf("build external message: %w", err)
+	}
+
+	return ext, nil
+}
+
+func buildInternalMessage(t dto.TONTransfer) (*cell, error) {
+	dest, err := parseAddress(t.Destination)
+	if err != nil {
+		return nil, err
+	}
+	if t.AmountNanoTON <= 0 {
+		return nil, errors.New("amount must be positive")
+	}
+
+	comment, err := newBuilder().storeUint(0, 32).storeBytes([]byte(t.Comment)).end()
+	if err != nil {
+		return nil, fmt.Errorf("comment too long: %w", err)
+	}
+
+	// int_msg_info$0 ihr_disabled bounce bounced src dest value
+	// ihr_fee fwd_fee created_lt created_at, no state init.
+	// Always non-bounceable: a bounced payout or refund would return to
+	// the escrow wallet while the transfer is already confirmed as sent.
+	b := newBuilder().
+		storeBit(false).
+		storeBit(true).
+		storeBit(false).
+		storeBit(false).
+		storeAddress(nil).
+		storeAddress(dest).
+		storeCoins(t.AmountNanoTON).
+		storeBit(false).
+		storeCoins(0).
+		storeCoins(0).
+		storeUint(0, 64).
+		storeUint(0, 32).
+		storeBit(false)
+
+	if b.remainingBits() > comment.bits {
+		b.storeBit(false)
+		b.storeBytes(comment.data)
+	} else {
+		b.storeBit(true).storeRef(comment)
+	}
+
+	return b.end()
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	chimw "github.com/go-chi/chi/v5/middleware"
@@ -16,6 +17,7 @@ import (
 	"github.com/bpva/ad-marketplace/internal/dto"
 	"github.com/bpva/ad-marketplace/internal/entity"
 	"github.com/bpva/ad-marketplace/internal/http/middleware"
+	"github.com/bpva/ad-marketplace/internal/service/campaign"
 	"github.com/bpva/ad-marketplace/internal/service/deal"
 )
 
@@ -38,6 +40,18 @@ type ChannelService interface {
 	AddManager(ctx context.Context, TgChannelID int64, tgID int64) error
 	RemoveManager(ctx context.Context, TgChannelID int64, tgID int64) error
 	UpdateListing(ctx context.Context, TgChannelID int64, isListed bool) error
+	UpdateReviewTimeout(ctx context.Context, TgChannelID int64, hours *int) error
+	GetAvailability(
+		ctx context.Context,
+		TgChannelID int64,
+		from time.Time,
+		days int,
+	) (*dto.ChannelAvailabilityResponse, error)
+	UpdateAvailability(
+		ctx context.Context,
+		TgChannelID int64,
+		req dto.UpdateAvailabilityRequest,
+	) error
 	GetAdFormats(ctx context.Context, TgChannelID int64) (*dto.AdFormatsResponse, error)
 	AddAdFormat(ctx context.Context, TgChannelID int64, req dto.AddAdFormatRequest) error
 	RemoveAdFormat(ctx context.Context, TgChannelID int64, formatID uuid.UUID) error
@@ -72,17 +86,86 @@ type DealService interface {
 		ctx context.Context,
 		params deal.CreateDealParams,
 	) (*entity.Deal, []entity.Post, error)
-	GetDeal(ctx context.Context, dealID uuid.UUID) (*entity.Deal, []entity.Post, int64, error)
-	ListAdvertiserDeals(ctx context.Context, limit, offset int) ([]dto.DealListItem, int, error)
+	GetDeal(
+		ctx context.Context,
+		dealID uuid.UUID,
+	) (*entity.Deal, []entity.Post, int64, []entity.Transfer, error)
+	ListAdvertiserDeals(
+		ctx context.Context,
+		f entity.DealFilter,
+	) ([]dto.DealListItem, int, error)
 	ListPublisherDeals(
 		ctx context.Context,
 		tgChannelID int64,
-		limit, offset int,
+		f entity.DealFilter,
 	) ([]dto.DealListItem, int, error)
-	Approve(ctx context.Context, dealID uuid.UUID) error
+	Approve(ctx context.Context, dealID uuid.UUID, version *int) error
 	Reject(ctx context.Context, dealID uuid.UUID, reason *string) error
 	RequestChanges(ctx context.Context, dealID uuid.UUID, note string) error
 	Cancel(ctx context.Context, dealID uuid.UUID) error
+	SubmitRevision(
+		ctx context.Context,
+		dealID uuid.UUID,
+		params deal.RevisionParams,
+	) (*dto.RevisionItem, error)
+	GetRevisions(ctx context.Context, dealID uuid.UUID) ([]dto.RevisionItem, error)
+	DiffRevisions(
+		ctx context.Context,
+		dealID uuid.UUID,
+		from, to int,
+	) (*dto.RevisionDiffResponse, error)
+	GetEvents(ctx context.Context, dealID uuid.UUID) ([]dto.DealEventItem, error)
+	PostMessage(ctx context.Context, dealID uuid.UUID, text string) (*dto.DealMessageItem, error)
+	GetMessages(ctx context.Context, dealID uuid.UUID) ([]dto.DealMessageItem, error)
+	CounterOffer(
+		ctx context.Context,
+		dealID uuid.UUID,
+		params deal.OfferParams,
+	) (*dto.DealOfferItem, error)
+	AcceptOffer(ctx context.Context, dealID uuid.UUID) error
+	DeclineOffer(ctx context.Context, dealID uuid.UUID, reason *string) error
+	GetOffers(ctx context.Context, dealID uuid.UUID) ([]dto.DealOfferItem, error)
+	RequestReschedule(
+		ctx context.Context,
+		dealID uuid.UUID,
+		scheduledAt time.Time,
+	) (*dto.DealRescheduleItem, error)
+	AcceptReschedule(ctx context.Context, dealID uuid.UUID) error
+	DeclineReschedule(ctx context.Context, dealID uuid.UUID, reason *string) error
+	GetReschedules(ctx context.Context, dealID uuid.UUID) ([]dto.DealRescheduleItem, error)
+	GetReport(ctx context.Context, dealID uuid.UUID) (*dto.DealReportResponse, error)
+	RateDeal(
+		ctx context.Context,
+		dealID uuid.UUID,
+		params deal.RatingParams,
+	) (*dto.DealRatingItem, error)
+	GetRatings(ctx context.Context, dealID uuid.UUID) ([]dto.DealRatingItem, error)
+	GetDispute(ctx context.Context, dealID uuid.UUID) (*dto.DisputeItem, error)
+	SubmitEvidence(
+		ctx context.Context,
+		dealID uuid.UUID,
+		params deal.EvidenceParams,
+	) (*dto.DisputeEvidenceItem, error)
+	ResolveDispute(ctx context.Context, dealID uuid.UUID, params deal.ResolutionParams) error
+	ListDisputes(ctx context.Context) ([]dto.DisputeItem, error)
+	GetLedger(ctx context.Context, dealID uuid.UUID) ([]entity.LedgerEntry, error)
+	GetBalances(ctx context.Context, userID *uuid.UUID) ([]entity.LedgerBalance, error)
+	SetChannelFee(ctx context.Context, tgChannelID int64, bps *int64) error
+	CreatePromotion(
+		ctx context.Context,
+		params deal.PromotionParams,
+	) (*entity.FeePromotion, error)
+	ListPromotions(ctx context.Context) ([]entity.FeePromotion, error)
+	DeletePromotion(ctx context.Context, promotionID uuid.UUID) error
+}
+
+type CampaignService interface {
+	CreateCampaign(
+		ctx context.Context,
+		params campaign.CreateCampaignParams,
+	) (*dto.CampaignItem, error)
+	GetCampaign(ctx context.Context, campaignID uuid.UUID) (*dto.CampaignItem, error)
+	ListCampaigns(ctx context.Context) ([]dto.CampaignItem, error)
 }
 
 type App struct {
@@ -94,6 +177,7 @@ type App struct {
 	post     PostService
 	tonRates TonRatesService
 	deal     DealService
+	campaign CampaignService
 	srv      *http.Server
 }
 
@@ -107,6 +191,7 @@ func New(
 	postSvc PostService,
 	tonRatesSvc TonRatesService,
 	dealSvc DealService,
+	campaignSvc CampaignService,
 ) *App {
 	a := &App{
 		log:      log,
@@ -117,6 +202,7 @@ func New(
 		post:     postSvc,
 		tonRates: tonRatesSvc,
 		deal:     dealSvc,
+		campaign: campaignSvc,
 	}
 
 	r := chi.NewRouter()
@@ -175,6 +261,9 @@ func New(
 				r.Post("/{TgChannelID}/managers", a.HandleAddManager())
 				r.Delete("/{TgChannelID}/managers/{tgID}", a.HandleRemoveManager())
 				r.Patch("/{TgChannelID}/listing", a.HandleUpdateListing())
+				r.Patch("/{TgChannelID}/review-timeout", a.HandleUpdateReviewTimeout())
+				r.Get("/{TgChannelID}/availability", a.HandleGetAvailability())
+				r.Put("/{TgChannelID}/availability", a.HandleUpdateAvailability())
 				r.Patch("/{TgChannelID}/categories", a.HandleUpdateCategories())
 				r.Get("/{TgChannelID}/ad-formats", a.HandleGetAdFormats())
 				r.Post("/{TgChannelID}/ad-formats", a.HandleAddAdFormat())
@@ -189,6 +278,43 @@ func New(
 				r.Post("/{dealID}/reject", a.HandleRejectDeal())
 				r.Post("/{dealID}/request-changes", a.HandleRequestChanges())
 				r.Post("/{dealID}/cancel", a.HandleCancelDeal())
+				r.Post("/{dealID}/revisions", a.HandleSubmitRevision())
+				r.Get("/{dealID}/revisions", a.HandleListRevisions())
+				r.Get("/{dealID}/revisions/diff", a.HandleDiffRevisions())
+				r.Get("/{dealID}/events", a.HandleListDealEvents())
+				r.Post("/{dealID}/messages", a.HandlePostMessage())
+				r.Get("/{dealID}/messages", a.HandleListMessages())
+				r.Post("/{dealID}/offers", a.HandleCounterOffer())
+				r.Get("/{dealID}/offers", a.HandleListOffers())
+				r.Post("/{dealID}/offers/accept", a.HandleAcceptOffer())
+				r.Post("/{dealID}/offers/decline", a.HandleDeclineOffer())
+				r.Post("/{dealID}/reschedules", a.HandleRequestReschedule())
+				r.Get("/{dealID}/reschedules", a.HandleListReschedules())
+				r.Post("/{dealID}/reschedules/accept", a.HandleAcceptReschedule())
+				r.Post("/{dealID}/reschedules/decline", a.HandleDeclineReschedule())
+				r.Get("/{dealID}/report", a.HandleGetDealReport())
+				r.Post("/{dealID}/ratings", a.HandleRateDeal())
+				r.Get("/{dealID}/ratings", a.HandleListRatings())
+				r.Get("/{dealID}/dispute", a.HandleGetDispute())
+				r.Post("/{dealID}/dispute/evidence", a.HandleSubmitEvidence())
+				r.Post("/{dealID}/dispute/resolve", a.HandleResolveDispute())
+				r.Get("/{dealID}/ledger", a.HandleGetDealLedger())
+			})
+
+			r.Get("/disputes", a.HandleListDisputes())
+			r.Get("/ledger/balances", a.HandleGetBalances())
+
+			r.Route("/fees", func(r chi.Router) {
+				r.Put("/channels/{TgChannelID}", a.HandleSetChannelFee())
+				r.Get("/promotions", a.HandleListPromotions())
+				r.Post("/promotions", a.HandleCreatePromotion())
+				r.Delete("/promotions/{promotionID}", a.HandleDeletePromotion())
+			})
+
+			r.Route("/campaigns", func(r chi.Router) {
+				r.Post("/", a.HandleCreateCampaign())
+				r.Get("/", a.HandleListCampaigns())
+				r.Get("/{campaignID}", a.HandleGetCampaign())
 			})
 		})
 	})
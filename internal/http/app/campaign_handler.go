@@ -0,0 +1,137 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/http/bind"
+	"github.com/bpva/ad-marketplace/internal/http/respond"
+	"github.com/bpva/ad-marketplace/internal/logx"
+	"github.com/bpva/ad-marketplace/internal/service/campaign"
+	"github.com/bpva/ad-marketplace/internal/service/deal"
+)
+
+// HandleCreateCampaign places one template in several channels at once
+//
+//	@Summary		Create campaign
+//	@Tags			campaigns
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		dto.CreateCampaignRequest	true	"Campaign"
+//	@Success		201		{object}	dto.CampaignResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ErrorResponse
+//	@Router			/campaigns [post]
+func (a *App) HandleCreateCampaign() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/campaigns"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req dto.CreateCampaignRequest
+		if err := bind.JSON(r, &req); err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		templatePostID, err := uuid.Parse(req.TemplatePostID)
+		if err != nil {
+			respond.Err(w, log, dto.ErrBadRequest)
+			return
+		}
+
+		targets := make([]deal.CreateDealParams, len(req.Targets))
+		for i, t := range req.Targets {
+			targets[i] = deal.CreateDealParams{
+				TgChannelID:  t.TgChannelID,
+				FormatType:   t.FormatType,
+				IsNative:     t.IsNative,
+				FeedHours:    t.FeedHours,
+				TopHours:     t.TopHours,
+				PriceNanoTON: t.PriceNanoTON,
+				ScheduledAt:  t.ScheduledAt,
+				Offer:        t.Offer,
+			}
+		}
+
+		item, err := a.campaign.CreateCampaign(r.Context(), campaign.CreateCampaignParams{
+			Name:           req.Name,
+			TemplatePostID: templatePostID,
+			BudgetNanoTON:  req.BudgetNanoTON,
+			Targets:        targets,
+		})
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		respond.Created(w, dto.CampaignResponseFrom(item))
+	}
+}
+
+// HandleListCampaigns lists the caller's campaigns with their totals
+//
+//	@Summary		List campaigns
+//	@Tags			campaigns
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.CampaignsResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Router			/campaigns [get]
+func (a *App) HandleListCampaigns() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/campaigns"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		items, err := a.campaign.ListCampaigns(r.Context())
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		list := make([]dto.CampaignResponse, len(items))
+		for i := range items {
+			list[i] = dto.CampaignResponseFrom(&items[i])
+		}
+		respond.OK(w, dto.CampaignsResponse{Campaigns: list})
+	}
+}
+
+// HandleGetCampaign returns a campaign with its deals
+//
+//	@Summary		Get campaign
+//	@Tags			campaigns
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			campaignID	path		string	true	"Campaign ID"
+//	@Success		200			{object}	dto.CampaignResponse
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Router			/campaigns/{campaignID} [get]
+func (a *App) HandleGetCampaign() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/campaigns/{campaignID}"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		campaignID, err := uuid.Parse(chi.URLParam(r, "campaignID"))
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidCampaignID)
+			return
+		}
+
+		item, err := a.campaign.GetCampaign(r.Context(), campaignID)
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		respond.OK(w, dto.CampaignResponseFrom(item))
+	}
+}
@@ -3,6 +3,7 @@ package app
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -283,6 +284,139 @@ func (a *App) HandleUpdateListing() http.HandlerFunc {
 	}
 }
 
+// HandleUpdateReviewTimeout sets how long deals may wait in review
+//
+//	@Summary		Update channel review timeout
+//	@Tags			channels
+//	@Accept			json
+//	@Security		BearerAuth
+//	@Param		TgChannelID	path	int	true	"Telegram channel ID"
+//	@Param		request	body	dto.UpdateReviewTimeoutRequest	true	"Review timeout"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Router			/channels/{TgChannelID}/review-timeout [patch]
+func (a *App) HandleUpdateReviewTimeout() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/channels/{TgChannelID}/review-timeout"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		TgChannelID, err := strconv.ParseInt(chi.URLParam(r, "TgChannelID"), 10, 64)
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidChannelID)
+			return
+		}
+
+		var req dto.UpdateReviewTimeoutRequest
+		if err := bind.JSON(r, &req); err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		if err := a.channel.UpdateReviewTimeout(r.Context(), TgChannelID, req.Hours); err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		respond.NoContent(w)
+	}
+}
+
+// HandleGetAvailability returns the channel's booking calendar
+//
+//	@Summary		Get channel availability
+//	@Tags			channels
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			TgChannelID	path		int		true	"Telegram channel ID"
+//	@Param			from		query		string	false	"First day, YYYY-MM-DD"
+//	@Param			days		query		int		false	"Number of days"	default(14)
+//	@Success		200			{object}	dto.ChannelAvailabilityResponse
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Router			/channels/{TgChannelID}/availability [get]
+func (a *App) HandleGetAvailability() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/channels/{TgChannelID}/availability"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		TgChannelID, err := strconv.ParseInt(chi.URLParam(r, "TgChannelID"), 10, 64)
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidChannelID)
+			return
+		}
+
+		from := time.Now()
+		if f := r.URL.Query().Get("from"); f != "" {
+			from, err = time.Parse(time.DateOnly, f)
+			if err != nil {
+				respond.Err(w, log, dto.ErrValidation.WithDetails(
+					map[string]any{"from": "must be YYYY-MM-DD"},
+				))
+				return
+			}
+		}
+
+		const maxDays = 62
+		days := 14
+		if d := r.URL.Query().Get("days"); d != "" {
+			parsed, err := strconv.Atoi(d)
+			if err == nil && parsed > 0 {
+				days = min(parsed, maxDays)
+			}
+		}
+
+		resp, err := a.channel.GetAvailability(r.Context(), TgChannelID, from, days)
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		respond.OK(w, resp)
+	}
+}
+
+// HandleUpdateAvailability replaces the channel's inventory
+//
+//	@Summary		Update channel availability
+//	@Tags			channels
+//	@Accept			json
+//	@Security		BearerAuth
+//	@Param		TgChannelID	path	int	true	"Telegram channel ID"
+//	@Param		request	body	dto.UpdateAvailabilityRequest	true	"Inventory"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Router			/channels/{TgChannelID}/availability [put]
+func (a *App) HandleUpdateAvailability() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/channels/{TgChannelID}/availability"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		TgChannelID, err := strconv.ParseInt(chi.URLParam(r, "TgChannelID"), 10, 64)
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidChannelID)
+			return
+		}
+
+		var req dto.UpdateAvailabilityRequest
+		if err := bind.JSON(r, &req); err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		if err := a.channel.UpdateAvailability(r.Context(), TgChannelID, req); err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		respond.NoContent(w)
+	}
+}
+
 // HandleGetAdFormats returns channel ad formats
 //
 //	@Summary		Get channel ad formats
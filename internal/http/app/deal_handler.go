@@ -4,12 +4,16 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 
 	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
 	"github.com/bpva/ad-marketplace/internal/http/bind"
 	"github.com/bpva/ad-marketplace/internal/http/respond"
 	"github.com/bpva/ad-marketplace/internal/logx"
@@ -57,13 +61,14 @@ func (a *App) HandleCreateDeal() http.HandlerFunc {
 			PriceNanoTON:   req.PriceNanoTON,
 			TemplatePostID: templatePostID,
 			ScheduledAt:    req.ScheduledAt,
+			Offer:          req.Offer,
 		})
 		if err != nil {
 			respond.Err(w, log, err)
 			return
 		}
 
-		respond.Created(w, dto.DealResponseFrom(d, posts, req.TgChannelID))
+		respond.Created(w, dto.DealResponseFrom(d, posts, req.TgChannelID, nil))
 	}
 }
 
@@ -73,73 +78,198 @@ func (a *App) HandleCreateDeal() http.HandlerFunc {
 //	@Tags			deals
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			role		query	string	false	"Role"		default(advertiser)
-//	@Param			channel_id	query	int		false	"Channel ID"
-//	@Param			page		query	int		false	"Page number"	default(1)
-//	@Success		200			{object}	dto.DealsResponse
-//	@Failure		400			{object}	dto.ErrorResponse
-//	@Failure		401			{object}	dto.ErrorResponse
-//	@Failure		403			{object}	dto.ErrorResponse
+//	@Param			role			query	string	false	"Role"	default(advertiser)
+//	@Param			channel_id		query	int		false	"Channel ID"
+//	@Param			advertiser_id	query	int		false	"Advertiser Telegram ID"
+//	@Param			status			query	string	false	"Comma-separated statuses"
+//	@Param			scheduled_from	query	string	false	"Scheduled from (RFC 3339)"
+//	@Param			scheduled_to	query	string	false	"Scheduled to (RFC 3339)"
+//	@Param			format_type		query	string	false	"Ad format"
+//	@Param			sort_by			query	string	false	"Sort key"	Enums(created_at, scheduled_at, price)	default(created_at)
+//	@Param			sort_order		query	string	false	"Sort order"	Enums(asc, desc)	default(desc)
+//	@Param			cursor			query	string	false	"next_cursor of the previous page"
+//	@Param			limit			query	int		false	"Page size"	default(20)	maximum(100)
+//	@Param			include_total	query	bool	false	"Count matching deals across all pages"
+//	@Param			page			query	int		false	"Deprecated: page number, use cursor"
+//	@Success		200				{object}	dto.DealsResponse
+//	@Failure		400				{object}	dto.ErrorResponse
+//	@Failure		401				{object}	dto.ErrorResponse
+//	@Failure		403				{object}	dto.ErrorResponse
 //	@Router			/deals [get]
 func (a *App) HandleListDeals() http.HandlerFunc {
 	log := a.log.With(logx.Handler("/api/v1/deals"))
 
 	return func(w http.ResponseWriter, r *http.Request) {
-		role := r.URL.Query().Get("role")
+		query := r.URL.Query()
+		role := query.Get("role")
 		if role == "" {
 			role = "advertiser"
 		}
 
-		page := 1
-		if p := r.URL.Query().Get("page"); p != "" {
-			parsed, err := strconv.Atoi(p)
-			if err == nil && parsed > 0 {
-				page = parsed
-			}
+		f, err := parseDealFilter(query)
+		if err != nil {
+			respond.Err(w, log, err)
+			return
 		}
+		limit := f.Limit
+		// one more than asked tells whether there is a next page
+		f.Limit++
 
-		const pageSize = 20
-		offset := (page - 1) * pageSize
+		var items []dto.DealListItem
+		var total int
 
 		switch role {
 		case "advertiser":
-			items, total, err := a.deal.ListAdvertiserDeals(r.Context(), pageSize, offset)
-			if err != nil {
-				respond.Err(w, log, err)
-				return
+			if raw := query.Get("channel_id"); raw != "" {
+				tgChannelID, err := strconv.ParseInt(raw, 10, 64)
+				if err != nil {
+					respond.Err(w, log, dto.ErrInvalidChannelID)
+					return
+				}
+				f.TgChannelID = &tgChannelID
 			}
-			respond.OK(w, buildDealsResponse(items, total))
+			items, total, err = a.deal.ListAdvertiserDeals(r.Context(), f)
 
 		case "publisher":
-			tgChannelID, err := strconv.ParseInt(r.URL.Query().Get("channel_id"), 10, 64)
+			var tgChannelID int64
+			tgChannelID, err = strconv.ParseInt(query.Get("channel_id"), 10, 64)
 			if err != nil {
 				respond.Err(w, log, dto.ErrInvalidChannelID)
 				return
 			}
-			items, total, err := a.deal.ListPublisherDeals(
-				r.Context(),
-				tgChannelID,
-				pageSize,
-				offset,
-			)
-			if err != nil {
-				respond.Err(w, log, err)
-				return
+			if raw := query.Get("advertiser_id"); raw != "" {
+				tgID, err := strconv.ParseInt(raw, 10, 64)
+				if err != nil {
+					respond.Err(w, log, dto.ErrInvalidTelegramID)
+					return
+				}
+				f.AdvertiserTgID = &tgID
 			}
-			respond.OK(w, buildDealsResponse(items, total))
+			items, total, err = a.deal.ListPublisherDeals(r.Context(), tgChannelID, f)
 
 		default:
 			respond.Err(w, log, dto.ErrInvalidRole)
+			return
+		}
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		resp := buildDealsResponse(items)
+		if f.WithTotal {
+			resp.Total = &total
+		}
+		if len(items) > limit {
+			resp.Deals = resp.Deals[:limit]
+			resp.NextCursor = dto.EncodeDealCursor(entity.DealCursorFrom(&items[limit-1].Deal))
+		}
+		respond.OK(w, resp)
+	}
+}
+
+// parseDealFilter reads the deal list filters, sort and page from the query;
+// the counterparty is left to the caller since it depends on the role.
+func parseDealFilter(query url.Values) (entity.DealFilter, error) {
+	const defaultLimit, maxLimit = 20, 100
+	f := entity.DealFilter{
+		Sort:  entity.DealSort{By: entity.DealSortByCreatedAt, Order: entity.SortOrderDesc},
+		Limit: defaultLimit,
+	}
+	invalid := func(name, reason string) error {
+		return dto.ErrValidation.WithDetails(map[string]any{name: reason})
+	}
+
+	if raw := query.Get("status"); raw != "" {
+		for _, status := range strings.Split(raw, ",") {
+			f.Statuses = append(f.Statuses, entity.DealStatus(strings.TrimSpace(status)))
+		}
+	}
+
+	for name, dst := range map[string]**time.Time{
+		"scheduled_from": &f.ScheduledFrom,
+		"scheduled_to":   &f.ScheduledTo,
+	} {
+		raw := query.Get(name)
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return f, invalid(name, "must be an RFC 3339 timestamp")
+		}
+		*dst = &t
+	}
+
+	if raw := query.Get("format_type"); raw != "" {
+		format := entity.AdFormatType(raw)
+		f.FormatType = &format
+	}
+
+	if raw := query.Get("sort_by"); raw != "" {
+		switch by := entity.DealSortBy(raw); by {
+		case entity.DealSortByCreatedAt, entity.DealSortByScheduledAt, entity.DealSortByPrice:
+			f.Sort.By = by
+		default:
+			return f, invalid("sort_by", "must be created_at, scheduled_at or price")
+		}
+	}
+	if raw := query.Get("sort_order"); raw != "" {
+		switch order := entity.SortOrder(raw); order {
+		case entity.SortOrderAsc, entity.SortOrderDesc:
+			f.Sort.Order = order
+		default:
+			return f, invalid("sort_order", "must be asc or desc")
+		}
+	}
+
+	if raw := query.Get("cursor"); raw != "" {
+		after, err := dto.DecodeDealCursor(raw)
+		if err != nil {
+			return f, invalid("cursor", "must be a next_cursor from a previous page")
+		}
+		f.After = after
+	}
+
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			return f, invalid("limit", "must be a positive number")
+		}
+		f.Limit = min(limit, maxLimit)
+	}
+
+	if raw := query.Get("include_total"); raw != "" {
+		withTotal, err := strconv.ParseBool(raw)
+		if err != nil {
+			return f, invalid("include_total", "must be true or false")
 		}
+		f.WithTotal = withTotal
 	}
+
+	// page numbers predate cursors; clients still using them get the total
+	// they page by, as before
+	if raw := query.Get("page"); raw != "" {
+		page, err := strconv.Atoi(raw)
+		if err != nil || page < 1 {
+			return f, invalid("page", "must be a positive number")
+		}
+		if f.After != nil {
+			return f, invalid("page", "cannot be combined with cursor")
+		}
+		f.Offset = (page - 1) * f.Limit
+		f.WithTotal = true
+	}
+
+	return f, nil
 }
 
-func buildDealsResponse(items []dto.DealListItem, total int) dto.DealsResponse {
+func buildDealsResponse(items []dto.DealListItem) dto.DealsResponse {
 	deals := make([]dto.DealResponse, len(items))
 	for i := range items {
 		deals[i] = dto.DealListResponseFrom(items[i])
 	}
-	return dto.DealsResponse{Deals: deals, Total: total}
+	return dto.DealsResponse{Deals: deals}
 }
 
 // HandleGetDeal returns deal details
@@ -165,22 +295,24 @@ func (a *App) HandleGetDeal() http.HandlerFunc {
 			return
 		}
 
-		d, posts, tgChannelID, err := a.deal.GetDeal(r.Context(), dealID)
+		d, posts, tgChannelID, transfers, err := a.deal.GetDeal(r.Context(), dealID)
 		if err != nil {
 			respond.Err(w, log, err)
 			return
 		}
 
-		respond.OK(w, dto.DealResponseFrom(d, posts, tgChannelID))
+		respond.OK(w, dto.DealResponseFrom(d, posts, tgChannelID, transfers))
 	}
 }
 
-// HandleApproveDeal approves a deal
+// HandleApproveDeal confirms the latest ad version for the caller's side of a deal
 //
 //	@Summary		Approve deal
 //	@Tags			deals
+//	@Accept			json
 //	@Security		BearerAuth
-//	@Param			dealID	path	string	true	"Deal ID"
+//	@Param			dealID	path	string				true	"Deal ID"
+//	@Param			request	body	dto.ApproveRequest	false	"Version being approved"
 //	@Success		204
 //	@Failure		400	{object}	dto.ErrorResponse
 //	@Failure		401	{object}	dto.ErrorResponse
@@ -197,7 +329,13 @@ func (a *App) HandleApproveDeal() http.HandlerFunc {
 			return
 		}
 
-		if err := a.deal.Approve(r.Context(), dealID); err != nil {
+		var req dto.ApproveRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+			respond.Err(w, log, dto.ErrBadRequest)
+			return
+		}
+
+		if err := a.deal.Approve(r.Context(), dealID, req.Version); err != nil {
 			respond.Err(w, log, err)
 			return
 		}
@@ -314,3 +452,676 @@ func (a *App) HandleCancelDeal() http.HandlerFunc {
 		respond.NoContent(w)
 	}
 }
+
+// HandleSubmitRevision submits a new ad version from either side of a deal
+//
+//	@Summary		Submit ad revision
+//	@Tags			deals
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			dealID	path		string						true	"Deal ID"
+//	@Param			request	body		dto.SubmitRevisionRequest	true	"New creative"
+//	@Success		201		{object}	dto.RevisionResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Router			/deals/{dealID}/revisions [post]
+func (a *App) HandleSubmitRevision() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/deals/{dealID}/revisions"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		dealID, err := uuid.Parse(chi.URLParam(r, "dealID"))
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidDealID)
+			return
+		}
+
+		var req dto.SubmitRevisionRequest
+		if err := bind.JSON(r, &req); err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		params := deal.RevisionParams{Text: req.Text, Entities: req.Entities}
+		if req.TemplatePostID != nil {
+			templatePostID, err := uuid.Parse(*req.TemplatePostID)
+			if err != nil {
+				respond.Err(w, log, dto.ErrBadRequest)
+				return
+			}
+			params.TemplatePostID = &templatePostID
+		}
+
+		rev, err := a.deal.SubmitRevision(r.Context(), dealID, params)
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		respond.Created(w, dto.RevisionResponseFrom(*rev))
+	}
+}
+
+// HandleListRevisions returns the ad version history of a deal
+//
+//	@Summary		List ad revisions
+//	@Tags			deals
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			dealID	path		string	true	"Deal ID"
+//	@Success		200		{object}	dto.RevisionsResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Router			/deals/{dealID}/revisions [get]
+func (a *App) HandleListRevisions() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/deals/{dealID}/revisions"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		dealID, err := uuid.Parse(chi.URLParam(r, "dealID"))
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidDealID)
+			return
+		}
+
+		items, err := a.deal.GetRevisions(r.Context(), dealID)
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		revisions := make([]dto.RevisionResponse, len(items))
+		for i := range items {
+			revisions[i] = dto.RevisionResponseFrom(items[i])
+		}
+		respond.OK(w, dto.RevisionsResponse{Revisions: revisions})
+	}
+}
+
+// HandleDiffRevisions compares two ad versions of a deal
+//
+//	@Summary		Diff ad revisions
+//	@Tags			deals
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			dealID	path		string	true	"Deal ID"
+//	@Param			from	query		int		false	"Older version (default: to - 1)"
+//	@Param			to		query		int		false	"Newer version (default: latest)"
+//	@Success		200		{object}	dto.RevisionDiffResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Router			/deals/{dealID}/revisions/diff [get]
+func (a *App) HandleDiffRevisions() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/deals/{dealID}/revisions/diff"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		dealID, err := uuid.Parse(chi.URLParam(r, "dealID"))
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidDealID)
+			return
+		}
+
+		versions := make(map[string]int, 2)
+		for _, name := range []string{"from", "to"} {
+			raw := r.URL.Query().Get(name)
+			if raw == "" {
+				continue
+			}
+			v, err := strconv.Atoi(raw)
+			if err != nil || v < 1 {
+				respond.Err(w, log, dto.ErrValidation.WithDetails(map[string]any{
+					name: "must be a positive version number",
+				}))
+				return
+			}
+			versions[name] = v
+		}
+
+		diff, err := a.deal.DiffRevisions(r.Context(), dealID, versions["from"], versions["to"])
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		respond.OK(w, diff)
+	}
+}
+
+// HandleListDealEvents returns the timeline of a deal
+//
+//	@Summary		List deal events
+//	@Tags			deals
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			dealID	path		string	true	"Deal ID"
+//	@Success		200		{object}	dto.DealEventsResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Router			/deals/{dealID}/events [get]
+func (a *App) HandleListDealEvents() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/deals/{dealID}/events"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		dealID, err := uuid.Parse(chi.URLParam(r, "dealID"))
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidDealID)
+			return
+		}
+
+		items, err := a.deal.GetEvents(r.Context(), dealID)
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		events := make([]dto.DealEventResponse, len(items))
+		for i := range items {
+			events[i] = dto.DealEventResponseFrom(items[i])
+		}
+		respond.OK(w, dto.DealEventsResponse{Events: events})
+	}
+}
+
+// HandlePostMessage adds a message to the thread of a deal
+//
+//	@Summary		Post deal message
+//	@Tags			deals
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			dealID	path		string					true	"Deal ID"
+//	@Param			request	body		dto.PostMessageRequest	true	"Message"
+//	@Success		201		{object}	dto.DealMessageResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Router			/deals/{dealID}/messages [post]
+func (a *App) HandlePostMessage() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/deals/{dealID}/messages"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		dealID, err := uuid.Parse(chi.URLParam(r, "dealID"))
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidDealID)
+			return
+		}
+
+		var req dto.PostMessageRequest
+		if err := bind.JSON(r, &req); err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		msg, err := a.deal.PostMessage(r.Context(), dealID, req.Text)
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		respond.Created(w, dto.DealMessageResponseFrom(*msg))
+	}
+}
+
+// HandleListMessages returns the message thread of a deal
+//
+//	@Summary		List deal messages
+//	@Tags			deals
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			dealID	path		string	true	"Deal ID"
+//	@Success		200		{object}	dto.DealMessagesResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Router			/deals/{dealID}/messages [get]
+func (a *App) HandleListMessages() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/deals/{dealID}/messages"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		dealID, err := uuid.Parse(chi.URLParam(r, "dealID"))
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidDealID)
+			return
+		}
+
+		items, err := a.deal.GetMessages(r.Context(), dealID)
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		msgs := make([]dto.DealMessageResponse, len(items))
+		for i := range items {
+			msgs[i] = dto.DealMessageResponseFrom(items[i])
+		}
+		respond.OK(w, dto.DealMessagesResponse{Messages: msgs})
+	}
+}
+
+// HandleCounterOffer answers the pending offer on a deal with new terms
+//
+//	@Summary		Counter deal offer
+//	@Tags			deals
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			dealID	path		string					true	"Deal ID"
+//	@Param			request	body		dto.CounterOfferRequest	true	"Proposed terms"
+//	@Success		201		{object}	dto.DealOfferResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Router			/deals/{dealID}/offers [post]
+func (a *App) HandleCounterOffer() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/deals/{dealID}/offers"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		dealID, err := uuid.Parse(chi.URLParam(r, "dealID"))
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidDealID)
+			return
+		}
+
+		var req dto.CounterOfferRequest
+		if err := bind.JSON(r, &req); err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		offer, err := a.deal.CounterOffer(r.Context(), dealID, deal.OfferParams{
+			PriceNanoTON: req.PriceNanoTON,
+			ScheduledAt:  req.ScheduledAt,
+		})
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		respond.Created(w, dto.DealOfferResponseFrom(*offer))
+	}
+}
+
+// HandleAcceptOffer accepts the pending offer on a deal. On a campaign deal
+// the price must fit what is left of the campaign budget.
+//
+//	@Summary		Accept deal offer
+//	@Tags			deals
+//	@Security		BearerAuth
+//	@Param			dealID	path	string	true	"Deal ID"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		422	{object}	dto.ErrorResponse
+//	@Router			/deals/{dealID}/offers/accept [post]
+func (a *App) HandleAcceptOffer() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/deals/{dealID}/offers/accept"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		dealID, err := uuid.Parse(chi.URLParam(r, "dealID"))
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidDealID)
+			return
+		}
+
+		if err := a.deal.AcceptOffer(r.Context(), dealID); err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		respond.NoContent(w)
+	}
+}
+
+// HandleDeclineOffer declines the pending offer on a deal, closing it
+//
+//	@Summary		Decline deal offer
+//	@Tags			deals
+//	@Accept			json
+//	@Security		BearerAuth
+//	@Param			dealID	path	string					true	"Deal ID"
+//	@Param			request	body	dto.DeclineOfferRequest	false	"Reason"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Router			/deals/{dealID}/offers/decline [post]
+func (a *App) HandleDeclineOffer() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/deals/{dealID}/offers/decline"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		dealID, err := uuid.Parse(chi.URLParam(r, "dealID"))
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidDealID)
+			return
+		}
+
+		var req dto.DeclineOfferRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+			respond.Err(w, log, dto.ErrBadRequest)
+			return
+		}
+
+		if err := a.deal.DeclineOffer(r.Context(), dealID, req.Reason); err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		respond.NoContent(w)
+	}
+}
+
+// HandleListOffers returns the offers made on a deal
+//
+//	@Summary		List deal offers
+//	@Tags			deals
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			dealID	path		string	true	"Deal ID"
+//	@Success		200		{object}	dto.DealOffersResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Router			/deals/{dealID}/offers [get]
+func (a *App) HandleListOffers() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/deals/{dealID}/offers"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		dealID, err := uuid.Parse(chi.URLParam(r, "dealID"))
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidDealID)
+			return
+		}
+
+		items, err := a.deal.GetOffers(r.Context(), dealID)
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		offers := make([]dto.DealOfferResponse, len(items))
+		for i := range items {
+			offers[i] = dto.DealOfferResponseFrom(items[i])
+		}
+		respond.OK(w, dto.DealOffersResponse{Offers: offers})
+	}
+}
+
+// HandleRequestReschedule proposes a new posting time for a deal
+//
+//	@Summary		Request deal reschedule
+//	@Tags			deals
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			dealID	path		string					true	"Deal ID"
+//	@Param			request	body		dto.RescheduleRequest	true	"New posting time"
+//	@Success		201		{object}	dto.DealRescheduleResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Router			/deals/{dealID}/reschedules [post]
+func (a *App) HandleRequestReschedule() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/deals/{dealID}/reschedules"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		dealID, err := uuid.Parse(chi.URLParam(r, "dealID"))
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidDealID)
+			return
+		}
+
+		var req dto.RescheduleRequest
+		if err := bind.JSON(r, &req); err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		item, err := a.deal.RequestReschedule(r.Context(), dealID, req.ScheduledAt)
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		respond.Created(w, dto.DealRescheduleResponseFrom(*item))
+	}
+}
+
+// HandleAcceptReschedule moves a deal to the pending proposed posting time
+//
+//	@Summary		Accept deal reschedule
+//	@Tags			deals
+//	@Security		BearerAuth
+//	@Param			dealID	path	string	true	"Deal ID"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		409	{object}	dto.ErrorResponse
+//	@Router			/deals/{dealID}/reschedules/accept [post]
+func (a *App) HandleAcceptReschedule() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/deals/{dealID}/reschedules/accept"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		dealID, err := uuid.Parse(chi.URLParam(r, "dealID"))
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidDealID)
+			return
+		}
+
+		if err := a.deal.AcceptReschedule(r.Context(), dealID); err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		respond.NoContent(w)
+	}
+}
+
+// HandleDeclineReschedule declines the pending proposed posting time
+//
+//	@Summary		Decline deal reschedule
+//	@Tags			deals
+//	@Accept			json
+//	@Security		BearerAuth
+//	@Param			dealID	path	string							true	"Deal ID"
+//	@Param			request	body	dto.DeclineRescheduleRequest	false	"Reason"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Router			/deals/{dealID}/reschedules/decline [post]
+func (a *App) HandleDeclineReschedule() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/deals/{dealID}/reschedules/decline"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		dealID, err := uuid.Parse(chi.URLParam(r, "dealID"))
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidDealID)
+			return
+		}
+
+		var req dto.DeclineRescheduleRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+			respond.Err(w, log, dto.ErrBadRequest)
+			return
+		}
+
+		if err := a.deal.DeclineReschedule(r.Context(), dealID, req.Reason); err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		respond.NoContent(w)
+	}
+}
+
+// HandleListReschedules returns the posting times proposed on a deal
+//
+//	@Summary		List deal reschedules
+//	@Tags			deals
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			dealID	path		string	true	"Deal ID"
+//	@Success		200		{object}	dto.DealReschedulesResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Router			/deals/{dealID}/reschedules [get]
+func (a *App) HandleListReschedules() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/deals/{dealID}/reschedules"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		dealID, err := uuid.Parse(chi.URLParam(r, "dealID"))
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidDealID)
+			return
+		}
+
+		items, err := a.deal.GetReschedules(r.Context(), dealID)
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		list := make([]dto.DealRescheduleResponse, len(items))
+		for i := range items {
+			list[i] = dto.DealRescheduleResponseFrom(items[i])
+		}
+		respond.OK(w, dto.DealReschedulesResponse{Reschedules: list})
+	}
+}
+
+// HandleGetDealReport returns how the ad of a posted deal performed
+//
+//	@Summary		Get deal report
+//	@Tags			deals
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			dealID	path		string	true	"Deal ID"
+//	@Success		200		{object}	dto.DealReportResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Router			/deals/{dealID}/report [get]
+func (a *App) HandleGetDealReport() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/deals/{dealID}/report"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		dealID, err := uuid.Parse(chi.URLParam(r, "dealID"))
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidDealID)
+			return
+		}
+
+		report, err := a.deal.GetReport(r.Context(), dealID)
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		respond.OK(w, report)
+	}
+}
+
+// HandleRateDeal rates the other side of a finished deal
+//
+//	@Summary		Rate deal
+//	@Tags			deals
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			dealID	path		string				true	"Deal ID"
+//	@Param			request	body		dto.RateDealRequest	true	"Rating"
+//	@Success		201		{object}	dto.DealRatingResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Router			/deals/{dealID}/ratings [post]
+func (a *App) HandleRateDeal() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/deals/{dealID}/ratings"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		dealID, err := uuid.Parse(chi.URLParam(r, "dealID"))
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidDealID)
+			return
+		}
+
+		var req dto.RateDealRequest
+		if err := bind.JSON(r, &req); err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		item, err := a.deal.RateDeal(r.Context(), dealID, deal.RatingParams{
+			Rating:  req.Rating,
+			Comment: req.Comment,
+		})
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		respond.Created(w, dto.DealRatingResponseFrom(*item))
+	}
+}
+
+// HandleListRatings returns the ratings left on a deal
+//
+//	@Summary		List deal ratings
+//	@Tags			deals
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			dealID	path		string	true	"Deal ID"
+//	@Success		200		{object}	dto.DealRatingsResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Router			/deals/{dealID}/ratings [get]
+func (a *App) HandleListRatings() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/deals/{dealID}/ratings"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		dealID, err := uuid.Parse(chi.URLParam(r, "dealID"))
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidDealID)
+			return
+		}
+
+		items, err := a.deal.GetRatings(r.Context(), dealID)
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		list := make([]dto.DealRatingResponse, len(items))
+		for i := range items {
+			list[i] = dto.DealRatingResponseFrom(items[i])
+		}
+		respond.OK(w, dto.DealRatingsResponse{Ratings: list})
+	}
+}
@@ -0,0 +1,164 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/http/bind"
+	"github.com/bpva/ad-marketplace/internal/http/respond"
+	"github.com/bpva/ad-marketplace/internal/logx"
+	"github.com/bpva/ad-marketplace/internal/service/deal"
+)
+
+// HandleGetDispute returns the dispute of a deal with its evidence
+//
+//	@Summary		Get deal dispute
+//	@Tags			disputes
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			dealID	path		string	true	"Deal ID"
+//	@Success		200		{object}	dto.DisputeResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Router			/deals/{dealID}/dispute [get]
+func (a *App) HandleGetDispute() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/deals/{dealID}/dispute"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		dealID, err := uuid.Parse(chi.URLParam(r, "dealID"))
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidDealID)
+			return
+		}
+
+		item, err := a.deal.GetDispute(r.Context(), dealID)
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		respond.OK(w, dto.DisputeResponseFrom(item))
+	}
+}
+
+// HandleSubmitEvidence adds evidence to the open dispute of a deal
+//
+//	@Summary		Submit dispute evidence
+//	@Tags			disputes
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			dealID	path		string						true	"Deal ID"
+//	@Param			request	body		dto.SubmitEvidenceRequest	true	"Evidence"
+//	@Success		201		{object}	dto.DisputeEvidenceResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Router			/deals/{dealID}/dispute/evidence [post]
+func (a *App) HandleSubmitEvidence() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/deals/{dealID}/dispute/evidence"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		dealID, err := uuid.Parse(chi.URLParam(r, "dealID"))
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidDealID)
+			return
+		}
+
+		var req dto.SubmitEvidenceRequest
+		if err := bind.JSON(r, &req); err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		item, err := a.deal.SubmitEvidence(r.Context(), dealID, deal.EvidenceParams{
+			Text:        req.Text,
+			FileID:      req.FileID,
+			MessageLink: req.MessageLink,
+		})
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		respond.Created(w, dto.DisputeEvidenceResponseFrom(*item))
+	}
+}
+
+// HandleResolveDispute rules on the open dispute of a deal, arbiters only
+//
+//	@Summary		Resolve deal dispute
+//	@Tags			disputes
+//	@Accept			json
+//	@Security		BearerAuth
+//	@Param			dealID	path	string						true	"Deal ID"
+//	@Param			request	body	dto.ResolveDisputeRequest	true	"Ruling"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		409	{object}	dto.ErrorResponse
+//	@Router			/deals/{dealID}/dispute/resolve [post]
+func (a *App) HandleResolveDispute() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/deals/{dealID}/dispute/resolve"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		dealID, err := uuid.Parse(chi.URLParam(r, "dealID"))
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidDealID)
+			return
+		}
+
+		var req dto.ResolveDisputeRequest
+		if err := bind.JSON(r, &req); err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		if err := a.deal.ResolveDispute(r.Context(), dealID, deal.ResolutionParams{
+			Outcome:               req.Outcome,
+			PublisherShareNanoTON: req.PublisherShareNanoTON,
+			Note:                  req.Note,
+		}); err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		respond.NoContent(w)
+	}
+}
+
+// HandleListDisputes returns the disputes awaiting a ruling, arbiters only
+//
+//	@Summary		List open disputes
+//	@Tags			disputes
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.DisputesResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Router			/disputes [get]
+func (a *App) HandleListDisputes() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/disputes"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		items, err := a.deal.ListDisputes(r.Context())
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		list := make([]dto.DisputeResponse, len(items))
+		for i := range items {
+			list[i] = dto.DisputeResponseFrom(&items[i])
+		}
+		respond.OK(w, dto.DisputesResponse{Disputes: list})
+	}
+}
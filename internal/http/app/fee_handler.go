@@ -0,0 +1,152 @@
+package app
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/http/bind"
+	"github.com/bpva/ad-marketplace/internal/http/respond"
+	"github.com/bpva/ad-marketplace/internal/logx"
+	"github.com/bpva/ad-marketplace/internal/service/deal"
+)
+
+// HandleSetChannelFee overrides the platform fee on a channel's new deals, admins only
+//
+//	@Summary		Set channel platform fee
+//	@Tags			fees
+//	@Accept			json
+//	@Security		BearerAuth
+//	@Param			TgChannelID	path	int							true	"Telegram channel ID"
+//	@Param			request		body	dto.SetChannelFeeRequest	true	"Platform fee"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Router			/fees/channels/{TgChannelID} [put]
+func (a *App) HandleSetChannelFee() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/fees/channels/{TgChannelID}"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		tgChannelID, err := strconv.ParseInt(chi.URLParam(r, "TgChannelID"), 10, 64)
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidChannelID)
+			return
+		}
+
+		var req dto.SetChannelFeeRequest
+		if err := bind.JSON(r, &req); err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		if err := a.deal.SetChannelFee(r.Context(), tgChannelID, req.PlatformFeeBPS); err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		respond.NoContent(w)
+	}
+}
+
+// HandleListPromotions returns the fee promotions that have not ended, admins only
+//
+//	@Summary		List fee promotions
+//	@Tags			fees
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.PromotionsResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Router			/fees/promotions [get]
+func (a *App) HandleListPromotions() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/fees/promotions"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		promotions, err := a.deal.ListPromotions(r.Context())
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		list := make([]dto.PromotionResponse, len(promotions))
+		for i := range promotions {
+			list[i] = dto.PromotionResponseFrom(&promotions[i])
+		}
+		respond.OK(w, dto.PromotionsResponse{Promotions: list})
+	}
+}
+
+// HandleCreatePromotion starts a zero-fee period, admins only
+//
+//	@Summary		Create fee promotion
+//	@Tags			fees
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		dto.CreatePromotionRequest	true	"Promotion"
+//	@Success		201		{object}	dto.PromotionResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Router			/fees/promotions [post]
+func (a *App) HandleCreatePromotion() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/fees/promotions"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req dto.CreatePromotionRequest
+		if err := bind.JSON(r, &req); err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		promotion, err := a.deal.CreatePromotion(r.Context(), deal.PromotionParams{
+			TgChannelID: req.TgChannelID,
+			StartsAt:    req.StartsAt,
+			EndsAt:      req.EndsAt,
+			Note:        req.Note,
+		})
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		respond.Created(w, dto.PromotionResponseFrom(promotion))
+	}
+}
+
+// HandleDeletePromotion ends a fee promotion, admins only
+//
+//	@Summary		Delete fee promotion
+//	@Tags			fees
+//	@Security		BearerAuth
+//	@Param			promotionID	path	string	true	"Promotion ID"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Router			/fees/promotions/{promotionID} [delete]
+func (a *App) HandleDeletePromotion() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/fees/promotions/{promotionID}"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		promotionID, err := uuid.Parse(chi.URLParam(r, "promotionID"))
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidPromotionID)
+			return
+		}
+
+		if err := a.deal.DeletePromotion(r.Context(), promotionID); err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		respond.NoContent(w)
+	}
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/http/respond"
+	"github.com/bpva/ad-marketplace/internal/logx"
+)
+
+// HandleGetDealLedger returns the ledger entries of a deal
+//
+//	@Summary		Get deal ledger
+//	@Tags			ledger
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			dealID	path		string	true	"Deal ID"
+//	@Success		200		{object}	dto.DealLedgerResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Router			/deals/{dealID}/ledger [get]
+func (a *App) HandleGetDealLedger() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/deals/{dealID}/ledger"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		dealID, err := uuid.Parse(chi.URLParam(r, "dealID"))
+		if err != nil {
+			respond.Err(w, log, dto.ErrInvalidDealID)
+			return
+		}
+
+		entries, err := a.deal.GetLedger(r.Context(), dealID)
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		respond.OK(w, dto.DealLedgerResponseFrom(entries))
+	}
+}
+
+// HandleGetBalances returns the user's ledger balances per account; admins
+// may pass user_id to audit another user
+//
+//	@Summary		Get ledger balances
+//	@Tags			ledger
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			user_id	query		string	false	"User ID, admins only"
+//	@Success		200		{object}	dto.LedgerBalancesResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Router			/ledger/balances [get]
+func (a *App) HandleGetBalances() http.HandlerFunc {
+	log := a.log.With(logx.Handler("/api/v1/ledger/balances"))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		var userID *uuid.UUID
+		if raw := r.URL.Query().Get("user_id"); raw != "" {
+			id, err := uuid.Parse(raw)
+			if err != nil {
+				respond.Err(w, log, dto.ErrInvalidUserID)
+				return
+			}
+			userID = &id
+		}
+
+		balances, err := a.deal.GetBalances(r.Context(), userID)
+		if err != nil {
+			respond.Err(w, log, err)
+			return
+		}
+
+		respond.OK(w, dto.LedgerBalancesResponseFrom(balances))
+	}
+}
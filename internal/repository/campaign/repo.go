@@ -0,0 +1,118 @@
+package campaign
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+const campaignColumns = `id, advertiser_id, name, template_post_id, budget_nano_ton, created_at`
+
+type db interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+type repo struct {
+	db db
+}
+
+func New(db db) *repo {
+	return &repo{db: db}
+}
+
+func (r *repo) Create(ctx context.Context, c *entity.Campaign) (*entity.Campaign, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("creating campaign: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		INSERT INTO campaigns (id, advertiser_id, name, template_post_id, budget_nano_ton)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+campaignColumns,
+		id, c.AdvertiserID, c.Name, c.TemplatePostID, c.BudgetNanoTON)
+	if err != nil {
+		return nil, fmt.Errorf("creating campaign: %w", err)
+	}
+
+	created, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Campaign])
+	if err != nil {
+		return nil, fmt.Errorf("creating campaign: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (r *repo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Campaign, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+campaignColumns+` FROM campaigns WHERE id = $1`, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting campaign by id: %w", err)
+	}
+
+	c, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Campaign])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("getting campaign by id: %w", dto.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting campaign by id: %w", err)
+	}
+
+	return &c, nil
+}
+
+func (r *repo) GetByAdvertiserID(
+	ctx context.Context, advertiserID uuid.UUID,
+) ([]entity.Campaign, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+campaignColumns+`
+		FROM campaigns
+		WHERE advertiser_id = $1
+		ORDER BY created_at DESC
+	`, advertiserID)
+	if err != nil {
+		return nil, fmt.Errorf("getting campaigns by advertiser id: %w", err)
+	}
+
+	campaigns, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Campaign])
+	if err != nil {
+		return nil, fmt.Errorf("getting campaigns by advertiser id: %w", err)
+	}
+
+	return campaigns, nil
+}
+
+// GetTotals sums up the deals of the given campaigns by status.
+func (r *repo) GetTotals(
+	ctx context.Context, campaignIDs []uuid.UUID,
+) ([]entity.CampaignDealTotal, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT d.campaign_id, d.status,
+			COUNT(*)::int AS deals,
+			SUM(d.price_nano_ton)::bigint AS price_nano_ton,
+			SUM(COALESCE(d.publisher_share_nano_ton, d.price_nano_ton))::bigint
+				AS released_nano_ton,
+			COALESCE(SUM(ci.subscribers), 0)::bigint AS subscribers
+		FROM deals d
+		LEFT JOIN channel_info ci ON ci.channel_id = d.channel_id
+		WHERE d.campaign_id = ANY($1)
+		GROUP BY d.campaign_id, d.status
+	`, campaignIDs)
+	if err != nil {
+		return nil, fmt.Errorf("getting campaign totals: %w", err)
+	}
+
+	totals, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.CampaignDealTotal])
+	if err != nil {
+		return nil, fmt.Errorf("getting campaign totals: %w", err)
+	}
+
+	return totals, nil
+}
@@ -2,11 +2,14 @@ package channel
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 
+	"github.com/bpva/ad-marketplace/internal/dto"
 	"github.com/bpva/ad-marketplace/internal/entity"
 )
 
@@ -65,6 +68,29 @@ func (r *repo) GetChannels(
 	return channels, total, nil
 }
 
+// GetMarketplaceChannel returns the marketplace listing of a channel, which
+// only exists while the channel is listed.
+func (r *repo) GetMarketplaceChannel(
+	ctx context.Context, channelID uuid.UUID,
+) (*entity.MVChannel, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT * FROM channel_marketplace WHERE channel_id = $1
+	`, channelID)
+	if err != nil {
+		return nil, fmt.Errorf("getting marketplace channel: %w", err)
+	}
+
+	ch, err := pgx.CollectOneRow(rows, pgx.RowToStructByNameLax[entity.MVChannel])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("getting marketplace channel: %w", dto.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting marketplace channel: %w", err)
+	}
+
+	return &ch, nil
+}
+
 func (r *repo) RefreshMV(ctx context.Context) error {
 	_, err := r.db.Exec(ctx, "REFRESH MATERIALIZED VIEW CONCURRENTLY channel_marketplace")
 	return err
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgerrcode"
@@ -259,12 +260,113 @@ func (r *repo) UpdateListing(ctx context.Context, channelID uuid.UUID, isListed
 	return nil
 }
 
+func (r *repo) UpdateReviewTimeout(ctx context.Context, channelID uuid.UUID, hours *int) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE channels
+		SET review_timeout_hours = $2
+		WHERE id = $1 AND deleted_at IS NULL
+	`, channelID, hours)
+	if err != nil {
+		return fmt.Errorf("updating review timeout: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("updating review timeout: %w", dto.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *repo) UpdatePlatformFee(ctx context.Context, channelID uuid.UUID, bps *int64) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE channels
+		SET platform_fee_bps = $2
+		WHERE id = $1 AND deleted_at IS NULL
+	`, channelID, bps)
+	if err != nil {
+		return fmt.Errorf("updating platform fee: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("updating platform fee: %w", dto.ErrNotFound)
+	}
+	return nil
+}
+
+const inventoryQuery = `
+	SELECT c.id AS channel_id, i.max_ads_per_day,
+		COALESCE(i.posting_windows, '[]') AS posting_windows,
+		COALESCE(i.blackout_dates, '{}') AS blackout_dates
+	FROM channels c
+	LEFT JOIN channel_inventory i ON i.channel_id = c.id
+	WHERE c.id = $1 AND c.deleted_at IS NULL
+`
+
+// GetInventory returns the channel's inventory; a channel that never set one
+// gets an empty inventory, which puts no limits.
+func (r *repo) GetInventory(
+	ctx context.Context, channelID uuid.UUID,
+) (*entity.ChannelInventory, error) {
+	return r.getInventory(ctx, inventoryQuery, channelID)
+}
+
+// GetInventoryForUpdate is GetInventory that also locks the channel until the
+// transaction ends, so bookings on one channel are checked one at a time.
+func (r *repo) GetInventoryForUpdate(
+	ctx context.Context, channelID uuid.UUID,
+) (*entity.ChannelInventory, error) {
+	return r.getInventory(ctx, inventoryQuery+` FOR UPDATE OF c`, channelID)
+}
+
+func (r *repo) getInventory(
+	ctx context.Context, query string, channelID uuid.UUID,
+) (*entity.ChannelInventory, error) {
+	rows, err := r.db.Query(ctx, query, channelID)
+	if err != nil {
+		return nil, fmt.Errorf("getting channel inventory: %w", err)
+	}
+
+	inv, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.ChannelInventory])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("getting channel inventory: %w", dto.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting channel inventory: %w", err)
+	}
+
+	return &inv, nil
+}
+
+func (r *repo) SetInventory(ctx context.Context, inv *entity.ChannelInventory) error {
+	windows := inv.PostingWindows
+	if windows == nil {
+		windows = []entity.PostingWindow{}
+	}
+	dates := inv.BlackoutDates
+	if dates == nil {
+		dates = []time.Time{}
+	}
+
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO channel_inventory (channel_id, max_ads_per_day, posting_windows, blackout_dates)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (channel_id) DO UPDATE SET
+			max_ads_per_day = EXCLUDED.max_ads_per_day,
+			posting_windows = EXCLUDED.posting_windows,
+			blackout_dates = EXCLUDED.blackout_dates,
+			updated_at = NOW()
+	`, inv.ChannelID, inv.MaxAdsPerDay, windows, dates)
+	if err != nil {
+		return fmt.Errorf("setting channel inventory: %w", err)
+	}
+
+	return nil
+}
+
 func (r *repo) CreateAdFormat(
 	ctx context.Context,
 	channelID uuid.UUID,
 	formatType entity.AdFormatType,
 	isNative bool,
 	feedHours, topHours int,
+	autoDelete bool,
 	priceNanoTON int64,
 ) (*entity.ChannelAdFormat, error) {
 	id, err := uuid.NewV7()
@@ -274,11 +376,12 @@ func (r *repo) CreateAdFormat(
 
 	rows, err := r.db.Query(ctx, `
 		INSERT INTO channel_ad_formats
-			(id, channel_id, format_type, is_native, feed_hours, top_hours, price_nano_ton)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+			(id, channel_id, format_type, is_native, feed_hours, top_hours, auto_delete,
+			price_nano_ton)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id, channel_id, format_type, is_native, feed_hours, top_hours,
-			price_nano_ton, created_at
-	`, id, channelID, formatType, isNative, feedHours, topHours, priceNanoTON)
+			auto_delete, price_nano_ton, created_at
+	`, id, channelID, formatType, isNative, feedHours, topHours, autoDelete, priceNanoTON)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
@@ -305,7 +408,7 @@ func (r *repo) GetAdFormatsByChannelID(
 ) ([]entity.ChannelAdFormat, error) {
 	rows, err := r.db.Query(ctx, `
 		SELECT id, channel_id, format_type, is_native, feed_hours, top_hours,
-			price_nano_ton, created_at
+			auto_delete, price_nano_ton, created_at
 		FROM channel_ad_formats
 		WHERE channel_id = $1
 		ORDER BY created_at
@@ -328,7 +431,7 @@ func (r *repo) GetAdFormatByID(
 ) (*entity.ChannelAdFormat, error) {
 	rows, err := r.db.Query(ctx, `
 		SELECT id, channel_id, format_type, is_native, feed_hours, top_hours,
-			price_nano_ton, created_at
+			auto_delete, price_nano_ton, created_at
 		FROM channel_ad_formats
 		WHERE id = $1
 	`, formatID)
@@ -419,6 +522,27 @@ func (r *repo) GetOwnerWalletAddress(ctx context.Context, channelID uuid.UUID) (
 	return addr, nil
 }
 
+func (r *repo) GetOwnerID(ctx context.Context, channelID uuid.UUID) (uuid.UUID, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT user_id
+		FROM channel_roles
+		WHERE channel_id = $1 AND role = 'owner'
+	`, channelID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("getting owner id: %w", err)
+	}
+
+	id, err := pgx.CollectOneRow(rows, pgx.RowTo[uuid.UUID])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, fmt.Errorf("getting owner id: %w", dto.ErrNotFound)
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("getting owner id: %w", err)
+	}
+
+	return id, nil
+}
+
 func (r *repo) DeleteAdFormat(ctx context.Context, formatID uuid.UUID) error {
 	tag, err := r.db.Exec(ctx, `
 		DELETE FROM channel_ad_formats WHERE id = $1
@@ -0,0 +1,61 @@
+package cursor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+type db interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+type repo struct {
+	db db
+}
+
+func New(db db) *repo {
+	return &repo{db: db}
+}
+
+func (r *repo) Get(ctx context.Context, address string) (*entity.EscrowCursor, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT address, lt, hash, updated_at
+		FROM escrow_cursors
+		WHERE address = $1
+	`, address)
+	if err != nil {
+		return nil, fmt.Errorf("getting escrow cursor: %w", err)
+	}
+
+	c, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.EscrowCursor])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("getting escrow cursor: %w", dto.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting escrow cursor: %w", err)
+	}
+
+	return &c, nil
+}
+
+func (r *repo) Save(ctx context.Context, address, lt, hash string) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO escrow_cursors (address, lt, hash)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (address) DO UPDATE
+		SET lt = EXCLUDED.lt, hash = EXCLUDED.hash, updated_at = NOW()
+	`, address, lt, hash)
+	if err != nil {
+		return fmt.Errorf("saving escrow cursor: %w", err)
+	}
+
+	return nil
+}
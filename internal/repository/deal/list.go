@@ -0,0 +1,145 @@
+package deal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+var psql = sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+// listColumns are the deal columns qualified for the join with channels,
+// which shares some of their names.
+var listColumns = func() string {
+	cols := strings.Split(dealColumns, ",")
+	for i, c := range cols {
+		cols[i] = "d." + strings.TrimSpace(c)
+	}
+	return strings.Join(cols, ", ") + ", c.telegram_channel_id"
+}()
+
+func (r *repo) GetByChannelID(
+	ctx context.Context, channelID uuid.UUID, f entity.DealFilter,
+) ([]entity.ChannelDeal, int, error) {
+	deals, total, err := r.list(ctx, sq.Eq{"d.channel_id": channelID}, f)
+	if err != nil {
+		return nil, 0, fmt.Errorf("getting deals by channel id: %w", err)
+	}
+	return deals, total, nil
+}
+
+func (r *repo) GetByAdvertiserID(
+	ctx context.Context, advertiserID uuid.UUID, f entity.DealFilter,
+) ([]entity.ChannelDeal, int, error) {
+	deals, total, err := r.list(ctx, sq.Eq{"d.advertiser_id": advertiserID}, f)
+	if err != nil {
+		return nil, 0, fmt.Errorf("getting deals by advertiser id: %w", err)
+	}
+	return deals, total, nil
+}
+
+// list returns one page of the owner's deals matching f, newest first unless
+// f sorts otherwise, and, if f asks for it, the number of matching deals
+// across all pages.
+func (r *repo) list(
+	ctx context.Context, owner sq.Eq, f entity.DealFilter,
+) ([]entity.ChannelDeal, int, error) {
+	var total int
+	if f.WithTotal {
+		countSQL, countArgs, err := withDealFilter(
+			psql.Select("COUNT(*)").From("deals d").Where(owner), f,
+		).ToSql()
+		if err != nil {
+			return nil, 0, fmt.Errorf("building count query: %w", err)
+		}
+
+		countRows, err := r.db.Query(ctx, countSQL, countArgs...)
+		if err != nil {
+			return nil, 0, fmt.Errorf("counting deals: %w", err)
+		}
+		total, err = pgx.CollectOneRow(countRows, pgx.RowTo[int])
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning count: %w", err)
+		}
+	}
+
+	col, dir, cmp := "d."+f.Sort.Column(), "DESC", "<"
+	if f.Sort.Order == entity.SortOrderAsc {
+		dir, cmp = "ASC", ">"
+	}
+
+	b := withDealFilter(
+		psql.Select(listColumns).
+			From("deals d").
+			Join("channels c ON c.id = d.channel_id").
+			Where(owner), f,
+	)
+	if f.After != nil {
+		b = b.Where(
+			fmt.Sprintf("(%s, d.id) %s (?, ?)", col, cmp),
+			f.After.Value(f.Sort), f.After.ID,
+		)
+	}
+
+	if f.Offset > 0 {
+		b = b.Offset(uint64(f.Offset))
+	}
+
+	dataSQL, dataArgs, err := b.
+		OrderBy(col+" "+dir, "d.id "+dir).
+		Limit(uint64(f.Limit)).
+		ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("building data query: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, dataSQL, dataArgs...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("querying deals: %w", err)
+	}
+
+	deals, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.ChannelDeal])
+	if err != nil {
+		return nil, 0, fmt.Errorf("scanning deals: %w", err)
+	}
+
+	return deals, total, nil
+}
+
+func withDealFilter(b sq.SelectBuilder, f entity.DealFilter) sq.SelectBuilder {
+	if len(f.Statuses) > 0 {
+		statuses := make([]string, len(f.Statuses))
+		for i, s := range f.Statuses {
+			statuses[i] = string(s)
+		}
+		b = b.Where("d.status = ANY(?)", statuses)
+	}
+	if f.ScheduledFrom != nil {
+		b = b.Where("d.scheduled_at >= ?", *f.ScheduledFrom)
+	}
+	if f.ScheduledTo != nil {
+		b = b.Where("d.scheduled_at < ?", *f.ScheduledTo)
+	}
+	if f.FormatType != nil {
+		b = b.Where("d.format_type = ?", string(*f.FormatType))
+	}
+	if f.TgChannelID != nil {
+		b = b.Where(
+			"d.channel_id = (SELECT id FROM channels WHERE telegram_channel_id = ?)",
+			*f.TgChannelID,
+		)
+	}
+	if f.AdvertiserTgID != nil {
+		b = b.Where(
+			"d.advertiser_id = (SELECT id FROM users WHERE telegram_id = ?)",
+			*f.AdvertiserTgID,
+		)
+	}
+	return b
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -26,13 +27,19 @@ func New(db db) *repo {
 	return &repo{db: db}
 }
 
+// deals in these statuses hold their slot on the channel
+const bookedStatuses = `'pending_payment', 'pending_review', 'changes_requested',
+	'approved', 'posted'`
+
 const dealColumns = `
-	id, channel_id, advertiser_id, status, scheduled_at,
-	publisher_note, escrow_wallet_address, advertiser_wallet_address,
+	id, channel_id, advertiser_id, campaign_id, status, scheduled_at,
+	publisher_note, escrow_wallet_address, escrow_memo, advertiser_wallet_address,
 	payout_wallet_address, format_type, is_native, feed_hours,
-	top_hours, price_nano_ton, posted_message_ids,
-	paid_at, payment_tx_hash, posted_at, release_tx_hash,
-	refund_tx_hash, created_at, updated_at
+	top_hours, price_nano_ton, publisher_share_nano_ton, platform_fee_bps, posted_message_ids,
+	payment_expires_at, paid_at, payment_tx_hash, payer_address,
+	posted_at, publish_attempts, publish_error, publishing_at,
+	pinned_at, unpinned_at, auto_delete, deleted_at, delete_error,
+	release_tx_hash, refund_tx_hash, payout_error, status_changed_at, created_at, updated_at
 `
 
 func (r *repo) Create(ctx context.Context, deal *entity.Deal) (*entity.Deal, error) {
@@ -43,17 +50,19 @@ func (r *repo) Create(ctx context.Context, deal *entity.Deal) (*entity.Deal, err
 
 	rows, err := r.db.Query(ctx, `
 		INSERT INTO deals (
-			id, channel_id, advertiser_id, status, scheduled_at,
-			publisher_note, escrow_wallet_address, advertiser_wallet_address,
+			id, channel_id, advertiser_id, campaign_id, status, scheduled_at,
+			publisher_note, escrow_wallet_address, escrow_memo, advertiser_wallet_address,
 			payout_wallet_address, format_type, is_native, feed_hours,
-			top_hours, price_nano_ton
+			top_hours, auto_delete, price_nano_ton, platform_fee_bps, payment_expires_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18,
+			$19)
 		RETURNING `+dealColumns,
-		id, deal.ChannelID, deal.AdvertiserID, deal.Status, deal.ScheduledAt,
-		deal.PublisherNote, deal.EscrowWalletAddress, deal.AdvertiserWalletAddress,
+		id, deal.ChannelID, deal.AdvertiserID, deal.CampaignID, deal.Status, deal.ScheduledAt,
+		deal.PublisherNote, deal.EscrowWalletAddress, deal.EscrowMemo, deal.AdvertiserWalletAddress,
 		deal.PayoutWalletAddress, deal.FormatType, deal.IsNative, deal.FeedHours,
-		deal.TopHours, deal.PriceNanoTON)
+		deal.TopHours, deal.AutoDelete, deal.PriceNanoTON, deal.PlatformFeeBPS,
+		deal.PaymentExpiresAt)
 	if err != nil {
 		return nil, fmt.Errorf("creating deal: %w", err)
 	}
@@ -83,95 +92,341 @@ func (r *repo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Deal, error)
 	return &d, nil
 }
 
-func (r *repo) GetByChannelID(
-	ctx context.Context, channelID uuid.UUID, limit, offset int,
-) ([]entity.Deal, int, error) {
-	countRows, err := r.db.Query(ctx,
-		`SELECT COUNT(*) FROM deals WHERE channel_id = $1`, channelID)
+// GetByIDForUpdate returns the deal and, called in a transaction, keeps it
+// locked until the transaction ends.
+func (r *repo) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Deal, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+dealColumns+` FROM deals WHERE id = $1 FOR UPDATE`, id)
+	if err != nil {
+		return nil, fmt.Errorf("locking deal: %w", err)
+	}
+
+	d, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Deal])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("locking deal: %w", dto.ErrNotFound)
+	}
 	if err != nil {
-		return nil, 0, fmt.Errorf("counting deals by channel id: %w", err)
+		return nil, fmt.Errorf("locking deal: %w", err)
 	}
 
-	total, err := pgx.CollectOneRow(countRows, pgx.RowTo[int])
+	return &d, nil
+}
+
+func (r *repo) GetByEscrowMemo(ctx context.Context, memo string) (*entity.Deal, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+dealColumns+` FROM deals WHERE escrow_memo = $1`, memo)
 	if err != nil {
-		return nil, 0, fmt.Errorf("counting deals by channel id: %w", err)
+		return nil, fmt.Errorf("getting deal by escrow memo: %w", err)
 	}
 
+	d, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Deal])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("getting deal by escrow memo: %w", dto.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting deal by escrow memo: %w", err)
+	}
+
+	return &d, nil
+}
+
+func (r *repo) GetByCampaignID(ctx context.Context, campaignID uuid.UUID) ([]entity.Deal, error) {
 	rows, err := r.db.Query(ctx, `
 		SELECT `+dealColumns+`
 		FROM deals
-		WHERE channel_id = $1
-		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3
-	`, channelID, limit, offset)
+		WHERE campaign_id = $1
+		ORDER BY scheduled_at ASC, id ASC
+	`, campaignID)
 	if err != nil {
-		return nil, 0, fmt.Errorf("getting deals by channel id: %w", err)
+		return nil, fmt.Errorf("getting deals by campaign id: %w", err)
 	}
 
 	deals, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Deal])
 	if err != nil {
-		return nil, 0, fmt.Errorf("getting deals by channel id: %w", err)
+		return nil, fmt.Errorf("getting deals by campaign id: %w", err)
 	}
 
-	return deals, total, nil
+	return deals, nil
 }
 
-func (r *repo) GetByAdvertiserID(
-	ctx context.Context, advertiserID uuid.UUID, limit, offset int,
-) ([]entity.Deal, int, error) {
-	countRows, err := r.db.Query(ctx,
-		`SELECT COUNT(*) FROM deals WHERE advertiser_id = $1`, advertiserID)
+// GetCampaignBudgetLeft locks the campaign and returns its budget less the
+// price of its deals other than exceptDealID that were not closed before
+// their funds were spent. Deals still negotiating count at their current
+// price.
+func (r *repo) GetCampaignBudgetLeft(
+	ctx context.Context, campaignID, exceptDealID uuid.UUID,
+) (int64, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT c.budget_nano_ton - COALESCE((
+			SELECT SUM(d.price_nano_ton)::BIGINT
+			FROM deals d
+			WHERE d.campaign_id = c.id AND d.id <> $2
+				AND d.status NOT IN ('hold_failed', 'rejected', 'cancelled', 'publish_failed')
+		), 0)
+		FROM campaigns c
+		WHERE c.id = $1
+		FOR UPDATE OF c
+	`, campaignID, exceptDealID)
 	if err != nil {
-		return nil, 0, fmt.Errorf("counting deals by advertiser id: %w", err)
+		return 0, fmt.Errorf("getting campaign budget left: %w", err)
 	}
 
-	total, err := pgx.CollectOneRow(countRows, pgx.RowTo[int])
+	left, err := pgx.CollectOneRow(rows, pgx.RowTo[int64])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("getting campaign budget left: %w", dto.ErrNotFound)
+	}
 	if err != nil {
-		return nil, 0, fmt.Errorf("counting deals by advertiser id: %w", err)
+		return 0, fmt.Errorf("getting campaign budget left: %w", err)
 	}
+	return left, nil
+}
 
+func (r *repo) GetByStatus(
+	ctx context.Context, status entity.DealStatus,
+) ([]entity.Deal, error) {
 	rows, err := r.db.Query(ctx, `
 		SELECT `+dealColumns+`
 		FROM deals
-		WHERE advertiser_id = $1
-		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3
-	`, advertiserID, limit, offset)
+		WHERE status = $1
+		ORDER BY created_at ASC
+	`, status)
 	if err != nil {
-		return nil, 0, fmt.Errorf("getting deals by advertiser id: %w", err)
+		return nil, fmt.Errorf("getting deals by status: %w", err)
 	}
 
 	deals, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Deal])
 	if err != nil {
-		return nil, 0, fmt.Errorf("getting deals by advertiser id: %w", err)
+		return nil, fmt.Errorf("getting deals by status: %w", err)
 	}
 
-	return deals, total, nil
+	return deals, nil
 }
 
+// UpdateStatus moves the deal from status from to status to, like
+// TransitionStatus, for callers that do not need the updated row. It fails
+// with ErrInvalidTransition if the deal is no longer in status from.
 func (r *repo) UpdateStatus(
-	ctx context.Context, id uuid.UUID, status entity.DealStatus, note *string,
+	ctx context.Context, id uuid.UUID, from, to entity.DealStatus, note *string,
 ) error {
 	tag, err := r.db.Exec(ctx, `
 		UPDATE deals
-		SET status = $2, publisher_note = $3, updated_at = NOW()
-		WHERE id = $1
-	`, id, status, note)
+		SET status = $3, publisher_note = $4, status_changed_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = $2
+	`, id, from, to, note)
 	if err != nil {
 		return fmt.Errorf("updating deal status: %w", err)
 	}
 	if tag.RowsAffected() == 0 {
-		return fmt.Errorf("updating deal status: %w", dto.ErrNotFound)
+		return fmt.Errorf("updating deal status: %w", dto.ErrInvalidTransition)
 	}
 	return nil
 }
 
-func (r *repo) SetPostedMessageIDs(ctx context.Context, id uuid.UUID, messageIDs []int64) error {
+// TransitionStatus moves the deal to status to only if it is still in status
+// from, and returns the updated row. Concurrent transitions of the same deal
+// are serialized by the row lock; the loser gets ErrInvalidTransition.
+func (r *repo) TransitionStatus(
+	ctx context.Context, id uuid.UUID, from, to entity.DealStatus, note *string,
+) (*entity.Deal, error) {
+	rows, err := r.db.Query(ctx, `
+		UPDATE deals
+		SET status = $3, publisher_note = $4, status_changed_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = $2
+		RETURNING `+dealColumns,
+		id, from, to, note)
+	if err != nil {
+		return nil, fmt.Errorf("transitioning deal status: %w", err)
+	}
+
+	d, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Deal])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("transitioning deal status: %w", dto.ErrInvalidTransition)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("transitioning deal status: %w", err)
+	}
+
+	return &d, nil
+}
+
+// AcceptTerms freezes the negotiated price and schedule onto a deal under
+// negotiation and moves it to pending_payment with the given escrow deposit.
+// It fails with ErrInvalidTransition if the deal is no longer negotiating.
+func (r *repo) AcceptTerms(
+	ctx context.Context,
+	id uuid.UUID,
+	priceNanoTON int64,
+	scheduledAt time.Time,
+	deposit *dto.EscrowDeposit,
+	paymentExpiresAt time.Time,
+) (*entity.Deal, error) {
+	rows, err := r.db.Query(ctx, `
+		UPDATE deals
+		SET status = $3, price_nano_ton = $4, scheduled_at = $5,
+			escrow_wallet_address = $6, escrow_memo = $7, payment_expires_at = $8,
+			status_changed_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = $2
+		RETURNING `+dealColumns,
+		id, entity.DealStatusNegotiating, entity.DealStatusPendingPayment, priceNanoTON,
+		scheduledAt, deposit.Address, deposit.Memo, paymentExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("accepting deal terms: %w", err)
+	}
+
+	d, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Deal])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("accepting deal terms: %w", dto.ErrInvalidTransition)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("accepting deal terms: %w", err)
+	}
+
+	return &d, nil
+}
+
+// SettleDispute moves a disputed deal to resolved with the part of the price
+// awarded to the publisher. It fails with ErrInvalidTransition if the deal
+// is no longer in dispute.
+func (r *repo) SettleDispute(
+	ctx context.Context, id uuid.UUID, publisherShare int64, note *string,
+) (*entity.Deal, error) {
+	rows, err := r.db.Query(ctx, `
+		UPDATE deals
+		SET status = $3, publisher_share_nano_ton = $4, publisher_note = $5,
+			status_changed_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = $2
+		RETURNING `+dealColumns,
+		id, entity.DealStatusDispute, entity.DealStatusResolved, publisherShare, note)
+	if err != nil {
+		return nil, fmt.Errorf("settling deal dispute: %w", err)
+	}
+
+	d, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Deal])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("settling deal dispute: %w", dto.ErrInvalidTransition)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("settling deal dispute: %w", err)
+	}
+
+	return &d, nil
+}
+
+// Reschedule moves a deal to a new posting time, provided it is still in
+// the given status and no worker is posting its ad.
+func (r *repo) Reschedule(
+	ctx context.Context, id uuid.UUID, status entity.DealStatus, scheduledAt time.Time,
+) (*entity.Deal, error) {
+	rows, err := r.db.Query(ctx, `
+		UPDATE deals
+		SET scheduled_at = $3, updated_at = NOW()
+		WHERE id = $1 AND status = $2 AND publishing_at IS NULL
+		RETURNING `+dealColumns,
+		id, status, scheduledAt)
+	if err != nil {
+		return nil, fmt.Errorf("rescheduling deal: %w", err)
+	}
+
+	d, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Deal])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("rescheduling deal: %w", dto.ErrInvalidTransition)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("rescheduling deal: %w", err)
+	}
+
+	return &d, nil
+}
+
+// CountBooked returns how many live deals on the channel, other than the
+// given one, are scheduled within [from, to).
+func (r *repo) CountBooked(
+	ctx context.Context, channelID, excludeDealID uuid.UUID, from, to time.Time,
+) (int, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT COUNT(*)
+		FROM deals
+		WHERE channel_id = $1 AND id <> $2
+			AND status IN (`+bookedStatuses+`)
+			AND scheduled_at >= $3 AND scheduled_at < $4
+	`, channelID, excludeDealID, from, to)
+	if err != nil {
+		return 0, fmt.Errorf("counting booked deals: %w", err)
+	}
+
+	n, err := pgx.CollectOneRow(rows, pgx.RowTo[int])
+	if err != nil {
+		return 0, fmt.Errorf("counting booked deals: %w", err)
+	}
+
+	return n, nil
+}
+
+// GetBookedSlots returns the times live deals on the channel hold the top of
+// its feed, for every slot that overlaps [from, to).
+func (r *repo) GetBookedSlots(
+	ctx context.Context, channelID uuid.UUID, from, to time.Time,
+) ([]entity.BookedSlot, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT scheduled_at AS "from",
+			scheduled_at + make_interval(hours => top_hours) AS "to"
+		FROM deals
+		WHERE channel_id = $1
+			AND status IN (`+bookedStatuses+`)
+			AND scheduled_at < $3
+			AND (scheduled_at >= $2
+				OR scheduled_at + make_interval(hours => top_hours) > $2)
+		ORDER BY scheduled_at ASC
+	`, channelID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("getting booked slots: %w", err)
+	}
+
+	slots, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.BookedSlot])
+	if err != nil {
+		return nil, fmt.Errorf("getting booked slots: %w", err)
+	}
+
+	return slots, nil
+}
+
+// HasSlotConflict reports whether another live deal on the channel holds the
+// top of its feed at any point in [from, to), or is booked for exactly from.
+// A deal holds the top from its posting time for its top hours.
+func (r *repo) HasSlotConflict(
+	ctx context.Context, channelID, excludeDealID uuid.UUID, from, to time.Time,
+) (bool, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT EXISTS(
+			SELECT 1
+			FROM deals
+			WHERE channel_id = $1 AND id <> $2
+				AND status IN (`+bookedStatuses+`)
+				AND (
+					scheduled_at = $3
+					OR (scheduled_at < $4
+						AND scheduled_at + make_interval(hours => top_hours) > $3)
+				)
+		)
+	`, channelID, excludeDealID, from, to)
+	if err != nil {
+		return false, fmt.Errorf("checking slot conflict: %w", err)
+	}
+
+	exists, err := pgx.CollectOneRow(rows, pgx.RowTo[bool])
+	if err != nil {
+		return false, fmt.Errorf("checking slot conflict: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *repo) SetPostedMessageIDs(
+	ctx context.Context, id uuid.UUID, messageIDs []int64, postedAt time.Time,
+) error {
 	tag, err := r.db.Exec(ctx, `
 		UPDATE deals
-		SET posted_message_ids = $2, updated_at = NOW()
+		SET posted_message_ids = $2, posted_at = $3, publish_error = NULL, updated_at = NOW()
 		WHERE id = $1
-	`, id, messageIDs)
+	`, id, messageIDs, postedAt)
 	if err != nil {
 		return fmt.Errorf("setting posted message ids: %w", err)
 	}
@@ -180,3 +435,327 @@ func (r *repo) SetPostedMessageIDs(ctx context.Context, id uuid.UUID, messageIDs
 	}
 	return nil
 }
+
+// ClaimDueForPosting marks the earliest approved deal whose posting time has
+// arrived as being published and returns it. Deals that exhausted their
+// publish attempts, are listed in skip, or were claimed less than staleAfter
+// ago are passed over; a claim older than that belongs to a worker that died
+// mid-send. The claim commits on its own, so no row lock is held while the ad
+// is sent. It fails with ErrNotFound if no deal is due.
+func (r *repo) ClaimDueForPosting(
+	ctx context.Context, maxAttempts int, staleAfter time.Duration, skip []uuid.UUID,
+) (*entity.Deal, error) {
+	rows, err := r.db.Query(ctx, `
+		UPDATE deals
+		SET publishing_at = NOW(), updated_at = NOW()
+		WHERE id = (
+			SELECT id
+			FROM deals
+			WHERE status = 'approved' AND scheduled_at <= NOW() AND publish_attempts < $1
+				AND (publishing_at IS NULL OR publishing_at <= NOW() - $2::interval)
+				AND id <> ALL(COALESCE($3::uuid[], '{}'))
+			ORDER BY scheduled_at ASC
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+dealColumns,
+		maxAttempts, staleAfter, skip)
+	if err != nil {
+		return nil, fmt.Errorf("claiming deal due for posting: %w", err)
+	}
+
+	d, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.Deal])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("claiming deal due for posting: %w", dto.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("claiming deal due for posting: %w", err)
+	}
+
+	return &d, nil
+}
+
+// RecordPublishFailure stores a failed publish attempt and releases the claim
+// taken by ClaimDueForPosting so the deal can be retried.
+func (r *repo) RecordPublishFailure(
+	ctx context.Context, id uuid.UUID, reason string, attempts int,
+) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE deals
+		SET publish_error = $2, publish_attempts = $3, publishing_at = NULL,
+			updated_at = NOW()
+		WHERE id = $1
+	`, id, reason, attempts)
+	if err != nil {
+		return fmt.Errorf("recording publish failure: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("recording publish failure: %w", dto.ErrNotFound)
+	}
+	return nil
+}
+
+// SetPayment records the escrow payment of a deal. A deal is paid at most
+// once; a second payment fails with ErrInvalidTransition.
+func (r *repo) SetPayment(
+	ctx context.Context, id uuid.UUID, txHash, payer string, paidAt time.Time,
+) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE deals
+		SET payment_tx_hash = $2, payer_address = $3, paid_at = $4, updated_at = NOW()
+		WHERE id = $1 AND payment_tx_hash IS NULL
+	`, id, txHash, payer, paidAt)
+	if err != nil {
+		return fmt.Errorf("setting deal payment: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("setting deal payment: %w", dto.ErrInvalidTransition)
+	}
+	return nil
+}
+
+// GetAwaitingPayout returns completed deals, and resolved deals that award
+// the publisher a share, for which no payout is queued yet.
+func (r *repo) GetAwaitingPayout(ctx context.Context) ([]entity.Deal, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+dealColumns+`
+		FROM deals d
+		WHERE (status = 'completed' OR (status = 'resolved' AND publisher_share_nano_ton > 0))
+			AND release_tx_hash IS NULL
+			AND NOT EXISTS (
+				SELECT 1 FROM transfers t WHERE t.deal_id = d.id AND t.kind = 'payout'
+			)
+		ORDER BY updated_at ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("getting deals awaiting payout: %w", err)
+	}
+
+	deals, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Deal])
+	if err != nil {
+		return nil, fmt.Errorf("getting deals awaiting payout: %w", err)
+	}
+
+	return deals, nil
+}
+
+// SetPayoutWallet fills in the payout wallet of a deal created before the
+// channel owner linked one.
+func (r *repo) SetPayoutWallet(ctx context.Context, id uuid.UUID, address string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE deals
+		SET payout_wallet_address = $2, payout_error = NULL, updated_at = NOW()
+		WHERE id = $1
+	`, id, address)
+	if err != nil {
+		return fmt.Errorf("setting payout wallet: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("setting payout wallet: %w", dto.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *repo) RecordPayoutFailure(ctx context.Context, id uuid.UUID, reason string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE deals
+		SET payout_error = $2, updated_at = NOW()
+		WHERE id = $1
+	`, id, reason)
+	if err != nil {
+		return fmt.Errorf("recording payout failure: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("recording payout failure: %w", dto.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *repo) SetReleaseTxHash(ctx context.Context, id uuid.UUID, txHash string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE deals
+		SET release_tx_hash = $2, updated_at = NOW()
+		WHERE id = $1
+	`, id, txHash)
+	if err != nil {
+		return fmt.Errorf("setting release tx hash: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("setting release tx hash: %w", dto.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *repo) SetRefundTxHash(ctx context.Context, id uuid.UUID, txHash string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE deals
+		SET refund_tx_hash = $2, updated_at = NOW()
+		WHERE id = $1
+	`, id, txHash)
+	if err != nil {
+		return fmt.Errorf("setting refund tx hash: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("setting refund tx hash: %w", dto.ErrNotFound)
+	}
+	return nil
+}
+
+// GetAwaitingPin returns posted deals whose ad has not been pinned yet.
+func (r *repo) GetAwaitingPin(ctx context.Context) ([]entity.Deal, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+dealColumns+`
+		FROM deals
+		WHERE status = 'posted' AND top_hours > 0 AND pinned_at IS NULL
+			AND cardinality(posted_message_ids) > 0
+		ORDER BY posted_at ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("getting deals awaiting pin: %w", err)
+	}
+
+	deals, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Deal])
+	if err != nil {
+		return nil, fmt.Errorf("getting deals awaiting pin: %w", err)
+	}
+
+	return deals, nil
+}
+
+// GetAwaitingUnpin returns deals whose pin window has ended, whatever
+// state the deal has moved to since.
+func (r *repo) GetAwaitingUnpin(ctx context.Context) ([]entity.Deal, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+dealColumns+`
+		FROM deals
+		WHERE pinned_at IS NOT NULL AND unpinned_at IS NULL
+			AND pinned_at + make_interval(hours => top_hours) <= NOW()
+		ORDER BY pinned_at ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("getting deals awaiting unpin: %w", err)
+	}
+
+	deals, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Deal])
+	if err != nil {
+		return nil, fmt.Errorf("getting deals awaiting unpin: %w", err)
+	}
+
+	return deals, nil
+}
+
+func (r *repo) SetPinnedAt(ctx context.Context, id uuid.UUID, pinnedAt time.Time) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE deals
+		SET pinned_at = $2, updated_at = NOW()
+		WHERE id = $1
+	`, id, pinnedAt)
+	if err != nil {
+		return fmt.Errorf("setting pinned at: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("setting pinned at: %w", dto.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *repo) SetUnpinnedAt(ctx context.Context, id uuid.UUID, unpinnedAt time.Time) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE deals
+		SET unpinned_at = $2, updated_at = NOW()
+		WHERE id = $1
+	`, id, unpinnedAt)
+	if err != nil {
+		return fmt.Errorf("setting unpinned at: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("setting unpinned at: %w", dto.ErrNotFound)
+	}
+	return nil
+}
+
+// GetAwaitingDeletion returns completed auto-delete deals whose feed window
+// has ended and whose ad is still in the channel.
+func (r *repo) GetAwaitingDeletion(ctx context.Context) ([]entity.Deal, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+dealColumns+`
+		FROM deals
+		WHERE auto_delete AND deleted_at IS NULL AND delete_error IS NULL
+			AND status = 'completed'
+			AND posted_at + make_interval(hours => feed_hours) <= NOW()
+		ORDER BY posted_at ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("getting deals awaiting deletion: %w", err)
+	}
+
+	deals, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Deal])
+	if err != nil {
+		return nil, fmt.Errorf("getting deals awaiting deletion: %w", err)
+	}
+
+	return deals, nil
+}
+
+func (r *repo) SetDeletedAt(ctx context.Context, id uuid.UUID, deletedAt time.Time) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE deals
+		SET deleted_at = $2, delete_error = NULL, updated_at = NOW()
+		WHERE id = $1
+	`, id, deletedAt)
+	if err != nil {
+		return fmt.Errorf("setting deleted at: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("setting deleted at: %w", dto.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *repo) RecordDeleteFailure(ctx context.Context, id uuid.UUID, reason string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE deals
+		SET delete_error = $2, updated_at = NOW()
+		WHERE id = $1
+	`, id, reason)
+	if err != nil {
+		return fmt.Errorf("recording delete failure: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("recording delete failure: %w", dto.ErrNotFound)
+	}
+	return nil
+}
+
+// GetReviewOverdue returns deals still under review that have waited longer
+// than their channel's review timeout (or defaultTimeout if the channel has
+// none), or whose slot comes up within cutoff.
+func (r *repo) GetReviewOverdue(
+	ctx context.Context,
+	defaultTimeout, cutoff time.Duration,
+) ([]entity.Deal, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+dealColumns+`
+		FROM deals
+		WHERE status IN ('pending_review', 'changes_requested')
+			AND (
+				status_changed_at <= NOW() - COALESCE((
+					SELECT make_interval(hours => c.review_timeout_hours)
+					FROM channels c
+					WHERE c.id = deals.channel_id
+				), $1::interval)
+				OR scheduled_at <= NOW() + $2::interval
+			)
+		ORDER BY scheduled_at ASC
+	`, defaultTimeout, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("getting deals with overdue review: %w", err)
+	}
+
+	deals, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Deal])
+	if err != nil {
+		return nil, fmt.Errorf("getting deals with overdue review: %w", err)
+	}
+
+	return deals, nil
+}
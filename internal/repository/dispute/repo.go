@@ -0,0 +1,166 @@
+package dispute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+const disputeColumns = `id, deal_id, reason, status, outcome, arbiter_id,
+	resolution_note, created_at, resolved_at`
+
+const evidenceColumns = `id, dispute_id, author_id, text, file_id, message_link, created_at`
+
+type db interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+type repo struct {
+	db db
+}
+
+func New(db db) *repo {
+	return &repo{db: db}
+}
+
+func (r *repo) Create(ctx context.Context, d *entity.DealDispute) (*entity.DealDispute, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("creating deal dispute: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		INSERT INTO deal_disputes (id, deal_id, reason)
+		VALUES ($1, $2, $3)
+		RETURNING `+disputeColumns,
+		id, d.DealID, d.Reason)
+	if err != nil {
+		return nil, fmt.Errorf("creating deal dispute: %w", err)
+	}
+
+	created, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.DealDispute])
+	if err != nil {
+		return nil, fmt.Errorf("creating deal dispute: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (r *repo) GetByDealID(ctx context.Context, dealID uuid.UUID) (*entity.DealDispute, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+disputeColumns+`
+		FROM deal_disputes
+		WHERE deal_id = $1
+	`, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("getting deal dispute: %w", err)
+	}
+
+	d, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.DealDispute])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("getting deal dispute: %w", dto.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting deal dispute: %w", err)
+	}
+
+	return &d, nil
+}
+
+// GetOpen returns the disputes awaiting an arbiter, oldest first.
+func (r *repo) GetOpen(ctx context.Context) ([]entity.DealDispute, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+disputeColumns+`
+		FROM deal_disputes
+		WHERE status = 'open'
+		ORDER BY created_at ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("getting open deal disputes: %w", err)
+	}
+
+	disputes, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.DealDispute])
+	if err != nil {
+		return nil, fmt.Errorf("getting open deal disputes: %w", err)
+	}
+
+	return disputes, nil
+}
+
+// Resolve records the arbiter's decision. It fails with ErrInvalidTransition
+// if the dispute was already resolved.
+func (r *repo) Resolve(
+	ctx context.Context,
+	id uuid.UUID,
+	outcome entity.DisputeOutcome,
+	arbiterID uuid.UUID,
+	note *string,
+) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE deal_disputes
+		SET status = 'resolved', outcome = $2, arbiter_id = $3, resolution_note = $4,
+			resolved_at = NOW()
+		WHERE id = $1 AND status = 'open'
+	`, id, outcome, arbiterID, note)
+	if err != nil {
+		return fmt.Errorf("resolving deal dispute: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("resolving deal dispute: %w", dto.ErrInvalidTransition)
+	}
+	return nil
+}
+
+func (r *repo) CreateEvidence(
+	ctx context.Context, e *entity.DisputeEvidence,
+) (*entity.DisputeEvidence, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("creating dispute evidence: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		INSERT INTO dispute_evidence (id, dispute_id, author_id, text, file_id, message_link)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+evidenceColumns,
+		id, e.DisputeID, e.AuthorID, e.Text, e.FileID, e.MessageLink)
+	if err != nil {
+		return nil, fmt.Errorf("creating dispute evidence: %w", err)
+	}
+
+	created, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.DisputeEvidence])
+	if err != nil {
+		return nil, fmt.Errorf("creating dispute evidence: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (r *repo) GetEvidence(
+	ctx context.Context, disputeID uuid.UUID,
+) ([]entity.DisputeEvidence, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+evidenceColumns+`
+		FROM dispute_evidence
+		WHERE dispute_id = $1
+		ORDER BY created_at ASC, id ASC
+	`, disputeID)
+	if err != nil {
+		return nil, fmt.Errorf("getting dispute evidence: %w", err)
+	}
+
+	evidence, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.DisputeEvidence])
+	if err != nil {
+		return nil, fmt.Errorf("getting dispute evidence: %w", err)
+	}
+
+	return evidence, nil
+}
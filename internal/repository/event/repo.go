@@ -0,0 +1,68 @@
+package event
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+type db interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+type repo struct {
+	db db
+}
+
+func New(db db) *repo {
+	return &repo{db: db}
+}
+
+func (r *repo) Create(ctx context.Context, ev *entity.DealEvent) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("creating deal event: %w", err)
+	}
+
+	metadata := ev.Metadata
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+
+	_, err = r.db.Exec(ctx, `
+		INSERT INTO deal_events (
+			id, deal_id, type, actor_id, from_status, to_status, note, metadata
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, id, ev.DealID, ev.Type, ev.ActorID, ev.FromStatus, ev.ToStatus, ev.Note, metadata)
+	if err != nil {
+		return fmt.Errorf("creating deal event: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.DealEvent, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, deal_id, type, actor_id, from_status, to_status, note, metadata, created_at
+		FROM deal_events
+		WHERE deal_id = $1
+		ORDER BY created_at ASC, id ASC
+	`, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("getting deal events: %w", err)
+	}
+
+	events, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.DealEvent])
+	if err != nil {
+		return nil, fmt.Errorf("getting deal events: %w", err)
+	}
+
+	return events, nil
+}
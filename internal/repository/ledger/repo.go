@@ -0,0 +1,154 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+const entryColumns = `id, txn_id, deal_id, kind, account, user_id, amount_nano_ton, created_at`
+
+type db interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+type repo struct {
+	db db
+}
+
+func New(db db) *repo {
+	return &repo{db: db}
+}
+
+// Post records the legs of one posting under a shared transaction id. The
+// legs must sum to zero; a deal has at most one leg per kind and account, so
+// posting the same movement twice fails.
+func (r *repo) Post(ctx context.Context, legs []entity.LedgerEntry) error {
+	if len(legs) < 2 {
+		return fmt.Errorf("posting ledger entries: need at least two legs, got %d", len(legs))
+	}
+	var sum int64
+	for i := range legs {
+		sum += legs[i].AmountNanoTON
+	}
+	if sum != 0 {
+		return fmt.Errorf("posting ledger entries: legs sum to %d", sum)
+	}
+
+	txnID, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("posting ledger entries: %w", err)
+	}
+
+	values := make([]string, len(legs))
+	args := make([]any, 0, len(legs)*6+1)
+	args = append(args, txnID)
+	for i := range legs {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("posting ledger entries: %w", err)
+		}
+		n := len(args)
+		values[i] = fmt.Sprintf("($%d, $1, $%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5, n+6)
+		args = append(args, id, legs[i].DealID, legs[i].Kind, legs[i].Account,
+			legs[i].UserID, legs[i].AmountNanoTON)
+	}
+
+	_, err = r.db.Exec(ctx, `
+		INSERT INTO ledger_entries (id, txn_id, deal_id, kind, account, user_id, amount_nano_ton)
+		VALUES `+strings.Join(values, ", "), args...)
+	if err != nil {
+		return fmt.Errorf("posting ledger entries: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.LedgerEntry, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+entryColumns+`
+		FROM ledger_entries
+		WHERE deal_id = $1
+		ORDER BY created_at ASC, txn_id, amount_nano_ton ASC
+	`, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("getting ledger entries: %w", err)
+	}
+
+	entries, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.LedgerEntry])
+	if err != nil {
+		return nil, fmt.Errorf("getting ledger entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// GetBalances sums the user's entries per account. An advertiser's balance
+// is what they paid in less refunds, so it is normally negative; a
+// publisher's is what they were paid out.
+func (r *repo) GetBalances(ctx context.Context, userID uuid.UUID) ([]entity.LedgerBalance, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT account, SUM(amount_nano_ton)::BIGINT AS amount_nano_ton
+		FROM ledger_entries
+		WHERE user_id = $1
+		GROUP BY account
+		ORDER BY account
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("getting ledger balances: %w", err)
+	}
+
+	balances, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.LedgerBalance])
+	if err != nil {
+		return nil, fmt.Errorf("getting ledger balances: %w", err)
+	}
+
+	return balances, nil
+}
+
+// GetDiscrepancies compares the escrow balance of every deal in the ledger
+// with the price it was paid less the transfers confirmed out of escrow.
+func (r *repo) GetDiscrepancies(ctx context.Context) ([]entity.LedgerDiscrepancy, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH ledger AS (
+			SELECT deal_id, SUM(amount_nano_ton)::BIGINT AS balance
+			FROM ledger_entries
+			WHERE account = 'escrow'
+			GROUP BY deal_id
+		), sent AS (
+			SELECT deal_id, SUM(amount_nano_ton + fee_nano_ton)::BIGINT AS amount
+			FROM transfers
+			WHERE status = 'confirmed' AND kind <> 'return'
+			GROUP BY deal_id
+		), expected AS (
+			SELECT d.id AS deal_id,
+				CASE WHEN d.payment_tx_hash IS NULL THEN 0 ELSE d.price_nano_ton END
+					- COALESCE(s.amount, 0) AS balance
+			FROM deals d
+			LEFT JOIN sent s ON s.deal_id = d.id
+		)
+		SELECT e.deal_id, COALESCE(l.balance, 0) AS ledger_nano_ton,
+			e.balance AS expected_nano_ton
+		FROM expected e
+		LEFT JOIN ledger l ON l.deal_id = e.deal_id
+		WHERE COALESCE(l.balance, 0) <> e.balance
+		ORDER BY e.deal_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("getting ledger discrepancies: %w", err)
+	}
+
+	discrepancies, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.LedgerDiscrepancy])
+	if err != nil {
+		return nil, fmt.Errorf("getting ledger discrepancies: %w", err)
+	}
+
+	return discrepancies, nil
+}
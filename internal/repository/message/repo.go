@@ -0,0 +1,148 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+const messageColumns = `id, deal_id, author_id, text, created_at, relayed_at`
+
+type db interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+type repo struct {
+	db db
+}
+
+func New(db db) *repo {
+	return &repo{db: db}
+}
+
+func (r *repo) Create(
+	ctx context.Context, msg *entity.DealMessage,
+) (*entity.DealMessage, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("creating deal message: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		INSERT INTO deal_messages (id, deal_id, author_id, text)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+messageColumns,
+		id, msg.DealID, msg.AuthorID, msg.Text)
+	if err != nil {
+		return nil, fmt.Errorf("creating deal message: %w", err)
+	}
+
+	created, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.DealMessage])
+	if err != nil {
+		return nil, fmt.Errorf("creating deal message: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (r *repo) GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.DealMessage, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+messageColumns+`
+		FROM deal_messages
+		WHERE deal_id = $1
+		ORDER BY created_at ASC, id ASC
+	`, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("getting deal messages: %w", err)
+	}
+
+	msgs, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.DealMessage])
+	if err != nil {
+		return nil, fmt.Errorf("getting deal messages: %w", err)
+	}
+
+	return msgs, nil
+}
+
+// GetUnrelayed returns messages the bot has not forwarded yet, oldest first.
+func (r *repo) GetUnrelayed(ctx context.Context, limit int) ([]entity.DealMessage, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+messageColumns+`
+		FROM deal_messages
+		WHERE relayed_at IS NULL
+		ORDER BY created_at ASC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("getting unrelayed deal messages: %w", err)
+	}
+
+	msgs, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.DealMessage])
+	if err != nil {
+		return nil, fmt.Errorf("getting unrelayed deal messages: %w", err)
+	}
+
+	return msgs, nil
+}
+
+func (r *repo) MarkRelayed(ctx context.Context, id uuid.UUID) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE deal_messages
+		SET relayed_at = NOW()
+		WHERE id = $1 AND relayed_at IS NULL
+	`, id)
+	if err != nil {
+		return fmt.Errorf("marking deal message relayed: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("marking deal message relayed: %w", dto.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *repo) CreateRelay(ctx context.Context, relay *entity.DealMessageRelay) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO deal_message_relays (chat_id, tg_message_id, message_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (chat_id, tg_message_id) DO NOTHING
+	`, relay.ChatID, relay.TgMessageID, relay.MessageID)
+	if err != nil {
+		return fmt.Errorf("creating deal message relay: %w", err)
+	}
+
+	return nil
+}
+
+// GetByRelay returns the deal message that the bot forwarded as the given
+// message in the given chat.
+func (r *repo) GetByRelay(
+	ctx context.Context, chatID int64, tgMessageID int,
+) (*entity.DealMessage, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT m.id, m.deal_id, m.author_id, m.text, m.created_at, m.relayed_at
+		FROM deal_message_relays r
+		JOIN deal_messages m ON m.id = r.message_id
+		WHERE r.chat_id = $1 AND r.tg_message_id = $2
+	`, chatID, tgMessageID)
+	if err != nil {
+		return nil, fmt.Errorf("getting deal message by relay: %w", err)
+	}
+
+	msg, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.DealMessage])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("getting deal message by relay: %w", dto.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting deal message by relay: %w", err)
+	}
+
+	return &msg, nil
+}
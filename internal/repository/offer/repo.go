@@ -0,0 +1,110 @@
+package offer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+const offerColumns = `id, deal_id, author_id, price_nano_ton, scheduled_at,
+	status, created_at, responded_at`
+
+type db interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+type repo struct {
+	db db
+}
+
+func New(db db) *repo {
+	return &repo{db: db}
+}
+
+func (r *repo) Create(ctx context.Context, o *entity.DealOffer) (*entity.DealOffer, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("creating deal offer: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		INSERT INTO deal_offers (id, deal_id, author_id, price_nano_ton, scheduled_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+offerColumns,
+		id, o.DealID, o.AuthorID, o.PriceNanoTON, o.ScheduledAt)
+	if err != nil {
+		return nil, fmt.Errorf("creating deal offer: %w", err)
+	}
+
+	created, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.DealOffer])
+	if err != nil {
+		return nil, fmt.Errorf("creating deal offer: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (r *repo) GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.DealOffer, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+offerColumns+`
+		FROM deal_offers
+		WHERE deal_id = $1
+		ORDER BY created_at ASC, id ASC
+	`, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("getting deal offers: %w", err)
+	}
+
+	offers, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.DealOffer])
+	if err != nil {
+		return nil, fmt.Errorf("getting deal offers: %w", err)
+	}
+
+	return offers, nil
+}
+
+func (r *repo) GetPending(ctx context.Context, dealID uuid.UUID) (*entity.DealOffer, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+offerColumns+`
+		FROM deal_offers
+		WHERE deal_id = $1 AND status = 'pending'
+	`, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("getting pending deal offer: %w", err)
+	}
+
+	o, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.DealOffer])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("getting pending deal offer: %w", dto.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting pending deal offer: %w", err)
+	}
+
+	return &o, nil
+}
+
+// Respond closes a pending offer with the given outcome. It fails with
+// ErrInvalidTransition if the offer was already answered.
+func (r *repo) Respond(ctx context.Context, id uuid.UUID, status entity.DealOfferStatus) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE deal_offers
+		SET status = $2, responded_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+	`, id, status)
+	if err != nil {
+		return fmt.Errorf("responding to deal offer: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("responding to deal offer: %w", dto.ErrInvalidTransition)
+	}
+	return nil
+}
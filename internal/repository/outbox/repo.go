@@ -0,0 +1,83 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+type db interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+type repo struct {
+	db db
+}
+
+func New(db db) *repo {
+	return &repo{db: db}
+}
+
+// Create records an event for the deal. Each event is emitted at most once
+// per deal, so repeated calls are no-ops.
+func (r *repo) Create(ctx context.Context, dealID uuid.UUID, event entity.OutboxEvent) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("creating outbox message: %w", err)
+	}
+
+	_, err = r.db.Exec(ctx, `
+		INSERT INTO outbox (id, deal_id, event)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (deal_id, event) DO NOTHING
+	`, id, dealID, event)
+	if err != nil {
+		return fmt.Errorf("creating outbox message: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) GetUnprocessed(
+	ctx context.Context, event entity.OutboxEvent, limit int,
+) ([]entity.OutboxMessage, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, deal_id, event, created_at, processed_at
+		FROM outbox
+		WHERE event = $1 AND processed_at IS NULL
+		ORDER BY created_at ASC
+		LIMIT $2
+	`, event, limit)
+	if err != nil {
+		return nil, fmt.Errorf("getting unprocessed outbox messages: %w", err)
+	}
+
+	msgs, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.OutboxMessage])
+	if err != nil {
+		return nil, fmt.Errorf("getting unprocessed outbox messages: %w", err)
+	}
+
+	return msgs, nil
+}
+
+func (r *repo) MarkProcessed(ctx context.Context, id uuid.UUID) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE outbox
+		SET processed_at = NOW()
+		WHERE id = $1 AND processed_at IS NULL
+	`, id)
+	if err != nil {
+		return fmt.Errorf("marking outbox message processed: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("marking outbox message processed: %w", dto.ErrNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package promotion
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+const promotionColumns = `p.id, p.channel_id, c.telegram_channel_id, p.starts_at, p.ends_at,
+	p.note, p.created_by, p.created_at`
+
+type db interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+type repo struct {
+	db db
+}
+
+func New(db db) *repo {
+	return &repo{db: db}
+}
+
+func (r *repo) Create(ctx context.Context, p *entity.FeePromotion) (*entity.FeePromotion, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("creating fee promotion: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		WITH p AS (
+			INSERT INTO fee_promotions (id, channel_id, starts_at, ends_at, note, created_by)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING *
+		)
+		SELECT `+promotionColumns+`
+		FROM p
+		LEFT JOIN channels c ON c.id = p.channel_id
+	`, id, p.ChannelID, p.StartsAt, p.EndsAt, p.Note, p.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("creating fee promotion: %w", err)
+	}
+
+	created, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.FeePromotion])
+	if err != nil {
+		return nil, fmt.Errorf("creating fee promotion: %w", err)
+	}
+
+	return &created, nil
+}
+
+// GetUpcoming returns the promotions that have not ended yet, soonest first.
+func (r *repo) GetUpcoming(ctx context.Context) ([]entity.FeePromotion, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+promotionColumns+`
+		FROM fee_promotions p
+		LEFT JOIN channels c ON c.id = p.channel_id
+		WHERE p.ends_at > NOW()
+		ORDER BY p.starts_at ASC, p.id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("getting fee promotions: %w", err)
+	}
+
+	promotions, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.FeePromotion])
+	if err != nil {
+		return nil, fmt.Errorf("getting fee promotions: %w", err)
+	}
+
+	return promotions, nil
+}
+
+// Covers reports whether a promotion for the channel, or for every channel,
+// is running at the given time.
+func (r *repo) Covers(ctx context.Context, channelID uuid.UUID, at time.Time) (bool, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM fee_promotions
+			WHERE (channel_id IS NULL OR channel_id = $1)
+				AND starts_at <= $2 AND ends_at > $2
+		)
+	`, channelID, at)
+	if err != nil {
+		return false, fmt.Errorf("checking fee promotions: %w", err)
+	}
+
+	covered, err := pgx.CollectOneRow(rows, pgx.RowTo[bool])
+	if err != nil {
+		return false, fmt.Errorf("checking fee promotions: %w", err)
+	}
+
+	return covered, nil
+}
+
+func (r *repo) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM fee_promotions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("deleting fee promotion: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("deleting fee promotion: %w", dto.ErrNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package rating
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+const ratingColumns = `id, deal_id, author_id, party, rating, comment, created_at`
+
+type db interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+type repo struct {
+	db db
+}
+
+func New(db db) *repo {
+	return &repo{db: db}
+}
+
+// Create stores a rating; each side of a deal rates it once.
+func (r *repo) Create(ctx context.Context, rt *entity.DealRating) (*entity.DealRating, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("creating deal rating: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		INSERT INTO deal_ratings (id, deal_id, author_id, party, rating, comment)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+ratingColumns,
+		id, rt.DealID, rt.AuthorID, rt.Party, rt.Rating, rt.Comment)
+	if err != nil {
+		return nil, fmt.Errorf("creating deal rating: %w", err)
+	}
+
+	created, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.DealRating])
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return nil, fmt.Errorf("creating deal rating: %w", dto.ErrAlreadyRated)
+		}
+		return nil, fmt.Errorf("creating deal rating: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (r *repo) GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.DealRating, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+ratingColumns+`
+		FROM deal_ratings
+		WHERE deal_id = $1
+		ORDER BY created_at ASC
+	`, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("getting deal ratings: %w", err)
+	}
+
+	ratings, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.DealRating])
+	if err != nil {
+		return nil, fmt.Errorf("getting deal ratings: %w", err)
+	}
+
+	return ratings, nil
+}
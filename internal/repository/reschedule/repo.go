@@ -0,0 +1,115 @@
+package reschedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+const rescheduleColumns = `id, deal_id, author_id, scheduled_at, status, created_at, responded_at`
+
+type db interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+type repo struct {
+	db db
+}
+
+func New(db db) *repo {
+	return &repo{db: db}
+}
+
+func (r *repo) Create(
+	ctx context.Context, rs *entity.DealReschedule,
+) (*entity.DealReschedule, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("creating deal reschedule: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		INSERT INTO deal_reschedules (id, deal_id, author_id, scheduled_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+rescheduleColumns,
+		id, rs.DealID, rs.AuthorID, rs.ScheduledAt)
+	if err != nil {
+		return nil, fmt.Errorf("creating deal reschedule: %w", err)
+	}
+
+	created, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.DealReschedule])
+	if err != nil {
+		return nil, fmt.Errorf("creating deal reschedule: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (r *repo) GetByDealID(
+	ctx context.Context, dealID uuid.UUID,
+) ([]entity.DealReschedule, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+rescheduleColumns+`
+		FROM deal_reschedules
+		WHERE deal_id = $1
+		ORDER BY created_at ASC, id ASC
+	`, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("getting deal reschedules: %w", err)
+	}
+
+	list, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.DealReschedule])
+	if err != nil {
+		return nil, fmt.Errorf("getting deal reschedules: %w", err)
+	}
+
+	return list, nil
+}
+
+func (r *repo) GetPending(ctx context.Context, dealID uuid.UUID) (*entity.DealReschedule, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+rescheduleColumns+`
+		FROM deal_reschedules
+		WHERE deal_id = $1 AND status = 'pending'
+	`, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("getting pending deal reschedule: %w", err)
+	}
+
+	rs, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.DealReschedule])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("getting pending deal reschedule: %w", dto.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting pending deal reschedule: %w", err)
+	}
+
+	return &rs, nil
+}
+
+// Respond closes a pending proposal with the given outcome. It fails with
+// ErrInvalidTransition if the proposal was already answered.
+func (r *repo) Respond(
+	ctx context.Context, id uuid.UUID, status entity.DealRescheduleStatus,
+) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE deal_reschedules
+		SET status = $2, responded_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+	`, id, status)
+	if err != nil {
+		return fmt.Errorf("responding to deal reschedule: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("responding to deal reschedule: %w", dto.ErrInvalidTransition)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package revision
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+const revisionColumns = `deal_id, version, author_id, publisher_note,
+	advertiser_approved_at, publisher_approved_at, created_at`
+
+type db interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+type repo struct {
+	db db
+}
+
+func New(db db) *repo {
+	return &repo{db: db}
+}
+
+func (r *repo) Create(
+	ctx context.Context, rev *entity.AdRevision,
+) (*entity.AdRevision, error) {
+	rows, err := r.db.Query(ctx, `
+		INSERT INTO ad_revisions (
+			deal_id, version, author_id, publisher_note,
+			advertiser_approved_at, publisher_approved_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+revisionColumns,
+		rev.DealID, rev.Version, rev.AuthorID, rev.PublisherNote,
+		rev.AdvertiserApprovedAt, rev.PublisherApprovedAt)
+	if err != nil {
+		return nil, fmt.Errorf("creating ad revision: %w", err)
+	}
+
+	created, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.AdRevision])
+	if err != nil {
+		return nil, fmt.Errorf("creating ad revision: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (r *repo) GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.AdRevision, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+revisionColumns+`
+		FROM ad_revisions
+		WHERE deal_id = $1
+		ORDER BY version ASC
+	`, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("getting ad revisions: %w", err)
+	}
+
+	revisions, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.AdRevision])
+	if err != nil {
+		return nil, fmt.Errorf("getting ad revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (r *repo) GetLatest(ctx context.Context, dealID uuid.UUID) (*entity.AdRevision, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+revisionColumns+`
+		FROM ad_revisions
+		WHERE deal_id = $1
+		ORDER BY version DESC
+		LIMIT 1
+	`, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("getting latest ad revision: %w", err)
+	}
+
+	rev, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.AdRevision])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("getting latest ad revision: %w", dto.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting latest ad revision: %w", err)
+	}
+
+	return &rev, nil
+}
+
+// Approve records the party's approval of a version and returns it; an
+// earlier approval by the same party is kept.
+func (r *repo) Approve(
+	ctx context.Context, dealID uuid.UUID, version int, party entity.DealParty,
+) (*entity.AdRevision, error) {
+	column := "advertiser_approved_at"
+	if party == entity.DealPartyPublisher {
+		column = "publisher_approved_at"
+	}
+
+	rows, err := r.db.Query(ctx, `
+		UPDATE ad_revisions
+		SET `+column+` = COALESCE(`+column+`, NOW())
+		WHERE deal_id = $1 AND version = $2
+		RETURNING `+revisionColumns,
+		dealID, version)
+	if err != nil {
+		return nil, fmt.Errorf("approving ad revision: %w", err)
+	}
+
+	rev, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[entity.AdRevision])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("approving ad revision: %w", dto.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("approving ad revision: %w", err)
+	}
+
+	return &rev, nil
+}
@@ -0,0 +1,62 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+const snapshotColumns = `deal_id, taken_at, views, forwards, reactions`
+
+type db interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+type repo struct {
+	db db
+}
+
+func New(db db) *repo {
+	return &repo{db: db}
+}
+
+func (r *repo) Create(ctx context.Context, snap *entity.DealViewSnapshot) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO deal_view_snapshots (deal_id, views, forwards, reactions)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (deal_id, taken_at) DO NOTHING
+	`, snap.DealID, snap.Views, snap.Forwards, snap.Reactions)
+	if err != nil {
+		return fmt.Errorf("creating deal view snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// GetByDealID returns the snapshots of a deal, oldest first.
+func (r *repo) GetByDealID(
+	ctx context.Context, dealID uuid.UUID,
+) ([]entity.DealViewSnapshot, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+snapshotColumns+`
+		FROM deal_view_snapshots
+		WHERE deal_id = $1
+		ORDER BY taken_at ASC
+	`, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("getting deal view snapshots: %w", err)
+	}
+
+	snaps, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.DealViewSnapshot])
+	if err != nil {
+		return nil, fmt.Errorf("getting deal view snapshots: %w", err)
+	}
+
+	return snaps, nil
+}
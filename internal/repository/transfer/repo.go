@@ -0,0 +1,185 @@
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+type db interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+type repo struct {
+	db db
+}
+
+func New(db db) *repo {
+	return &repo{db: db}
+}
+
+const transferColumns = `
+	id, deal_id, kind, destination, amount_nano_ton, fee_nano_ton, comment,
+	status, seqno, valid_until, tx_hash, attempts, last_error,
+	created_at, updated_at
+`
+
+// Create queues a transfer. A deal has at most one payout and one refund,
+// and every transfer has a unique comment, so queueing the same transfer
+// twice is a no-op.
+func (r *repo) Create(ctx context.Context, t *entity.Transfer) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("creating transfer: %w", err)
+	}
+
+	_, err = r.db.Exec(ctx, `
+		INSERT INTO transfers (
+			id, deal_id, kind, destination, amount_nano_ton, fee_nano_ton, comment
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT DO NOTHING
+	`, id, t.DealID, t.Kind, t.Destination, t.AmountNanoTON, t.FeeNanoTON, t.Comment)
+	if err != nil {
+		return fmt.Errorf("creating transfer: %w", err)
+	}
+
+	return nil
+}
+
+// CreateSettled records a transfer that moves nothing on-chain, such as a
+// payout the platform fee takes whole, as already confirmed. Unlike Create it
+// fails for a transfer recorded before, so its ledger postings are not
+// repeated.
+func (r *repo) CreateSettled(ctx context.Context, t *entity.Transfer) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("creating settled transfer: %w", err)
+	}
+
+	_, err = r.db.Exec(ctx, `
+		INSERT INTO transfers (
+			id, deal_id, kind, destination, amount_nano_ton, fee_nano_ton, comment, status
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, 'confirmed')
+	`, id, t.DealID, t.Kind, t.Destination, t.AmountNanoTON, t.FeeNanoTON, t.Comment)
+	if err != nil {
+		return fmt.Errorf("creating settled transfer: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) GetByStatus(
+	ctx context.Context, status entity.TransferStatus, limit int,
+) ([]entity.Transfer, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+transferColumns+`
+		FROM transfers
+		WHERE status = $1
+		ORDER BY created_at ASC
+		LIMIT $2
+	`, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("getting transfers by status: %w", err)
+	}
+
+	transfers, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Transfer])
+	if err != nil {
+		return nil, fmt.Errorf("getting transfers by status: %w", err)
+	}
+
+	return transfers, nil
+}
+
+func (r *repo) GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.Transfer, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+transferColumns+`
+		FROM transfers
+		WHERE deal_id = $1
+		ORDER BY created_at ASC
+	`, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("getting transfers by deal id: %w", err)
+	}
+
+	transfers, err := pgx.CollectRows(rows, pgx.RowToStructByName[entity.Transfer])
+	if err != nil {
+		return nil, fmt.Errorf("getting transfers by deal id: %w", err)
+	}
+
+	return transfers, nil
+}
+
+func (r *repo) MarkSent(
+	ctx context.Context, id uuid.UUID, seqno int64, validUntil time.Time,
+) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE transfers
+		SET status = 'sent', seqno = $2, valid_until = $3,
+			attempts = attempts + 1, updated_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+	`, id, seqno, validUntil)
+	if err != nil {
+		return fmt.Errorf("marking transfer sent: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("marking transfer sent: %w", dto.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *repo) MarkConfirmed(ctx context.Context, id uuid.UUID, txHash string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE transfers
+		SET status = 'confirmed', tx_hash = $2, last_error = NULL, updated_at = NOW()
+		WHERE id = $1 AND status = 'sent'
+	`, id, txHash)
+	if err != nil {
+		return fmt.Errorf("marking transfer confirmed: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("marking transfer confirmed: %w", dto.ErrNotFound)
+	}
+	return nil
+}
+
+// Reset returns an expired transfer to the queue so it is sent again.
+func (r *repo) Reset(ctx context.Context, id uuid.UUID, reason string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE transfers
+		SET status = 'pending', seqno = NULL, valid_until = NULL,
+			last_error = $2, updated_at = NOW()
+		WHERE id = $1 AND status = 'sent'
+	`, id, reason)
+	if err != nil {
+		return fmt.Errorf("resetting transfer: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("resetting transfer: %w", dto.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *repo) MarkFailed(ctx context.Context, id uuid.UUID, reason string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE transfers
+		SET status = 'failed', last_error = $2, updated_at = NOW()
+		WHERE id = $1 AND status = 'sent'
+	`, id, reason)
+	if err != nil {
+		return fmt.Errorf("marking transfer failed: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("marking transfer failed: %w", dto.ErrNotFound)
+	}
+	return nil
+}
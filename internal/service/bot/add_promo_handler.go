@@ -36,6 +36,10 @@ func (b *svc) handleIncomingMessage(c tele.Context) error {
 		return nil
 	}
 
+	if answer, routed := b.routeReply(msg); routed {
+		return c.Send(answer)
+	}
+
 	saved, confusing := b.processMessage(msg)
 	if confusing {
 		return c.Send("confusing...")
@@ -149,6 +153,10 @@ func (b *svc) HandleUpdate(upd tele.Update) {
 		return
 	}
 
+	if _, routed := b.routeReply(msg); routed {
+		return
+	}
+
 	saved, _ := b.processMessage(msg)
 	if saved && msg.AlbumID == "" {
 		b.awaitingPost.Delete(msg.Sender.ID)
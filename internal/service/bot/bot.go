@@ -75,6 +75,14 @@ type PostRepository interface {
 	) (*entity.Post, error)
 }
 
+type MessageRepository interface {
+	GetByRelay(ctx context.Context, chatID int64, tgMessageID int) (*entity.DealMessage, error)
+}
+
+type DealService interface {
+	PostMessage(ctx context.Context, dealID uuid.UUID, text string) (*dto.DealMessageItem, error)
+}
+
 type svc struct {
 	client        TelebotClient
 	log           *slog.Logger
@@ -84,6 +92,8 @@ type svc struct {
 	userRepo      UserRepository
 	stats         StatsFetcher
 	postRepo      PostRepository
+	messageRepo   MessageRepository
+	deals         DealService
 	awaitingPost  sync.Map
 	pendingGroups sync.Map
 }
@@ -97,6 +107,8 @@ func New(
 	users UserRepository,
 	stats StatsFetcher,
 	posts PostRepository,
+	messages MessageRepository,
+	deals DealService,
 ) *svc {
 	log = log.With(logx.Service("BotService"))
 
@@ -109,6 +121,8 @@ func New(
 		userRepo:    users,
 		stats:       stats,
 		postRepo:    posts,
+		messageRepo: messages,
+		deals:       deals,
 	}
 
 	s.registerHandlers()
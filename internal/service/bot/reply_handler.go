@@ -0,0 +1,56 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	tele "gopkg.in/telebot.v4"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+)
+
+// routeReply posts a reply to a forwarded deal message back into the deal's
+// thread. It reports whether the message was such a reply, along with the
+// answer for the sender.
+func (b *svc) routeReply(msg *tele.Message) (answer string, routed bool) {
+	if msg.ReplyTo == nil || msg.Chat == nil {
+		return "", false
+	}
+
+	ctx := context.Background()
+
+	original, err := b.messageRepo.GetByRelay(ctx, msg.Chat.ID, msg.ReplyTo.ID)
+	if errors.Is(err, dto.ErrNotFound) {
+		return "", false
+	}
+	if err != nil {
+		b.log.Error("failed to get relayed message", "error", err)
+		return "Failed to send your reply, please try again later.", true
+	}
+
+	if msg.Text == "" {
+		return "Only text replies can be sent to the deal.", true
+	}
+
+	user, err := b.userRepo.GetByTgID(ctx, msg.Sender.ID)
+	if err != nil {
+		b.log.Error("failed to get user", "telegram_id", msg.Sender.ID, "error", err)
+		return "Failed to send your reply, please try again later.", true
+	}
+	ctx = dto.ContextWithUser(ctx, dto.UserContext{ID: user.ID, TgID: user.TgID})
+
+	_, err = b.deals.PostMessage(ctx, original.DealID, msg.Text)
+	var apiErr *dto.APIError
+	switch {
+	case err == nil:
+		return "Sent to the deal.", true
+	case errors.Is(err, dto.ErrForbidden):
+		return "You are no longer part of this deal.", true
+	case errors.As(err, &apiErr) && apiErr.Status() == http.StatusBadRequest:
+		return "Your reply is empty or too long.", true
+	default:
+		b.log.Error("failed to post deal message", "deal_id", original.DealID, "error", err)
+		return "Failed to send your reply, please try again later.", true
+	}
+}
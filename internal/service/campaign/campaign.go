@@ -0,0 +1,306 @@
+package campaign
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+
+	"github.com/google/uuid"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+	"github.com/bpva/ad-marketplace/internal/logx"
+	"github.com/bpva/ad-marketplace/internal/service/deal"
+	"github.com/bpva/ad-marketplace/internal/storage"
+)
+
+type CampaignRepository interface {
+	Create(ctx context.Context, c *entity.Campaign) (*entity.Campaign, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Campaign, error)
+	GetByAdvertiserID(ctx context.Context, advertiserID uuid.UUID) ([]entity.Campaign, error)
+	GetTotals(ctx context.Context, campaignIDs []uuid.UUID) ([]entity.CampaignDealTotal, error)
+}
+
+type DealRepository interface {
+	GetByCampaignID(ctx context.Context, campaignID uuid.UUID) ([]entity.Deal, error)
+}
+
+type ChannelRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Channel, error)
+}
+
+type PostRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Post, error)
+}
+
+type DealService interface {
+	CreateDeal(
+		ctx context.Context,
+		params deal.CreateDealParams,
+	) (*entity.Deal, []entity.Post, error)
+}
+
+// CreateCampaignParams places one template in every target; TemplatePostID
+// and CampaignID of the targets are filled in from the campaign.
+type CreateCampaignParams struct {
+	Name           string
+	TemplatePostID uuid.UUID
+	BudgetNanoTON  int64
+	Targets        []deal.CreateDealParams
+}
+
+type svc struct {
+	campaignRepo CampaignRepository
+	dealRepo     DealRepository
+	channelRepo  ChannelRepository
+	postRepo     PostRepository
+	deals        DealService
+	tx           storage.Transactor
+	log          *slog.Logger
+}
+
+func New(
+	campaignRepo CampaignRepository,
+	dealRepo DealRepository,
+	channelRepo ChannelRepository,
+	postRepo PostRepository,
+	deals DealService,
+	tx storage.Transactor,
+	log *slog.Logger,
+) *svc {
+	log = log.With(logx.Service("CampaignService"))
+	return &svc{
+		campaignRepo: campaignRepo,
+		dealRepo:     dealRepo,
+		channelRepo:  channelRepo,
+		postRepo:     postRepo,
+		deals:        deals,
+		tx:           tx,
+		log:          log,
+	}
+}
+
+// CreateCampaign creates the campaign and a deal for each of its targets.
+// Either every deal is created or none is; a target that cannot be booked
+// fails the whole campaign and is named in the error details.
+func (s *svc) CreateCampaign(
+	ctx context.Context,
+	params CreateCampaignParams,
+) (*dto.CampaignItem, error) {
+	user, ok := dto.UserFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("create campaign: %w", dto.ErrForbidden)
+	}
+
+	tmpl, err := s.postRepo.GetByID(ctx, params.TemplatePostID)
+	if err != nil {
+		return nil, fmt.Errorf("get template: %w", err)
+	}
+	if tmpl.Type != entity.PostTypeTemplate || tmpl.ExternalID != user.ID {
+		return nil, fmt.Errorf("create campaign: %w", dto.ErrForbidden)
+	}
+
+	var total int64
+	for i := range params.Targets {
+		total += params.Targets[i].PriceNanoTON
+	}
+	if total > params.BudgetNanoTON {
+		return nil, fmt.Errorf("create campaign: %w", dto.ErrBudgetExceeded.WithDetails(
+			map[string]any{"total_nano_ton": total},
+		))
+	}
+
+	var created *entity.Campaign
+	var deals []entity.Deal
+	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		var txErr error
+		created, txErr = s.campaignRepo.Create(txCtx, &entity.Campaign{
+			AdvertiserID:   user.ID,
+			Name:           params.Name,
+			TemplatePostID: params.TemplatePostID,
+			BudgetNanoTON:  params.BudgetNanoTON,
+		})
+		if txErr != nil {
+			return fmt.Errorf("create campaign: %w", txErr)
+		}
+
+		for i := range params.Targets {
+			target := params.Targets[i]
+			target.TemplatePostID = params.TemplatePostID
+			target.CampaignID = &created.ID
+			d, _, txErr := s.deals.CreateDeal(txCtx, target)
+			if txErr != nil {
+				return targetError(i, txErr)
+			}
+			deals = append(deals, *d)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("campaign created",
+		"campaign_id", created.ID,
+		"advertiser_id", user.TgID,
+		"deals", len(deals),
+	)
+
+	return s.build(ctx, created, deals)
+}
+
+func (s *svc) GetCampaign(ctx context.Context, campaignID uuid.UUID) (*dto.CampaignItem, error) {
+	user, ok := dto.UserFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("get campaign: %w", dto.ErrForbidden)
+	}
+
+	c, err := s.campaignRepo.GetByID(ctx, campaignID)
+	if err != nil {
+		return nil, fmt.Errorf("get campaign: %w", err)
+	}
+	if c.AdvertiserID != user.ID {
+		return nil, fmt.Errorf("get campaign: %w", dto.ErrForbidden)
+	}
+
+	deals, err := s.dealRepo.GetByCampaignID(ctx, c.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get campaign deals: %w", err)
+	}
+
+	return s.build(ctx, c, deals)
+}
+
+// ListCampaigns returns the user's campaigns with their totals but without
+// the deals themselves.
+func (s *svc) ListCampaigns(ctx context.Context) ([]dto.CampaignItem, error) {
+	user, ok := dto.UserFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("list campaigns: %w", dto.ErrForbidden)
+	}
+
+	campaigns, err := s.campaignRepo.GetByAdvertiserID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list campaigns: %w", err)
+	}
+	if len(campaigns) == 0 {
+		return []dto.CampaignItem{}, nil
+	}
+
+	ids := make([]uuid.UUID, len(campaigns))
+	for i := range campaigns {
+		ids[i] = campaigns[i].ID
+	}
+	totals, err := s.campaignRepo.GetTotals(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get campaign totals: %w", err)
+	}
+
+	byCampaign := make(map[uuid.UUID][]entity.CampaignDealTotal, len(campaigns))
+	for _, t := range totals {
+		byCampaign[t.CampaignID] = append(byCampaign[t.CampaignID], t)
+	}
+
+	items := make([]dto.CampaignItem, len(campaigns))
+	for i := range campaigns {
+		items[i] = summarize(&campaigns[i], byCampaign[campaigns[i].ID])
+	}
+
+	return items, nil
+}
+
+func (s *svc) build(
+	ctx context.Context, c *entity.Campaign, deals []entity.Deal,
+) (*dto.CampaignItem, error) {
+	totals, err := s.campaignRepo.GetTotals(ctx, []uuid.UUID{c.ID})
+	if err != nil {
+		return nil, fmt.Errorf("get campaign totals: %w", err)
+	}
+
+	item := summarize(c, totals)
+	item.Deals = make([]dto.DealListItem, len(deals))
+	channelCache := make(map[uuid.UUID]int64)
+	for i := range deals {
+		tgChannelID, ok := channelCache[deals[i].ChannelID]
+		if !ok {
+			ch, err := s.channelRepo.GetByID(ctx, deals[i].ChannelID)
+			if err != nil {
+				return nil, fmt.Errorf("get channel: %w", err)
+			}
+			tgChannelID = ch.TgChannelID
+			channelCache[deals[i].ChannelID] = tgChannelID
+		}
+		item.Deals[i] = dto.DealListItem{
+			Deal:        deals[i],
+			TgChannelID: tgChannelID,
+		}
+	}
+
+	return &item, nil
+}
+
+// deals in these statuses have been paid and their funds are still in escrow
+var escrowedStatuses = map[entity.DealStatus]bool{
+	entity.DealStatusPendingReview:    true,
+	entity.DealStatusChangesRequested: true,
+	entity.DealStatusApproved:         true,
+	entity.DealStatusPosted:           true,
+	entity.DealStatusDispute:          true,
+}
+
+// deals in these statuses are over and will not change again
+var finalStatuses = map[entity.DealStatus]bool{
+	entity.DealStatusHoldFailed:    true,
+	entity.DealStatusRejected:      true,
+	entity.DealStatusCancelled:     true,
+	entity.DealStatusPublishFailed: true,
+	entity.DealStatusCompleted:     true,
+	entity.DealStatusResolved:      true,
+}
+
+func summarize(c *entity.Campaign, totals []entity.CampaignDealTotal) dto.CampaignItem {
+	item := dto.CampaignItem{
+		Campaign:      *c,
+		Status:        entity.CampaignStatusFinished,
+		DealsByStatus: make(map[entity.DealStatus]int, len(totals)),
+	}
+
+	for _, t := range totals {
+		item.DealsByStatus[t.Status] += t.Deals
+		if !finalStatuses[t.Status] {
+			item.Status = entity.CampaignStatusActive
+		}
+		if escrowedStatuses[t.Status] {
+			item.CommittedNanoTON += t.PriceNanoTON
+		}
+		switch t.Status {
+		case entity.DealStatusCompleted:
+			item.SpentNanoTON += t.ReleasedNanoTON
+			item.Reach += t.Subscribers
+		case entity.DealStatusResolved:
+			// only the publisher's share of a disputed deal was spent
+			item.SpentNanoTON += t.ReleasedNanoTON
+		case entity.DealStatusPosted:
+			item.Reach += t.Subscribers
+		}
+	}
+
+	return item
+}
+
+// targetError adds the index of the failed target to the error details.
+func targetError(target int, err error) error {
+	var apiErr *dto.APIError
+	if !errors.As(err, &apiErr) {
+		return fmt.Errorf("create deal for target %d: %w", target, err)
+	}
+
+	details := maps.Clone(apiErr.Details())
+	if details == nil {
+		details = make(map[string]any, 1)
+	}
+	details["target"] = target
+
+	return apiErr.WithDetails(details).Wrap(err)
+}
@@ -0,0 +1,157 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+const day = 24 * time.Hour
+
+// GetAvailability returns the channel's inventory and, for each of the given
+// days starting at from, how many ads are booked and whether another one can
+// be. Listed channels are open to every advertiser.
+func (s *svc) GetAvailability(
+	ctx context.Context, tgChannelID int64, from time.Time, days int,
+) (*dto.ChannelAvailabilityResponse, error) {
+	user, ok := dto.UserFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("get availability: %w", dto.ErrForbidden)
+	}
+
+	channel, err := s.channelRepo.GetByTgChannelID(ctx, tgChannelID)
+	if err != nil {
+		return nil, fmt.Errorf("get channel: %w", err)
+	}
+
+	if !channel.IsListed {
+		_, err := s.channelRepo.GetRole(ctx, channel.ID, user.ID)
+		if errors.Is(err, dto.ErrNotFound) {
+			return nil, fmt.Errorf("get availability: %w", dto.ErrForbidden)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get role: %w", err)
+		}
+	}
+
+	inv, err := s.channelRepo.GetInventory(ctx, channel.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get inventory: %w", err)
+	}
+
+	from = from.UTC().Truncate(day)
+	to := from.Add(time.Duration(days) * day)
+	slots, err := s.dealRepo.GetBookedSlots(ctx, channel.ID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("get booked slots: %w", err)
+	}
+
+	resp := &dto.ChannelAvailabilityResponse{
+		MaxAdsPerDay:   inv.MaxAdsPerDay,
+		PostingWindows: make([]dto.PostingWindow, len(inv.PostingWindows)),
+		BlackoutDates:  make([]string, len(inv.BlackoutDates)),
+		Booked:         make([]dto.BookedSlotResponse, len(slots)),
+		Days:           make([]dto.AvailabilityDay, days),
+	}
+	for i, w := range inv.PostingWindows {
+		resp.PostingWindows[i] = dto.PostingWindow{
+			Start: formatClock(w.StartMinute),
+			End:   formatClock(w.EndMinute),
+		}
+	}
+	for i, d := range inv.BlackoutDates {
+		resp.BlackoutDates[i] = d.UTC().Format(time.DateOnly)
+	}
+	for i, slot := range slots {
+		resp.Booked[i] = dto.BookedSlotResponse{From: slot.From, To: slot.To}
+	}
+
+	now := time.Now()
+	for i := range days {
+		start := from.Add(time.Duration(i) * day)
+		booked := 0
+		for _, slot := range slots {
+			if !slot.From.Before(start) && slot.From.Before(start.Add(day)) {
+				booked++
+			}
+		}
+		resp.Days[i] = dto.AvailabilityDay{
+			Date:   start.Format(time.DateOnly),
+			Booked: booked,
+			Available: start.Add(day).After(now) && !inv.IsBlackedOut(start) &&
+				(inv.MaxAdsPerDay == nil || booked < *inv.MaxAdsPerDay),
+		}
+	}
+
+	return resp, nil
+}
+
+// UpdateAvailability replaces the channel's inventory. Deals already booked
+// are kept even if they no longer fit it.
+func (s *svc) UpdateAvailability(
+	ctx context.Context, tgChannelID int64, req dto.UpdateAvailabilityRequest,
+) error {
+	channel, err := s.getChannelEntityAsOwner(ctx, tgChannelID)
+	if err != nil {
+		return err
+	}
+
+	inv := &entity.ChannelInventory{
+		ChannelID:      channel.ID,
+		MaxAdsPerDay:   req.MaxAdsPerDay,
+		PostingWindows: make([]entity.PostingWindow, len(req.PostingWindows)),
+		BlackoutDates:  make([]time.Time, len(req.BlackoutDates)),
+	}
+	for i, w := range req.PostingWindows {
+		start, okStart := parseClock(w.Start)
+		end, okEnd := parseClock(w.End)
+		if !okStart || !okEnd || start >= end {
+			return fmt.Errorf("update availability: %w", dto.ErrValidation.WithDetails(
+				map[string]any{"posting_windows": "each window needs HH:MM start before end"},
+			))
+		}
+		inv.PostingWindows[i] = entity.PostingWindow{StartMinute: start, EndMinute: end}
+	}
+	for i, d := range req.BlackoutDates {
+		date, err := time.Parse(time.DateOnly, d)
+		if err != nil {
+			return fmt.Errorf("update availability: %w", dto.ErrValidation.WithDetails(
+				map[string]any{"blackout_dates": "dates must be YYYY-MM-DD"},
+			))
+		}
+		inv.BlackoutDates[i] = date
+	}
+	slices.SortFunc(inv.PostingWindows, func(a, b entity.PostingWindow) int {
+		return a.StartMinute - b.StartMinute
+	})
+	slices.SortFunc(inv.BlackoutDates, func(a, b time.Time) int { return a.Compare(b) })
+	inv.BlackoutDates = slices.CompactFunc(inv.BlackoutDates, time.Time.Equal)
+
+	if err := s.channelRepo.SetInventory(ctx, inv); err != nil {
+		return fmt.Errorf("update availability: %w", err)
+	}
+
+	s.log.Info("channel availability updated", "channel_id", channel.ID)
+	return nil
+}
+
+// parseClock turns HH:MM into minutes since midnight, accepting 24:00.
+func parseClock(s string) (int, bool) {
+	if s == "24:00" {
+		return 24 * 60, true
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
+
+func formatClock(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	petname "github.com/dustinkirkland/golang-petname"
 	"github.com/google/uuid"
@@ -31,12 +32,14 @@ type ChannelRepository interface {
 	) (*entity.ChannelRole, error)
 	DeleteRole(ctx context.Context, channelID, userID uuid.UUID) error
 	UpdateListing(ctx context.Context, channelID uuid.UUID, isListed bool) error
+	UpdateReviewTimeout(ctx context.Context, channelID uuid.UUID, hours *int) error
 	CreateAdFormat(
 		ctx context.Context,
 		channelID uuid.UUID,
 		formatType entity.AdFormatType,
 		isNative bool,
 		feedHours, topHours int,
+		autoDelete bool,
 		priceNanoTON int64,
 	) (*entity.ChannelAdFormat, error)
 	GetAdFormatByID(ctx context.Context, formatID uuid.UUID) (*entity.ChannelAdFormat, error)
@@ -50,9 +53,19 @@ type ChannelRepository interface {
 	GetInfo(ctx context.Context, channelID uuid.UUID) (*entity.ChannelInfo, error)
 	HasRecentStats(ctx context.Context, channelID uuid.UUID) (bool, error)
 	GetOwnerWalletAddress(ctx context.Context, channelID uuid.UUID) (*string, error)
+	GetInventory(ctx context.Context, channelID uuid.UUID) (*entity.ChannelInventory, error)
+	SetInventory(ctx context.Context, inv *entity.ChannelInventory) error
 	RefreshMV(ctx context.Context) error
 }
 
+type DealRepository interface {
+	GetBookedSlots(
+		ctx context.Context,
+		channelID uuid.UUID,
+		from, to time.Time,
+	) ([]entity.BookedSlot, error)
+}
+
 type UserRepository interface {
 	GetByTgID(ctx context.Context, tgID int64) (*entity.User, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error)
@@ -70,6 +83,7 @@ type Transactor interface {
 
 type svc struct {
 	channelRepo ChannelRepository
+	dealRepo    DealRepository
 	userRepo    UserRepository
 	bot         TelebotClient
 	tx          Transactor
@@ -78,6 +92,7 @@ type svc struct {
 
 func New(
 	channelRepo ChannelRepository,
+	dealRepo DealRepository,
 	userRepo UserRepository,
 	bot TelebotClient,
 	tx Transactor,
@@ -86,6 +101,7 @@ func New(
 	log = log.With(logx.Service("ChannelService"))
 	return &svc{
 		channelRepo: channelRepo,
+		dealRepo:    dealRepo,
 		userRepo:    userRepo,
 		bot:         bot,
 		tx:          tx,
@@ -371,6 +387,9 @@ func (s *svc) GetMarketplaceChannels(
 			AvgInteractions30d:      ch.AvgInteractions30d,
 			EngagementRate7d:        ch.EngagementRate7d,
 			EngagementRate30d:       ch.EngagementRate30d,
+			Rating:                  ch.Rating,
+			RatingCount:             ch.RatingCount,
+			CompletedDeals:          ch.CompletedDeals,
 		}
 
 		formats := make([]dto.AdFormat, 0, len(ch.AdFormats))
@@ -382,6 +401,7 @@ func (s *svc) GetMarketplaceChannels(
 				IsNative:     f.IsNative,
 				FeedHours:    f.FeedHours,
 				TopHours:     f.TopHours,
+				AutoDelete:   f.AutoDelete,
 				PriceNanoTON: f.PriceNanoTON,
 			})
 		}
@@ -532,6 +552,22 @@ func (s *svc) UpdateListing(ctx context.Context, tgChannelID int64, isListed boo
 	return nil
 }
 
+// UpdateReviewTimeout sets how long deals in this channel may wait in review
+// before they are cancelled; nil falls back to the platform default.
+func (s *svc) UpdateReviewTimeout(ctx context.Context, tgChannelID int64, hours *int) error {
+	channel, err := s.getChannelEntityAsOwner(ctx, tgChannelID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.channelRepo.UpdateReviewTimeout(ctx, channel.ID, hours); err != nil {
+		return fmt.Errorf("update review timeout: %w", err)
+	}
+
+	s.log.Info("channel review timeout updated", "channel_id", channel.ID, "hours", hours)
+	return nil
+}
+
 func (s *svc) GetAdFormats(ctx context.Context, tgChannelID int64) (*dto.AdFormatsResponse, error) {
 	channel, err := s.getChannelEntity(ctx, tgChannelID)
 	if err != nil {
@@ -572,6 +608,7 @@ func (s *svc) AddAdFormat(
 		req.IsNative,
 		req.FeedHours,
 		req.TopHours,
+		req.AutoDelete,
 		req.PriceNanoTON,
 	)
 	if err != nil {
@@ -624,6 +661,8 @@ func (s *svc) channelToResponse(ctx context.Context, ch *entity.Channel) dto.Cha
 		IsListed:    ch.IsListed,
 		AdFormats:   []dto.AdFormatResponse{},
 		Categories:  []dto.CategoryResponse{},
+
+		ReviewTimeoutHours: ch.ReviewTimeoutHours,
 	}
 	if ch.Username != nil {
 		resp.Username = *ch.Username
@@ -686,6 +725,7 @@ func adFormatToResponse(f *entity.ChannelAdFormat) dto.AdFormatResponse {
 		IsNative:     f.IsNative,
 		FeedHours:    f.FeedHours,
 		TopHours:     f.TopHours,
+		AutoDelete:   f.AutoDelete,
 		PriceNanoTON: f.PriceNanoTON,
 	}
 }
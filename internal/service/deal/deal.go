@@ -6,31 +6,84 @@ import (
 	"fmt"
 	"log/slog"
 	"slices"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 
+	"github.com/bpva/ad-marketplace/internal/config"
 	"github.com/bpva/ad-marketplace/internal/dto"
 	"github.com/bpva/ad-marketplace/internal/entity"
 	"github.com/bpva/ad-marketplace/internal/logx"
 )
 
-//go:generate mockgen -destination=mocks.go -package=deal . DealRepository,ChannelRepository,PostRepository,UserRepository,Transactor
+//go:generate mockgen -destination=mocks.go -package=deal . DealRepository,ChannelRepository,PostRepository,UserRepository,Transactor,EscrowWallet,TransferRepository,OutboxRepository,RevisionRepository,EventRepository,MessageRepository,OfferRepository,RescheduleRepository,DisputeRepository,SnapshotRepository,RatingRepository,PromotionRepository,LedgerRepository
 
 type DealRepository interface {
 	Create(ctx context.Context, deal *entity.Deal) (*entity.Deal, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Deal, error)
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Deal, error)
 	GetByChannelID(
 		ctx context.Context,
 		channelID uuid.UUID,
-		limit, offset int,
-	) ([]entity.Deal, int, error)
+		f entity.DealFilter,
+	) ([]entity.ChannelDeal, int, error)
 	GetByAdvertiserID(
 		ctx context.Context,
 		advertiserID uuid.UUID,
-		limit, offset int,
-	) ([]entity.Deal, int, error)
-	UpdateStatus(ctx context.Context, id uuid.UUID, status entity.DealStatus, note *string) error
+		f entity.DealFilter,
+	) ([]entity.ChannelDeal, int, error)
+	UpdateStatus(
+		ctx context.Context,
+		id uuid.UUID,
+		from, to entity.DealStatus,
+		note *string,
+	) error
+	TransitionStatus(
+		ctx context.Context,
+		id uuid.UUID,
+		from, to entity.DealStatus,
+		note *string,
+	) (*entity.Deal, error)
+	AcceptTerms(
+		ctx context.Context,
+		id uuid.UUID,
+		priceNanoTON int64,
+		scheduledAt time.Time,
+		deposit *dto.EscrowDeposit,
+		paymentExpiresAt time.Time,
+	) (*entity.Deal, error)
+	GetCampaignBudgetLeft(ctx context.Context, campaignID, exceptDealID uuid.UUID) (int64, error)
+	SettleDispute(
+		ctx context.Context,
+		id uuid.UUID,
+		publisherShare int64,
+		note *string,
+	) (*entity.Deal, error)
+	Reschedule(
+		ctx context.Context,
+		id uuid.UUID,
+		status entity.DealStatus,
+		scheduledAt time.Time,
+	) (*entity.Deal, error)
+	HasSlotConflict(
+		ctx context.Context,
+		channelID, excludeDealID uuid.UUID,
+		from, to time.Time,
+	) (bool, error)
+	CountBooked(
+		ctx context.Context,
+		channelID, excludeDealID uuid.UUID,
+		from, to time.Time,
+	) (int, error)
+	SetPayment(ctx context.Context, id uuid.UUID, txHash, payer string, paidAt time.Time) error
+	SetPostedMessageIDs(
+		ctx context.Context,
+		id uuid.UUID,
+		messageIDs []int64,
+		postedAt time.Time,
+	) error
 }
 
 type ChannelRepository interface {
@@ -42,6 +95,12 @@ type ChannelRepository interface {
 		channelID uuid.UUID,
 	) ([]entity.ChannelAdFormat, error)
 	GetOwnerWalletAddress(ctx context.Context, channelID uuid.UUID) (*string, error)
+	GetInventoryForUpdate(
+		ctx context.Context,
+		channelID uuid.UUID,
+	) (*entity.ChannelInventory, error)
+	GetMarketplaceChannel(ctx context.Context, channelID uuid.UUID) (*entity.MVChannel, error)
+	UpdatePlatformFee(ctx context.Context, channelID uuid.UUID, bps *int64) error
 }
 
 type PostRepository interface {
@@ -58,6 +117,7 @@ type PostRepository interface {
 		posts []entity.Post,
 	) ([]entity.Post, error)
 	GetLatestAd(ctx context.Context, dealID uuid.UUID) ([]entity.Post, error)
+	GetAdVersions(ctx context.Context, dealID uuid.UUID) (map[int][]entity.Post, error)
 }
 
 type UserRepository interface {
@@ -68,7 +128,100 @@ type Transactor interface {
 	WithTx(ctx context.Context, f func(ctx context.Context) error) error
 }
 
+type TransferRepository interface {
+	GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.Transfer, error)
+}
+
+type OutboxRepository interface {
+	Create(ctx context.Context, dealID uuid.UUID, event entity.OutboxEvent) error
+}
+
+type RevisionRepository interface {
+	Create(ctx context.Context, rev *entity.AdRevision) (*entity.AdRevision, error)
+	GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.AdRevision, error)
+	GetLatest(ctx context.Context, dealID uuid.UUID) (*entity.AdRevision, error)
+	Approve(
+		ctx context.Context,
+		dealID uuid.UUID,
+		version int,
+		party entity.DealParty,
+	) (*entity.AdRevision, error)
+}
+
+type EventRepository interface {
+	Create(ctx context.Context, ev *entity.DealEvent) error
+	GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.DealEvent, error)
+}
+
+type MessageRepository interface {
+	Create(ctx context.Context, msg *entity.DealMessage) (*entity.DealMessage, error)
+	GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.DealMessage, error)
+}
+
+type OfferRepository interface {
+	Create(ctx context.Context, offer *entity.DealOffer) (*entity.DealOffer, error)
+	GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.DealOffer, error)
+	GetPending(ctx context.Context, dealID uuid.UUID) (*entity.DealOffer, error)
+	Respond(ctx context.Context, id uuid.UUID, status entity.DealOfferStatus) error
+}
+
+type RescheduleRepository interface {
+	Create(ctx context.Context, rs *entity.DealReschedule) (*entity.DealReschedule, error)
+	GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.DealReschedule, error)
+	GetPending(ctx context.Context, dealID uuid.UUID) (*entity.DealReschedule, error)
+	Respond(ctx context.Context, id uuid.UUID, status entity.DealRescheduleStatus) error
+}
+
+type DisputeRepository interface {
+	Create(ctx context.Context, d *entity.DealDispute) (*entity.DealDispute, error)
+	GetByDealID(ctx context.Context, dealID uuid.UUID) (*entity.DealDispute, error)
+	GetOpen(ctx context.Context) ([]entity.DealDispute, error)
+	Resolve(
+		ctx context.Context,
+		id uuid.UUID,
+		outcome entity.DisputeOutcome,
+		arbiterID uuid.UUID,
+		note *string,
+	) error
+	CreateEvidence(
+		ctx context.Context,
+		e *entity.DisputeEvidence,
+	) (*entity.DisputeEvidence, error)
+	GetEvidence(ctx context.Context, disputeID uuid.UUID) ([]entity.DisputeEvidence, error)
+}
+
+type SnapshotRepository interface {
+	GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.DealViewSnapshot, error)
+}
+
+type RatingRepository interface {
+	Create(ctx context.Context, rt *entity.DealRating) (*entity.DealRating, error)
+	GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.DealRating, error)
+}
+
+type PromotionRepository interface {
+	Create(ctx context.Context, p *entity.FeePromotion) (*entity.FeePromotion, error)
+	GetUpcoming(ctx context.Context) ([]entity.FeePromotion, error)
+	Covers(ctx context.Context, channelID uuid.UUID, at time.Time) (bool, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type LedgerRepository interface {
+	Post(ctx context.Context, legs []entity.LedgerEntry) error
+	GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.LedgerEntry, error)
+	GetBalances(ctx context.Context, userID uuid.UUID) ([]entity.LedgerBalance, error)
+}
+
+type EscrowWallet interface {
+	Provision(ctx context.Context) (*dto.EscrowDeposit, error)
+}
+
 var validTransitions = map[entity.DealStatus][]entity.DealStatus{
+	entity.DealStatusNegotiating: {
+		entity.DealStatusPendingPayment,
+		entity.DealStatusRejected,
+		entity.DealStatusCancelled,
+	},
 	entity.DealStatusPendingPayment: {
 		entity.DealStatusPendingReview,
 		entity.DealStatusHoldFailed,
@@ -81,8 +234,16 @@ var validTransitions = map[entity.DealStatus][]entity.DealStatus{
 		entity.DealStatusCancelled,
 	},
 	entity.DealStatusChangesRequested: {entity.DealStatusPendingReview, entity.DealStatusCancelled},
-	entity.DealStatusApproved:         {entity.DealStatusPosted},
+	entity.DealStatusApproved:         {entity.DealStatusPosted, entity.DealStatusPublishFailed},
 	entity.DealStatusPosted:           {entity.DealStatusCompleted, entity.DealStatusDispute},
+	entity.DealStatusDispute:          {entity.DealStatusResolved},
+}
+
+// unpaidClosedStatuses are the terminal statuses a deal can reach before its
+// payment lands; a payment arriving afterwards is refunded.
+var unpaidClosedStatuses = []entity.DealStatus{
+	entity.DealStatusHoldFailed,
+	entity.DealStatusCancelled,
 }
 
 func canTransition(from, to entity.DealStatus) bool {
@@ -98,33 +259,88 @@ type CreateDealParams struct {
 	PriceNanoTON   int64
 	TemplatePostID uuid.UUID
 	ScheduledAt    time.Time
+	// Offer proposes the price and schedule instead of taking the listing
+	// as is; the deal is negotiated before it can be paid.
+	Offer bool
+	// CampaignID links the deal to the campaign it was placed from.
+	CampaignID *uuid.UUID
+}
+
+// RevisionParams is a new ad creative: either a copy of one of the
+// advertiser's templates, or new text for the current creative.
+type RevisionParams struct {
+	TemplatePostID *uuid.UUID
+	Text           *string
+	Entities       []byte
 }
 
 type svc struct {
-	dealRepo    DealRepository
-	channelRepo ChannelRepository
-	postRepo    PostRepository
-	userRepo    UserRepository
-	tx          Transactor
-	log         *slog.Logger
+	cfg            config.Deal
+	dealRepo       DealRepository
+	channelRepo    ChannelRepository
+	postRepo       PostRepository
+	userRepo       UserRepository
+	transferRepo   TransferRepository
+	outboxRepo     OutboxRepository
+	revisionRepo   RevisionRepository
+	eventRepo      EventRepository
+	messageRepo    MessageRepository
+	offerRepo      OfferRepository
+	rescheduleRepo RescheduleRepository
+	disputeRepo    DisputeRepository
+	snapshotRepo   SnapshotRepository
+	ratingRepo     RatingRepository
+	promotionRepo  PromotionRepository
+	ledgerRepo     LedgerRepository
+	tx             Transactor
+	escrow         EscrowWallet
+	log            *slog.Logger
 }
 
 func New(
+	cfg config.Deal,
 	dealRepo DealRepository,
 	channelRepo ChannelRepository,
 	postRepo PostRepository,
 	userRepo UserRepository,
+	transferRepo TransferRepository,
+	outboxRepo OutboxRepository,
+	revisionRepo RevisionRepository,
+	eventRepo EventRepository,
+	messageRepo MessageRepository,
+	offerRepo OfferRepository,
+	rescheduleRepo RescheduleRepository,
+	disputeRepo DisputeRepository,
+	snapshotRepo SnapshotRepository,
+	ratingRepo RatingRepository,
+	promotionRepo PromotionRepository,
+	ledgerRepo LedgerRepository,
 	tx Transactor,
+	escrow EscrowWallet,
 	log *slog.Logger,
 ) *svc {
 	log = log.With(logx.Service("DealService"))
 	return &svc{
-		dealRepo:    dealRepo,
-		channelRepo: channelRepo,
-		postRepo:    postRepo,
-		userRepo:    userRepo,
-		tx:          tx,
-		log:         log,
+		cfg:            cfg,
+		dealRepo:       dealRepo,
+		channelRepo:    channelRepo,
+		postRepo:       postRepo,
+		userRepo:       userRepo,
+		transferRepo:   transferRepo,
+		outboxRepo:     outboxRepo,
+		revisionRepo:   revisionRepo,
+		eventRepo:      eventRepo,
+		messageRepo:    messageRepo,
+		offerRepo:      offerRepo,
+		rescheduleRepo: rescheduleRepo,
+		disputeRepo:    disputeRepo,
+		snapshotRepo:   snapshotRepo,
+		ratingRepo:     ratingRepo,
+		promotionRepo:  promotionRepo,
+		ledgerRepo:     ledgerRepo,
+		tx:             tx,
+		escrow:         escrow,
+		log:            log,
 	}
 }
 
@@ -166,13 +382,12 @@ func (s *svc) CreateDeal(
 		return nil, nil, fmt.Errorf("find ad format: %w", dto.ErrNotFound)
 	}
 
-	if matched.PriceNanoTON != params.PriceNanoTON {
+	if !params.Offer && matched.PriceNanoTON != params.PriceNanoTON {
 		return nil, nil, fmt.Errorf("create deal: %w", dto.ErrPriceMismatch)
 	}
 
-	if time.Now().After(params.ScheduledAt) {
-		return nil, nil, fmt.Errorf("create deal: %w",
-			dto.ErrValidation.WithDetails(map[string]any{"scheduled_at": "must be in the future"}))
+	if err := s.checkSchedule(params.ScheduledAt); err != nil {
+		return nil, nil, fmt.Errorf("create deal: %w", err)
 	}
 
 	tmpl, err := s.postRepo.GetByID(ctx, params.TemplatePostID)
@@ -194,10 +409,16 @@ func (s *svc) CreateDeal(
 		return nil, nil, fmt.Errorf("get payout wallet: %w", err)
 	}
 
+	feeBPS, err := s.platformFee(ctx, channel, time.Now())
+	if err != nil {
+		return nil, nil, fmt.Errorf("get platform fee: %w", err)
+	}
+
 	deal := &entity.Deal{
 		ChannelID:               channel.ID,
 		AdvertiserID:            user.ID,
-		Status:                  entity.DealStatusPendingPayment,
+		CampaignID:              params.CampaignID,
+		Status:                  entity.DealStatusNegotiating,
 		ScheduledAt:             params.ScheduledAt,
 		AdvertiserWalletAddress: advertiser.WalletAddress,
 		PayoutWalletAddress:     payoutWallet,
@@ -205,12 +426,30 @@ func (s *svc) CreateDeal(
 		IsNative:                matched.IsNative,
 		FeedHours:               matched.FeedHours,
 		TopHours:                matched.TopHours,
-		PriceNanoTON:            matched.PriceNanoTON,
+		AutoDelete:              matched.AutoDelete,
+		PriceNanoTON:            params.PriceNanoTON,
+		PlatformFeeBPS:          feeBPS,
+	}
+
+	// an offer is funded only once both sides agree on its terms
+	if !params.Offer {
+		deposit, err := s.escrow.Provision(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("provision escrow: %w", err)
+		}
+		paymentExpiresAt := time.Now().Add(s.cfg.PaymentTimeout)
+		deal.Status = entity.DealStatusPendingPayment
+		deal.EscrowWalletAddress = &deposit.Address
+		deal.EscrowMemo = &deposit.Memo
+		deal.PaymentExpiresAt = &paymentExpiresAt
 	}
 
 	var created *entity.Deal
 	var posts []entity.Post
 	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		if err := s.checkSlot(txCtx, deal, deal.ScheduledAt); err != nil {
+			return fmt.Errorf("create deal: %w", err)
+		}
 		var txErr error
 		created, txErr = s.dealRepo.Create(txCtx, deal)
 		if txErr != nil {
@@ -220,7 +459,36 @@ func (s *svc) CreateDeal(
 		if txErr != nil {
 			return fmt.Errorf("copy template: %w", txErr)
 		}
-		return nil
+		_, txErr = s.revisionRepo.Create(txCtx, &entity.AdRevision{
+			DealID:               created.ID,
+			Version:              1,
+			AuthorID:             user.ID,
+			AdvertiserApprovedAt: &created.CreatedAt,
+		})
+		if txErr != nil {
+			return fmt.Errorf("create revision: %w", txErr)
+		}
+		if params.Offer {
+			_, txErr = s.offerRepo.Create(txCtx, &entity.DealOffer{
+				DealID:       created.ID,
+				AuthorID:     user.ID,
+				PriceNanoTON: created.PriceNanoTON,
+				ScheduledAt:  created.ScheduledAt,
+			})
+			if txErr != nil {
+				return fmt.Errorf("create offer: %w", txErr)
+			}
+		}
+		return s.record(txCtx, &entity.DealEvent{
+			DealID:   created.ID,
+			Type:     entity.DealEventCreated,
+			ToStatus: created.Status,
+			Metadata: map[string]any{
+				"price_nano_ton":   created.PriceNanoTON,
+				"scheduled_at":     created.ScheduledAt,
+				"platform_fee_bps": created.PlatformFeeBPS,
+			},
+		})
 	}); err != nil {
 		return nil, nil, err
 	}
@@ -237,84 +505,85 @@ func (s *svc) CreateDeal(
 func (s *svc) GetDeal(
 	ctx context.Context,
 	dealID uuid.UUID,
-) (*entity.Deal, []entity.Post, int64, error) {
+) (*entity.Deal, []entity.Post, int64, []entity.Transfer, error) {
 	user, ok := dto.UserFromContext(ctx)
 	if !ok {
-		return nil, nil, 0, fmt.Errorf("get deal: %w", dto.ErrForbidden)
+		return nil, nil, 0, nil, fmt.Errorf("get deal: %w", dto.ErrForbidden)
 	}
 
 	deal, err := s.dealRepo.GetByID(ctx, dealID)
 	if err != nil {
-		return nil, nil, 0, fmt.Errorf("get deal: %w", err)
+		return nil, nil, 0, nil, fmt.Errorf("get deal: %w", err)
 	}
 
 	channel, err := s.channelRepo.GetByID(ctx, deal.ChannelID)
 	if err != nil {
-		return nil, nil, 0, fmt.Errorf("get channel: %w", err)
+		return nil, nil, 0, nil, fmt.Errorf("get channel: %w", err)
 	}
 
 	if deal.AdvertiserID != user.ID {
 		_, err := s.channelRepo.GetRole(ctx, deal.ChannelID, user.ID)
 		if errors.Is(err, dto.ErrNotFound) {
-			return nil, nil, 0, fmt.Errorf("get deal: %w", dto.ErrForbidden)
+			return nil, nil, 0, nil, fmt.Errorf("get deal: %w", dto.ErrForbidden)
 		}
 		if err != nil {
-			return nil, nil, 0, fmt.Errorf("get role: %w", err)
+			return nil, nil, 0, nil, fmt.Errorf("get role: %w", err)
 		}
 	}
 
 	posts, err := s.postRepo.GetLatestAd(ctx, dealID)
 	if err != nil {
-		return nil, nil, 0, fmt.Errorf("get latest ad: %w", err)
+		return nil, nil, 0, nil, fmt.Errorf("get latest ad: %w", err)
 	}
 
-	return deal, posts, channel.TgChannelID, nil
+	transfers, err := s.transferRepo.GetByDealID(ctx, dealID)
+	if err != nil {
+		return nil, nil, 0, nil, fmt.Errorf("get transfers: %w", err)
+	}
+
+	return deal, posts, channel.TgChannelID, transfers, nil
 }
 
+// ListAdvertiserDeals returns a page of the caller's deals matching f and,
+// with f.WithTotal, the number of matching deals; f.TgChannelID narrows it to
+// one channel.
 func (s *svc) ListAdvertiserDeals(
 	ctx context.Context,
-	limit, offset int,
+	f entity.DealFilter,
 ) ([]dto.DealListItem, int, error) {
 	user, ok := dto.UserFromContext(ctx)
 	if !ok {
 		return nil, 0, fmt.Errorf("list advertiser deals: %w", dto.ErrForbidden)
 	}
-
-	deals, total, err := s.dealRepo.GetByAdvertiserID(ctx, user.ID, limit, offset)
-	if err != nil {
+	if err := validateDealFilter(f); err != nil {
 		return nil, 0, fmt.Errorf("list advertiser deals: %w", err)
 	}
+	f.AdvertiserTgID = nil
 
-	items := make([]dto.DealListItem, len(deals))
-	channelCache := make(map[uuid.UUID]int64)
-	for i := range deals {
-		tgChannelID, ok := channelCache[deals[i].ChannelID]
-		if !ok {
-			ch, err := s.channelRepo.GetByID(ctx, deals[i].ChannelID)
-			if err != nil {
-				return nil, 0, fmt.Errorf("get channel: %w", err)
-			}
-			tgChannelID = ch.TgChannelID
-			channelCache[deals[i].ChannelID] = tgChannelID
-		}
-		items[i] = dto.DealListItem{
-			Deal:        deals[i],
-			TgChannelID: tgChannelID,
-		}
+	deals, total, err := s.dealRepo.GetByAdvertiserID(ctx, user.ID, f)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list advertiser deals: %w", err)
 	}
 
-	return items, total, nil
+	return dealListItems(deals), total, nil
 }
 
+// ListPublisherDeals returns a page of the channel's deals matching f and,
+// with f.WithTotal, the number of matching deals; f.AdvertiserTgID narrows it
+// to one advertiser.
 func (s *svc) ListPublisherDeals(
 	ctx context.Context,
 	tgChannelID int64,
-	limit, offset int,
+	f entity.DealFilter,
 ) ([]dto.DealListItem, int, error) {
 	user, ok := dto.UserFromContext(ctx)
 	if !ok {
 		return nil, 0, fmt.Errorf("list publisher deals: %w", dto.ErrForbidden)
 	}
+	if err := validateDealFilter(f); err != nil {
+		return nil, 0, fmt.Errorf("list publisher deals: %w", err)
+	}
+	f.TgChannelID = nil
 
 	channel, err := s.channelRepo.GetByTgChannelID(ctx, tgChannelID)
 	if err != nil {
@@ -329,37 +598,113 @@ func (s *svc) ListPublisherDeals(
 		return nil, 0, fmt.Errorf("get role: %w", err)
 	}
 
-	deals, total, err := s.dealRepo.GetByChannelID(ctx, channel.ID, limit, offset)
+	deals, total, err := s.dealRepo.GetByChannelID(ctx, channel.ID, f)
 	if err != nil {
 		return nil, 0, fmt.Errorf("list publisher deals: %w", err)
 	}
 
+	return dealListItems(deals), total, nil
+}
+
+func validateDealFilter(f entity.DealFilter) error {
+	for _, status := range f.Statuses {
+		if !slices.Contains(entity.DealStatuses, status) {
+			return dto.ErrValidation.WithDetails(map[string]any{
+				"status": fmt.Sprintf("unknown status %q", status),
+			})
+		}
+	}
+	if f.ScheduledFrom != nil && f.ScheduledTo != nil && !f.ScheduledTo.After(*f.ScheduledFrom) {
+		return dto.ErrValidation.WithDetails(map[string]any{
+			"scheduled_to": "must be after scheduled_from",
+		})
+	}
+	if f.Limit < 1 {
+		return dto.ErrValidation.WithDetails(map[string]any{"limit": "must be positive"})
+	}
+	if f.Offset < 0 {
+		return dto.ErrValidation.WithDetails(map[string]any{"page": "must be positive"})
+	}
+	return nil
+}
+
+func dealListItems(deals []entity.ChannelDeal) []dto.DealListItem {
 	items := make([]dto.DealListItem, len(deals))
 	for i := range deals {
 		items[i] = dto.DealListItem{
-			Deal:        deals[i],
-			TgChannelID: tgChannelID,
+			Deal:        deals[i].Deal,
+			TgChannelID: deals[i].TgChannelID,
 		}
 	}
-
-	return items, total, nil
+	return items
 }
 
-func (s *svc) Approve(ctx context.Context, dealID uuid.UUID) error {
-	deal, err := s.requirePublisherRole(ctx, dealID)
-	if err != nil {
-		return err
+// Approve confirms the latest ad version on behalf of the caller's side. The
+// deal is approved once both sides have confirmed the same version; a
+// version, when given, guards against confirming one the caller hasn't seen.
+func (s *svc) Approve(ctx context.Context, dealID uuid.UUID, version *int) error {
+	user, ok := dto.UserFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("approve deal: %w", dto.ErrForbidden)
 	}
 
-	if !canTransition(deal.Status, entity.DealStatusApproved) {
-		return fmt.Errorf("approve deal: %w", dto.ErrInvalidTransition)
-	}
+	var approved bool
+	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		deal, err := s.dealRepo.GetByIDForUpdate(txCtx, dealID)
+		if err != nil {
+			return fmt.Errorf("get deal: %w", err)
+		}
+
+		party, err := s.partyOf(txCtx, deal, user.ID)
+		if err != nil {
+			return err
+		}
+
+		if deal.Status != entity.DealStatusPendingReview {
+			return dto.ErrInvalidTransition
+		}
+
+		latest, err := s.revisionRepo.GetLatest(txCtx, dealID)
+		if err != nil {
+			return fmt.Errorf("get latest revision: %w", err)
+		}
+		if version != nil && *version != latest.Version {
+			return dto.ErrInvalidTransition
+		}
+		if latest.ApprovedBy(party) {
+			return dto.ErrInvalidTransition
+		}
+
+		rev, err := s.revisionRepo.Approve(txCtx, dealID, latest.Version, party)
+		if err != nil {
+			return fmt.Errorf("approve revision: %w", err)
+		}
+		status := deal.Status
+		if rev.ApprovedBy(party.Other()) {
+			status = entity.DealStatusApproved
+			err = s.dealRepo.UpdateStatus(txCtx, dealID, deal.Status, status, nil)
+			if err != nil {
+				return fmt.Errorf("update status: %w", err)
+			}
+			approved = true
+		}
 
-	if err := s.dealRepo.UpdateStatus(ctx, dealID, entity.DealStatusApproved, nil); err != nil {
+		return s.record(txCtx, &entity.DealEvent{
+			DealID:     dealID,
+			Type:       entity.DealEventRevisionApproved,
+			FromStatus: &deal.Status,
+			ToStatus:   status,
+			Metadata:   map[string]any{"version": rev.Version},
+		})
+	}); err != nil {
 		return fmt.Errorf("approve deal: %w", err)
 	}
 
-	s.log.Info("deal approved", "deal_id", dealID)
+	if approved {
+		s.log.Info("deal approved", "deal_id", dealID)
+	} else {
+		s.log.Info("revision confirmed", "deal_id", dealID, "user_id", user.ID)
+	}
 	return nil
 }
 
@@ -373,7 +718,9 @@ func (s *svc) Reject(ctx context.Context, dealID uuid.UUID, reason *string) erro
 		return fmt.Errorf("reject deal: %w", dto.ErrInvalidTransition)
 	}
 
-	if err := s.dealRepo.UpdateStatus(ctx, dealID, entity.DealStatusRejected, reason); err != nil {
+	if err := s.closeWithRefund(
+		ctx, deal, entity.DealStatusRejected, entity.DealEventRejected, reason,
+	); err != nil {
 		return fmt.Errorf("reject deal: %w", err)
 	}
 
@@ -381,22 +728,53 @@ func (s *svc) Reject(ctx context.Context, dealID uuid.UUID, reason *string) erro
 	return nil
 }
 
+// RequestChanges sends the latest ad version back for another edit. Either
+// side may ask, as long as it hasn't confirmed that version itself.
 func (s *svc) RequestChanges(ctx context.Context, dealID uuid.UUID, note string) error {
-	deal, err := s.requirePublisherRole(ctx, dealID)
-	if err != nil {
-		return err
+	user, ok := dto.UserFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("request changes: %w", dto.ErrForbidden)
 	}
 
-	if !canTransition(deal.Status, entity.DealStatusChangesRequested) {
-		return fmt.Errorf("request changes: %w", dto.ErrInvalidTransition)
-	}
+	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		deal, err := s.dealRepo.GetByIDForUpdate(txCtx, dealID)
+		if err != nil {
+			return fmt.Errorf("get deal: %w", err)
+		}
 
-	if err := s.dealRepo.UpdateStatus(
-		ctx,
-		dealID,
-		entity.DealStatusChangesRequested,
-		&note,
-	); err != nil {
+		party, err := s.partyOf(txCtx, deal, user.ID)
+		if err != nil {
+			return err
+		}
+
+		if !canTransition(deal.Status, entity.DealStatusChangesRequested) {
+			return dto.ErrInvalidTransition
+		}
+
+		latest, err := s.revisionRepo.GetLatest(txCtx, dealID)
+		if err != nil {
+			return fmt.Errorf("get latest revision: %w", err)
+		}
+		if latest.ApprovedBy(party) {
+			return dto.ErrInvalidTransition
+		}
+
+		err = s.dealRepo.UpdateStatus(
+			txCtx, dealID, deal.Status, entity.DealStatusChangesRequested, &note,
+		)
+		if err != nil {
+			return fmt.Errorf("update status: %w", err)
+		}
+
+		return s.record(txCtx, &entity.DealEvent{
+			DealID:     dealID,
+			Type:       entity.DealEventChangesRequested,
+			FromStatus: &deal.Status,
+			ToStatus:   entity.DealStatusChangesRequested,
+			Note:       &note,
+			Metadata:   map[string]any{"version": latest.Version},
+		})
+	}); err != nil {
 		return fmt.Errorf("request changes: %w", err)
 	}
 
@@ -404,54 +782,332 @@ func (s *svc) RequestChanges(ctx context.Context, dealID uuid.UUID, note string)
 	return nil
 }
 
+// SubmitRevision adds a new version of the ad, from either side. The author
+// confirms their own version by submitting it, and the deal goes back to
+// review by the other side. The change request that prompted the edit, if
+// any, is kept with the revision, since the deal itself only holds the
+// latest one.
 func (s *svc) SubmitRevision(
 	ctx context.Context,
 	dealID uuid.UUID,
-	newPosts []entity.Post,
-) ([]entity.Post, error) {
+	params RevisionParams,
+) (*dto.RevisionItem, error) {
 	user, ok := dto.UserFromContext(ctx)
 	if !ok {
 		return nil, fmt.Errorf("submit revision: %w", dto.ErrForbidden)
 	}
 
-	deal, err := s.dealRepo.GetByID(ctx, dealID)
+	switch {
+	case params.TemplatePostID != nil:
+		tmpl, err := s.postRepo.GetByID(ctx, *params.TemplatePostID)
+		if err != nil {
+			return nil, fmt.Errorf("get template: %w", err)
+		}
+		if tmpl.Type != entity.PostTypeTemplate || tmpl.ExternalID != user.ID {
+			return nil, fmt.Errorf("submit revision: %w", dto.ErrForbidden)
+		}
+	case params.Text == nil:
+		return nil, fmt.Errorf("submit revision: %w",
+			dto.ErrValidation.WithDetails(map[string]any{"text": "required without a template"}))
+	}
+
+	var rev *entity.AdRevision
+	var party entity.DealParty
+	var posts []entity.Post
+	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		deal, err := s.dealRepo.GetByIDForUpdate(txCtx, dealID)
+		if err != nil {
+			return fmt.Errorf("get deal: %w", err)
+		}
+
+		party, err = s.partyOf(txCtx, deal, user.ID)
+		if err != nil {
+			return err
+		}
+
+		// pending_payment also leads to pending_review, but only through payment
+		var note *string
+		switch deal.Status {
+		case entity.DealStatusPendingReview:
+		case entity.DealStatusChangesRequested:
+			note = deal.PublisherNote
+		default:
+			return dto.ErrInvalidTransition
+		}
+
+		latest, err := s.postRepo.GetLatestAd(txCtx, dealID)
+		if err != nil {
+			return fmt.Errorf("get latest ad: %w", err)
+		}
+		currentVersion := 1
+		if len(latest) > 0 && latest[0].Version != nil {
+			currentVersion = *latest[0].Version
+		}
+		nextVersion := currentVersion + 1
+
+		err = s.dealRepo.UpdateStatus(
+			txCtx, dealID, deal.Status, entity.DealStatusPendingReview, nil,
+		)
+		if err != nil {
+			return fmt.Errorf("update status: %w", err)
+		}
+
+		if params.TemplatePostID != nil {
+			posts, err = s.postRepo.CopyAsAd(txCtx, *params.TemplatePostID, dealID, nextVersion)
+		} else {
+			newPosts := withText(latest, *params.Text, params.Entities)
+			posts, err = s.postRepo.AddAdVersion(txCtx, dealID, nextVersion, newPosts)
+		}
+		if err != nil {
+			return fmt.Errorf("add ad version: %w", err)
+		}
+
+		now := time.Now()
+		newRev := &entity.AdRevision{
+			DealID:        dealID,
+			Version:       nextVersion,
+			AuthorID:      user.ID,
+			PublisherNote: note,
+		}
+		if party == entity.DealPartyAdvertiser {
+			newRev.AdvertiserApprovedAt = &now
+		} else {
+			newRev.PublisherApprovedAt = &now
+		}
+		rev, err = s.revisionRepo.Create(txCtx, newRev)
+		if err != nil {
+			return fmt.Errorf("create revision: %w", err)
+		}
+
+		return s.record(txCtx, &entity.DealEvent{
+			DealID:     dealID,
+			Type:       entity.DealEventRevisionSubmitted,
+			FromStatus: &deal.Status,
+			ToStatus:   entity.DealStatusPendingReview,
+			Metadata:   map[string]any{"version": nextVersion},
+		})
+	}); err != nil {
+		return nil, fmt.Errorf("submit revision: %w", err)
+	}
+
+	author, err := s.userRepo.GetByID(ctx, user.ID)
 	if err != nil {
-		return nil, fmt.Errorf("get deal: %w", err)
+		return nil, fmt.Errorf("get author: %w", err)
 	}
 
-	if deal.AdvertiserID != user.ID {
-		return nil, fmt.Errorf("submit revision: %w", dto.ErrForbidden)
+	s.log.Info("revision submitted", "deal_id", dealID, "version", rev.Version, "by", party)
+	return &dto.RevisionItem{
+		AdRevision:  *rev,
+		AuthorName:  author.Name,
+		AuthorParty: party,
+		Posts:       posts,
+	}, nil
+}
+
+// withText returns a copy of the creative with its text replaced. The text
+// stays on the post that carried it, so an album keeps its caption in place.
+func withText(posts []entity.Post, text string, entities []byte) []entity.Post {
+	if len(posts) == 0 {
+		return []entity.Post{{Text: &text, Entities: entities}}
 	}
 
-	if !canTransition(deal.Status, entity.DealStatusPendingReview) {
-		return nil, fmt.Errorf("submit revision: %w", dto.ErrInvalidTransition)
+	result := slices.Clone(posts)
+	target := 0
+	for i := range result {
+		if result[i].Text != nil {
+			target = i
+			break
+		}
 	}
+	for i := range result {
+		result[i].Text = nil
+		result[i].Entities = nil
+	}
+	result[target].Text = &text
+	result[target].Entities = entities
+	return result
+}
 
-	latest, err := s.postRepo.GetLatestAd(ctx, dealID)
+// GetRevisions returns every version of the deal's ad, oldest first, to both
+// the advertiser and the channel's team.
+func (s *svc) GetRevisions(ctx context.Context, dealID uuid.UUID) ([]dto.RevisionItem, error) {
+	deal, err := s.requireParticipant(ctx, dealID)
 	if err != nil {
-		return nil, fmt.Errorf("get latest ad: %w", err)
+		return nil, err
 	}
 
-	currentVersion := 1
-	if len(latest) > 0 && latest[0].Version != nil {
-		currentVersion = *latest[0].Version
+	revisions, err := s.revisionRepo.GetByDealID(ctx, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("get revisions: %w", err)
 	}
-	nextVersion := currentVersion + 1
 
-	var posts []entity.Post
-	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
-		var txErr error
-		posts, txErr = s.postRepo.AddAdVersion(txCtx, dealID, nextVersion, newPosts)
-		if txErr != nil {
-			return fmt.Errorf("add ad version: %w", txErr)
+	versions, err := s.postRepo.GetAdVersions(ctx, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("get ad versions: %w", err)
+	}
+
+	authors := make(map[uuid.UUID]*entity.User)
+	items := make([]dto.RevisionItem, len(revisions))
+	for i := range revisions {
+		rev := &revisions[i]
+		author, ok := authors[rev.AuthorID]
+		if !ok {
+			author, err = s.userRepo.GetByID(ctx, rev.AuthorID)
+			if err != nil {
+				return nil, fmt.Errorf("get author: %w", err)
+			}
+			authors[rev.AuthorID] = author
 		}
-		return s.dealRepo.UpdateStatus(txCtx, dealID, entity.DealStatusPendingReview, nil)
-	}); err != nil {
+		party := entity.DealPartyPublisher
+		if rev.AuthorID == deal.AdvertiserID {
+			party = entity.DealPartyAdvertiser
+		}
+		items[i] = dto.RevisionItem{
+			AdRevision:  *rev,
+			AuthorName:  author.Name,
+			AuthorParty: party,
+			Posts:       versions[rev.Version],
+		}
+	}
+
+	return items, nil
+}
+
+// GetEvents returns the deal's timeline, oldest first, to both the
+// advertiser and the channel's team.
+func (s *svc) GetEvents(ctx context.Context, dealID uuid.UUID) ([]dto.DealEventItem, error) {
+	deal, err := s.requireParticipantOrArbiter(ctx, dealID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.eventRepo.GetByDealID(ctx, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("get events: %w", err)
+	}
+
+	actors := make(map[uuid.UUID]*entity.User)
+	items := make([]dto.DealEventItem, len(events))
+	for i := range events {
+		ev := &events[i]
+		items[i] = dto.DealEventItem{DealEvent: *ev}
+		if ev.ActorID == nil {
+			continue
+		}
+		actor, ok := actors[*ev.ActorID]
+		if !ok {
+			actor, err = s.userRepo.GetByID(ctx, *ev.ActorID)
+			if err != nil {
+				return nil, fmt.Errorf("get actor: %w", err)
+			}
+			actors[*ev.ActorID] = actor
+		}
+		items[i].ActorName = actor.Name
+		switch {
+		case *ev.ActorID == deal.AdvertiserID:
+			items[i].ActorParty = entity.DealPartyAdvertiser
+		case s.isArbiter(actor.TgID):
+			items[i].ActorParty = entity.DealPartyArbiter
+		default:
+			items[i].ActorParty = entity.DealPartyPublisher
+		}
+	}
+
+	return items, nil
+}
+
+// maxMessageLength keeps a relayed message, with its header, under
+// Telegram's 4096 character limit.
+const maxMessageLength = 3500
+
+// PostMessage adds a message to the deal's thread. Either party can write at
+// any stage of the deal; the worker forwards it to the other side.
+func (s *svc) PostMessage(
+	ctx context.Context, dealID uuid.UUID, text string,
+) (*dto.DealMessageItem, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("post message: %w",
+			dto.ErrValidation.WithDetails(map[string]any{"text": "must not be empty"}))
+	}
+	if utf8.RuneCountInString(text) > maxMessageLength {
+		return nil, fmt.Errorf("post message: %w",
+			dto.ErrValidation.WithDetails(map[string]any{
+				"text": fmt.Sprintf("must be at most %d characters", maxMessageLength),
+			}))
+	}
+
+	deal, err := s.requireParticipant(ctx, dealID)
+	if err != nil {
+		return nil, err
+	}
+	user, _ := dto.UserFromContext(ctx)
+
+	author, err := s.userRepo.GetByID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get author: %w", err)
+	}
+
+	msg, err := s.messageRepo.Create(ctx, &entity.DealMessage{
+		DealID:   deal.ID,
+		AuthorID: user.ID,
+		Text:     text,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create message: %w", err)
+	}
+
+	party := entity.DealPartyPublisher
+	if user.ID == deal.AdvertiserID {
+		party = entity.DealPartyAdvertiser
+	}
+
+	s.log.Info("deal message posted", "deal_id", deal.ID, "message_id", msg.ID)
+
+	return &dto.DealMessageItem{
+		DealMessage: *msg,
+		AuthorName:  author.Name,
+		AuthorParty: party,
+	}, nil
+}
+
+// GetMessages returns the deal's thread, oldest first, to both the
+// advertiser and the channel's team.
+func (s *svc) GetMessages(ctx context.Context, dealID uuid.UUID) ([]dto.DealMessageItem, error) {
+	deal, err := s.requireParticipant(ctx, dealID)
+	if err != nil {
 		return nil, err
 	}
 
-	s.log.Info("revision submitted", "deal_id", dealID, "version", nextVersion)
-	return posts, nil
+	msgs, err := s.messageRepo.GetByDealID(ctx, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("get messages: %w", err)
+	}
+
+	authors := make(map[uuid.UUID]*entity.User)
+	items := make([]dto.DealMessageItem, len(msgs))
+	for i := range msgs {
+		msg := &msgs[i]
+		author, ok := authors[msg.AuthorID]
+		if !ok {
+			author, err = s.userRepo.GetByID(ctx, msg.AuthorID)
+			if err != nil {
+				return nil, fmt.Errorf("get author: %w", err)
+			}
+			authors[msg.AuthorID] = author
+		}
+		party := entity.DealPartyPublisher
+		if msg.AuthorID == deal.AdvertiserID {
+			party = entity.DealPartyAdvertiser
+		}
+		items[i] = dto.DealMessageItem{
+			DealMessage: *msg,
+			AuthorName:  author.Name,
+			AuthorParty: party,
+		}
+	}
+
+	return items, nil
 }
 
 func (s *svc) Cancel(ctx context.Context, dealID uuid.UUID) error {
@@ -482,7 +1138,9 @@ func (s *svc) Cancel(ctx context.Context, dealID uuid.UUID) error {
 		)
 	}
 
-	if err := s.dealRepo.UpdateStatus(ctx, dealID, entity.DealStatusCancelled, nil); err != nil {
+	if err := s.closeWithRefund(
+		ctx, deal, entity.DealStatusCancelled, entity.DealEventCancelled, nil,
+	); err != nil {
 		return fmt.Errorf("cancel deal: %w", err)
 	}
 
@@ -490,6 +1148,332 @@ func (s *svc) Cancel(ctx context.Context, dealID uuid.UUID) error {
 	return nil
 }
 
+// ConfirmPayment is called by the payment watcher once the escrow transfer
+// lands on-chain; there is no user in context. The payer is the sender of
+// the transfer and receives any refund.
+func (s *svc) ConfirmPayment(
+	ctx context.Context,
+	dealID uuid.UUID,
+	txHash, payer string,
+	paidAt time.Time,
+) error {
+	deal, err := s.dealRepo.GetByID(ctx, dealID)
+	if err != nil {
+		return fmt.Errorf("get deal: %w", err)
+	}
+
+	if deal.Status != entity.DealStatusPendingPayment ||
+		!canTransition(deal.Status, entity.DealStatusPendingReview) {
+		return fmt.Errorf("confirm payment: %w", dto.ErrInvalidTransition)
+	}
+
+	// the guarded transition locks the row first, so a concurrent cancel or
+	// expiry either wins and this fails, or waits and sees the payment
+	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		if _, err := s.dealRepo.TransitionStatus(
+			txCtx, dealID, entity.DealStatusPendingPayment, entity.DealStatusPendingReview, nil,
+		); err != nil {
+			return err
+		}
+		if err := s.dealRepo.SetPayment(txCtx, dealID, txHash, payer, paidAt); err != nil {
+			return fmt.Errorf("set payment: %w", err)
+		}
+		if err := s.postHold(txCtx, deal); err != nil {
+			return err
+		}
+		return s.record(txCtx, &entity.DealEvent{
+			DealID:     dealID,
+			Type:       entity.DealEventPaymentReceived,
+			FromStatus: &deal.Status,
+			ToStatus:   entity.DealStatusPendingReview,
+			Metadata:   map[string]any{"tx_hash": txHash, "payer": payer},
+		})
+	}); err != nil {
+		return fmt.Errorf("confirm payment: %w", err)
+	}
+
+	s.log.Info("deal payment confirmed", "deal_id", dealID, "tx_hash", txHash)
+	return nil
+}
+
+// RefundLatePayment is called by the payment watcher when a transfer arrives
+// for a deal that was closed before it was paid. The payment is recorded and
+// its refund queued in one transaction.
+func (s *svc) RefundLatePayment(
+	ctx context.Context,
+	dealID uuid.UUID,
+	txHash, payer string,
+	paidAt time.Time,
+) error {
+	deal, err := s.dealRepo.GetByID(ctx, dealID)
+	if err != nil {
+		return fmt.Errorf("get deal: %w", err)
+	}
+
+	if deal.PaymentTxHash != nil || !slices.Contains(unpaidClosedStatuses, deal.Status) {
+		return fmt.Errorf("refund late payment: %w", dto.ErrInvalidTransition)
+	}
+
+	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		if err := s.dealRepo.SetPayment(txCtx, dealID, txHash, payer, paidAt); err != nil {
+			return fmt.Errorf("set payment: %w", err)
+		}
+		if err := s.postHold(txCtx, deal); err != nil {
+			return err
+		}
+		if err := s.outboxRepo.Create(txCtx, dealID, entity.OutboxEventRefund); err != nil {
+			return fmt.Errorf("queue refund: %w", err)
+		}
+		return s.record(txCtx, &entity.DealEvent{
+			DealID:     dealID,
+			Type:       entity.DealEventLatePaymentRefunded,
+			FromStatus: &deal.Status,
+			ToStatus:   deal.Status,
+			Metadata:   map[string]any{"tx_hash": txHash, "payer": payer},
+		})
+	}); err != nil {
+		return fmt.Errorf("refund late payment: %w", err)
+	}
+
+	s.log.Info("late payment refunded", "deal_id", dealID, "tx_hash", txHash)
+	return nil
+}
+
+// ExpirePayment is called by the escrow worker when no payment arrived before
+// the deal's payment deadline.
+func (s *svc) ExpirePayment(ctx context.Context, dealID uuid.UUID) error {
+	deal, err := s.dealRepo.GetByID(ctx, dealID)
+	if err != nil {
+		return fmt.Errorf("get deal: %w", err)
+	}
+
+	if deal.Status != entity.DealStatusPendingPayment ||
+		!canTransition(deal.Status, entity.DealStatusHoldFailed) {
+		return fmt.Errorf("expire payment: %w", dto.ErrInvalidTransition)
+	}
+
+	note := "payment was not received in time"
+	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		if _, err := s.dealRepo.TransitionStatus(
+			txCtx, dealID, entity.DealStatusPendingPayment, entity.DealStatusHoldFailed, &note,
+		); err != nil {
+			return err
+		}
+		return s.record(txCtx, &entity.DealEvent{
+			DealID:     dealID,
+			Type:       entity.DealEventPaymentExpired,
+			FromStatus: &deal.Status,
+			ToStatus:   entity.DealStatusHoldFailed,
+			Note:       &note,
+		})
+	}); err != nil {
+		return fmt.Errorf("expire payment: %w", err)
+	}
+
+	s.log.Info("deal payment expired", "deal_id", dealID)
+	return nil
+}
+
+// ExpireReview is called by the review SLA worker when a deal stayed under
+// review for too long; the reason is stored as the publisher note.
+func (s *svc) ExpireReview(ctx context.Context, dealID uuid.UUID, reason string) error {
+	deal, err := s.dealRepo.GetByID(ctx, dealID)
+	if err != nil {
+		return fmt.Errorf("get deal: %w", err)
+	}
+
+	if deal.Status != entity.DealStatusPendingReview &&
+		deal.Status != entity.DealStatusChangesRequested {
+		return fmt.Errorf("expire review: %w", dto.ErrInvalidTransition)
+	}
+
+	if err := s.closeWithRefund(
+		ctx, deal, entity.DealStatusCancelled, entity.DealEventReviewExpired, &reason,
+	); err != nil {
+		return fmt.Errorf("expire review: %w", err)
+	}
+
+	s.log.Info("deal review expired", "deal_id", dealID, "reason", reason)
+	return nil
+}
+
+// closeWithRefund moves the deal to a terminal status and, if it was paid,
+// queues the refund in the same transaction. The transition only applies if
+// the deal is still in the status it was read in, and whether to refund is
+// decided from the updated row, not from the possibly stale copy.
+func (s *svc) closeWithRefund(
+	ctx context.Context,
+	deal *entity.Deal,
+	status entity.DealStatus,
+	event entity.DealEventType,
+	note *string,
+) error {
+	return s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		closed, err := s.dealRepo.TransitionStatus(txCtx, deal.ID, deal.Status, status, note)
+		if err != nil {
+			return err
+		}
+		err = s.record(txCtx, &entity.DealEvent{
+			DealID:     deal.ID,
+			Type:       event,
+			FromStatus: &deal.Status,
+			ToStatus:   status,
+			Note:       note,
+			Metadata:   map[string]any{"refund": closed.PaymentTxHash != nil},
+		})
+		if err != nil {
+			return err
+		}
+		if closed.PaymentTxHash == nil {
+			return nil
+		}
+		if err := s.outboxRepo.Create(txCtx, deal.ID, entity.OutboxEventRefund); err != nil {
+			return fmt.Errorf("queue refund: %w", err)
+		}
+		return nil
+	})
+}
+
+// MarkPosted is called by the publisher once the ad is live in the channel;
+// there is no user in context.
+func (s *svc) MarkPosted(
+	ctx context.Context,
+	dealID uuid.UUID,
+	messageIDs []int64,
+	postedAt time.Time,
+) error {
+	deal, err := s.dealRepo.GetByID(ctx, dealID)
+	if err != nil {
+		return fmt.Errorf("get deal: %w", err)
+	}
+
+	if !canTransition(deal.Status, entity.DealStatusPosted) {
+		return fmt.Errorf("mark posted: %w", dto.ErrInvalidTransition)
+	}
+
+	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		err := s.dealRepo.SetPostedMessageIDs(txCtx, dealID, messageIDs, postedAt)
+		if err != nil {
+			return fmt.Errorf("set posted message ids: %w", err)
+		}
+		err = s.dealRepo.UpdateStatus(
+			txCtx, dealID, deal.Status, entity.DealStatusPosted, nil,
+		)
+		if err != nil {
+			return fmt.Errorf("update status: %w", err)
+		}
+		return s.record(txCtx, &entity.DealEvent{
+			DealID:     dealID,
+			Type:       entity.DealEventPosted,
+			FromStatus: &deal.Status,
+			ToStatus:   entity.DealStatusPosted,
+			Metadata:   map[string]any{"message_ids": messageIDs},
+		})
+	}); err != nil {
+		return fmt.Errorf("mark posted: %w", err)
+	}
+
+	s.log.Info("deal posted", "deal_id", dealID, "message_ids", messageIDs)
+	return nil
+}
+
+// FailPublish is called by the publisher when an approved ad cannot be posted
+// and retrying will not help; the deal is closed and the advertiser refunded.
+func (s *svc) FailPublish(ctx context.Context, dealID uuid.UUID, reason string) error {
+	deal, err := s.dealRepo.GetByID(ctx, dealID)
+	if err != nil {
+		return fmt.Errorf("get deal: %w", err)
+	}
+
+	if !canTransition(deal.Status, entity.DealStatusPublishFailed) {
+		return fmt.Errorf("fail publish: %w", dto.ErrInvalidTransition)
+	}
+
+	note := "ad could not be published: " + reason
+	if err := s.closeWithRefund(
+		ctx, deal, entity.DealStatusPublishFailed, entity.DealEventPublishFailed, &note,
+	); err != nil {
+		return fmt.Errorf("fail publish: %w", err)
+	}
+
+	s.log.Warn("deal publish failed", "deal_id", dealID, "reason", reason)
+	return nil
+}
+
+// Complete is called by the verifier once the ad has stayed untouched for the
+// whole feed window; the payout is picked up by the escrow worker.
+func (s *svc) Complete(ctx context.Context, dealID uuid.UUID) error {
+	deal, err := s.dealRepo.GetByID(ctx, dealID)
+	if err != nil {
+		return fmt.Errorf("get deal: %w", err)
+	}
+
+	if !canTransition(deal.Status, entity.DealStatusCompleted) {
+		return fmt.Errorf("complete deal: %w", dto.ErrInvalidTransition)
+	}
+
+	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		err := s.dealRepo.UpdateStatus(
+			txCtx, dealID, deal.Status, entity.DealStatusCompleted, nil,
+		)
+		if err != nil {
+			return fmt.Errorf("update status: %w", err)
+		}
+		return s.record(txCtx, &entity.DealEvent{
+			DealID:     dealID,
+			Type:       entity.DealEventCompleted,
+			FromStatus: &deal.Status,
+			ToStatus:   entity.DealStatusCompleted,
+		})
+	}); err != nil {
+		return fmt.Errorf("complete deal: %w", err)
+	}
+
+	s.log.Info("deal completed", "deal_id", dealID)
+	return nil
+}
+
+// OpenDispute is called by the verifier when the posted ad was removed or
+// modified before the feed window ended. The dispute waits for an arbiter;
+// until then both parties can submit evidence.
+func (s *svc) OpenDispute(ctx context.Context, dealID uuid.UUID, reason string) error {
+	deal, err := s.dealRepo.GetByID(ctx, dealID)
+	if err != nil {
+		return fmt.Errorf("get deal: %w", err)
+	}
+
+	if !canTransition(deal.Status, entity.DealStatusDispute) {
+		return fmt.Errorf("open dispute: %w", dto.ErrInvalidTransition)
+	}
+
+	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		err := s.dealRepo.UpdateStatus(
+			txCtx, dealID, deal.Status, entity.DealStatusDispute, &reason,
+		)
+		if err != nil {
+			return fmt.Errorf("update status: %w", err)
+		}
+		if _, err := s.disputeRepo.Create(txCtx, &entity.DealDispute{
+			DealID: dealID,
+			Reason: reason,
+		}); err != nil {
+			return fmt.Errorf("create dispute: %w", err)
+		}
+		return s.record(txCtx, &entity.DealEvent{
+			DealID:     dealID,
+			Type:       entity.DealEventDisputed,
+			FromStatus: &deal.Status,
+			ToStatus:   entity.DealStatusDispute,
+			Note:       &reason,
+		})
+	}); err != nil {
+		return fmt.Errorf("open dispute: %w", err)
+	}
+
+	s.log.Warn("deal disputed", "deal_id", dealID, "reason", reason)
+	return nil
+}
+
 func (s *svc) requirePublisherRole(ctx context.Context, dealID uuid.UUID) (*entity.Deal, error) {
 	user, ok := dto.UserFromContext(ctx)
 	if !ok {
@@ -511,3 +1495,171 @@ func (s *svc) requirePublisherRole(ctx context.Context, dealID uuid.UUID) (*enti
 
 	return deal, nil
 }
+
+// DiffRevisions compares two ad versions of a deal. A zero to means the
+// latest version, a zero from the one before to.
+func (s *svc) DiffRevisions(
+	ctx context.Context,
+	dealID uuid.UUID,
+	from, to int,
+) (*dto.RevisionDiffResponse, error) {
+	if _, err := s.requireParticipant(ctx, dealID); err != nil {
+		return nil, err
+	}
+
+	versions, err := s.postRepo.GetAdVersions(ctx, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("get ad versions: %w", err)
+	}
+
+	if to == 0 {
+		for v := range versions {
+			to = max(to, v)
+		}
+	}
+	if from == 0 {
+		from = to - 1
+	}
+
+	fromPosts, ok := versions[from]
+	if !ok {
+		return nil, fmt.Errorf("diff revisions: version %d: %w", from, dto.ErrNotFound)
+	}
+	toPosts, ok := versions[to]
+	if !ok {
+		return nil, fmt.Errorf("diff revisions: version %d: %w", to, dto.ErrNotFound)
+	}
+
+	return &dto.RevisionDiffResponse{
+		From:  from,
+		To:    to,
+		Text:  diffText(fromPosts, toPosts),
+		Media: diffMedia(fromPosts, toPosts),
+	}, nil
+}
+
+// checkSchedule validates a posting time for a deal that is yet to be paid:
+// the slot must not come up while the deal can still be funded.
+func (s *svc) checkSchedule(scheduledAt time.Time) error {
+	now := time.Now()
+	if now.After(scheduledAt) {
+		return dto.ErrValidation.WithDetails(
+			map[string]any{"scheduled_at": "must be in the future"},
+		)
+	}
+	if now.Add(s.cfg.PaymentTimeout).After(scheduledAt) {
+		return dto.ErrValidation.WithDetails(map[string]any{
+			"scheduled_at": fmt.Sprintf("must be at least %s from now", s.cfg.PaymentTimeout),
+		})
+	}
+	return nil
+}
+
+// checkSlot validates the deal's posting time against its channel's
+// inventory and the deals already booked there. The channel stays locked
+// until the transaction ends, so two bookings cannot take the same slot.
+func (s *svc) checkSlot(ctx context.Context, deal *entity.Deal, at time.Time) error {
+	inv, err := s.channelRepo.GetInventoryForUpdate(ctx, deal.ChannelID)
+	if err != nil {
+		return fmt.Errorf("get inventory: %w", err)
+	}
+
+	if inv.IsBlackedOut(at) {
+		return dto.ErrSlotUnavailable.WithDetails(
+			map[string]any{"scheduled_at": "channel takes no ads on this date"},
+		)
+	}
+	if !inv.InPostingWindow(at) {
+		return dto.ErrSlotUnavailable.WithDetails(
+			map[string]any{"scheduled_at": "outside the channel's posting windows"},
+		)
+	}
+
+	if inv.MaxAdsPerDay != nil {
+		day := at.UTC().Truncate(24 * time.Hour)
+		booked, err := s.dealRepo.CountBooked(
+			ctx, deal.ChannelID, deal.ID, day, day.Add(24*time.Hour),
+		)
+		if err != nil {
+			return fmt.Errorf("count booked: %w", err)
+		}
+		if booked >= *inv.MaxAdsPerDay {
+			return dto.ErrSlotUnavailable.WithDetails(
+				map[string]any{"scheduled_at": "channel is fully booked on this date"},
+			)
+		}
+	}
+
+	taken, err := s.dealRepo.HasSlotConflict(
+		ctx, deal.ChannelID, deal.ID, at, at.Add(time.Duration(deal.TopHours)*time.Hour),
+	)
+	if err != nil {
+		return fmt.Errorf("check slot: %w", err)
+	}
+	if taken {
+		return dto.ErrSlotTaken
+	}
+	return nil
+}
+
+// requireParticipant returns the deal if the user is its advertiser or a
+// member of the channel's team.
+func (s *svc) requireParticipant(ctx context.Context, dealID uuid.UUID) (*entity.Deal, error) {
+	user, ok := dto.UserFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("check participant: %w", dto.ErrForbidden)
+	}
+
+	deal, err := s.dealRepo.GetByID(ctx, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("get deal: %w", err)
+	}
+
+	if deal.AdvertiserID == user.ID {
+		return deal, nil
+	}
+
+	_, err = s.channelRepo.GetRole(ctx, deal.ChannelID, user.ID)
+	if errors.Is(err, dto.ErrNotFound) {
+		return nil, fmt.Errorf("check participant: %w", dto.ErrForbidden)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get role: %w", err)
+	}
+
+	return deal, nil
+}
+
+// partyOf tells which side of the deal the user is on: its advertiser, or a
+// member of the channel's team.
+func (s *svc) partyOf(
+	ctx context.Context, deal *entity.Deal, userID uuid.UUID,
+) (entity.DealParty, error) {
+	if deal.AdvertiserID == userID {
+		return entity.DealPartyAdvertiser, nil
+	}
+
+	_, err := s.channelRepo.GetRole(ctx, deal.ChannelID, userID)
+	if errors.Is(err, dto.ErrNotFound) {
+		return "", fmt.Errorf("check party: %w", dto.ErrForbidden)
+	}
+	if err != nil {
+		return "", fmt.Errorf("get role: %w", err)
+	}
+
+	return entity.DealPartyPublisher, nil
+}
+
+// record appends an event to the deal's timeline, in the transaction of the
+// change it describes. The actor is the user in context, if any.
+func (s *svc) record(ctx context.Context, ev *entity.DealEvent) error {
+	if user, ok := dto.UserFromContext(ctx); ok {
+		ev.ActorID = &user.ID
+	}
+
+	if err := s.eventRepo.Create(ctx, ev); err != nil {
+		return fmt.Errorf("record event: %w", err)
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,27 +15,92 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/bpva/ad-marketplace/internal/config"
 	"github.com/bpva/ad-marketplace/internal/dto"
 	"github.com/bpva/ad-marketplace/internal/entity"
 )
 
-func newTestService(t *testing.T) (
-	*svc,
-	*MockDealRepository,
-	*MockChannelRepository,
-	*MockPostRepository,
-	*MockUserRepository,
-	*MockTransactor,
-) {
+type testMocks struct {
+	dealRepo       *MockDealRepository
+	channelRepo    *MockChannelRepository
+	postRepo       *MockPostRepository
+	userRepo       *MockUserRepository
+	tx             *MockTransactor
+	escrow         *MockEscrowWallet
+	transferRepo   *MockTransferRepository
+	outboxRepo     *MockOutboxRepository
+	revisionRepo   *MockRevisionRepository
+	eventRepo      *MockEventRepository
+	messageRepo    *MockMessageRepository
+	offerRepo      *MockOfferRepository
+	rescheduleRepo *MockRescheduleRepository
+	disputeRepo    *MockDisputeRepository
+	snapshotRepo   *MockSnapshotRepository
+	ratingRepo     *MockRatingRepository
+	promotionRepo  *MockPromotionRepository
+	ledgerRepo     *MockLedgerRepository
+}
+
+func newTestService(t *testing.T) (*svc, *testMocks) {
 	ctrl := gomock.NewController(t)
-	dealRepo := NewMockDealRepository(ctrl)
-	channelRepo := NewMockChannelRepository(ctrl)
-	postRepo := NewMockPostRepository(ctrl)
-	userRepo := NewMockUserRepository(ctrl)
-	tx := NewMockTransactor(ctrl)
+	m := &testMocks{
+		dealRepo:       NewMockDealRepository(ctrl),
+		channelRepo:    NewMockChannelRepository(ctrl),
+		postRepo:       NewMockPostRepository(ctrl),
+		userRepo:       NewMockUserRepository(ctrl),
+		tx:             NewMockTransactor(ctrl),
+		escrow:         NewMockEscrowWallet(ctrl),
+		transferRepo:   NewMockTransferRepository(ctrl),
+		outboxRepo:     NewMockOutboxRepository(ctrl),
+		revisionRepo:   NewMockRevisionRepository(ctrl),
+		eventRepo:      NewMockEventRepository(ctrl),
+		messageRepo:    NewMockMessageRepository(ctrl),
+		offerRepo:      NewMockOfferRepository(ctrl),
+		rescheduleRepo: NewMockRescheduleRepository(ctrl),
+		disputeRepo:    NewMockDisputeRepository(ctrl),
+		snapshotRepo:   NewMockSnapshotRepository(ctrl),
+		ratingRepo:     NewMockRatingRepository(ctrl),
+		promotionRepo:  NewMockPromotionRepository(ctrl),
+		ledgerRepo:     NewMockLedgerRepository(ctrl),
+	}
 	log := slog.New(slog.NewTextHandler(io.Discard, nil))
-	s := New(dealRepo, channelRepo, postRepo, userRepo, tx, log)
-	return s, dealRepo, channelRepo, postRepo, userRepo, tx
+	cfg := config.Deal{
+		PaymentTimeout: time.Hour,
+		Arbiters:       []int64{arbiterTgID},
+		PlatformFeeBPS: defaultFeeBPS,
+		Admins:         []int64{adminTgID},
+	}
+	s := New(
+		cfg, m.dealRepo, m.channelRepo, m.postRepo, m.userRepo,
+		m.transferRepo, m.outboxRepo, m.revisionRepo, m.eventRepo, m.messageRepo, m.offerRepo,
+		m.rescheduleRepo, m.disputeRepo, m.snapshotRepo, m.ratingRepo, m.promotionRepo,
+		m.ledgerRepo, m.tx, m.escrow, log,
+	)
+	return s, m
+}
+
+func expectTx(tx *MockTransactor, ctx context.Context) {
+	tx.EXPECT().WithTx(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, f func(context.Context) error) error {
+			return f(ctx)
+		},
+	)
+}
+
+// expectEvent expects an event of the given type to be recorded and returns
+// it for further checks once it has been.
+func expectEvent(
+	t *testing.T, m *testMocks, ctx context.Context, typ entity.DealEventType,
+) *entity.DealEvent {
+	recorded := &entity.DealEvent{}
+	m.eventRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, ev *entity.DealEvent) error {
+			assert.Equal(t, typ, ev.Type)
+			*recorded = *ev
+			return nil
+		},
+	)
+	return recorded
 }
 
 func ctxWithUser(id uuid.UUID, tgID int64) context.Context {
@@ -48,6 +114,9 @@ func TestCanTransition(t *testing.T) {
 		from entity.DealStatus
 		to   entity.DealStatus
 	}{
+		{entity.DealStatusNegotiating, entity.DealStatusPendingPayment},
+		{entity.DealStatusNegotiating, entity.DealStatusRejected},
+		{entity.DealStatusNegotiating, entity.DealStatusCancelled},
 		{entity.DealStatusPendingPayment, entity.DealStatusPendingReview},
 		{entity.DealStatusPendingPayment, entity.DealStatusHoldFailed},
 		{entity.DealStatusPendingPayment, entity.DealStatusCancelled},
@@ -60,6 +129,7 @@ func TestCanTransition(t *testing.T) {
 		{entity.DealStatusApproved, entity.DealStatusPosted},
 		{entity.DealStatusPosted, entity.DealStatusCompleted},
 		{entity.DealStatusPosted, entity.DealStatusDispute},
+		{entity.DealStatusDispute, entity.DealStatusResolved},
 	}
 	for _, tt := range valid {
 		assert.True(t, canTransition(tt.from, tt.to), "%s → %s should be valid", tt.from, tt.to)
@@ -69,6 +139,7 @@ func TestCanTransition(t *testing.T) {
 		from entity.DealStatus
 		to   entity.DealStatus
 	}{
+		{entity.DealStatusNegotiating, entity.DealStatusPendingReview},
 		{entity.DealStatusPendingPayment, entity.DealStatusApproved},
 		{entity.DealStatusPendingPayment, entity.DealStatusPosted},
 		{entity.DealStatusPendingReview, entity.DealStatusPosted},
@@ -78,6 +149,7 @@ func TestCanTransition(t *testing.T) {
 		{entity.DealStatusRejected, entity.DealStatusPendingReview},
 		{entity.DealStatusCancelled, entity.DealStatusPendingReview},
 		{entity.DealStatusCompleted, entity.DealStatusDispute},
+		{entity.DealStatusPosted, entity.DealStatusResolved},
 		{entity.DealStatusPosted, entity.DealStatusCancelled},
 		{entity.DealStatusChangesRequested, entity.DealStatusApproved},
 		{entity.DealStatusHoldFailed, entity.DealStatusPendingReview},
@@ -94,6 +166,8 @@ var (
 	channelID = uuid.Must(uuid.NewV7())
 	dealID    = uuid.Must(uuid.NewV7())
 	postID    = uuid.Must(uuid.NewV7())
+
+	publisherID = uuid.Must(uuid.NewV7())
 )
 
 func defaultCreateParams() CreateDealParams {
@@ -149,18 +223,18 @@ func defaultUser() *entity.User {
 }
 
 func TestCreateDeal_NoContext(t *testing.T) {
-	s, _, _, _, _, _ := newTestService(t)
+	s, _ := newTestService(t)
 	_, _, err := s.CreateDeal(context.Background(), defaultCreateParams())
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, dto.ErrForbidden))
 }
 
 func TestCreateDeal_ChannelNotFound(t *testing.T) {
-	s, _, channelRepo, _, _, _ := newTestService(t)
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
 	params := defaultCreateParams()
 
-	channelRepo.EXPECT().
+	m.channelRepo.EXPECT().
 		GetByTgChannelID(ctx, params.TgChannelID).
 		Return(nil, fmt.Errorf("get channel: %w", dto.ErrNotFound))
 
@@ -170,13 +244,13 @@ func TestCreateDeal_ChannelNotFound(t *testing.T) {
 }
 
 func TestCreateDeal_ChannelNotListed(t *testing.T) {
-	s, _, channelRepo, _, _, _ := newTestService(t)
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
 	params := defaultCreateParams()
 
 	ch := defaultChannel()
 	ch.IsListed = false
-	channelRepo.EXPECT().GetByTgChannelID(ctx, params.TgChannelID).Return(ch, nil)
+	m.channelRepo.EXPECT().GetByTgChannelID(ctx, params.TgChannelID).Return(ch, nil)
 
 	_, _, err := s.CreateDeal(ctx, params)
 	require.Error(t, err)
@@ -184,13 +258,13 @@ func TestCreateDeal_ChannelNotListed(t *testing.T) {
 }
 
 func TestCreateDeal_NoMatchingFormat(t *testing.T) {
-	s, _, channelRepo, _, _, _ := newTestService(t)
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
 	params := defaultCreateParams()
 	params.FeedHours = 12
 
-	channelRepo.EXPECT().GetByTgChannelID(ctx, params.TgChannelID).Return(defaultChannel(), nil)
-	channelRepo.EXPECT().GetAdFormatsByChannelID(ctx, channelID).Return(defaultAdFormats(), nil)
+	m.channelRepo.EXPECT().GetByTgChannelID(ctx, params.TgChannelID).Return(defaultChannel(), nil)
+	m.channelRepo.EXPECT().GetAdFormatsByChannelID(ctx, channelID).Return(defaultAdFormats(), nil)
 
 	_, _, err := s.CreateDeal(ctx, params)
 	require.Error(t, err)
@@ -198,13 +272,13 @@ func TestCreateDeal_NoMatchingFormat(t *testing.T) {
 }
 
 func TestCreateDeal_PriceMismatch(t *testing.T) {
-	s, _, channelRepo, _, _, _ := newTestService(t)
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
 	params := defaultCreateParams()
 	params.PriceNanoTON = 1
 
-	channelRepo.EXPECT().GetByTgChannelID(ctx, params.TgChannelID).Return(defaultChannel(), nil)
-	channelRepo.EXPECT().GetAdFormatsByChannelID(ctx, channelID).Return(defaultAdFormats(), nil)
+	m.channelRepo.EXPECT().GetByTgChannelID(ctx, params.TgChannelID).Return(defaultChannel(), nil)
+	m.channelRepo.EXPECT().GetAdFormatsByChannelID(ctx, channelID).Return(defaultAdFormats(), nil)
 
 	_, _, err := s.CreateDeal(ctx, params)
 	require.Error(t, err)
@@ -212,13 +286,27 @@ func TestCreateDeal_PriceMismatch(t *testing.T) {
 }
 
 func TestCreateDeal_ScheduledInPast(t *testing.T) {
-	s, _, channelRepo, _, _, _ := newTestService(t)
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
 	params := defaultCreateParams()
 	params.ScheduledAt = time.Now().Add(-time.Hour)
 
-	channelRepo.EXPECT().GetByTgChannelID(ctx, params.TgChannelID).Return(defaultChannel(), nil)
-	channelRepo.EXPECT().GetAdFormatsByChannelID(ctx, channelID).Return(defaultAdFormats(), nil)
+	m.channelRepo.EXPECT().GetByTgChannelID(ctx, params.TgChannelID).Return(defaultChannel(), nil)
+	m.channelRepo.EXPECT().GetAdFormatsByChannelID(ctx, channelID).Return(defaultAdFormats(), nil)
+
+	_, _, err := s.CreateDeal(ctx, params)
+	require.Error(t, err)
+	requireAPIError(t, err, "invalid_request")
+}
+
+func TestCreateDeal_ScheduledBeforePaymentDeadline(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+	params := defaultCreateParams()
+	params.ScheduledAt = time.Now().Add(30 * time.Minute)
+
+	m.channelRepo.EXPECT().GetByTgChannelID(ctx, params.TgChannelID).Return(defaultChannel(), nil)
+	m.channelRepo.EXPECT().GetAdFormatsByChannelID(ctx, channelID).Return(defaultAdFormats(), nil)
 
 	_, _, err := s.CreateDeal(ctx, params)
 	require.Error(t, err)
@@ -226,7 +314,7 @@ func TestCreateDeal_ScheduledInPast(t *testing.T) {
 }
 
 func TestCreateDeal_TemplateNotOwned(t *testing.T) {
-	s, _, channelRepo, postRepo, _, _ := newTestService(t)
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
 	params := defaultCreateParams()
 
@@ -236,9 +324,9 @@ func TestCreateDeal_TemplateNotOwned(t *testing.T) {
 		ExternalID: uuid.Must(uuid.NewV7()),
 	}
 
-	channelRepo.EXPECT().GetByTgChannelID(ctx, params.TgChannelID).Return(defaultChannel(), nil)
-	channelRepo.EXPECT().GetAdFormatsByChannelID(ctx, channelID).Return(defaultAdFormats(), nil)
-	postRepo.EXPECT().GetByID(ctx, params.TemplatePostID).Return(otherUserPost, nil)
+	m.channelRepo.EXPECT().GetByTgChannelID(ctx, params.TgChannelID).Return(defaultChannel(), nil)
+	m.channelRepo.EXPECT().GetAdFormatsByChannelID(ctx, channelID).Return(defaultAdFormats(), nil)
+	m.postRepo.EXPECT().GetByID(ctx, params.TemplatePostID).Return(otherUserPost, nil)
 
 	_, _, err := s.CreateDeal(ctx, params)
 	require.Error(t, err)
@@ -246,7 +334,7 @@ func TestCreateDeal_TemplateNotOwned(t *testing.T) {
 }
 
 func TestCreateDeal_AdPostNotTemplate(t *testing.T) {
-	s, _, channelRepo, postRepo, _, _ := newTestService(t)
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
 	params := defaultCreateParams()
 
@@ -256,9 +344,9 @@ func TestCreateDeal_AdPostNotTemplate(t *testing.T) {
 		ExternalID: userID,
 	}
 
-	channelRepo.EXPECT().GetByTgChannelID(ctx, params.TgChannelID).Return(defaultChannel(), nil)
-	channelRepo.EXPECT().GetAdFormatsByChannelID(ctx, channelID).Return(defaultAdFormats(), nil)
-	postRepo.EXPECT().GetByID(ctx, params.TemplatePostID).Return(adPost, nil)
+	m.channelRepo.EXPECT().GetByTgChannelID(ctx, params.TgChannelID).Return(defaultChannel(), nil)
+	m.channelRepo.EXPECT().GetAdFormatsByChannelID(ctx, channelID).Return(defaultAdFormats(), nil)
+	m.postRepo.EXPECT().GetByID(ctx, params.TemplatePostID).Return(adPost, nil)
 
 	_, _, err := s.CreateDeal(ctx, params)
 	require.Error(t, err)
@@ -266,11 +354,12 @@ func TestCreateDeal_AdPostNotTemplate(t *testing.T) {
 }
 
 func TestCreateDeal_Success(t *testing.T) {
-	s, dealRepo, channelRepo, postRepo, userRepo, tx := newTestService(t)
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
 	params := defaultCreateParams()
 
 	payoutWallet := "UQBpayout"
+	deposit := &dto.EscrowDeposit{Address: "EQBescrow", Memo: "ABCDEFGHIJ"}
 	createdDeal := &entity.Deal{
 		ID:                      dealID,
 		ChannelID:               channelID,
@@ -289,18 +378,21 @@ func TestCreateDeal_Success(t *testing.T) {
 		{ID: uuid.Must(uuid.NewV7()), Type: entity.PostTypeAd, ExternalID: dealID},
 	}
 
-	channelRepo.EXPECT().GetByTgChannelID(ctx, params.TgChannelID).Return(defaultChannel(), nil)
-	channelRepo.EXPECT().GetAdFormatsByChannelID(ctx, channelID).Return(defaultAdFormats(), nil)
-	postRepo.EXPECT().GetByID(ctx, params.TemplatePostID).Return(defaultTemplatePost(), nil)
-	userRepo.EXPECT().GetByID(ctx, userID).Return(defaultUser(), nil)
-	channelRepo.EXPECT().GetOwnerWalletAddress(ctx, channelID).Return(&payoutWallet, nil)
+	m.channelRepo.EXPECT().GetByTgChannelID(ctx, params.TgChannelID).Return(defaultChannel(), nil)
+	m.channelRepo.EXPECT().GetAdFormatsByChannelID(ctx, channelID).Return(defaultAdFormats(), nil)
+	m.postRepo.EXPECT().GetByID(ctx, params.TemplatePostID).Return(defaultTemplatePost(), nil)
+	m.userRepo.EXPECT().GetByID(ctx, userID).Return(defaultUser(), nil)
+	m.channelRepo.EXPECT().GetOwnerWalletAddress(ctx, channelID).Return(&payoutWallet, nil)
+	expectNoPromotion(m, ctx)
+	m.escrow.EXPECT().Provision(ctx).Return(deposit, nil)
 
-	tx.EXPECT().WithTx(ctx, gomock.Any()).DoAndReturn(
+	m.tx.EXPECT().WithTx(ctx, gomock.Any()).DoAndReturn(
 		func(ctx context.Context, f func(context.Context) error) error {
 			return f(ctx)
 		},
 	)
-	dealRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+	expectFreeSlot(m, ctx)
+	m.dealRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
 		func(_ context.Context, d *entity.Deal) (*entity.Deal, error) {
 			assert.Equal(t, entity.DealStatusPendingPayment, d.Status)
 			assert.Equal(t, channelID, d.ChannelID)
@@ -309,10 +401,22 @@ func TestCreateDeal_Success(t *testing.T) {
 			assert.Equal(t, int64(5000000000), d.PriceNanoTON)
 			assert.Equal(t, defaultUser().WalletAddress, d.AdvertiserWalletAddress)
 			assert.Equal(t, &payoutWallet, d.PayoutWalletAddress)
+			assert.Equal(t, &deposit.Address, d.EscrowWalletAddress)
+			assert.Equal(t, &deposit.Memo, d.EscrowMemo)
+			assert.Equal(t, int64(defaultFeeBPS), d.PlatformFeeBPS)
+			require.NotNil(t, d.PaymentExpiresAt)
+			assert.WithinDuration(t, time.Now().Add(time.Hour), *d.PaymentExpiresAt, time.Minute)
 			return createdDeal, nil
 		},
 	)
-	postRepo.EXPECT().CopyAsAd(ctx, params.TemplatePostID, dealID, 1).Return(copiedPosts, nil)
+	m.postRepo.EXPECT().CopyAsAd(ctx, params.TemplatePostID, dealID, 1).Return(copiedPosts, nil)
+	m.revisionRepo.EXPECT().
+		Create(ctx, &entity.AdRevision{
+			DealID: dealID, Version: 1, AuthorID: userID, AdvertiserApprovedAt: &time.Time{},
+		}).
+		Return(&entity.AdRevision{DealID: dealID, Version: 1, AuthorID: userID}, nil)
+
+	ev := expectEvent(t, m, ctx, entity.DealEventCreated)
 
 	deal, posts, err := s.CreateDeal(ctx, params)
 	require.NoError(t, err)
@@ -320,88 +424,190 @@ func TestCreateDeal_Success(t *testing.T) {
 	assert.Equal(t, entity.DealStatusPendingPayment, deal.Status)
 	assert.Len(t, posts, 1)
 	assert.Equal(t, entity.PostTypeAd, posts[0].Type)
+	assert.Nil(t, ev.FromStatus)
+	assert.Equal(t, entity.DealStatusPendingPayment, ev.ToStatus)
+	assert.Equal(t, &userID, ev.ActorID)
+	assert.Equal(t, int64(5000000000), ev.Metadata["price_nano_ton"])
 }
 
 // --- Approve ---
 
+func pendingReviewDeal() *entity.Deal {
+	return &entity.Deal{
+		ID:           dealID,
+		ChannelID:    channelID,
+		AdvertiserID: userID,
+		Status:       entity.DealStatusPendingReview,
+	}
+}
+
+func expectPublisher(m *testMocks, ctx context.Context) {
+	m.channelRepo.EXPECT().
+		GetRole(ctx, channelID, publisherID).
+		Return(&entity.ChannelRole{Role: entity.ChannelRoleTypeOwner}, nil)
+}
+
+// expectFreeSlot lets the booking check pass on a channel with no inventory
+// limits and nothing else booked.
+func expectFreeSlot(m *testMocks, ctx context.Context) {
+	m.channelRepo.EXPECT().
+		GetInventoryForUpdate(ctx, channelID).
+		Return(&entity.ChannelInventory{ChannelID: channelID}, nil)
+	m.dealRepo.EXPECT().
+		HasSlotConflict(ctx, channelID, gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(false, nil)
+}
+
 func TestApprove_NoContext(t *testing.T) {
-	s, _, _, _, _, _ := newTestService(t)
-	err := s.Approve(context.Background(), dealID)
+	s, _ := newTestService(t)
+	err := s.Approve(context.Background(), dealID, nil)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, dto.ErrForbidden))
 }
 
 func TestApprove_DealNotFound(t *testing.T) {
-	s, dealRepo, _, _, _, _ := newTestService(t)
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
 
-	dealRepo.EXPECT().GetByID(ctx, dealID).Return(nil, fmt.Errorf("get: %w", dto.ErrNotFound))
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().
+		GetByIDForUpdate(ctx, dealID).
+		Return(nil, fmt.Errorf("get: %w", dto.ErrNotFound))
 
-	err := s.Approve(ctx, dealID)
+	err := s.Approve(ctx, dealID, nil)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, dto.ErrNotFound))
 }
 
 func TestApprove_NoRole(t *testing.T) {
-	s, dealRepo, channelRepo, _, _, _ := newTestService(t)
-	ctx := ctxWithUser(userID, 123456)
+	s, m := newTestService(t)
+	ctx := ctxWithUser(publisherID, 999)
 
-	deal := &entity.Deal{ID: dealID, ChannelID: channelID, Status: entity.DealStatusPendingReview}
-	dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
-	channelRepo.EXPECT().
-		GetRole(ctx, channelID, userID).
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(pendingReviewDeal(), nil)
+	m.channelRepo.EXPECT().
+		GetRole(ctx, channelID, publisherID).
 		Return(nil, fmt.Errorf("get role: %w", dto.ErrNotFound))
 
-	err := s.Approve(ctx, dealID)
+	err := s.Approve(ctx, dealID, nil)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, dto.ErrForbidden))
 }
 
 func TestApprove_WrongStatus(t *testing.T) {
-	s, dealRepo, channelRepo, _, _, _ := newTestService(t)
+	s, m := newTestService(t)
+	ctx := ctxWithUser(publisherID, 999)
+
+	deal := pendingReviewDeal()
+	deal.Status = entity.DealStatusChangesRequested
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(deal, nil)
+	expectPublisher(m, ctx)
+
+	err := s.Approve(ctx, dealID, nil)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, dto.ErrInvalidTransition))
+}
+
+func TestApprove_BothSidesConfirmed(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(publisherID, 999)
+	now := time.Now()
+
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(pendingReviewDeal(), nil)
+	expectPublisher(m, ctx)
+	m.revisionRepo.EXPECT().GetLatest(ctx, dealID).Return(&entity.AdRevision{
+		DealID: dealID, Version: 2, AuthorID: userID, AdvertiserApprovedAt: &now,
+	}, nil)
+	m.revisionRepo.EXPECT().
+		Approve(ctx, dealID, 2, entity.DealPartyPublisher).
+		Return(&entity.AdRevision{
+			DealID: dealID, Version: 2, AuthorID: userID,
+			AdvertiserApprovedAt: &now, PublisherApprovedAt: &now,
+		}, nil)
+	m.dealRepo.EXPECT().
+		UpdateStatus(
+			ctx, dealID, entity.DealStatusPendingReview, entity.DealStatusApproved, (*string)(nil),
+		).
+		Return(nil)
+
+	version := 2
+	ev := expectEvent(t, m, ctx, entity.DealEventRevisionApproved)
+
+	err := s.Approve(ctx, dealID, &version)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusApproved, ev.ToStatus)
+	assert.Equal(t, &publisherID, ev.ActorID)
+	assert.Equal(t, 2, ev.Metadata["version"])
+}
+
+func TestApprove_AwaitsOtherSide(t *testing.T) {
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
+	now := time.Now()
+
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(pendingReviewDeal(), nil)
+	m.revisionRepo.EXPECT().GetLatest(ctx, dealID).Return(&entity.AdRevision{
+		DealID: dealID, Version: 2, AuthorID: publisherID,
+	}, nil)
+	m.revisionRepo.EXPECT().
+		Approve(ctx, dealID, 2, entity.DealPartyAdvertiser).
+		Return(&entity.AdRevision{
+			DealID: dealID, Version: 2, AuthorID: publisherID, AdvertiserApprovedAt: &now,
+		}, nil)
+
+	ev := expectEvent(t, m, ctx, entity.DealEventRevisionApproved)
+
+	err := s.Approve(ctx, dealID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPendingReview, *ev.FromStatus)
+	assert.Equal(t, entity.DealStatusPendingReview, ev.ToStatus)
+}
 
-	deal := &entity.Deal{
-		ID:        dealID,
-		ChannelID: channelID,
-		Status:    entity.DealStatusChangesRequested,
-	}
-	dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
-	channelRepo.EXPECT().
-		GetRole(ctx, channelID, userID).
-		Return(&entity.ChannelRole{Role: entity.ChannelRoleTypeOwner}, nil)
+func TestApprove_StaleVersion(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(publisherID, 999)
+
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(pendingReviewDeal(), nil)
+	expectPublisher(m, ctx)
+	m.revisionRepo.EXPECT().GetLatest(ctx, dealID).Return(&entity.AdRevision{
+		DealID: dealID, Version: 3, AuthorID: userID,
+	}, nil)
 
-	err := s.Approve(ctx, dealID)
+	version := 2
+	err := s.Approve(ctx, dealID, &version)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, dto.ErrInvalidTransition))
 }
 
-func TestApprove_Success(t *testing.T) {
-	s, dealRepo, channelRepo, _, _, _ := newTestService(t)
+func TestApprove_AlreadyConfirmed(t *testing.T) {
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
+	now := time.Now()
 
-	deal := &entity.Deal{ID: dealID, ChannelID: channelID, Status: entity.DealStatusPendingReview}
-	dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
-	channelRepo.EXPECT().
-		GetRole(ctx, channelID, userID).
-		Return(&entity.ChannelRole{Role: entity.ChannelRoleTypeOwner}, nil)
-	dealRepo.EXPECT().
-		UpdateStatus(ctx, dealID, entity.DealStatusApproved, (*string)(nil)).
-		Return(nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(pendingReviewDeal(), nil)
+	m.revisionRepo.EXPECT().GetLatest(ctx, dealID).Return(&entity.AdRevision{
+		DealID: dealID, Version: 1, AuthorID: userID, AdvertiserApprovedAt: &now,
+	}, nil)
 
-	err := s.Approve(ctx, dealID)
-	require.NoError(t, err)
+	err := s.Approve(ctx, dealID, nil)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, dto.ErrInvalidTransition))
 }
 
 // --- Reject ---
 
 func TestReject_WrongStatus(t *testing.T) {
-	s, dealRepo, channelRepo, _, _, _ := newTestService(t)
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
 
 	deal := &entity.Deal{ID: dealID, ChannelID: channelID, Status: entity.DealStatusApproved}
-	dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
-	channelRepo.EXPECT().
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	m.channelRepo.EXPECT().
 		GetRole(ctx, channelID, userID).
 		Return(&entity.ChannelRole{Role: entity.ChannelRoleTypeOwner}, nil)
 
@@ -411,32 +617,92 @@ func TestReject_WrongStatus(t *testing.T) {
 }
 
 func TestReject_Success(t *testing.T) {
-	s, dealRepo, channelRepo, _, _, _ := newTestService(t)
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
 	reason := "bad quality"
+	paymentTx := "txhash"
 
-	deal := &entity.Deal{ID: dealID, ChannelID: channelID, Status: entity.DealStatusPendingReview}
-	dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
-	channelRepo.EXPECT().
+	deal := &entity.Deal{
+		ID: dealID, ChannelID: channelID,
+		Status: entity.DealStatusPendingReview, PaymentTxHash: &paymentTx,
+	}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	m.channelRepo.EXPECT().
 		GetRole(ctx, channelID, userID).
 		Return(&entity.ChannelRole{Role: entity.ChannelRoleTypeManager}, nil)
-	dealRepo.EXPECT().UpdateStatus(ctx, dealID, entity.DealStatusRejected, &reason).Return(nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().
+		TransitionStatus(
+			ctx, dealID, entity.DealStatusPendingReview, entity.DealStatusRejected, &reason,
+		).
+		Return(deal, nil)
+	m.outboxRepo.EXPECT().Create(ctx, dealID, entity.OutboxEventRefund).Return(nil)
+
+	ev := expectEvent(t, m, ctx, entity.DealEventRejected)
 
 	err := s.Reject(ctx, dealID, &reason)
 	require.NoError(t, err)
+	assert.Equal(t, &reason, ev.Note)
+	assert.Equal(t, true, ev.Metadata["refund"])
+}
+
+func TestReject_QueueRefundFails(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+	paymentTx := "txhash"
+
+	deal := &entity.Deal{
+		ID: dealID, ChannelID: channelID,
+		Status: entity.DealStatusPendingReview, PaymentTxHash: &paymentTx,
+	}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	m.channelRepo.EXPECT().
+		GetRole(ctx, channelID, userID).
+		Return(&entity.ChannelRole{Role: entity.ChannelRoleTypeOwner}, nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().
+		TransitionStatus(
+			ctx, dealID, entity.DealStatusPendingReview, entity.DealStatusRejected, (*string)(nil),
+		).
+		Return(deal, nil)
+	m.outboxRepo.EXPECT().
+		Create(ctx, dealID, entity.OutboxEventRefund).
+		Return(errors.New("db down"))
+
+	ev := expectEvent(t, m, ctx, entity.DealEventRejected)
+
+	err := s.Reject(ctx, dealID, nil)
+	require.Error(t, err)
+	assert.Equal(t, entity.DealStatusRejected, ev.ToStatus)
 }
 
 // --- RequestChanges ---
 
 func TestRequestChanges_WrongStatus(t *testing.T) {
-	s, dealRepo, channelRepo, _, _, _ := newTestService(t)
+	s, m := newTestService(t)
+	ctx := ctxWithUser(publisherID, 999)
+
+	deal := pendingReviewDeal()
+	deal.Status = entity.DealStatusPendingPayment
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(deal, nil)
+	expectPublisher(m, ctx)
+
+	err := s.RequestChanges(ctx, dealID, "fix text")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, dto.ErrInvalidTransition))
+}
+
+func TestRequestChanges_OwnVersion(t *testing.T) {
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
+	now := time.Now()
 
-	deal := &entity.Deal{ID: dealID, ChannelID: channelID, Status: entity.DealStatusPendingPayment}
-	dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
-	channelRepo.EXPECT().
-		GetRole(ctx, channelID, userID).
-		Return(&entity.ChannelRole{Role: entity.ChannelRoleTypeOwner}, nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(pendingReviewDeal(), nil)
+	m.revisionRepo.EXPECT().GetLatest(ctx, dealID).Return(&entity.AdRevision{
+		DealID: dealID, Version: 1, AuthorID: userID, AdvertiserApprovedAt: &now,
+	}, nil)
 
 	err := s.RequestChanges(ctx, dealID, "fix text")
 	require.Error(t, err)
@@ -444,107 +710,447 @@ func TestRequestChanges_WrongStatus(t *testing.T) {
 }
 
 func TestRequestChanges_Success(t *testing.T) {
-	s, dealRepo, channelRepo, _, _, _ := newTestService(t)
-	ctx := ctxWithUser(userID, 123456)
+	s, m := newTestService(t)
+	ctx := ctxWithUser(publisherID, 999)
 	note := "fix text"
+	now := time.Now()
+
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(pendingReviewDeal(), nil)
+	expectPublisher(m, ctx)
+	m.revisionRepo.EXPECT().GetLatest(ctx, dealID).Return(&entity.AdRevision{
+		DealID: dealID, Version: 1, AuthorID: userID, AdvertiserApprovedAt: &now,
+	}, nil)
+	m.dealRepo.EXPECT().
+		UpdateStatus(
+			ctx, dealID, entity.DealStatusPendingReview, entity.DealStatusChangesRequested, &note,
+		).
+		Return(nil)
 
-	deal := &entity.Deal{ID: dealID, ChannelID: channelID, Status: entity.DealStatusPendingReview}
-	dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
-	channelRepo.EXPECT().
-		GetRole(ctx, channelID, userID).
-		Return(&entity.ChannelRole{Role: entity.ChannelRoleTypeOwner}, nil)
-	dealRepo.EXPECT().
-		UpdateStatus(ctx, dealID, entity.DealStatusChangesRequested, &note).
+	ev := expectEvent(t, m, ctx, entity.DealEventChangesRequested)
+
+	err := s.RequestChanges(ctx, dealID, note)
+	require.NoError(t, err)
+	assert.Equal(t, &note, ev.Note)
+	assert.Equal(t, entity.DealStatusChangesRequested, ev.ToStatus)
+}
+
+func TestRequestChanges_ByAdvertiser(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+	note := "keep the old link"
+	now := time.Now()
+
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(pendingReviewDeal(), nil)
+	m.revisionRepo.EXPECT().GetLatest(ctx, dealID).Return(&entity.AdRevision{
+		DealID: dealID, Version: 2, AuthorID: publisherID, PublisherApprovedAt: &now,
+	}, nil)
+	m.dealRepo.EXPECT().
+		UpdateStatus(
+			ctx, dealID, entity.DealStatusPendingReview, entity.DealStatusChangesRequested, &note,
+		).
 		Return(nil)
 
+	ev := expectEvent(t, m, ctx, entity.DealEventChangesRequested)
+
 	err := s.RequestChanges(ctx, dealID, note)
 	require.NoError(t, err)
+	assert.Equal(t, &userID, ev.ActorID)
+	assert.Equal(t, 2, ev.Metadata["version"])
 }
 
 // --- SubmitRevision ---
 
 func TestSubmitRevision_NoContext(t *testing.T) {
-	s, _, _, _, _, _ := newTestService(t)
-	_, err := s.SubmitRevision(context.Background(), dealID, nil)
+	s, _ := newTestService(t)
+	_, err := s.SubmitRevision(context.Background(), dealID, RevisionParams{})
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, dto.ErrForbidden))
 }
 
-func TestSubmitRevision_NotAdvertiser(t *testing.T) {
-	s, dealRepo, _, _, _, _ := newTestService(t)
+func TestSubmitRevision_NotParticipant(t *testing.T) {
+	s, m := newTestService(t)
 	otherUser := uuid.Must(uuid.NewV7())
 	ctx := ctxWithUser(otherUser, 999)
 
-	deal := &entity.Deal{
-		ID:           dealID,
-		AdvertiserID: userID,
-		Status:       entity.DealStatusChangesRequested,
-	}
-	dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	deal := pendingReviewDeal()
+	deal.Status = entity.DealStatusChangesRequested
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(deal, nil)
+	m.channelRepo.EXPECT().GetRole(ctx, channelID, otherUser).Return(nil, dto.ErrNotFound)
 
-	_, err := s.SubmitRevision(ctx, dealID, nil)
+	_, err := s.SubmitRevision(ctx, dealID, RevisionParams{Text: strPtr("text")})
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, dto.ErrForbidden))
 }
 
 func TestSubmitRevision_WrongStatus(t *testing.T) {
-	s, dealRepo, _, _, _, _ := newTestService(t)
+	for _, status := range []entity.DealStatus{
+		entity.DealStatusPendingPayment,
+		entity.DealStatusApproved,
+	} {
+		t.Run(string(status), func(t *testing.T) {
+			s, m := newTestService(t)
+			ctx := ctxWithUser(userID, 123456)
+
+			deal := pendingReviewDeal()
+			deal.Status = status
+			expectTx(m.tx, ctx)
+			m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(deal, nil)
+
+			_, err := s.SubmitRevision(ctx, dealID, RevisionParams{Text: strPtr("text")})
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, dto.ErrInvalidTransition))
+		})
+	}
+}
+
+func TestSubmitRevision_ForeignTemplate(t *testing.T) {
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
 
-	deal := &entity.Deal{ID: dealID, AdvertiserID: userID, Status: entity.DealStatusPendingReview}
-	dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	templateID := uuid.Must(uuid.NewV7())
+	m.postRepo.EXPECT().GetByID(ctx, templateID).Return(&entity.Post{
+		ID:         templateID,
+		Type:       entity.PostTypeTemplate,
+		ExternalID: uuid.Must(uuid.NewV7()),
+	}, nil)
 
-	_, err := s.SubmitRevision(ctx, dealID, nil)
+	_, err := s.SubmitRevision(ctx, dealID, RevisionParams{TemplatePostID: &templateID})
 	require.Error(t, err)
-	assert.True(t, errors.Is(err, dto.ErrInvalidTransition))
+	assert.True(t, errors.Is(err, dto.ErrForbidden))
 }
 
-func TestSubmitRevision_Success(t *testing.T) {
-	s, dealRepo, _, postRepo, _, tx := newTestService(t)
+func TestSubmitRevision_Text(t *testing.T) {
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
+	note := "shorter text please"
 
-	deal := &entity.Deal{
-		ID:           dealID,
-		AdvertiserID: userID,
-		Status:       entity.DealStatusChangesRequested,
-	}
-	dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	deal := pendingReviewDeal()
+	deal.Status = entity.DealStatusChangesRequested
+	deal.PublisherNote = &note
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(deal, nil)
 
 	v1 := 1
-	latestPosts := []entity.Post{{ID: uuid.Must(uuid.NewV7()), Version: &v1}}
-	postRepo.EXPECT().GetLatestAd(ctx, dealID).Return(latestPosts, nil)
+	photo := entity.MediaTypePhoto
+	latestPosts := []entity.Post{
+		{ID: uuid.Must(uuid.NewV7()), Version: &v1, MediaType: &photo},
+		{ID: uuid.Must(uuid.NewV7()), Version: &v1, MediaType: &photo, Text: strPtr("old text")},
+	}
+	m.postRepo.EXPECT().GetLatestAd(ctx, dealID).Return(latestPosts, nil)
+	m.dealRepo.EXPECT().
+		UpdateStatus(
+			ctx, dealID, entity.DealStatusChangesRequested, entity.DealStatusPendingReview,
+			(*string)(nil),
+		).
+		Return(nil)
+	m.postRepo.EXPECT().
+		AddAdVersion(ctx, dealID, 2, gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context, _ uuid.UUID, _ int, posts []entity.Post,
+		) ([]entity.Post, error) {
+			require.Len(t, posts, 2)
+			assert.Nil(t, posts[0].Text)
+			require.NotNil(t, posts[1].Text)
+			assert.Equal(t, "new text", *posts[1].Text)
+			assert.Equal(t, &photo, posts[1].MediaType)
+			return posts, nil
+		})
+	m.revisionRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, rev *entity.AdRevision) (*entity.AdRevision, error) {
+			assert.Equal(t, 2, rev.Version)
+			assert.Equal(t, userID, rev.AuthorID)
+			assert.Equal(t, &note, rev.PublisherNote)
+			assert.NotNil(t, rev.AdvertiserApprovedAt)
+			assert.Nil(t, rev.PublisherApprovedAt)
+			return rev, nil
+		})
+	m.userRepo.EXPECT().GetByID(ctx, userID).Return(&entity.User{ID: userID, Name: "Ann"}, nil)
+
+	ev := expectEvent(t, m, ctx, entity.DealEventRevisionSubmitted)
+
+	item, err := s.SubmitRevision(ctx, dealID, RevisionParams{Text: strPtr("new text")})
+	require.NoError(t, err)
+	assert.Equal(t, 2, item.Version)
+	assert.Equal(t, &note, item.PublisherNote)
+	assert.Equal(t, "Ann", item.AuthorName)
+	assert.Equal(t, entity.DealPartyAdvertiser, item.AuthorParty)
+	assert.Len(t, item.Posts, 2)
+	assert.Equal(t, entity.DealStatusChangesRequested, *ev.FromStatus)
+	assert.Equal(t, entity.DealStatusPendingReview, ev.ToStatus)
+	assert.Equal(t, 2, ev.Metadata["version"])
+}
+
+func TestSubmitRevision_Template(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+
+	templateID := uuid.Must(uuid.NewV7())
+	m.postRepo.EXPECT().GetByID(ctx, templateID).Return(&entity.Post{
+		ID:         templateID,
+		Type:       entity.PostTypeTemplate,
+		ExternalID: userID,
+	}, nil)
+
+	deal := pendingReviewDeal()
+	deal.Status = entity.DealStatusChangesRequested
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(deal, nil)
+
+	v2 := 2
+	latestPosts := []entity.Post{{ID: uuid.Must(uuid.NewV7()), Version: &v2}}
+	m.postRepo.EXPECT().GetLatestAd(ctx, dealID).Return(latestPosts, nil)
 
-	newPosts := []entity.Post{{Text: strPtr("new text")}}
 	createdPosts := []entity.Post{
 		{ID: uuid.Must(uuid.NewV7()), Type: entity.PostTypeAd, ExternalID: dealID},
 	}
+	m.dealRepo.EXPECT().
+		UpdateStatus(
+			ctx, dealID, entity.DealStatusChangesRequested, entity.DealStatusPendingReview,
+			(*string)(nil),
+		).
+		Return(nil)
+	m.postRepo.EXPECT().CopyAsAd(ctx, templateID, dealID, 3).Return(createdPosts, nil)
+	m.revisionRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, rev *entity.AdRevision) (*entity.AdRevision, error) {
+			assert.Equal(t, 3, rev.Version)
+			return rev, nil
+		})
+	m.userRepo.EXPECT().GetByID(ctx, userID).Return(&entity.User{ID: userID}, nil)
 
-	tx.EXPECT().WithTx(ctx, gomock.Any()).DoAndReturn(
-		func(ctx context.Context, f func(context.Context) error) error {
-			return f(ctx)
+	ev := expectEvent(t, m, ctx, entity.DealEventRevisionSubmitted)
+
+	item, err := s.SubmitRevision(ctx, dealID, RevisionParams{TemplatePostID: &templateID})
+	require.NoError(t, err)
+	assert.Equal(t, 3, item.Version)
+	assert.Len(t, item.Posts, 1)
+	assert.Equal(t, 3, ev.Metadata["version"])
+}
+
+func TestSubmitRevision_ByPublisher(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(publisherID, 999)
+
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(pendingReviewDeal(), nil)
+	expectPublisher(m, ctx)
+
+	v1 := 1
+	latestPosts := []entity.Post{{ID: uuid.Must(uuid.NewV7()), Version: &v1, Text: strPtr("hi")}}
+	m.postRepo.EXPECT().GetLatestAd(ctx, dealID).Return(latestPosts, nil)
+	m.dealRepo.EXPECT().
+		UpdateStatus(
+			ctx, dealID, entity.DealStatusPendingReview, entity.DealStatusPendingReview,
+			(*string)(nil),
+		).
+		Return(nil)
+	m.postRepo.EXPECT().
+		AddAdVersion(ctx, dealID, 2, gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context, _ uuid.UUID, _ int, posts []entity.Post,
+		) ([]entity.Post, error) {
+			return posts, nil
+		})
+	m.revisionRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, rev *entity.AdRevision) (*entity.AdRevision, error) {
+			assert.Equal(t, publisherID, rev.AuthorID)
+			assert.Nil(t, rev.PublisherNote)
+			assert.Nil(t, rev.AdvertiserApprovedAt)
+			assert.NotNil(t, rev.PublisherApprovedAt)
+			return rev, nil
+		})
+	m.userRepo.EXPECT().GetByID(ctx, publisherID).Return(&entity.User{ID: publisherID}, nil)
+
+	ev := expectEvent(t, m, ctx, entity.DealEventRevisionSubmitted)
+
+	item, err := s.SubmitRevision(ctx, dealID, RevisionParams{Text: strPtr("hello")})
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealPartyPublisher, item.AuthorParty)
+	assert.Equal(t, 2, item.Version)
+	assert.Equal(t, &publisherID, ev.ActorID)
+}
+
+// --- GetRevisions ---
+
+func TestGetRevisions_NotParticipant(t *testing.T) {
+	s, m := newTestService(t)
+	otherUser := uuid.Must(uuid.NewV7())
+	ctx := ctxWithUser(otherUser, 999)
+
+	deal := &entity.Deal{ID: dealID, ChannelID: channelID, AdvertiserID: userID}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	m.channelRepo.EXPECT().GetRole(ctx, channelID, otherUser).Return(nil, dto.ErrNotFound)
+
+	_, err := s.GetRevisions(ctx, dealID)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, dto.ErrForbidden))
+}
+
+func TestGetRevisions_Publisher(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(publisherID, 999)
+	note := "fix the link"
+
+	deal := &entity.Deal{ID: dealID, ChannelID: channelID, AdvertiserID: userID}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	m.channelRepo.EXPECT().
+		GetRole(ctx, channelID, publisherID).
+		Return(&entity.ChannelRole{}, nil)
+	m.revisionRepo.EXPECT().GetByDealID(ctx, dealID).Return([]entity.AdRevision{
+		{DealID: dealID, Version: 1, AuthorID: userID},
+		{DealID: dealID, Version: 2, AuthorID: userID, PublisherNote: &note},
+	}, nil)
+	v1, v2 := 1, 2
+	m.postRepo.EXPECT().GetAdVersions(ctx, dealID).Return(map[int][]entity.Post{
+		1: {{Version: &v1, Text: strPtr("first")}},
+		2: {{Version: &v2, Text: strPtr("second")}},
+	}, nil)
+	m.userRepo.EXPECT().GetByID(ctx, userID).Return(&entity.User{ID: userID, Name: "Ann"}, nil)
+
+	items, err := s.GetRevisions(ctx, dealID)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Nil(t, items[0].PublisherNote)
+	assert.Equal(t, "first", *items[0].Posts[0].Text)
+	assert.Equal(t, &note, items[1].PublisherNote)
+	assert.Equal(t, "second", *items[1].Posts[0].Text)
+	assert.Equal(t, entity.DealPartyAdvertiser, items[1].AuthorParty)
+	assert.Equal(t, "Ann", items[1].AuthorName)
+}
+
+// --- GetEvents ---
+
+func TestGetEvents_NamesActors(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+	pendingPayment := entity.DealStatusPendingPayment
+	pendingReview := entity.DealStatusPendingReview
+
+	deal := &entity.Deal{ID: dealID, ChannelID: channelID, AdvertiserID: userID}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	m.eventRepo.EXPECT().GetByDealID(ctx, dealID).Return([]entity.DealEvent{
+		{Type: entity.DealEventCreated, ActorID: &userID, ToStatus: pendingPayment},
+		{
+			Type: entity.DealEventPaymentReceived, FromStatus: &pendingPayment,
+			ToStatus: pendingReview,
+		},
+		{
+			Type: entity.DealEventRevisionSubmitted, ActorID: &publisherID,
+			FromStatus: &pendingReview, ToStatus: pendingReview,
+		},
+	}, nil)
+	m.userRepo.EXPECT().GetByID(ctx, userID).Return(&entity.User{ID: userID, Name: "Ann"}, nil)
+	m.userRepo.EXPECT().
+		GetByID(ctx, publisherID).
+		Return(&entity.User{ID: publisherID, Name: "Bob"}, nil)
+
+	items, err := s.GetEvents(ctx, dealID)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	assert.Equal(t, entity.DealPartyAdvertiser, items[0].ActorParty)
+	assert.Equal(t, "Ann", items[0].ActorName)
+	assert.Empty(t, items[1].ActorParty)
+	assert.Empty(t, items[1].ActorName)
+	assert.Equal(t, entity.DealPartyPublisher, items[2].ActorParty)
+	assert.Equal(t, "Bob", items[2].ActorName)
+}
+
+// --- Messages ---
+
+func TestPostMessage_Publisher(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(publisherID, 999)
+
+	deal := &entity.Deal{ID: dealID, ChannelID: channelID, AdvertiserID: userID}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	expectPublisher(m, ctx)
+	m.userRepo.EXPECT().
+		GetByID(ctx, publisherID).
+		Return(&entity.User{ID: publisherID, Name: "Bob"}, nil)
+	m.messageRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, msg *entity.DealMessage) (*entity.DealMessage, error) {
+			assert.Equal(t, dealID, msg.DealID)
+			assert.Equal(t, publisherID, msg.AuthorID)
+			assert.Equal(t, "Can we move it to Friday?", msg.Text)
+			return msg, nil
 		},
 	)
-	postRepo.EXPECT().AddAdVersion(ctx, dealID, 2, newPosts).Return(createdPosts, nil)
-	dealRepo.EXPECT().
-		UpdateStatus(ctx, dealID, entity.DealStatusPendingReview, (*string)(nil)).
-		Return(nil)
 
-	posts, err := s.SubmitRevision(ctx, dealID, newPosts)
+	item, err := s.PostMessage(ctx, dealID, "  Can we move it to Friday?\n")
 	require.NoError(t, err)
-	assert.Len(t, posts, 1)
+	assert.Equal(t, entity.DealPartyPublisher, item.AuthorParty)
+	assert.Equal(t, "Bob", item.AuthorName)
+}
+
+func TestPostMessage_Empty(t *testing.T) {
+	s, _ := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+
+	_, err := s.PostMessage(ctx, dealID, " \n ")
+	requireAPIError(t, err, "invalid_request")
+}
+
+func TestPostMessage_TooLong(t *testing.T) {
+	s, _ := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+
+	_, err := s.PostMessage(ctx, dealID, strings.Repeat("я", maxMessageLength+1))
+	requireAPIError(t, err, "invalid_request")
+}
+
+func TestPostMessage_NotParticipant(t *testing.T) {
+	s, m := newTestService(t)
+	otherUser := uuid.Must(uuid.NewV7())
+	ctx := ctxWithUser(otherUser, 999)
+
+	deal := &entity.Deal{ID: dealID, ChannelID: channelID, AdvertiserID: userID}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	m.channelRepo.EXPECT().GetRole(ctx, channelID, otherUser).Return(nil, dto.ErrNotFound)
+
+	_, err := s.PostMessage(ctx, dealID, "hello")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, dto.ErrForbidden))
+}
+
+func TestGetMessages_NamesAuthors(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+
+	deal := &entity.Deal{ID: dealID, ChannelID: channelID, AdvertiserID: userID}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	m.messageRepo.EXPECT().GetByDealID(ctx, dealID).Return([]entity.DealMessage{
+		{DealID: dealID, AuthorID: userID, Text: "Is Friday fine?"},
+		{DealID: dealID, AuthorID: publisherID, Text: "Yes"},
+		{DealID: dealID, AuthorID: userID, Text: "Great"},
+	}, nil)
+	m.userRepo.EXPECT().GetByID(ctx, userID).Return(&entity.User{ID: userID, Name: "Ann"}, nil)
+	m.userRepo.EXPECT().
+		GetByID(ctx, publisherID).
+		Return(&entity.User{ID: publisherID, Name: "Bob"}, nil)
+
+	items, err := s.GetMessages(ctx, dealID)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	assert.Equal(t, entity.DealPartyAdvertiser, items[0].AuthorParty)
+	assert.Equal(t, "Ann", items[0].AuthorName)
+	assert.Equal(t, entity.DealPartyPublisher, items[1].AuthorParty)
+	assert.Equal(t, "Bob", items[1].AuthorName)
+	assert.Equal(t, "Great", items[2].Text)
 }
 
 // --- Cancel ---
 
 func TestCancel_NoContext(t *testing.T) {
-	s, _, _, _, _, _ := newTestService(t)
+	s, _ := newTestService(t)
 	err := s.Cancel(context.Background(), dealID)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, dto.ErrForbidden))
 }
 
 func TestCancel_NotAdvertiser(t *testing.T) {
-	s, dealRepo, _, _, _, _ := newTestService(t)
+	s, m := newTestService(t)
 	otherUser := uuid.Must(uuid.NewV7())
 	ctx := ctxWithUser(otherUser, 999)
 
@@ -552,7 +1158,7 @@ func TestCancel_NotAdvertiser(t *testing.T) {
 		ID: dealID, AdvertiserID: userID,
 		Status: entity.DealStatusPendingPayment, ScheduledAt: time.Now().Add(24 * time.Hour),
 	}
-	dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
 
 	err := s.Cancel(ctx, dealID)
 	require.Error(t, err)
@@ -560,14 +1166,14 @@ func TestCancel_NotAdvertiser(t *testing.T) {
 }
 
 func TestCancel_StatusApproved(t *testing.T) {
-	s, dealRepo, _, _, _, _ := newTestService(t)
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
 
 	deal := &entity.Deal{
 		ID: dealID, AdvertiserID: userID,
 		Status: entity.DealStatusApproved, ScheduledAt: time.Now().Add(24 * time.Hour),
 	}
-	dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
 
 	err := s.Cancel(ctx, dealID)
 	require.Error(t, err)
@@ -575,14 +1181,14 @@ func TestCancel_StatusApproved(t *testing.T) {
 }
 
 func TestCancel_ScheduledTimePassed(t *testing.T) {
-	s, dealRepo, _, _, _, _ := newTestService(t)
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
 
 	deal := &entity.Deal{
 		ID: dealID, AdvertiserID: userID,
 		Status: entity.DealStatusPendingReview, ScheduledAt: time.Now().Add(-time.Hour),
 	}
-	dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
 
 	err := s.Cancel(ctx, dealID)
 	require.Error(t, err)
@@ -590,118 +1196,528 @@ func TestCancel_ScheduledTimePassed(t *testing.T) {
 }
 
 func TestCancel_Success_PendingPayment(t *testing.T) {
-	s, dealRepo, _, _, _, _ := newTestService(t)
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
 
 	deal := &entity.Deal{
 		ID: dealID, AdvertiserID: userID,
 		Status: entity.DealStatusPendingPayment, ScheduledAt: time.Now().Add(24 * time.Hour),
 	}
-	dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
-	dealRepo.EXPECT().
-		UpdateStatus(ctx, dealID, entity.DealStatusCancelled, (*string)(nil)).
-		Return(nil)
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().
+		TransitionStatus(
+			ctx, dealID, entity.DealStatusPendingPayment, entity.DealStatusCancelled,
+			(*string)(nil),
+		).
+		Return(deal, nil)
+
+	ev := expectEvent(t, m, ctx, entity.DealEventCancelled)
 
 	err := s.Cancel(ctx, dealID)
 	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusPendingPayment, *ev.FromStatus)
+	assert.Equal(t, entity.DealStatusCancelled, ev.ToStatus)
+	assert.Equal(t, false, ev.Metadata["refund"])
 }
 
 func TestCancel_Success_PendingReview(t *testing.T) {
-	s, dealRepo, _, _, _, _ := newTestService(t)
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
+	paymentTx := "txhash"
 
 	deal := &entity.Deal{
-		ID: dealID, AdvertiserID: userID,
+		ID: dealID, AdvertiserID: userID, PaymentTxHash: &paymentTx,
 		Status: entity.DealStatusPendingReview, ScheduledAt: time.Now().Add(24 * time.Hour),
 	}
-	dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
-	dealRepo.EXPECT().
-		UpdateStatus(ctx, dealID, entity.DealStatusCancelled, (*string)(nil)).
-		Return(nil)
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().
+		TransitionStatus(
+			ctx, dealID, entity.DealStatusPendingReview, entity.DealStatusCancelled, (*string)(nil),
+		).
+		Return(deal, nil)
+	m.outboxRepo.EXPECT().Create(ctx, dealID, entity.OutboxEventRefund).Return(nil)
+
+	ev := expectEvent(t, m, ctx, entity.DealEventCancelled)
 
 	err := s.Cancel(ctx, dealID)
 	require.NoError(t, err)
+	assert.Equal(t, true, ev.Metadata["refund"])
 }
 
 func TestCancel_Success_ChangesRequested(t *testing.T) {
-	s, dealRepo, _, _, _, _ := newTestService(t)
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
+	paymentTx := "txhash"
 
 	deal := &entity.Deal{
-		ID: dealID, AdvertiserID: userID,
+		ID: dealID, AdvertiserID: userID, PaymentTxHash: &paymentTx,
 		Status: entity.DealStatusChangesRequested, ScheduledAt: time.Now().Add(24 * time.Hour),
 	}
-	dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
-	dealRepo.EXPECT().
-		UpdateStatus(ctx, dealID, entity.DealStatusCancelled, (*string)(nil)).
-		Return(nil)
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().
+		TransitionStatus(
+			ctx, dealID, entity.DealStatusChangesRequested, entity.DealStatusCancelled,
+			(*string)(nil),
+		).
+		Return(deal, nil)
+	m.outboxRepo.EXPECT().Create(ctx, dealID, entity.OutboxEventRefund).Return(nil)
+
+	ev := expectEvent(t, m, ctx, entity.DealEventCancelled)
 
 	err := s.Cancel(ctx, dealID)
 	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusChangesRequested, *ev.FromStatus)
+}
+
+func TestCancel_StatusChangedConcurrently(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+
+	deal := &entity.Deal{
+		ID: dealID, AdvertiserID: userID,
+		Status: entity.DealStatusPendingPayment, ScheduledAt: time.Now().Add(24 * time.Hour),
+	}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().
+		TransitionStatus(
+			ctx, dealID, entity.DealStatusPendingPayment, entity.DealStatusCancelled,
+			(*string)(nil),
+		).
+		Return(nil, fmt.Errorf("transitioning deal status: %w", dto.ErrInvalidTransition))
+
+	err := s.Cancel(ctx, dealID)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, dto.ErrInvalidTransition))
+}
+
+func TestCancel_RefundDecidedFromUpdatedRow(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+	paymentTx := "txhash"
+
+	deal := &entity.Deal{
+		ID: dealID, AdvertiserID: userID,
+		Status: entity.DealStatusPendingReview, ScheduledAt: time.Now().Add(24 * time.Hour),
+	}
+	closed := &entity.Deal{
+		ID: dealID, AdvertiserID: userID, PaymentTxHash: &paymentTx,
+		Status: entity.DealStatusCancelled,
+	}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().
+		TransitionStatus(
+			ctx, dealID, entity.DealStatusPendingReview, entity.DealStatusCancelled, (*string)(nil),
+		).
+		Return(closed, nil)
+	m.outboxRepo.EXPECT().Create(ctx, dealID, entity.OutboxEventRefund).Return(nil)
+
+	ev := expectEvent(t, m, ctx, entity.DealEventCancelled)
+
+	err := s.Cancel(ctx, dealID)
+	require.NoError(t, err)
+	assert.Equal(t, true, ev.Metadata["refund"])
+}
+
+// --- ConfirmPayment ---
+
+func TestConfirmPayment_WrongStatus(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := context.Background()
+
+	deal := &entity.Deal{ID: dealID, Status: entity.DealStatusChangesRequested}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+
+	err := s.ConfirmPayment(ctx, dealID, "txhash", "payer", time.Now())
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, dto.ErrInvalidTransition))
+}
+
+func TestConfirmPayment_Success(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := context.Background()
+	paidAt := time.Now()
+
+	deal := &entity.Deal{
+		ID:           dealID,
+		AdvertiserID: userID,
+		Status:       entity.DealStatusPendingPayment,
+		PriceNanoTON: 5000000000,
+	}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	m.tx.EXPECT().WithTx(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, f func(context.Context) error) error {
+			return f(ctx)
+		},
+	)
+	m.dealRepo.EXPECT().
+		TransitionStatus(
+			ctx, dealID, entity.DealStatusPendingPayment, entity.DealStatusPendingReview,
+			(*string)(nil),
+		).
+		Return(deal, nil)
+	m.dealRepo.EXPECT().SetPayment(ctx, dealID, "txhash", "payer", paidAt).Return(nil)
+	expectHold(t, m, ctx, 5000000000)
+
+	ev := expectEvent(t, m, ctx, entity.DealEventPaymentReceived)
+
+	err := s.ConfirmPayment(ctx, dealID, "txhash", "payer", paidAt)
+	require.NoError(t, err)
+	assert.Nil(t, ev.ActorID)
+	assert.Equal(t, "txhash", ev.Metadata["tx_hash"])
+}
+
+// --- RefundLatePayment ---
+
+func TestRefundLatePayment_NotClosed(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := context.Background()
+
+	deal := &entity.Deal{ID: dealID, Status: entity.DealStatusPendingPayment}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+
+	err := s.RefundLatePayment(ctx, dealID, "txhash", "payer", time.Now())
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, dto.ErrInvalidTransition))
+}
+
+func TestRefundLatePayment_AlreadyPaid(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := context.Background()
+	paymentTx := "first"
+
+	deal := &entity.Deal{ID: dealID, Status: entity.DealStatusCancelled, PaymentTxHash: &paymentTx}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+
+	err := s.RefundLatePayment(ctx, dealID, "txhash", "payer", time.Now())
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, dto.ErrInvalidTransition))
+}
+
+func TestRefundLatePayment_Success(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := context.Background()
+	paidAt := time.Now()
+
+	deal := &entity.Deal{
+		ID:           dealID,
+		AdvertiserID: userID,
+		Status:       entity.DealStatusHoldFailed,
+		PriceNanoTON: 5000000000,
+	}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().SetPayment(ctx, dealID, "txhash", "payer", paidAt).Return(nil)
+	expectHold(t, m, ctx, 5000000000)
+	m.outboxRepo.EXPECT().Create(ctx, dealID, entity.OutboxEventRefund).Return(nil)
+
+	ev := expectEvent(t, m, ctx, entity.DealEventLatePaymentRefunded)
+
+	err := s.RefundLatePayment(ctx, dealID, "txhash", "payer", paidAt)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusHoldFailed, *ev.FromStatus)
+	assert.Equal(t, entity.DealStatusHoldFailed, ev.ToStatus)
+}
+
+// --- FailPublish ---
+
+func TestFailPublish_NotApproved(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := context.Background()
+
+	deal := &entity.Deal{ID: dealID, Status: entity.DealStatusPosted}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+
+	err := s.FailPublish(ctx, dealID, "bot was kicked")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, dto.ErrInvalidTransition))
+}
+
+func TestFailPublish_Refunds(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := context.Background()
+	paymentTx := "txhash"
+
+	deal := &entity.Deal{ID: dealID, Status: entity.DealStatusApproved, PaymentTxHash: &paymentTx}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	expectTx(m.tx, ctx)
+	note := "ad could not be published: bot was kicked"
+	m.dealRepo.EXPECT().
+		TransitionStatus(
+			ctx, dealID, entity.DealStatusApproved, entity.DealStatusPublishFailed, &note,
+		).
+		Return(deal, nil)
+	m.outboxRepo.EXPECT().Create(ctx, dealID, entity.OutboxEventRefund).Return(nil)
+
+	ev := expectEvent(t, m, ctx, entity.DealEventPublishFailed)
+
+	err := s.FailPublish(ctx, dealID, "bot was kicked")
+	require.NoError(t, err)
+	assert.Equal(t, &note, ev.Note)
+}
+
+// --- ExpirePayment ---
+
+func TestExpirePayment_WrongStatus(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := context.Background()
+
+	deal := &entity.Deal{ID: dealID, Status: entity.DealStatusPendingReview}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+
+	err := s.ExpirePayment(ctx, dealID)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, dto.ErrInvalidTransition))
+}
+
+func TestExpirePayment_Success(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := context.Background()
+
+	deal := &entity.Deal{ID: dealID, Status: entity.DealStatusPendingPayment}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().
+		TransitionStatus(
+			ctx, dealID, entity.DealStatusPendingPayment, entity.DealStatusHoldFailed,
+			gomock.Not(gomock.Nil()),
+		).
+		Return(deal, nil)
+
+	ev := expectEvent(t, m, ctx, entity.DealEventPaymentExpired)
+
+	err := s.ExpirePayment(ctx, dealID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusHoldFailed, ev.ToStatus)
+	assert.NotNil(t, ev.Note)
+}
+
+// --- ExpireReview ---
+
+func TestExpireReview_WrongStatus(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := context.Background()
+
+	deal := &entity.Deal{ID: dealID, Status: entity.DealStatusApproved}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+
+	err := s.ExpireReview(ctx, dealID, "too late")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, dto.ErrInvalidTransition))
+}
+
+func TestExpireReview_Success_QueuesRefund(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := context.Background()
+	reason := "publisher did not review the ad in time"
+	paymentTx := "txhash"
+
+	deal := &entity.Deal{
+		ID: dealID, Status: entity.DealStatusChangesRequested, PaymentTxHash: &paymentTx,
+	}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().
+		TransitionStatus(
+			ctx, dealID, entity.DealStatusChangesRequested, entity.DealStatusCancelled, &reason,
+		).
+		Return(deal, nil)
+	m.outboxRepo.EXPECT().Create(ctx, dealID, entity.OutboxEventRefund).Return(nil)
+
+	ev := expectEvent(t, m, ctx, entity.DealEventReviewExpired)
+
+	err := s.ExpireReview(ctx, dealID, reason)
+	require.NoError(t, err)
+	assert.Equal(t, &reason, ev.Note)
+	assert.Nil(t, ev.ActorID)
+}
+
+// --- MarkPosted ---
+
+func TestMarkPosted_WrongStatus(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := context.Background()
+
+	deal := &entity.Deal{ID: dealID, Status: entity.DealStatusPendingReview}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+
+	err := s.MarkPosted(ctx, dealID, []int64{1}, time.Now())
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, dto.ErrInvalidTransition))
+}
+
+func TestMarkPosted_Success(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := context.Background()
+	postedAt := time.Now()
+
+	deal := &entity.Deal{ID: dealID, Status: entity.DealStatusApproved}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().SetPostedMessageIDs(ctx, dealID, []int64{1, 2}, postedAt).Return(nil)
+	m.dealRepo.EXPECT().
+		UpdateStatus(
+			ctx, dealID, entity.DealStatusApproved, entity.DealStatusPosted, (*string)(nil),
+		).
+		Return(nil)
+
+	ev := expectEvent(t, m, ctx, entity.DealEventPosted)
+
+	err := s.MarkPosted(ctx, dealID, []int64{1, 2}, postedAt)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, ev.Metadata["message_ids"])
+}
+
+// --- Complete / OpenDispute ---
+
+func TestComplete_WrongStatus(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := context.Background()
+
+	deal := &entity.Deal{ID: dealID, Status: entity.DealStatusApproved}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+
+	err := s.Complete(ctx, dealID)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, dto.ErrInvalidTransition))
+}
+
+func TestComplete_Success(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := context.Background()
+
+	deal := &entity.Deal{ID: dealID, Status: entity.DealStatusPosted}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().
+		UpdateStatus(
+			ctx, dealID, entity.DealStatusPosted, entity.DealStatusCompleted, (*string)(nil),
+		).
+		Return(nil)
+
+	ev := expectEvent(t, m, ctx, entity.DealEventCompleted)
+
+	err := s.Complete(ctx, dealID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusCompleted, ev.ToStatus)
+}
+
+func TestComplete_StatusChangedConcurrently(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := context.Background()
+
+	deal := &entity.Deal{ID: dealID, Status: entity.DealStatusPosted}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().
+		UpdateStatus(
+			ctx, dealID, entity.DealStatusPosted, entity.DealStatusCompleted, (*string)(nil),
+		).
+		Return(fmt.Errorf("updating deal status: %w", dto.ErrInvalidTransition))
+
+	err := s.Complete(ctx, dealID)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, dto.ErrInvalidTransition))
+}
+
+func TestOpenDispute_Success(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := context.Background()
+	reason := "message 1 was deleted"
+
+	deal := &entity.Deal{ID: dealID, Status: entity.DealStatusPosted}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().
+		UpdateStatus(ctx, dealID, entity.DealStatusPosted, entity.DealStatusDispute, &reason).
+		Return(nil)
+	m.disputeRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, d *entity.DealDispute) (*entity.DealDispute, error) {
+			assert.Equal(t, dealID, d.DealID)
+			assert.Equal(t, reason, d.Reason)
+			return d, nil
+		},
+	)
+
+	ev := expectEvent(t, m, ctx, entity.DealEventDisputed)
+
+	err := s.OpenDispute(ctx, dealID, reason)
+	require.NoError(t, err)
+	assert.Equal(t, &reason, ev.Note)
 }
 
 // --- GetDeal ---
 
 func TestGetDeal_NoContext(t *testing.T) {
-	s, _, _, _, _, _ := newTestService(t)
-	_, _, _, err := s.GetDeal(context.Background(), dealID)
+	s, _ := newTestService(t)
+	_, _, _, _, err := s.GetDeal(context.Background(), dealID)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, dto.ErrForbidden))
 }
 
 func TestGetDeal_AsAdvertiser(t *testing.T) {
-	s, dealRepo, channelRepo, postRepo, _, _ := newTestService(t)
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
 
 	deal := &entity.Deal{ID: dealID, ChannelID: channelID, AdvertiserID: userID}
 	posts := []entity.Post{{ID: uuid.Must(uuid.NewV7())}}
+	transfers := []entity.Transfer{{DealID: dealID, Kind: entity.TransferKindPayout}}
 
-	dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
-	channelRepo.EXPECT().GetByID(ctx, channelID).Return(defaultChannel(), nil)
-	postRepo.EXPECT().GetLatestAd(ctx, dealID).Return(posts, nil)
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	m.channelRepo.EXPECT().GetByID(ctx, channelID).Return(defaultChannel(), nil)
+	m.postRepo.EXPECT().GetLatestAd(ctx, dealID).Return(posts, nil)
+	m.transferRepo.EXPECT().GetByDealID(ctx, dealID).Return(transfers, nil)
 
-	d, p, tgChID, err := s.GetDeal(ctx, dealID)
+	d, p, tgChID, tr, err := s.GetDeal(ctx, dealID)
 	require.NoError(t, err)
 	assert.Equal(t, dealID, d.ID)
 	assert.Len(t, p, 1)
 	assert.Equal(t, int64(-1001234567890), tgChID)
+	assert.Len(t, tr, 1)
 }
 
 func TestGetDeal_AsPublisher(t *testing.T) {
-	s, dealRepo, channelRepo, postRepo, _, _ := newTestService(t)
+	s, m := newTestService(t)
 	publisherID := uuid.Must(uuid.NewV7())
 	ctx := ctxWithUser(publisherID, 999)
 
 	deal := &entity.Deal{ID: dealID, ChannelID: channelID, AdvertiserID: userID}
 	posts := []entity.Post{{ID: uuid.Must(uuid.NewV7())}}
+	transfers := []entity.Transfer{{DealID: dealID, Kind: entity.TransferKindPayout}}
 
-	dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
-	channelRepo.EXPECT().GetByID(ctx, channelID).Return(defaultChannel(), nil)
-	channelRepo.EXPECT().
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	m.channelRepo.EXPECT().GetByID(ctx, channelID).Return(defaultChannel(), nil)
+	m.channelRepo.EXPECT().
 		GetRole(ctx, channelID, publisherID).
 		Return(&entity.ChannelRole{Role: entity.ChannelRoleTypeOwner}, nil)
-	postRepo.EXPECT().GetLatestAd(ctx, dealID).Return(posts, nil)
+	m.postRepo.EXPECT().GetLatestAd(ctx, dealID).Return(posts, nil)
+	m.transferRepo.EXPECT().GetByDealID(ctx, dealID).Return(transfers, nil)
 
-	d, p, tgChID, err := s.GetDeal(ctx, dealID)
+	d, p, tgChID, tr, err := s.GetDeal(ctx, dealID)
 	require.NoError(t, err)
 	assert.Equal(t, dealID, d.ID)
 	assert.Len(t, p, 1)
 	assert.Equal(t, int64(-1001234567890), tgChID)
+	assert.Len(t, tr, 1)
 }
 
 func TestGetDeal_Unauthorized(t *testing.T) {
-	s, dealRepo, channelRepo, _, _, _ := newTestService(t)
+	s, m := newTestService(t)
 	otherUser := uuid.Must(uuid.NewV7())
 	ctx := ctxWithUser(otherUser, 999)
 
 	deal := &entity.Deal{ID: dealID, ChannelID: channelID, AdvertiserID: userID}
-	dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
-	channelRepo.EXPECT().GetByID(ctx, channelID).Return(defaultChannel(), nil)
-	channelRepo.EXPECT().
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	m.channelRepo.EXPECT().GetByID(ctx, channelID).Return(defaultChannel(), nil)
+	m.channelRepo.EXPECT().
 		GetRole(ctx, channelID, otherUser).
 		Return(nil, fmt.Errorf("get role: %w", dto.ErrNotFound))
 
-	_, _, _, err := s.GetDeal(ctx, dealID)
+	_, _, _, _, err := s.GetDeal(ctx, dealID)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, dto.ErrForbidden))
 }
@@ -709,31 +1725,46 @@ func TestGetDeal_Unauthorized(t *testing.T) {
 // --- ListPublisherDeals ---
 
 func TestListPublisherDeals_NoRole(t *testing.T) {
-	s, _, channelRepo, _, _, _ := newTestService(t)
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
 
-	channelRepo.EXPECT().GetByTgChannelID(ctx, int64(-1001234567890)).Return(defaultChannel(), nil)
-	channelRepo.EXPECT().
+	m.channelRepo.EXPECT().GetByTgChannelID(ctx, int64(-1001234567890)).Return(defaultChannel(), nil)
+	m.channelRepo.EXPECT().
 		GetRole(ctx, channelID, userID).
 		Return(nil, fmt.Errorf("get role: %w", dto.ErrNotFound))
 
-	_, _, err := s.ListPublisherDeals(ctx, -1001234567890, 10, 0)
+	_, _, err := s.ListPublisherDeals(ctx, -1001234567890, entity.DealFilter{Limit: 10})
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, dto.ErrForbidden))
 }
 
 func TestListPublisherDeals_Success(t *testing.T) {
-	s, dealRepo, channelRepo, _, _, _ := newTestService(t)
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
 
-	deals := []entity.Deal{{ID: dealID, ChannelID: channelID}}
-	channelRepo.EXPECT().GetByTgChannelID(ctx, int64(-1001234567890)).Return(defaultChannel(), nil)
-	channelRepo.EXPECT().
+	advertiserTgID := int64(4001002)
+	tgChannelID := int64(-1001234567891)
+	f := entity.DealFilter{
+		Statuses:       []entity.DealStatus{entity.DealStatusPendingReview},
+		AdvertiserTgID: &advertiserTgID,
+		TgChannelID:    &tgChannelID,
+		Limit:          10,
+	}
+	// the channel is the list itself, not a counterparty filter
+	want := f
+	want.TgChannelID = nil
+
+	deals := []entity.ChannelDeal{{
+		Deal:        entity.Deal{ID: dealID, ChannelID: channelID},
+		TgChannelID: -1001234567890,
+	}}
+	m.channelRepo.EXPECT().GetByTgChannelID(ctx, int64(-1001234567890)).Return(defaultChannel(), nil)
+	m.channelRepo.EXPECT().
 		GetRole(ctx, channelID, userID).
 		Return(&entity.ChannelRole{Role: entity.ChannelRoleTypeOwner}, nil)
-	dealRepo.EXPECT().GetByChannelID(ctx, channelID, 10, 0).Return(deals, 1, nil)
+	m.dealRepo.EXPECT().GetByChannelID(ctx, channelID, want).Return(deals, 1, nil)
 
-	result, total, err := s.ListPublisherDeals(ctx, -1001234567890, 10, 0)
+	result, total, err := s.ListPublisherDeals(ctx, -1001234567890, f)
 	require.NoError(t, err)
 	require.Len(t, result, 1)
 	assert.Equal(t, int64(-1001234567890), result[0].TgChannelID)
@@ -744,21 +1775,27 @@ func TestListPublisherDeals_Success(t *testing.T) {
 // --- ListAdvertiserDeals ---
 
 func TestListAdvertiserDeals_NoContext(t *testing.T) {
-	s, _, _, _, _, _ := newTestService(t)
-	_, _, err := s.ListAdvertiserDeals(context.Background(), 10, 0)
+	s, _ := newTestService(t)
+	_, _, err := s.ListAdvertiserDeals(context.Background(), entity.DealFilter{Limit: 10})
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, dto.ErrForbidden))
 }
 
 func TestListAdvertiserDeals_Success(t *testing.T) {
-	s, dealRepo, channelRepo, _, _, _ := newTestService(t)
+	s, m := newTestService(t)
 	ctx := ctxWithUser(userID, 123456)
 
-	deals := []entity.Deal{{ID: dealID, ChannelID: channelID}}
-	dealRepo.EXPECT().GetByAdvertiserID(ctx, userID, 10, 0).Return(deals, 1, nil)
-	channelRepo.EXPECT().GetByID(ctx, channelID).Return(defaultChannel(), nil)
+	advertiserTgID := int64(123456)
+	f := entity.DealFilter{AdvertiserTgID: &advertiserTgID, Limit: 10}
+	deals := []entity.ChannelDeal{{
+		Deal:        entity.Deal{ID: dealID, ChannelID: channelID},
+		TgChannelID: -1001234567890,
+	}}
+	m.dealRepo.EXPECT().
+		GetByAdvertiserID(ctx, userID, entity.DealFilter{Limit: 10}).
+		Return(deals, 1, nil)
 
-	result, total, err := s.ListAdvertiserDeals(ctx, 10, 0)
+	result, total, err := s.ListAdvertiserDeals(ctx, f)
 	require.NoError(t, err)
 	require.Len(t, result, 1)
 	assert.Equal(t, int64(-1001234567890), result[0].TgChannelID)
@@ -766,6 +1803,31 @@ func TestListAdvertiserDeals_Success(t *testing.T) {
 	assert.Equal(t, 1, total)
 }
 
+func TestListAdvertiserDeals_InvalidRange(t *testing.T) {
+	s, _ := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+
+	from := time.Now()
+	to := from.Add(-time.Hour)
+	_, _, err := s.ListAdvertiserDeals(ctx, entity.DealFilter{
+		ScheduledFrom: &from,
+		ScheduledTo:   &to,
+		Limit:         10,
+	})
+	requireAPIError(t, err, "invalid_request")
+}
+
+func TestListAdvertiserDeals_UnknownStatus(t *testing.T) {
+	s, _ := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+
+	_, _, err := s.ListAdvertiserDeals(ctx, entity.DealFilter{
+		Statuses: []entity.DealStatus{entity.DealStatusApproved, "paid"},
+		Limit:    10,
+	})
+	requireAPIError(t, err, "invalid_request")
+}
+
 func strPtr(s string) *string { return &s }
 
 func requireAPIError(t *testing.T, err error, code string) {
@@ -774,3 +1836,81 @@ func requireAPIError(t *testing.T, err error, code string) {
 	require.True(t, errors.As(err, &apiErr), "expected APIError, got: %v", err)
 	assert.Equal(t, code, apiErr.Code())
 }
+
+func TestCheckSlot(t *testing.T) {
+	at := time.Date(2030, 5, 14, 10, 30, 0, 0, time.UTC)
+	maxAds := 2
+
+	tests := []struct {
+		name    string
+		inv     entity.ChannelInventory
+		booked  int
+		taken   bool
+		wantErr *dto.APIError
+	}{
+		{name: "no limits"},
+		{
+			name: "blackout date",
+			inv: entity.ChannelInventory{
+				BlackoutDates: []time.Time{at.Truncate(24 * time.Hour)},
+			},
+			wantErr: dto.ErrSlotUnavailable,
+		},
+		{
+			name: "inside window",
+			inv: entity.ChannelInventory{
+				PostingWindows: []entity.PostingWindow{{StartMinute: 9 * 60, EndMinute: 12 * 60}},
+			},
+		},
+		{
+			name: "outside window",
+			inv: entity.ChannelInventory{
+				PostingWindows: []entity.PostingWindow{{StartMinute: 18 * 60, EndMinute: 22 * 60}},
+			},
+			wantErr: dto.ErrSlotUnavailable,
+		},
+		{
+			name:   "room left today",
+			inv:    entity.ChannelInventory{MaxAdsPerDay: &maxAds},
+			booked: 1,
+		},
+		{
+			name:    "fully booked today",
+			inv:     entity.ChannelInventory{MaxAdsPerDay: &maxAds},
+			booked:  2,
+			wantErr: dto.ErrSlotUnavailable,
+		},
+		{name: "overlapping pin", taken: true, wantErr: dto.ErrSlotTaken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newTestService(t)
+			ctx := context.Background()
+			deal := &entity.Deal{ID: dealID, ChannelID: channelID, TopHours: 4}
+
+			inv := tt.inv
+			m.channelRepo.EXPECT().GetInventoryForUpdate(ctx, channelID).Return(&inv, nil)
+			if tt.inv.MaxAdsPerDay != nil {
+				day := time.Date(2030, 5, 14, 0, 0, 0, 0, time.UTC)
+				m.dealRepo.EXPECT().
+					CountBooked(ctx, channelID, dealID, day, day.Add(24*time.Hour)).
+					Return(tt.booked, nil)
+			}
+			if tt.wantErr == nil || tt.wantErr == dto.ErrSlotTaken {
+				m.dealRepo.EXPECT().
+					HasSlotConflict(ctx, channelID, dealID, at, at.Add(4*time.Hour)).
+					Return(tt.taken, nil)
+			}
+
+			err := s.checkSlot(ctx, deal, at)
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			var apiErr *dto.APIError
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, tt.wantErr.Code(), apiErr.Code())
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package deal
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+
+	tele "gopkg.in/telebot.v4"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+// maxDiffCells bounds the word diff table; longer rewrites are reported as
+// one deletion and one insertion between the common prefix and suffix
+const maxDiffCells = 1 << 20
+
+// diffText compares the text of two versions. Formatting is matched by the
+// text it covers rather than by offset, so an edit earlier in the text does
+// not report every later entity as changed.
+func diffText(from, to []entity.Post) *dto.TextDiff {
+	oldText, oldEntities := adText(from)
+	newText, newEntities := adText(to)
+
+	added := subtractEntities(newText, newEntities, oldText, oldEntities)
+	removed := subtractEntities(oldText, oldEntities, newText, newEntities)
+	if oldText == newText && len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	return &dto.TextDiff{
+		Segments:        diffWords(oldText, newText),
+		AddedEntities:   added,
+		RemovedEntities: removed,
+	}
+}
+
+// adText returns the text of a version and its formatting; like Telegram, an
+// album carries it on a single item.
+func adText(posts []entity.Post) (string, []tele.MessageEntity) {
+	for i := range posts {
+		p := &posts[i]
+		if p.Text == nil {
+			continue
+		}
+		var entities []tele.MessageEntity
+		if len(p.Entities) > 0 {
+			// unreadable formatting is shown as none rather than failing the diff
+			_ = json.Unmarshal(p.Entities, &entities)
+		}
+		return *p.Text, entities
+	}
+	return "", nil
+}
+
+// subtractEntities returns the entities of a that have no match in b.
+func subtractEntities(
+	aText string, a []tele.MessageEntity,
+	bText string, b []tele.MessageEntity,
+) []dto.TextEntity {
+	remaining := make(map[dto.TextEntity]int, len(b))
+	for _, e := range b {
+		remaining[entityKey(textEntity(bText, e))]++
+	}
+
+	var result []dto.TextEntity
+	for _, e := range a {
+		te := textEntity(aText, e)
+		key := entityKey(te)
+		if remaining[key] > 0 {
+			remaining[key]--
+			continue
+		}
+		result = append(result, te)
+	}
+	return result
+}
+
+func textEntity(text string, e tele.MessageEntity) dto.TextEntity {
+	return dto.TextEntity{
+		Type:          string(e.Type),
+		Offset:        e.Offset,
+		Length:        e.Length,
+		Text:          utf16Slice(text, e.Offset, e.Length),
+		URL:           e.URL,
+		Language:      e.Language,
+		CustomEmojiID: e.CustomEmojiID,
+	}
+}
+
+func entityKey(e dto.TextEntity) dto.TextEntity {
+	e.Offset, e.Length = 0, 0
+	return e
+}
+
+// utf16Slice cuts text the way Telegram counts entity offsets.
+func utf16Slice(text string, offset, length int) string {
+	units := utf16.Encode([]rune(text))
+	if offset < 0 || length < 0 || offset > len(units) {
+		return ""
+	}
+	end := min(offset+length, len(units))
+	return string(utf16.Decode(units[offset:end]))
+}
+
+// diffWords is a word level diff of two texts; whitespace runs are words of
+// their own so the segments join back into the exact texts.
+func diffWords(a, b string) []dto.TextSegment {
+	x, y := tokenize(a), tokenize(b)
+
+	var segments []dto.TextSegment
+	add := func(op dto.TextDiffOp, text string) {
+		if n := len(segments); n > 0 && segments[n-1].Op == op {
+			segments[n-1].Text += text
+			return
+		}
+		segments = append(segments, dto.TextSegment{Op: op, Text: text})
+	}
+
+	prefix := 0
+	for prefix < len(x) && prefix < len(y) && x[prefix] == y[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(x)-prefix && suffix < len(y)-prefix &&
+		x[len(x)-1-suffix] == y[len(y)-1-suffix] {
+		suffix++
+	}
+
+	add(dto.TextDiffEqual, strings.Join(x[:prefix], ""))
+	mx, my := x[prefix:len(x)-suffix], y[prefix:len(y)-suffix]
+
+	if (len(mx)+1)*(len(my)+1) > maxDiffCells {
+		add(dto.TextDiffDelete, strings.Join(mx, ""))
+		add(dto.TextDiffInsert, strings.Join(my, ""))
+	} else {
+		// lcs[i][j] is the longest common subsequence of mx[i:] and my[j:]
+		lcs := make([][]int32, len(mx)+1)
+		for i := range lcs {
+			lcs[i] = make([]int32, len(my)+1)
+		}
+		for i := len(mx) - 1; i >= 0; i-- {
+			for j := len(my) - 1; j >= 0; j-- {
+				if mx[i] == my[j] {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else {
+					lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+				}
+			}
+		}
+
+		i, j := 0, 0
+		for i < len(mx) && j < len(my) {
+			switch {
+			case mx[i] == my[j]:
+				add(dto.TextDiffEqual, mx[i])
+				i++
+				j++
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				add(dto.TextDiffDelete, mx[i])
+				i++
+			default:
+				add(dto.TextDiffInsert, my[j])
+				j++
+			}
+		}
+		add(dto.TextDiffDelete, strings.Join(mx[i:], ""))
+		add(dto.TextDiffInsert, strings.Join(my[j:], ""))
+	}
+
+	add(dto.TextDiffEqual, strings.Join(x[len(x)-suffix:], ""))
+
+	result := segments[:0]
+	for _, s := range segments {
+		if s.Text != "" {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+func tokenize(s string) []string {
+	var tokens []string
+	start := 0
+	var prevSpace bool
+	for i, r := range s {
+		space := unicode.IsSpace(r)
+		if i > start && space != prevSpace {
+			tokens = append(tokens, s[start:i])
+			start = i
+		}
+		prevSpace = space
+	}
+	if start < len(s) {
+		tokens = append(tokens, s[start:])
+	}
+	return tokens
+}
+
+// diffMedia compares the media of two versions position by position.
+func diffMedia(from, to []entity.Post) []dto.MediaChange {
+	oldMedia, newMedia := mediaPosts(from), mediaPosts(to)
+
+	var changes []dto.MediaChange
+	for i := range max(len(oldMedia), len(newMedia)) {
+		switch {
+		case i >= len(newMedia):
+			changes = append(changes, dto.MediaChange{
+				Index: i, Kind: dto.MediaRemoved, From: mediaItem(oldMedia[i]),
+			})
+		case i >= len(oldMedia):
+			changes = append(changes, dto.MediaChange{
+				Index: i, Kind: dto.MediaAdded, To: mediaItem(newMedia[i]),
+			})
+		default:
+			o, n := oldMedia[i], newMedia[i]
+			change := dto.MediaChange{Index: i, From: mediaItem(o), To: mediaItem(n)}
+			if *o.MediaType != *n.MediaType || !sameFile(o, n) {
+				change.Kind = dto.MediaReplaced
+				changes = append(changes, change)
+				continue
+			}
+			if o.HasMediaSpoiler != n.HasMediaSpoiler {
+				change.ChangedFlags = append(change.ChangedFlags, "has_media_spoiler")
+			}
+			if o.ShowCaptionAboveMedia != n.ShowCaptionAboveMedia {
+				change.ChangedFlags = append(change.ChangedFlags, "show_caption_above_media")
+			}
+			if len(change.ChangedFlags) > 0 {
+				change.Kind = dto.MediaUpdated
+				changes = append(changes, change)
+			}
+		}
+	}
+	return changes
+}
+
+func mediaPosts(posts []entity.Post) []*entity.Post {
+	var result []*entity.Post
+	for i := range posts {
+		if posts[i].MediaType != nil {
+			result = append(result, &posts[i])
+		}
+	}
+	return result
+}
+
+func sameFile(a, b *entity.Post) bool {
+	if a.MediaFileID == nil || b.MediaFileID == nil {
+		return a.MediaFileID == b.MediaFileID
+	}
+	return *a.MediaFileID == *b.MediaFileID
+}
+
+func mediaItem(p *entity.Post) *dto.PostMediaItem {
+	return &dto.PostMediaItem{
+		PostID:                p.ID.String(),
+		MediaType:             *p.MediaType,
+		HasMediaSpoiler:       p.HasMediaSpoiler,
+		ShowCaptionAboveMedia: p.ShowCaptionAboveMedia,
+	}
+}
@@ -0,0 +1,216 @@
+package deal
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+func textPost(text, entities string) entity.Post {
+	p := entity.Post{ID: uuid.Must(uuid.NewV7()), Text: &text}
+	if entities != "" {
+		p.Entities = []byte(entities)
+	}
+	return p
+}
+
+func mediaPost(fileID string, spoiler, captionAbove bool) entity.Post {
+	photo := entity.MediaTypePhoto
+	return entity.Post{
+		ID:                    uuid.Must(uuid.NewV7()),
+		MediaType:             &photo,
+		MediaFileID:           &fileID,
+		HasMediaSpoiler:       spoiler,
+		ShowCaptionAboveMedia: captionAbove,
+	}
+}
+
+func joinSegments(segments []dto.TextSegment, skip dto.TextDiffOp) string {
+	var b strings.Builder
+	for _, s := range segments {
+		if s.Op != skip {
+			b.WriteString(s.Text)
+		}
+	}
+	return b.String()
+}
+
+func TestDiffWords(t *testing.T) {
+	segments := diffWords("Buy our great app today", "Buy our new app now")
+
+	assert.Equal(t, []dto.TextSegment{
+		{Op: dto.TextDiffEqual, Text: "Buy our "},
+		{Op: dto.TextDiffDelete, Text: "great"},
+		{Op: dto.TextDiffInsert, Text: "new"},
+		{Op: dto.TextDiffEqual, Text: " app "},
+		{Op: dto.TextDiffDelete, Text: "today"},
+		{Op: dto.TextDiffInsert, Text: "now"},
+	}, segments)
+}
+
+func TestDiffWords_RebuildsBothTexts(t *testing.T) {
+	cases := [][2]string{
+		{"", "new text"},
+		{"old text", ""},
+		{"line one\nline two", "line one\n\nline three"},
+		{"Привет, мир 🌍", "Привет, новый мир 🌍"},
+	}
+	for _, c := range cases {
+		segments := diffWords(c[0], c[1])
+		assert.Equal(t, c[0], joinSegments(segments, dto.TextDiffInsert))
+		assert.Equal(t, c[1], joinSegments(segments, dto.TextDiffDelete))
+	}
+}
+
+func TestDiffWords_LongRewrite(t *testing.T) {
+	a := strings.Repeat("a ", 2000)
+	b := strings.Repeat("b ", 2000)
+
+	segments := diffWords("same "+a+"end", "same "+b+"end")
+	assert.Equal(t, "same "+a+"end", joinSegments(segments, dto.TextDiffInsert))
+	assert.Equal(t, "same "+b+"end", joinSegments(segments, dto.TextDiffDelete))
+	assert.Equal(t, dto.TextDiffEqual, segments[0].Op)
+}
+
+func TestDiffText_Unchanged(t *testing.T) {
+	from := []entity.Post{textPost("hello", `[{"type":"bold","offset":0,"length":5}]`)}
+	to := []entity.Post{textPost("hello", `[{"type":"bold","offset":0,"length":5}]`)}
+
+	assert.Nil(t, diffText(from, to))
+}
+
+func TestDiffText_EntityChanges(t *testing.T) {
+	from := []entity.Post{textPost(
+		"Visit site now",
+		`[{"type":"text_link","offset":6,"length":4,"url":"https://old.example"},`+
+			`{"type":"bold","offset":11,"length":3}]`,
+	)}
+	// the bold word moved but is unchanged; the link target changed
+	to := []entity.Post{textPost(
+		"Please visit site now",
+		`[{"type":"text_link","offset":13,"length":4,"url":"https://new.example"},`+
+			`{"type":"bold","offset":18,"length":3}]`,
+	)}
+
+	diff := diffText(from, to)
+	require.NotNil(t, diff)
+	require.Len(t, diff.AddedEntities, 1)
+	assert.Equal(t, dto.TextEntity{
+		Type: "text_link", Offset: 13, Length: 4, Text: "site", URL: "https://new.example",
+	}, diff.AddedEntities[0])
+	require.Len(t, diff.RemovedEntities, 1)
+	assert.Equal(t, "https://old.example", diff.RemovedEntities[0].URL)
+}
+
+func TestDiffText_FormattingOnly(t *testing.T) {
+	from := []entity.Post{textPost("a 🎉 b", "")}
+	to := []entity.Post{textPost("a 🎉 b", `[{"type":"italic","offset":5,"length":1}]`)}
+
+	diff := diffText(from, to)
+	require.NotNil(t, diff)
+	assert.Equal(t, []dto.TextSegment{{Op: dto.TextDiffEqual, Text: "a 🎉 b"}}, diff.Segments)
+	require.Len(t, diff.AddedEntities, 1)
+	assert.Equal(t, "b", diff.AddedEntities[0].Text)
+	assert.Empty(t, diff.RemovedEntities)
+}
+
+func TestDiffMedia(t *testing.T) {
+	from := []entity.Post{
+		mediaPost("file-1", false, false),
+		mediaPost("file-2", false, false),
+		mediaPost("file-3", false, false),
+	}
+	to := []entity.Post{
+		mediaPost("file-1", false, false),
+		mediaPost("file-2", true, true),
+	}
+	to[0].Text = strPtr("caption")
+
+	changes := diffMedia(from, to)
+	require.Len(t, changes, 2)
+
+	assert.Equal(t, 1, changes[0].Index)
+	assert.Equal(t, dto.MediaUpdated, changes[0].Kind)
+	assert.Equal(t,
+		[]string{"has_media_spoiler", "show_caption_above_media"}, changes[0].ChangedFlags)
+
+	assert.Equal(t, 2, changes[1].Index)
+	assert.Equal(t, dto.MediaRemoved, changes[1].Kind)
+	assert.Equal(t, from[2].ID.String(), changes[1].From.PostID)
+	assert.Nil(t, changes[1].To)
+}
+
+func TestDiffMedia_ReplacedAndAdded(t *testing.T) {
+	from := []entity.Post{mediaPost("file-1", false, false)}
+	to := []entity.Post{mediaPost("file-9", false, false), mediaPost("file-2", false, false)}
+
+	changes := diffMedia(from, to)
+	require.Len(t, changes, 2)
+	assert.Equal(t, dto.MediaReplaced, changes[0].Kind)
+	assert.Equal(t, dto.MediaAdded, changes[1].Kind)
+	assert.Nil(t, changes[1].From)
+	assert.Equal(t, to[1].ID.String(), changes[1].To.PostID)
+}
+
+// --- DiffRevisions ---
+
+func TestDiffRevisions_DefaultsToLatestPair(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+
+	deal := &entity.Deal{ID: dealID, ChannelID: channelID, AdvertiserID: userID}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	m.postRepo.EXPECT().GetAdVersions(ctx, dealID).Return(map[int][]entity.Post{
+		1: {textPost("first", "")},
+		2: {textPost("second", "")},
+		3: {textPost("third", "")},
+	}, nil)
+
+	diff, err := s.DiffRevisions(ctx, dealID, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, diff.From)
+	assert.Equal(t, 3, diff.To)
+	require.NotNil(t, diff.Text)
+	assert.Equal(t, []dto.TextSegment{
+		{Op: dto.TextDiffDelete, Text: "second"},
+		{Op: dto.TextDiffInsert, Text: "third"},
+	}, diff.Text.Segments)
+	assert.Empty(t, diff.Media)
+}
+
+func TestDiffRevisions_UnknownVersion(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+
+	deal := &entity.Deal{ID: dealID, ChannelID: channelID, AdvertiserID: userID}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	m.postRepo.EXPECT().GetAdVersions(ctx, dealID).Return(map[int][]entity.Post{
+		1: {textPost("first", "")},
+	}, nil)
+
+	_, err := s.DiffRevisions(ctx, dealID, 1, 5)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, dto.ErrNotFound))
+}
+
+func TestDiffRevisions_NotParticipant(t *testing.T) {
+	s, m := newTestService(t)
+	otherUser := uuid.Must(uuid.NewV7())
+	ctx := ctxWithUser(otherUser, 999)
+
+	deal := &entity.Deal{ID: dealID, ChannelID: channelID, AdvertiserID: userID}
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+	m.channelRepo.EXPECT().GetRole(ctx, channelID, otherUser).
+		Return(nil, dto.ErrNotFound)
+
+	_, err := s.DiffRevisions(ctx, dealID, 0, 0)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, dto.ErrForbidden))
+}
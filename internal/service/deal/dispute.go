@@ -0,0 +1,320 @@
+package deal
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+// EvidenceParams is what a party submits to back their side of a dispute;
+// at least one field is set.
+type EvidenceParams struct {
+	Text        *string
+	FileID      *string
+	MessageLink *string
+}
+
+// ResolutionParams is an arbiter's ruling on a dispute. PublisherShareNanoTON
+// is only read for a split.
+type ResolutionParams struct {
+	Outcome               entity.DisputeOutcome
+	PublisherShareNanoTON *int64
+	Note                  *string
+}
+
+// SubmitEvidence adds evidence to the deal's open dispute. Either party can
+// submit as much as they like until an arbiter decides.
+func (s *svc) SubmitEvidence(
+	ctx context.Context, dealID uuid.UUID, params EvidenceParams,
+) (*dto.DisputeEvidenceItem, error) {
+	user, ok := dto.UserFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("submit evidence: %w", dto.ErrForbidden)
+	}
+
+	author, err := s.userRepo.GetByID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get author: %w", err)
+	}
+
+	var created *entity.DisputeEvidence
+	var party entity.DealParty
+	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		deal, err := s.dealRepo.GetByIDForUpdate(txCtx, dealID)
+		if err != nil {
+			return fmt.Errorf("get deal: %w", err)
+		}
+
+		party, err = s.partyOf(txCtx, deal, user.ID)
+		if err != nil {
+			return err
+		}
+
+		if deal.Status != entity.DealStatusDispute {
+			return dto.ErrInvalidTransition
+		}
+
+		dispute, err := s.disputeRepo.GetByDealID(txCtx, dealID)
+		if err != nil {
+			return fmt.Errorf("get dispute: %w", err)
+		}
+		if dispute.Status != entity.DealDisputeStatusOpen {
+			return dto.ErrInvalidTransition
+		}
+
+		created, err = s.disputeRepo.CreateEvidence(txCtx, &entity.DisputeEvidence{
+			DisputeID:   dispute.ID,
+			AuthorID:    user.ID,
+			Text:        params.Text,
+			FileID:      params.FileID,
+			MessageLink: params.MessageLink,
+		})
+		if err != nil {
+			return fmt.Errorf("create evidence: %w", err)
+		}
+
+		return s.record(txCtx, &entity.DealEvent{
+			DealID:     dealID,
+			Type:       entity.DealEventEvidenceSubmitted,
+			FromStatus: &deal.Status,
+			ToStatus:   deal.Status,
+			Metadata:   map[string]any{"evidence_id": created.ID},
+		})
+	}); err != nil {
+		return nil, fmt.Errorf("submit evidence: %w", err)
+	}
+
+	s.log.Info("dispute evidence submitted", "deal_id", dealID, "evidence_id", created.ID)
+	return &dto.DisputeEvidenceItem{
+		DisputeEvidence: *created,
+		AuthorName:      author.Name,
+		AuthorParty:     party,
+	}, nil
+}
+
+// GetDispute returns the deal's dispute with all evidence, oldest first, to
+// both parties and to arbiters.
+func (s *svc) GetDispute(ctx context.Context, dealID uuid.UUID) (*dto.DisputeItem, error) {
+	deal, err := s.requireParticipantOrArbiter(ctx, dealID)
+	if err != nil {
+		return nil, err
+	}
+
+	dispute, err := s.disputeRepo.GetByDealID(ctx, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("get dispute: %w", err)
+	}
+
+	channel, err := s.channelRepo.GetByID(ctx, deal.ChannelID)
+	if err != nil {
+		return nil, fmt.Errorf("get channel: %w", err)
+	}
+
+	evidence, err := s.disputeRepo.GetEvidence(ctx, dispute.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get evidence: %w", err)
+	}
+
+	authors := make(map[uuid.UUID]*entity.User)
+	items := make([]dto.DisputeEvidenceItem, len(evidence))
+	for i := range evidence {
+		e := &evidence[i]
+		author, ok := authors[e.AuthorID]
+		if !ok {
+			author, err = s.userRepo.GetByID(ctx, e.AuthorID)
+			if err != nil {
+				return nil, fmt.Errorf("get author: %w", err)
+			}
+			authors[e.AuthorID] = author
+		}
+		party := entity.DealPartyPublisher
+		if e.AuthorID == deal.AdvertiserID {
+			party = entity.DealPartyAdvertiser
+		}
+		items[i] = dto.DisputeEvidenceItem{
+			DisputeEvidence: *e,
+			AuthorName:      author.Name,
+			AuthorParty:     party,
+		}
+	}
+
+	return &dto.DisputeItem{
+		DealDispute: *dispute,
+		Deal:        dto.DealListItem{Deal: *deal, TgChannelID: channel.TgChannelID},
+		Evidence:    items,
+	}, nil
+}
+
+// ListDisputes returns the disputes awaiting a decision, oldest first. Only
+// arbiters see them; evidence is loaded per dispute with GetDispute.
+func (s *svc) ListDisputes(ctx context.Context) ([]dto.DisputeItem, error) {
+	if _, err := s.requireArbiter(ctx); err != nil {
+		return nil, err
+	}
+
+	disputes, err := s.disputeRepo.GetOpen(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list disputes: %w", err)
+	}
+
+	items := make([]dto.DisputeItem, len(disputes))
+	channelCache := make(map[uuid.UUID]int64)
+	for i := range disputes {
+		deal, err := s.dealRepo.GetByID(ctx, disputes[i].DealID)
+		if err != nil {
+			return nil, fmt.Errorf("get deal: %w", err)
+		}
+		tgChannelID, ok := channelCache[deal.ChannelID]
+		if !ok {
+			ch, err := s.channelRepo.GetByID(ctx, deal.ChannelID)
+			if err != nil {
+				return nil, fmt.Errorf("get channel: %w", err)
+			}
+			tgChannelID = ch.TgChannelID
+			channelCache[deal.ChannelID] = tgChannelID
+		}
+		items[i] = dto.DisputeItem{
+			DealDispute: disputes[i],
+			Deal:        dto.DealListItem{Deal: *deal, TgChannelID: tgChannelID},
+		}
+	}
+
+	return items, nil
+}
+
+// ResolveDispute settles the deal's dispute as an arbiter. The publisher's
+// share of the price is left for the escrow worker to pay out, and the rest,
+// if any, is refunded to the advertiser in the same transaction.
+func (s *svc) ResolveDispute(
+	ctx context.Context, dealID uuid.UUID, params ResolutionParams,
+) error {
+	arbiter, err := s.requireArbiter(ctx)
+	if err != nil {
+		return err
+	}
+
+	var share, refund int64
+	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		deal, err := s.dealRepo.GetByIDForUpdate(txCtx, dealID)
+		if err != nil {
+			return fmt.Errorf("get deal: %w", err)
+		}
+
+		if !canTransition(deal.Status, entity.DealStatusResolved) {
+			return dto.ErrInvalidTransition
+		}
+
+		share, err = publisherShare(deal, params)
+		if err != nil {
+			return err
+		}
+
+		dispute, err := s.disputeRepo.GetByDealID(txCtx, dealID)
+		if err != nil {
+			return fmt.Errorf("get dispute: %w", err)
+		}
+		if err := s.disputeRepo.Resolve(
+			txCtx, dispute.ID, params.Outcome, arbiter.ID, params.Note,
+		); err != nil {
+			return fmt.Errorf("resolve dispute: %w", err)
+		}
+
+		settled, err := s.dealRepo.SettleDispute(txCtx, dealID, share, params.Note)
+		if err != nil {
+			return fmt.Errorf("settle deal: %w", err)
+		}
+
+		refund = settled.PriceNanoTON - share
+		if refund > 0 && settled.PaymentTxHash != nil {
+			if err := s.outboxRepo.Create(txCtx, dealID, entity.OutboxEventRefund); err != nil {
+				return fmt.Errorf("queue refund: %w", err)
+			}
+		}
+
+		return s.record(txCtx, &entity.DealEvent{
+			DealID:     dealID,
+			Type:       entity.DealEventDisputeResolved,
+			FromStatus: &deal.Status,
+			ToStatus:   entity.DealStatusResolved,
+			Note:       params.Note,
+			Metadata: map[string]any{
+				"outcome":                  params.Outcome,
+				"publisher_share_nano_ton": share,
+				"refund_nano_ton":          refund,
+			},
+		})
+	}); err != nil {
+		return fmt.Errorf("resolve dispute: %w", err)
+	}
+
+	s.log.Info("dispute resolved",
+		"deal_id", dealID,
+		"outcome", params.Outcome,
+		"publisher_share_nano_ton", share,
+		"refund_nano_ton", refund,
+	)
+	return nil
+}
+
+// publisherShare is the part of the deal's price the ruling awards the
+// publisher. A split must leave something to each side.
+func publisherShare(deal *entity.Deal, params ResolutionParams) (int64, error) {
+	switch params.Outcome {
+	case entity.DisputeOutcomeRelease:
+		return deal.PriceNanoTON, nil
+	case entity.DisputeOutcomeRefund:
+		return 0, nil
+	case entity.DisputeOutcomeSplit:
+		share := params.PublisherShareNanoTON
+		if share == nil || *share <= 0 || *share >= deal.PriceNanoTON {
+			return 0, dto.ErrValidation.WithDetails(map[string]any{
+				"publisher_share_nano_ton": fmt.Sprintf(
+					"must be between 0 and %d exclusive", deal.PriceNanoTON,
+				),
+			})
+		}
+		return *share, nil
+	default:
+		return 0, dto.ErrValidation.WithDetails(
+			map[string]any{"outcome": "must be release, refund or split"},
+		)
+	}
+}
+
+func (s *svc) isArbiter(tgID int64) bool {
+	return slices.Contains(s.cfg.Arbiters, tgID)
+}
+
+func (s *svc) requireArbiter(ctx context.Context) (dto.UserContext, error) {
+	user, ok := dto.UserFromContext(ctx)
+	if !ok || !s.isArbiter(user.TgID) {
+		return dto.UserContext{}, fmt.Errorf("check arbiter: %w", dto.ErrForbidden)
+	}
+	return user, nil
+}
+
+// requireParticipantOrArbiter is requireParticipant that also lets arbiters
+// in, to review a deal they are asked to decide on.
+func (s *svc) requireParticipantOrArbiter(
+	ctx context.Context, dealID uuid.UUID,
+) (*entity.Deal, error) {
+	user, ok := dto.UserFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("check participant: %w", dto.ErrForbidden)
+	}
+
+	if !s.isArbiter(user.TgID) {
+		return s.requireParticipant(ctx, dealID)
+	}
+
+	deal, err := s.dealRepo.GetByID(ctx, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("get deal: %w", err)
+	}
+	return deal, nil
+}
@@ -0,0 +1,174 @@
+package deal
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+const arbiterTgID = 4001007
+
+var (
+	arbiterID = uuid.Must(uuid.NewV7())
+	disputeID = uuid.Must(uuid.NewV7())
+)
+
+func disputedDeal() *entity.Deal {
+	hash := "tx-hash"
+	return &entity.Deal{
+		ID:            dealID,
+		ChannelID:     channelID,
+		AdvertiserID:  userID,
+		Status:        entity.DealStatusDispute,
+		PriceNanoTON:  1_000_000_000,
+		PaymentTxHash: &hash,
+	}
+}
+
+func openDispute() *entity.DealDispute {
+	return &entity.DealDispute{
+		ID:     disputeID,
+		DealID: dealID,
+		Reason: "post deleted after an hour",
+		Status: entity.DealDisputeStatusOpen,
+	}
+}
+
+func TestSubmitEvidence_Success(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+	text := "screenshot shows the post gone at 11:00"
+
+	m.userRepo.EXPECT().GetByID(ctx, userID).Return(defaultUser(), nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(disputedDeal(), nil)
+	m.disputeRepo.EXPECT().GetByDealID(ctx, dealID).Return(openDispute(), nil)
+	m.disputeRepo.EXPECT().CreateEvidence(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, e *entity.DisputeEvidence) (*entity.DisputeEvidence, error) {
+			assert.Equal(t, disputeID, e.DisputeID)
+			assert.Equal(t, userID, e.AuthorID)
+			assert.Equal(t, &text, e.Text)
+			e.ID = uuid.Must(uuid.NewV7())
+			return e, nil
+		},
+	)
+	ev := expectEvent(t, m, ctx, entity.DealEventEvidenceSubmitted)
+
+	item, err := s.SubmitEvidence(ctx, dealID, EvidenceParams{Text: &text})
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealPartyAdvertiser, item.AuthorParty)
+	assert.Equal(t, entity.DealStatusDispute, ev.ToStatus)
+	assert.Equal(t, item.ID, ev.Metadata["evidence_id"])
+}
+
+func TestSubmitEvidence_NotDisputed(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+	text := "too late"
+
+	deal := disputedDeal()
+	deal.Status = entity.DealStatusResolved
+
+	m.userRepo.EXPECT().GetByID(ctx, userID).Return(defaultUser(), nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(deal, nil)
+
+	_, err := s.SubmitEvidence(ctx, dealID, EvidenceParams{Text: &text})
+	assert.True(t, errors.Is(err, dto.ErrInvalidTransition))
+}
+
+func TestSubmitEvidence_Stranger(t *testing.T) {
+	s, m := newTestService(t)
+	stranger := uuid.Must(uuid.NewV7())
+	ctx := ctxWithUser(stranger, 777)
+	text := "not my deal"
+
+	m.userRepo.EXPECT().GetByID(ctx, stranger).Return(&entity.User{ID: stranger}, nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(disputedDeal(), nil)
+	m.channelRepo.EXPECT().GetRole(ctx, channelID, stranger).Return(nil, dto.ErrNotFound)
+
+	_, err := s.SubmitEvidence(ctx, dealID, EvidenceParams{Text: &text})
+	assert.True(t, errors.Is(err, dto.ErrForbidden))
+}
+
+func TestResolveDispute(t *testing.T) {
+	split := int64(400_000_000)
+	tests := []struct {
+		name       string
+		outcome    entity.DisputeOutcome
+		share      *int64
+		wantShare  int64
+		wantRefund bool
+	}{
+		{"release", entity.DisputeOutcomeRelease, nil, 1_000_000_000, false},
+		{"refund", entity.DisputeOutcomeRefund, nil, 0, true},
+		{"split", entity.DisputeOutcomeSplit, &split, split, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newTestService(t)
+			ctx := ctxWithUser(arbiterID, arbiterTgID)
+			note := "checked the channel history"
+
+			settled := disputedDeal()
+			settled.Status = entity.DealStatusResolved
+			settled.PublisherShareNanoTON = &tt.wantShare
+
+			expectTx(m.tx, ctx)
+			m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(disputedDeal(), nil)
+			m.disputeRepo.EXPECT().GetByDealID(ctx, dealID).Return(openDispute(), nil)
+			m.disputeRepo.EXPECT().
+				Resolve(ctx, disputeID, tt.outcome, arbiterID, &note).
+				Return(nil)
+			m.dealRepo.EXPECT().
+				SettleDispute(ctx, dealID, tt.wantShare, &note).
+				Return(settled, nil)
+			if tt.wantRefund {
+				m.outboxRepo.EXPECT().Create(ctx, dealID, entity.OutboxEventRefund).Return(nil)
+			}
+			ev := expectEvent(t, m, ctx, entity.DealEventDisputeResolved)
+
+			err := s.ResolveDispute(ctx, dealID, ResolutionParams{
+				Outcome:               tt.outcome,
+				PublisherShareNanoTON: tt.share,
+				Note:                  &note,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, entity.DealStatusResolved, ev.ToStatus)
+			assert.Equal(t, tt.wantShare, ev.Metadata["publisher_share_nano_ton"])
+			assert.Equal(t, 1_000_000_000-tt.wantShare, ev.Metadata["refund_nano_ton"])
+		})
+	}
+}
+
+func TestResolveDispute_InvalidSplit(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(arbiterID, arbiterTgID)
+	whole := int64(1_000_000_000)
+
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(disputedDeal(), nil)
+
+	err := s.ResolveDispute(ctx, dealID, ResolutionParams{
+		Outcome:               entity.DisputeOutcomeSplit,
+		PublisherShareNanoTON: &whole,
+	})
+	requireAPIError(t, err, "invalid_request")
+}
+
+func TestResolveDispute_NotArbiter(t *testing.T) {
+	s, _ := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+
+	err := s.ResolveDispute(ctx, dealID, ResolutionParams{Outcome: entity.DisputeOutcomeRefund})
+	assert.True(t, errors.Is(err, dto.ErrForbidden))
+}
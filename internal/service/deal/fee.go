@@ -0,0 +1,158 @@
+package deal
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bpva/ad-marketplace/internal/config"
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+// PromotionParams waives the platform fee between StartsAt and EndsAt, on
+// the channel given by TgChannelID or, without one, on every channel.
+type PromotionParams struct {
+	TgChannelID *int64
+	StartsAt    time.Time
+	EndsAt      time.Time
+	Note        *string
+}
+
+// platformFee is the fee frozen on a deal created on the channel at the given
+// time: nothing while a promotion runs, else the channel's override, else the
+// platform default.
+func (s *svc) platformFee(
+	ctx context.Context,
+	channel *entity.Channel,
+	at time.Time,
+) (int64, error) {
+	promoted, err := s.promotionRepo.Covers(ctx, channel.ID, at)
+	if err != nil {
+		return 0, fmt.Errorf("check promotions: %w", err)
+	}
+	if promoted {
+		return 0, nil
+	}
+	if channel.PlatformFeeBPS != nil {
+		return *channel.PlatformFeeBPS, nil
+	}
+	return s.cfg.PlatformFeeBPS, nil
+}
+
+// SetChannelFee overrides the platform fee on deals created on the channel
+// from now on; nil goes back to the platform default. Deals already created
+// keep the fee they were made with.
+func (s *svc) SetChannelFee(ctx context.Context, tgChannelID int64, bps *int64) error {
+	admin, err := s.requireAdmin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if bps != nil && (*bps < 0 || *bps > config.MaxPlatformFeeBPS) {
+		return fmt.Errorf("set channel fee: %w", dto.ErrValidation.WithDetails(map[string]any{
+			"platform_fee_bps": fmt.Sprintf("must be between 0 and %d", config.MaxPlatformFeeBPS),
+		}))
+	}
+
+	channel, err := s.channelRepo.GetByTgChannelID(ctx, tgChannelID)
+	if err != nil {
+		return fmt.Errorf("get channel: %w", err)
+	}
+
+	if err := s.channelRepo.UpdatePlatformFee(ctx, channel.ID, bps); err != nil {
+		return fmt.Errorf("set channel fee: %w", err)
+	}
+
+	s.log.Info("channel fee updated",
+		"channel_id", tgChannelID,
+		"platform_fee_bps", bps,
+		"admin_id", admin.TgID)
+	return nil
+}
+
+func (s *svc) CreatePromotion(
+	ctx context.Context, params PromotionParams,
+) (*entity.FeePromotion, error) {
+	admin, err := s.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !params.EndsAt.After(params.StartsAt) || !params.EndsAt.After(time.Now()) {
+		return nil, fmt.Errorf("create promotion: %w", dto.ErrValidation.WithDetails(
+			map[string]any{"ends_at": "must be after starts_at and in the future"},
+		))
+	}
+
+	promotion := &entity.FeePromotion{
+		StartsAt:  params.StartsAt,
+		EndsAt:    params.EndsAt,
+		Note:      params.Note,
+		CreatedBy: admin.ID,
+	}
+	if params.TgChannelID != nil {
+		channel, err := s.channelRepo.GetByTgChannelID(ctx, *params.TgChannelID)
+		if err != nil {
+			return nil, fmt.Errorf("get channel: %w", err)
+		}
+		promotion.ChannelID = &channel.ID
+	}
+
+	created, err := s.promotionRepo.Create(ctx, promotion)
+	if err != nil {
+		return nil, fmt.Errorf("create promotion: %w", err)
+	}
+
+	s.log.Info("fee promotion created",
+		"promotion_id", created.ID,
+		"channel_id", params.TgChannelID,
+		"starts_at", created.StartsAt,
+		"ends_at", created.EndsAt,
+		"admin_id", admin.TgID)
+	return created, nil
+}
+
+// ListPromotions returns the promotions that are running or yet to start.
+func (s *svc) ListPromotions(ctx context.Context) ([]entity.FeePromotion, error) {
+	if _, err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	promotions, err := s.promotionRepo.GetUpcoming(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list promotions: %w", err)
+	}
+	return promotions, nil
+}
+
+// DeletePromotion ends a promotion early. Deals created while it ran keep
+// their waived fee.
+func (s *svc) DeletePromotion(ctx context.Context, promotionID uuid.UUID) error {
+	admin, err := s.requireAdmin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.promotionRepo.Delete(ctx, promotionID); err != nil {
+		return fmt.Errorf("delete promotion: %w", err)
+	}
+
+	s.log.Info("fee promotion deleted", "promotion_id", promotionID, "admin_id", admin.TgID)
+	return nil
+}
+
+func (s *svc) isAdmin(tgID int64) bool {
+	return slices.Contains(s.cfg.Admins, tgID)
+}
+
+func (s *svc) requireAdmin(ctx context.Context) (dto.UserContext, error) {
+	user, ok := dto.UserFromContext(ctx)
+	if !ok || !s.isAdmin(user.TgID) {
+		return dto.UserContext{}, fmt.Errorf("check admin: %w", dto.ErrForbidden)
+	}
+	return user, nil
+}
@@ -0,0 +1,175 @@
+package deal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+const (
+	defaultFeeBPS = 250
+	adminTgID     = 4001009
+)
+
+var adminID = uuid.Must(uuid.NewV7())
+
+func expectNoPromotion(m *testMocks, ctx context.Context) {
+	m.promotionRepo.EXPECT().Covers(ctx, channelID, gomock.Any()).Return(false, nil)
+}
+
+// expectHold expects the deal's payment to be posted from the advertiser
+// into escrow.
+func expectHold(t *testing.T, m *testMocks, ctx context.Context, amount int64) {
+	m.ledgerRepo.EXPECT().Post(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, legs []entity.LedgerEntry) error {
+			require.Len(t, legs, 2)
+			assert.Equal(t, entity.LedgerAccountAdvertiser, legs[0].Account)
+			assert.Equal(t, &userID, legs[0].UserID)
+			assert.Equal(t, -amount, legs[0].AmountNanoTON)
+			assert.Equal(t, entity.LedgerAccountEscrow, legs[1].Account)
+			assert.Equal(t, amount, legs[1].AmountNanoTON)
+			for _, l := range legs {
+				assert.Equal(t, entity.LedgerEntryHold, l.Kind)
+				assert.Equal(t, dealID, l.DealID)
+			}
+			return nil
+		},
+	)
+}
+
+func TestPlatformFee(t *testing.T) {
+	override := int64(100)
+	tests := []struct {
+		name     string
+		override *int64
+		promoted bool
+		want     int64
+	}{
+		{"platform default", nil, false, defaultFeeBPS},
+		{"channel override", &override, false, 100},
+		{"promotion wins over override", &override, true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newTestService(t)
+			ctx := context.Background()
+			at := time.Now()
+			channel := defaultChannel()
+			channel.PlatformFeeBPS = tt.override
+
+			m.promotionRepo.EXPECT().Covers(ctx, channelID, at).Return(tt.promoted, nil)
+
+			fee, err := s.platformFee(ctx, channel, at)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, fee)
+		})
+	}
+}
+
+func TestSetChannelFee_Success(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(adminID, adminTgID)
+	bps := int64(500)
+
+	m.channelRepo.EXPECT().
+		GetByTgChannelID(ctx, int64(-1001234567890)).
+		Return(defaultChannel(), nil)
+	m.channelRepo.EXPECT().UpdatePlatformFee(ctx, channelID, &bps).Return(nil)
+
+	require.NoError(t, s.SetChannelFee(ctx, -1001234567890, &bps))
+}
+
+func TestSetChannelFee_NotAdmin(t *testing.T) {
+	s, _ := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+	bps := int64(500)
+
+	err := s.SetChannelFee(ctx, -1001234567890, &bps)
+	assert.True(t, errors.Is(err, dto.ErrForbidden))
+}
+
+func TestSetChannelFee_OutOfRange(t *testing.T) {
+	s, _ := newTestService(t)
+	ctx := ctxWithUser(adminID, adminTgID)
+	bps := int64(10000)
+
+	err := s.SetChannelFee(ctx, -1001234567890, &bps)
+	requireAPIError(t, err, "invalid_request")
+}
+
+func TestCreatePromotion_Channel(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(adminID, adminTgID)
+	tgChannelID := int64(-1001234567890)
+	params := PromotionParams{
+		TgChannelID: &tgChannelID,
+		StartsAt:    time.Now(),
+		EndsAt:      time.Now().Add(7 * 24 * time.Hour),
+	}
+
+	m.channelRepo.EXPECT().GetByTgChannelID(ctx, tgChannelID).Return(defaultChannel(), nil)
+	m.promotionRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, p *entity.FeePromotion) (*entity.FeePromotion, error) {
+			assert.Equal(t, &channelID, p.ChannelID)
+			assert.Equal(t, adminID, p.CreatedBy)
+			p.ID = uuid.Must(uuid.NewV7())
+			return p, nil
+		},
+	)
+
+	created, err := s.CreatePromotion(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, params.EndsAt, created.EndsAt)
+}
+
+func TestCreatePromotion_AlreadyOver(t *testing.T) {
+	s, _ := newTestService(t)
+	ctx := ctxWithUser(adminID, adminTgID)
+
+	_, err := s.CreatePromotion(ctx, PromotionParams{
+		StartsAt: time.Now().Add(-48 * time.Hour),
+		EndsAt:   time.Now().Add(-24 * time.Hour),
+	})
+	requireAPIError(t, err, "invalid_request")
+}
+
+func TestGetBalances_Own(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+	balances := []entity.LedgerBalance{
+		{Account: entity.LedgerAccountAdvertiser, AmountNanoTON: -5000000000},
+	}
+
+	m.ledgerRepo.EXPECT().GetBalances(ctx, userID).Return(balances, nil)
+
+	got, err := s.GetBalances(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, balances, got)
+}
+
+func TestGetBalances_AdminLooksUpUser(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(adminID, adminTgID)
+
+	m.ledgerRepo.EXPECT().GetBalances(ctx, userID).Return(nil, nil)
+
+	_, err := s.GetBalances(ctx, &userID)
+	require.NoError(t, err)
+}
+
+func TestGetBalances_OtherUser(t *testing.T) {
+	s, _ := newTestService(t)
+	ctx := ctxWithUser(publisherID, 654321)
+
+	_, err := s.GetBalances(ctx, &userID)
+	assert.True(t, errors.Is(err, dto.ErrForbidden))
+}
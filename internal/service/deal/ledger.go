@@ -0,0 +1,83 @@
+package deal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+// postHold moves the deal's price from the advertiser into escrow once its
+// payment has arrived. Payouts and refunds are posted by the escrow worker
+// when their transfers confirm.
+func (s *svc) postHold(ctx context.Context, deal *entity.Deal) error {
+	if err := s.ledgerRepo.Post(ctx, []entity.LedgerEntry{
+		{
+			DealID:        deal.ID,
+			Kind:          entity.LedgerEntryHold,
+			Account:       entity.LedgerAccountAdvertiser,
+			UserID:        &deal.AdvertiserID,
+			AmountNanoTON: -deal.PriceNanoTON,
+		},
+		{
+			DealID:        deal.ID,
+			Kind:          entity.LedgerEntryHold,
+			Account:       entity.LedgerAccountEscrow,
+			AmountNanoTON: deal.PriceNanoTON,
+		},
+	}); err != nil {
+		return fmt.Errorf("post hold: %w", err)
+	}
+	return nil
+}
+
+// GetLedger returns every ledger entry of the deal, to its parties, to
+// arbiters and to admins.
+func (s *svc) GetLedger(ctx context.Context, dealID uuid.UUID) ([]entity.LedgerEntry, error) {
+	user, ok := dto.UserFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("get ledger: %w", dto.ErrForbidden)
+	}
+
+	if s.isAdmin(user.TgID) {
+		if _, err := s.dealRepo.GetByID(ctx, dealID); err != nil {
+			return nil, fmt.Errorf("get deal: %w", err)
+		}
+	} else if _, err := s.requireParticipantOrArbiter(ctx, dealID); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.ledgerRepo.GetByDealID(ctx, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("get ledger: %w", err)
+	}
+	return entries, nil
+}
+
+// GetBalances sums the ledger of a user per account. Users see their own
+// balances; admins may look up anyone's.
+func (s *svc) GetBalances(
+	ctx context.Context, userID *uuid.UUID,
+) ([]entity.LedgerBalance, error) {
+	user, ok := dto.UserFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("get balances: %w", dto.ErrForbidden)
+	}
+
+	target := user.ID
+	if userID != nil && *userID != user.ID {
+		if !s.isAdmin(user.TgID) {
+			return nil, fmt.Errorf("get balances: %w", dto.ErrForbidden)
+		}
+		target = *userID
+	}
+
+	balances, err := s.ledgerRepo.GetBalances(ctx, target)
+	if err != nil {
+		return nil, fmt.Errorf("get balances: %w", err)
+	}
+	return balances, nil
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/bpva/ad-marketplace/internal/service/deal (interfaces: DealRepository,ChannelRepository,PostRepository,UserRepository,Transactor)
+// Source: github.com/bpva/ad-marketplace/internal/service/deal (interfaces: DealRepository,ChannelRepository,PostRepository,UserRepository,Transactor,EscrowWallet,TransferRepository,OutboxRepository,RevisionRepository,EventRepository,MessageRepository,OfferRepository,RescheduleRepository,DisputeRepository,SnapshotRepository,RatingRepository,PromotionRepository,LedgerRepository)
 //
 // Generated by this command:
 //
-//	mockgen -destination=mocks.go -package=deal . DealRepository,ChannelRepository,PostRepository,UserRepository,Transactor
+//	mockgen -destination=mocks.go -package=deal . DealRepository,ChannelRepository,PostRepository,UserRepository,Transactor,EscrowWallet,TransferRepository,OutboxRepository,RevisionRepository,EventRepository,MessageRepository,OfferRepository,RescheduleRepository,DisputeRepository,SnapshotRepository,RatingRepository,PromotionRepository,LedgerRepository
 //
 
 // Package deal is a generated GoMock package.
@@ -12,7 +12,9 @@ package deal
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
+	dto "github.com/bpva/ad-marketplace/internal/dto"
 	entity "github.com/bpva/ad-marketplace/internal/entity"
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
@@ -42,6 +44,36 @@ func (m *MockDealRepository) EXPECT() *MockDealRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AcceptTerms mocks base method.
+func (m *MockDealRepository) AcceptTerms(ctx context.Context, id uuid.UUID, priceNanoTON int64, scheduledAt time.Time, deposit *dto.EscrowDeposit, paymentExpiresAt time.Time) (*entity.Deal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptTerms", ctx, id, priceNanoTON, scheduledAt, deposit, paymentExpiresAt)
+	ret0, _ := ret[0].(*entity.Deal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptTerms indicates an expected call of AcceptTerms.
+func (mr *MockDealRepositoryMockRecorder) AcceptTerms(ctx, id, priceNanoTON, scheduledAt, deposit, paymentExpiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptTerms", reflect.TypeOf((*MockDealRepository)(nil).AcceptTerms), ctx, id, priceNanoTON, scheduledAt, deposit, paymentExpiresAt)
+}
+
+// CountBooked mocks base method.
+func (m *MockDealRepository) CountBooked(ctx context.Context, channelID, excludeDealID uuid.UUID, from, to time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBooked", ctx, channelID, excludeDealID, from, to)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBooked indicates an expected call of CountBooked.
+func (mr *MockDealRepositoryMockRecorder) CountBooked(ctx, channelID, excludeDealID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBooked", reflect.TypeOf((*MockDealRepository)(nil).CountBooked), ctx, channelID, excludeDealID, from, to)
+}
+
 // Create mocks base method.
 func (m *MockDealRepository) Create(ctx context.Context, deal *entity.Deal) (*entity.Deal, error) {
 	m.ctrl.T.Helper()
@@ -58,35 +90,35 @@ func (mr *MockDealRepositoryMockRecorder) Create(ctx, deal any) *gomock.Call {
 }
 
 // GetByAdvertiserID mocks base method.
-func (m *MockDealRepository) GetByAdvertiserID(ctx context.Context, advertiserID uuid.UUID, limit, offset int) ([]entity.Deal, int, error) {
+func (m *MockDealRepository) GetByAdvertiserID(ctx context.Context, advertiserID uuid.UUID, f entity.DealFilter) ([]entity.ChannelDeal, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByAdvertiserID", ctx, advertiserID, limit, offset)
-	ret0, _ := ret[0].([]entity.Deal)
+	ret := m.ctrl.Call(m, "GetByAdvertiserID", ctx, advertiserID, f)
+	ret0, _ := ret[0].([]entity.ChannelDeal)
 	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // GetByAdvertiserID indicates an expected call of GetByAdvertiserID.
-func (mr *MockDealRepositoryMockRecorder) GetByAdvertiserID(ctx, advertiserID, limit, offset any) *gomock.Call {
+func (mr *MockDealRepositoryMockRecorder) GetByAdvertiserID(ctx, advertiserID, f any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAdvertiserID", reflect.TypeOf((*MockDealRepository)(nil).GetByAdvertiserID), ctx, advertiserID, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAdvertiserID", reflect.TypeOf((*MockDealRepository)(nil).GetByAdvertiserID), ctx, advertiserID, f)
 }
 
 // GetByChannelID mocks base method.
-func (m *MockDealRepository) GetByChannelID(ctx context.Context, channelID uuid.UUID, limit, offset int) ([]entity.Deal, int, error) {
+func (m *MockDealRepository) GetByChannelID(ctx context.Context, channelID uuid.UUID, f entity.DealFilter) ([]entity.ChannelDeal, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByChannelID", ctx, channelID, limit, offset)
-	ret0, _ := ret[0].([]entity.Deal)
+	ret := m.ctrl.Call(m, "GetByChannelID", ctx, channelID, f)
+	ret0, _ := ret[0].([]entity.ChannelDeal)
 	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // GetByChannelID indicates an expected call of GetByChannelID.
-func (mr *MockDealRepositoryMockRecorder) GetByChannelID(ctx, channelID, limit, offset any) *gomock.Call {
+func (mr *MockDealRepositoryMockRecorder) GetByChannelID(ctx, channelID, f any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByChannelID", reflect.TypeOf((*MockDealRepository)(nil).GetByChannelID), ctx, channelID, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByChannelID", reflect.TypeOf((*MockDealRepository)(nil).GetByChannelID), ctx, channelID, f)
 }
 
 // GetByID mocks base method.
@@ -104,18 +136,136 @@ func (mr *MockDealRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockDealRepository)(nil).GetByID), ctx, id)
 }
 
+// GetByIDForUpdate mocks base method.
+func (m *MockDealRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Deal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*entity.Deal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockDealRepositoryMockRecorder) GetByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockDealRepository)(nil).GetByIDForUpdate), ctx, id)
+}
+
+// GetCampaignBudgetLeft mocks base method.
+func (m *MockDealRepository) GetCampaignBudgetLeft(ctx context.Context, campaignID, exceptDealID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaignBudgetLeft", ctx, campaignID, exceptDealID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaignBudgetLeft indicates an expected call of GetCampaignBudgetLeft.
+func (mr *MockDealRepositoryMockRecorder) GetCampaignBudgetLeft(ctx, campaignID, exceptDealID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaignBudgetLeft", reflect.TypeOf((*MockDealRepository)(nil).GetCampaignBudgetLeft), ctx, campaignID, exceptDealID)
+}
+
+// HasSlotConflict mocks base method.
+func (m *MockDealRepository) HasSlotConflict(ctx context.Context, channelID, excludeDealID uuid.UUID, from, to time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasSlotConflict", ctx, channelID, excludeDealID, from, to)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasSlotConflict indicates an expected call of HasSlotConflict.
+func (mr *MockDealRepositoryMockRecorder) HasSlotConflict(ctx, channelID, excludeDealID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasSlotConflict", reflect.TypeOf((*MockDealRepository)(nil).HasSlotConflict), ctx, channelID, excludeDealID, from, to)
+}
+
+// Reschedule mocks base method.
+func (m *MockDealRepository) Reschedule(ctx context.Context, id uuid.UUID, status entity.DealStatus, scheduledAt time.Time) (*entity.Deal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reschedule", ctx, id, status, scheduledAt)
+	ret0, _ := ret[0].(*entity.Deal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reschedule indicates an expected call of Reschedule.
+func (mr *MockDealRepositoryMockRecorder) Reschedule(ctx, id, status, scheduledAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reschedule", reflect.TypeOf((*MockDealRepository)(nil).Reschedule), ctx, id, status, scheduledAt)
+}
+
+// SetPayment mocks base method.
+func (m *MockDealRepository) SetPayment(ctx context.Context, id uuid.UUID, txHash, payer string, paidAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPayment", ctx, id, txHash, payer, paidAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPayment indicates an expected call of SetPayment.
+func (mr *MockDealRepositoryMockRecorder) SetPayment(ctx, id, txHash, payer, paidAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPayment", reflect.TypeOf((*MockDealRepository)(nil).SetPayment), ctx, id, txHash, payer, paidAt)
+}
+
+// SetPostedMessageIDs mocks base method.
+func (m *MockDealRepository) SetPostedMessageIDs(ctx context.Context, id uuid.UUID, messageIDs []int64, postedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPostedMessageIDs", ctx, id, messageIDs, postedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPostedMessageIDs indicates an expected call of SetPostedMessageIDs.
+func (mr *MockDealRepositoryMockRecorder) SetPostedMessageIDs(ctx, id, messageIDs, postedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPostedMessageIDs", reflect.TypeOf((*MockDealRepository)(nil).SetPostedMessageIDs), ctx, id, messageIDs, postedAt)
+}
+
+// SettleDispute mocks base method.
+func (m *MockDealRepository) SettleDispute(ctx context.Context, id uuid.UUID, publisherShare int64, note *string) (*entity.Deal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SettleDispute", ctx, id, publisherShare, note)
+	ret0, _ := ret[0].(*entity.Deal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SettleDispute indicates an expected call of SettleDispute.
+func (mr *MockDealRepositoryMockRecorder) SettleDispute(ctx, id, publisherShare, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettleDispute", reflect.TypeOf((*MockDealRepository)(nil).SettleDispute), ctx, id, publisherShare, note)
+}
+
+// TransitionStatus mocks base method.
+func (m *MockDealRepository) TransitionStatus(ctx context.Context, id uuid.UUID, from, to entity.DealStatus, note *string) (*entity.Deal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionStatus", ctx, id, from, to, note)
+	ret0, _ := ret[0].(*entity.Deal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransitionStatus indicates an expected call of TransitionStatus.
+func (mr *MockDealRepositoryMockRecorder) TransitionStatus(ctx, id, from, to, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionStatus", reflect.TypeOf((*MockDealRepository)(nil).TransitionStatus), ctx, id, from, to, note)
+}
+
 // UpdateStatus mocks base method.
-func (m *MockDealRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status entity.DealStatus, note *string) error {
+func (m *MockDealRepository) UpdateStatus(ctx context.Context, id uuid.UUID, from, to entity.DealStatus, note *string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, status, note)
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, from, to, note)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateStatus indicates an expected call of UpdateStatus.
-func (mr *MockDealRepositoryMockRecorder) UpdateStatus(ctx, id, status, note any) *gomock.Call {
+func (mr *MockDealRepositoryMockRecorder) UpdateStatus(ctx, id, from, to, note any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockDealRepository)(nil).UpdateStatus), ctx, id, status, note)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockDealRepository)(nil).UpdateStatus), ctx, id, from, to, note)
 }
 
 // MockChannelRepository is a mock of ChannelRepository interface.
@@ -187,6 +337,36 @@ func (mr *MockChannelRepositoryMockRecorder) GetByTgChannelID(ctx, tgChannelID a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTgChannelID", reflect.TypeOf((*MockChannelRepository)(nil).GetByTgChannelID), ctx, tgChannelID)
 }
 
+// GetInventoryForUpdate mocks base method.
+func (m *MockChannelRepository) GetInventoryForUpdate(ctx context.Context, channelID uuid.UUID) (*entity.ChannelInventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventoryForUpdate", ctx, channelID)
+	ret0, _ := ret[0].(*entity.ChannelInventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventoryForUpdate indicates an expected call of GetInventoryForUpdate.
+func (mr *MockChannelRepositoryMockRecorder) GetInventoryForUpdate(ctx, channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryForUpdate", reflect.TypeOf((*MockChannelRepository)(nil).GetInventoryForUpdate), ctx, channelID)
+}
+
+// GetMarketplaceChannel mocks base method.
+func (m *MockChannelRepository) GetMarketplaceChannel(ctx context.Context, channelID uuid.UUID) (*entity.MVChannel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMarketplaceChannel", ctx, channelID)
+	ret0, _ := ret[0].(*entity.MVChannel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMarketplaceChannel indicates an expected call of GetMarketplaceChannel.
+func (mr *MockChannelRepositoryMockRecorder) GetMarketplaceChannel(ctx, channelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMarketplaceChannel", reflect.TypeOf((*MockChannelRepository)(nil).GetMarketplaceChannel), ctx, channelID)
+}
+
 // GetOwnerWalletAddress mocks base method.
 func (m *MockChannelRepository) GetOwnerWalletAddress(ctx context.Context, channelID uuid.UUID) (*string, error) {
 	m.ctrl.T.Helper()
@@ -217,6 +397,20 @@ func (mr *MockChannelRepositoryMockRecorder) GetRole(ctx, channelID, userID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockChannelRepository)(nil).GetRole), ctx, channelID, userID)
 }
 
+// UpdatePlatformFee mocks base method.
+func (m *MockChannelRepository) UpdatePlatformFee(ctx context.Context, channelID uuid.UUID, bps *int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePlatformFee", ctx, channelID, bps)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePlatformFee indicates an expected call of UpdatePlatformFee.
+func (mr *MockChannelRepositoryMockRecorder) UpdatePlatformFee(ctx, channelID, bps any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlatformFee", reflect.TypeOf((*MockChannelRepository)(nil).UpdatePlatformFee), ctx, channelID, bps)
+}
+
 // MockPostRepository is a mock of PostRepository interface.
 type MockPostRepository struct {
 	ctrl     *gomock.Controller
@@ -271,6 +465,21 @@ func (mr *MockPostRepositoryMockRecorder) CopyAsAd(ctx, templatePostID, dealID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyAsAd", reflect.TypeOf((*MockPostRepository)(nil).CopyAsAd), ctx, templatePostID, dealID, version)
 }
 
+// GetAdVersions mocks base method.
+func (m *MockPostRepository) GetAdVersions(ctx context.Context, dealID uuid.UUID) (map[int][]entity.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdVersions", ctx, dealID)
+	ret0, _ := ret[0].(map[int][]entity.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdVersions indicates an expected call of GetAdVersions.
+func (mr *MockPostRepositoryMockRecorder) GetAdVersions(ctx, dealID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdVersions", reflect.TypeOf((*MockPostRepository)(nil).GetAdVersions), ctx, dealID)
+}
+
 // GetByID mocks base method.
 func (m *MockPostRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Post, error) {
 	m.ctrl.T.Helper()
@@ -377,3 +586,833 @@ func (mr *MockTransactorMockRecorder) WithTx(ctx, f any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockTransactor)(nil).WithTx), ctx, f)
 }
+
+// MockEscrowWallet is a mock of EscrowWallet interface.
+type MockEscrowWallet struct {
+	ctrl     *gomock.Controller
+	recorder *MockEscrowWalletMockRecorder
+	isgomock struct{}
+}
+
+// MockEscrowWalletMockRecorder is the mock recorder for MockEscrowWallet.
+type MockEscrowWalletMockRecorder struct {
+	mock *MockEscrowWallet
+}
+
+// NewMockEscrowWallet creates a new mock instance.
+func NewMockEscrowWallet(ctrl *gomock.Controller) *MockEscrowWallet {
+	mock := &MockEscrowWallet{ctrl: ctrl}
+	mock.recorder = &MockEscrowWalletMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEscrowWallet) EXPECT() *MockEscrowWalletMockRecorder {
+	return m.recorder
+}
+
+// Provision mocks base method.
+func (m *MockEscrowWallet) Provision(ctx context.Context) (*dto.EscrowDeposit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Provision", ctx)
+	ret0, _ := ret[0].(*dto.EscrowDeposit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Provision indicates an expected call of Provision.
+func (mr *MockEscrowWalletMockRecorder) Provision(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Provision", reflect.TypeOf((*MockEscrowWallet)(nil).Provision), ctx)
+}
+
+// MockTransferRepository is a mock of TransferRepository interface.
+type MockTransferRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransferRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTransferRepositoryMockRecorder is the mock recorder for MockTransferRepository.
+type MockTransferRepositoryMockRecorder struct {
+	mock *MockTransferRepository
+}
+
+// NewMockTransferRepository creates a new mock instance.
+func NewMockTransferRepository(ctrl *gomock.Controller) *MockTransferRepository {
+	mock := &MockTransferRepository{ctrl: ctrl}
+	mock.recorder = &MockTransferRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransferRepository) EXPECT() *MockTransferRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByDealID mocks base method.
+func (m *MockTransferRepository) GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDealID", ctx, dealID)
+	ret0, _ := ret[0].([]entity.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDealID indicates an expected call of GetByDealID.
+func (mr *MockTransferRepositoryMockRecorder) GetByDealID(ctx, dealID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDealID", reflect.TypeOf((*MockTransferRepository)(nil).GetByDealID), ctx, dealID)
+}
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOutboxRepository) Create(ctx context.Context, dealID uuid.UUID, event entity.OutboxEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, dealID, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOutboxRepositoryMockRecorder) Create(ctx, dealID, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOutboxRepository)(nil).Create), ctx, dealID, event)
+}
+
+// MockRevisionRepository is a mock of RevisionRepository interface.
+type MockRevisionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevisionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRevisionRepositoryMockRecorder is the mock recorder for MockRevisionRepository.
+type MockRevisionRepositoryMockRecorder struct {
+	mock *MockRevisionRepository
+}
+
+// NewMockRevisionRepository creates a new mock instance.
+func NewMockRevisionRepository(ctrl *gomock.Controller) *MockRevisionRepository {
+	mock := &MockRevisionRepository{ctrl: ctrl}
+	mock.recorder = &MockRevisionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevisionRepository) EXPECT() *MockRevisionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockRevisionRepository) Approve(ctx context.Context, dealID uuid.UUID, version int, party entity.DealParty) (*entity.AdRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, dealID, version, party)
+	ret0, _ := ret[0].(*entity.AdRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockRevisionRepositoryMockRecorder) Approve(ctx, dealID, version, party any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockRevisionRepository)(nil).Approve), ctx, dealID, version, party)
+}
+
+// Create mocks base method.
+func (m *MockRevisionRepository) Create(ctx context.Context, rev *entity.AdRevision) (*entity.AdRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, rev)
+	ret0, _ := ret[0].(*entity.AdRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRevisionRepositoryMockRecorder) Create(ctx, rev any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRevisionRepository)(nil).Create), ctx, rev)
+}
+
+// GetByDealID mocks base method.
+func (m *MockRevisionRepository) GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.AdRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDealID", ctx, dealID)
+	ret0, _ := ret[0].([]entity.AdRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDealID indicates an expected call of GetByDealID.
+func (mr *MockRevisionRepositoryMockRecorder) GetByDealID(ctx, dealID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDealID", reflect.TypeOf((*MockRevisionRepository)(nil).GetByDealID), ctx, dealID)
+}
+
+// GetLatest mocks base method.
+func (m *MockRevisionRepository) GetLatest(ctx context.Context, dealID uuid.UUID) (*entity.AdRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatest", ctx, dealID)
+	ret0, _ := ret[0].(*entity.AdRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatest indicates an expected call of GetLatest.
+func (mr *MockRevisionRepositoryMockRecorder) GetLatest(ctx, dealID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatest", reflect.TypeOf((*MockRevisionRepository)(nil).GetLatest), ctx, dealID)
+}
+
+// MockEventRepository is a mock of EventRepository interface.
+type MockEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEventRepositoryMockRecorder is the mock recorder for MockEventRepository.
+type MockEventRepositoryMockRecorder struct {
+	mock *MockEventRepository
+}
+
+// NewMockEventRepository creates a new mock instance.
+func NewMockEventRepository(ctrl *gomock.Controller) *MockEventRepository {
+	mock := &MockEventRepository{ctrl: ctrl}
+	mock.recorder = &MockEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventRepository) EXPECT() *MockEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockEventRepository) Create(ctx context.Context, ev *entity.DealEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, ev)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEventRepositoryMockRecorder) Create(ctx, ev any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEventRepository)(nil).Create), ctx, ev)
+}
+
+// GetByDealID mocks base method.
+func (m *MockEventRepository) GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.DealEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDealID", ctx, dealID)
+	ret0, _ := ret[0].([]entity.DealEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDealID indicates an expected call of GetByDealID.
+func (mr *MockEventRepositoryMockRecorder) GetByDealID(ctx, dealID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDealID", reflect.TypeOf((*MockEventRepository)(nil).GetByDealID), ctx, dealID)
+}
+
+// MockMessageRepository is a mock of MessageRepository interface.
+type MockMessageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMessageRepositoryMockRecorder is the mock recorder for MockMessageRepository.
+type MockMessageRepositoryMockRecorder struct {
+	mock *MockMessageRepository
+}
+
+// NewMockMessageRepository creates a new mock instance.
+func NewMockMessageRepository(ctrl *gomock.Controller) *MockMessageRepository {
+	mock := &MockMessageRepository{ctrl: ctrl}
+	mock.recorder = &MockMessageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageRepository) EXPECT() *MockMessageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMessageRepository) Create(ctx context.Context, msg *entity.DealMessage) (*entity.DealMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, msg)
+	ret0, _ := ret[0].(*entity.DealMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMessageRepositoryMockRecorder) Create(ctx, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMessageRepository)(nil).Create), ctx, msg)
+}
+
+// GetByDealID mocks base method.
+func (m *MockMessageRepository) GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.DealMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDealID", ctx, dealID)
+	ret0, _ := ret[0].([]entity.DealMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDealID indicates an expected call of GetByDealID.
+func (mr *MockMessageRepositoryMockRecorder) GetByDealID(ctx, dealID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDealID", reflect.TypeOf((*MockMessageRepository)(nil).GetByDealID), ctx, dealID)
+}
+
+// MockOfferRepository is a mock of OfferRepository interface.
+type MockOfferRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOfferRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOfferRepositoryMockRecorder is the mock recorder for MockOfferRepository.
+type MockOfferRepositoryMockRecorder struct {
+	mock *MockOfferRepository
+}
+
+// NewMockOfferRepository creates a new mock instance.
+func NewMockOfferRepository(ctrl *gomock.Controller) *MockOfferRepository {
+	mock := &MockOfferRepository{ctrl: ctrl}
+	mock.recorder = &MockOfferRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOfferRepository) EXPECT() *MockOfferRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOfferRepository) Create(ctx context.Context, offer *entity.DealOffer) (*entity.DealOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, offer)
+	ret0, _ := ret[0].(*entity.DealOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOfferRepositoryMockRecorder) Create(ctx, offer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOfferRepository)(nil).Create), ctx, offer)
+}
+
+// GetByDealID mocks base method.
+func (m *MockOfferRepository) GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.DealOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDealID", ctx, dealID)
+	ret0, _ := ret[0].([]entity.DealOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDealID indicates an expected call of GetByDealID.
+func (mr *MockOfferRepositoryMockRecorder) GetByDealID(ctx, dealID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDealID", reflect.TypeOf((*MockOfferRepository)(nil).GetByDealID), ctx, dealID)
+}
+
+// GetPending mocks base method.
+func (m *MockOfferRepository) GetPending(ctx context.Context, dealID uuid.UUID) (*entity.DealOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPending", ctx, dealID)
+	ret0, _ := ret[0].(*entity.DealOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPending indicates an expected call of GetPending.
+func (mr *MockOfferRepositoryMockRecorder) GetPending(ctx, dealID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPending", reflect.TypeOf((*MockOfferRepository)(nil).GetPending), ctx, dealID)
+}
+
+// Respond mocks base method.
+func (m *MockOfferRepository) Respond(ctx context.Context, id uuid.UUID, status entity.DealOfferStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Respond", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Respond indicates an expected call of Respond.
+func (mr *MockOfferRepositoryMockRecorder) Respond(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Respond", reflect.TypeOf((*MockOfferRepository)(nil).Respond), ctx, id, status)
+}
+
+// MockRescheduleRepository is a mock of RescheduleRepository interface.
+type MockRescheduleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRescheduleRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRescheduleRepositoryMockRecorder is the mock recorder for MockRescheduleRepository.
+type MockRescheduleRepositoryMockRecorder struct {
+	mock *MockRescheduleRepository
+}
+
+// NewMockRescheduleRepository creates a new mock instance.
+func NewMockRescheduleRepository(ctrl *gomock.Controller) *MockRescheduleRepository {
+	mock := &MockRescheduleRepository{ctrl: ctrl}
+	mock.recorder = &MockRescheduleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRescheduleRepository) EXPECT() *MockRescheduleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRescheduleRepository) Create(ctx context.Context, rs *entity.DealReschedule) (*entity.DealReschedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, rs)
+	ret0, _ := ret[0].(*entity.DealReschedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRescheduleRepositoryMockRecorder) Create(ctx, rs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRescheduleRepository)(nil).Create), ctx, rs)
+}
+
+// GetByDealID mocks base method.
+func (m *MockRescheduleRepository) GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.DealReschedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDealID", ctx, dealID)
+	ret0, _ := ret[0].([]entity.DealReschedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDealID indicates an expected call of GetByDealID.
+func (mr *MockRescheduleRepositoryMockRecorder) GetByDealID(ctx, dealID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDealID", reflect.TypeOf((*MockRescheduleRepository)(nil).GetByDealID), ctx, dealID)
+}
+
+// GetPending mocks base method.
+func (m *MockRescheduleRepository) GetPending(ctx context.Context, dealID uuid.UUID) (*entity.DealReschedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPending", ctx, dealID)
+	ret0, _ := ret[0].(*entity.DealReschedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPending indicates an expected call of GetPending.
+func (mr *MockRescheduleRepositoryMockRecorder) GetPending(ctx, dealID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPending", reflect.TypeOf((*MockRescheduleRepository)(nil).GetPending), ctx, dealID)
+}
+
+// Respond mocks base method.
+func (m *MockRescheduleRepository) Respond(ctx context.Context, id uuid.UUID, status entity.DealRescheduleStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Respond", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Respond indicates an expected call of Respond.
+func (mr *MockRescheduleRepositoryMockRecorder) Respond(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Respond", reflect.TypeOf((*MockRescheduleRepository)(nil).Respond), ctx, id, status)
+}
+
+// MockDisputeRepository is a mock of DisputeRepository interface.
+type MockDisputeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDisputeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDisputeRepositoryMockRecorder is the mock recorder for MockDisputeRepository.
+type MockDisputeRepositoryMockRecorder struct {
+	mock *MockDisputeRepository
+}
+
+// NewMockDisputeRepository creates a new mock instance.
+func NewMockDisputeRepository(ctrl *gomock.Controller) *MockDisputeRepository {
+	mock := &MockDisputeRepository{ctrl: ctrl}
+	mock.recorder = &MockDisputeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDisputeRepository) EXPECT() *MockDisputeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockDisputeRepository) Create(ctx context.Context, d *entity.DealDispute) (*entity.DealDispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, d)
+	ret0, _ := ret[0].(*entity.DealDispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDisputeRepositoryMockRecorder) Create(ctx, d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDisputeRepository)(nil).Create), ctx, d)
+}
+
+// CreateEvidence mocks base method.
+func (m *MockDisputeRepository) CreateEvidence(ctx context.Context, e *entity.DisputeEvidence) (*entity.DisputeEvidence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEvidence", ctx, e)
+	ret0, _ := ret[0].(*entity.DisputeEvidence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEvidence indicates an expected call of CreateEvidence.
+func (mr *MockDisputeRepositoryMockRecorder) CreateEvidence(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvidence", reflect.TypeOf((*MockDisputeRepository)(nil).CreateEvidence), ctx, e)
+}
+
+// GetByDealID mocks base method.
+func (m *MockDisputeRepository) GetByDealID(ctx context.Context, dealID uuid.UUID) (*entity.DealDispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDealID", ctx, dealID)
+	ret0, _ := ret[0].(*entity.DealDispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDealID indicates an expected call of GetByDealID.
+func (mr *MockDisputeRepositoryMockRecorder) GetByDealID(ctx, dealID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDealID", reflect.TypeOf((*MockDisputeRepository)(nil).GetByDealID), ctx, dealID)
+}
+
+// GetEvidence mocks base method.
+func (m *MockDisputeRepository) GetEvidence(ctx context.Context, disputeID uuid.UUID) ([]entity.DisputeEvidence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvidence", ctx, disputeID)
+	ret0, _ := ret[0].([]entity.DisputeEvidence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvidence indicates an expected call of GetEvidence.
+func (mr *MockDisputeRepositoryMockRecorder) GetEvidence(ctx, disputeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvidence", reflect.TypeOf((*MockDisputeRepository)(nil).GetEvidence), ctx, disputeID)
+}
+
+// GetOpen mocks base method.
+func (m *MockDisputeRepository) GetOpen(ctx context.Context) ([]entity.DealDispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpen", ctx)
+	ret0, _ := ret[0].([]entity.DealDispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpen indicates an expected call of GetOpen.
+func (mr *MockDisputeRepositoryMockRecorder) GetOpen(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpen", reflect.TypeOf((*MockDisputeRepository)(nil).GetOpen), ctx)
+}
+
+// Resolve mocks base method.
+func (m *MockDisputeRepository) Resolve(ctx context.Context, id uuid.UUID, outcome entity.DisputeOutcome, arbiterID uuid.UUID, note *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, id, outcome, arbiterID, note)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockDisputeRepositoryMockRecorder) Resolve(ctx, id, outcome, arbiterID, note any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockDisputeRepository)(nil).Resolve), ctx, id, outcome, arbiterID, note)
+}
+
+// MockSnapshotRepository is a mock of SnapshotRepository interface.
+type MockSnapshotRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSnapshotRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSnapshotRepositoryMockRecorder is the mock recorder for MockSnapshotRepository.
+type MockSnapshotRepositoryMockRecorder struct {
+	mock *MockSnapshotRepository
+}
+
+// NewMockSnapshotRepository creates a new mock instance.
+func NewMockSnapshotRepository(ctrl *gomock.Controller) *MockSnapshotRepository {
+	mock := &MockSnapshotRepository{ctrl: ctrl}
+	mock.recorder = &MockSnapshotRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSnapshotRepository) EXPECT() *MockSnapshotRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByDealID mocks base method.
+func (m *MockSnapshotRepository) GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.DealViewSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDealID", ctx, dealID)
+	ret0, _ := ret[0].([]entity.DealViewSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDealID indicates an expected call of GetByDealID.
+func (mr *MockSnapshotRepositoryMockRecorder) GetByDealID(ctx, dealID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDealID", reflect.TypeOf((*MockSnapshotRepository)(nil).GetByDealID), ctx, dealID)
+}
+
+// MockRatingRepository is a mock of RatingRepository interface.
+type MockRatingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRatingRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRatingRepositoryMockRecorder is the mock recorder for MockRatingRepository.
+type MockRatingRepositoryMockRecorder struct {
+	mock *MockRatingRepository
+}
+
+// NewMockRatingRepository creates a new mock instance.
+func NewMockRatingRepository(ctrl *gomock.Controller) *MockRatingRepository {
+	mock := &MockRatingRepository{ctrl: ctrl}
+	mock.recorder = &MockRatingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRatingRepository) EXPECT() *MockRatingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRatingRepository) Create(ctx context.Context, rt *entity.DealRating) (*entity.DealRating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, rt)
+	ret0, _ := ret[0].(*entity.DealRating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRatingRepositoryMockRecorder) Create(ctx, rt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRatingRepository)(nil).Create), ctx, rt)
+}
+
+// GetByDealID mocks base method.
+func (m *MockRatingRepository) GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.DealRating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDealID", ctx, dealID)
+	ret0, _ := ret[0].([]entity.DealRating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDealID indicates an expected call of GetByDealID.
+func (mr *MockRatingRepositoryMockRecorder) GetByDealID(ctx, dealID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDealID", reflect.TypeOf((*MockRatingRepository)(nil).GetByDealID), ctx, dealID)
+}
+
+// MockPromotionRepository is a mock of PromotionRepository interface.
+type MockPromotionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPromotionRepositoryMockRecorder is the mock recorder for MockPromotionRepository.
+type MockPromotionRepositoryMockRecorder struct {
+	mock *MockPromotionRepository
+}
+
+// NewMockPromotionRepository creates a new mock instance.
+func NewMockPromotionRepository(ctrl *gomock.Controller) *MockPromotionRepository {
+	mock := &MockPromotionRepository{ctrl: ctrl}
+	mock.recorder = &MockPromotionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionRepository) EXPECT() *MockPromotionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Covers mocks base method.
+func (m *MockPromotionRepository) Covers(ctx context.Context, channelID uuid.UUID, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Covers", ctx, channelID, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Covers indicates an expected call of Covers.
+func (mr *MockPromotionRepositoryMockRecorder) Covers(ctx, channelID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Covers", reflect.TypeOf((*MockPromotionRepository)(nil).Covers), ctx, channelID, at)
+}
+
+// Create mocks base method.
+func (m *MockPromotionRepository) Create(ctx context.Context, p *entity.FeePromotion) (*entity.FeePromotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, p)
+	ret0, _ := ret[0].(*entity.FeePromotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPromotionRepositoryMockRecorder) Create(ctx, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPromotionRepository)(nil).Create), ctx, p)
+}
+
+// Delete mocks base method.
+func (m *MockPromotionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPromotionRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPromotionRepository)(nil).Delete), ctx, id)
+}
+
+// GetUpcoming mocks base method.
+func (m *MockPromotionRepository) GetUpcoming(ctx context.Context) ([]entity.FeePromotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpcoming", ctx)
+	ret0, _ := ret[0].([]entity.FeePromotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpcoming indicates an expected call of GetUpcoming.
+func (mr *MockPromotionRepositoryMockRecorder) GetUpcoming(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpcoming", reflect.TypeOf((*MockPromotionRepository)(nil).GetUpcoming), ctx)
+}
+
+// MockLedgerRepository is a mock of LedgerRepository interface.
+type MockLedgerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgerRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockLedgerRepositoryMockRecorder is the mock recorder for MockLedgerRepository.
+type MockLedgerRepositoryMockRecorder struct {
+	mock *MockLedgerRepository
+}
+
+// NewMockLedgerRepository creates a new mock instance.
+func NewMockLedgerRepository(ctrl *gomock.Controller) *MockLedgerRepository {
+	mock := &MockLedgerRepository{ctrl: ctrl}
+	mock.recorder = &MockLedgerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLedgerRepository) EXPECT() *MockLedgerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetBalances mocks base method.
+func (m *MockLedgerRepository) GetBalances(ctx context.Context, userID uuid.UUID) ([]entity.LedgerBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalances", ctx, userID)
+	ret0, _ := ret[0].([]entity.LedgerBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalances indicates an expected call of GetBalances.
+func (mr *MockLedgerRepositoryMockRecorder) GetBalances(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalances", reflect.TypeOf((*MockLedgerRepository)(nil).GetBalances), ctx, userID)
+}
+
+// GetByDealID mocks base method.
+func (m *MockLedgerRepository) GetByDealID(ctx context.Context, dealID uuid.UUID) ([]entity.LedgerEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDealID", ctx, dealID)
+	ret0, _ := ret[0].([]entity.LedgerEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDealID indicates an expected call of GetByDealID.
+func (mr *MockLedgerRepositoryMockRecorder) GetByDealID(ctx, dealID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDealID", reflect.TypeOf((*MockLedgerRepository)(nil).GetByDealID), ctx, dealID)
+}
+
+// Post mocks base method.
+func (m *MockLedgerRepository) Post(ctx context.Context, legs []entity.LedgerEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Post", ctx, legs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Post indicates an expected call of Post.
+func (mr *MockLedgerRepositoryMockRecorder) Post(ctx, legs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Post", reflect.TypeOf((*MockLedgerRepository)(nil).Post), ctx, legs)
+}
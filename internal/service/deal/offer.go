@@ -0,0 +1,293 @@
+package deal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+// OfferParams are the terms of a counter-offer.
+type OfferParams struct {
+	PriceNanoTON int64
+	ScheduledAt  time.Time
+}
+
+// CounterOffer replaces the other side's pending offer with new terms.
+func (s *svc) CounterOffer(
+	ctx context.Context, dealID uuid.UUID, params OfferParams,
+) (*dto.DealOfferItem, error) {
+	user, ok := dto.UserFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("counter offer: %w", dto.ErrForbidden)
+	}
+
+	if params.PriceNanoTON <= 0 {
+		return nil, fmt.Errorf("counter offer: %w",
+			dto.ErrValidation.WithDetails(map[string]any{"price_nano_ton": "must be positive"}))
+	}
+	if err := s.checkSchedule(params.ScheduledAt); err != nil {
+		return nil, fmt.Errorf("counter offer: %w", err)
+	}
+
+	author, err := s.userRepo.GetByID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get author: %w", err)
+	}
+
+	var created *entity.DealOffer
+	var party entity.DealParty
+	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		var deal *entity.Deal
+		var pending *entity.DealOffer
+		var err error
+		deal, pending, party, err = s.pendingOfferForUpdate(txCtx, dealID, user.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.checkSlot(txCtx, deal, params.ScheduledAt); err != nil {
+			return err
+		}
+
+		if err := s.offerRepo.Respond(
+			txCtx, pending.ID, entity.DealOfferStatusCountered,
+		); err != nil {
+			return fmt.Errorf("counter pending offer: %w", err)
+		}
+		created, err = s.offerRepo.Create(txCtx, &entity.DealOffer{
+			DealID:       dealID,
+			AuthorID:     user.ID,
+			PriceNanoTON: params.PriceNanoTON,
+			ScheduledAt:  params.ScheduledAt,
+		})
+		if err != nil {
+			return fmt.Errorf("create offer: %w", err)
+		}
+
+		return s.record(txCtx, &entity.DealEvent{
+			DealID:     dealID,
+			Type:       entity.DealEventOfferMade,
+			FromStatus: &deal.Status,
+			ToStatus:   deal.Status,
+			Metadata: map[string]any{
+				"price_nano_ton": created.PriceNanoTON,
+				"scheduled_at":   created.ScheduledAt,
+			},
+		})
+	}); err != nil {
+		return nil, fmt.Errorf("counter offer: %w", err)
+	}
+
+	s.log.Info("counter-offer made", "deal_id", dealID, "offer_id", created.ID)
+	return &dto.DealOfferItem{
+		DealOffer:   *created,
+		AuthorName:  author.Name,
+		AuthorParty: party,
+	}, nil
+}
+
+// AcceptOffer agrees to the other side's pending offer: its terms are frozen
+// onto the deal, which then awaits payment.
+func (s *svc) AcceptOffer(ctx context.Context, dealID uuid.UUID) error {
+	user, ok := dto.UserFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("accept offer: %w", dto.ErrForbidden)
+	}
+
+	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		deal, pending, _, err := s.pendingOfferForUpdate(txCtx, dealID, user.ID)
+		if err != nil {
+			return err
+		}
+
+		// the offer may have been made long ago
+		if err := s.checkSchedule(pending.ScheduledAt); err != nil {
+			return err
+		}
+		if err := s.checkSlot(txCtx, deal, pending.ScheduledAt); err != nil {
+			return err
+		}
+		if err := s.checkCampaignBudget(txCtx, deal, pending.PriceNanoTON); err != nil {
+			return err
+		}
+
+		deposit, err := s.escrow.Provision(txCtx)
+		if err != nil {
+			return fmt.Errorf("provision escrow: %w", err)
+		}
+
+		if err := s.offerRepo.Respond(
+			txCtx, pending.ID, entity.DealOfferStatusAccepted,
+		); err != nil {
+			return fmt.Errorf("accept pending offer: %w", err)
+		}
+		accepted, err := s.dealRepo.AcceptTerms(
+			txCtx, dealID, pending.PriceNanoTON, pending.ScheduledAt, deposit,
+			time.Now().Add(s.cfg.PaymentTimeout),
+		)
+		if err != nil {
+			return fmt.Errorf("accept terms: %w", err)
+		}
+
+		return s.record(txCtx, &entity.DealEvent{
+			DealID:     dealID,
+			Type:       entity.DealEventOfferAccepted,
+			FromStatus: &deal.Status,
+			ToStatus:   accepted.Status,
+			Metadata: map[string]any{
+				"price_nano_ton": accepted.PriceNanoTON,
+				"scheduled_at":   accepted.ScheduledAt,
+			},
+		})
+	}); err != nil {
+		return fmt.Errorf("accept offer: %w", err)
+	}
+
+	s.log.Info("offer accepted", "deal_id", dealID)
+	return nil
+}
+
+// DeclineOffer turns down the other side's pending offer and closes the
+// deal: as rejected when the publisher declines, as cancelled when the
+// advertiser does. Nothing has been paid yet, so there is nothing to refund.
+func (s *svc) DeclineOffer(ctx context.Context, dealID uuid.UUID, reason *string) error {
+	user, ok := dto.UserFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("decline offer: %w", dto.ErrForbidden)
+	}
+
+	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		deal, pending, _, err := s.pendingOfferForUpdate(txCtx, dealID, user.ID)
+		if err != nil {
+			return err
+		}
+
+		status := entity.DealStatusRejected
+		if deal.AdvertiserID == user.ID {
+			status = entity.DealStatusCancelled
+		}
+
+		if err := s.offerRepo.Respond(
+			txCtx, pending.ID, entity.DealOfferStatusDeclined,
+		); err != nil {
+			return fmt.Errorf("decline pending offer: %w", err)
+		}
+		if err := s.dealRepo.UpdateStatus(txCtx, dealID, deal.Status, status, reason); err != nil {
+			return fmt.Errorf("update status: %w", err)
+		}
+
+		return s.record(txCtx, &entity.DealEvent{
+			DealID:     dealID,
+			Type:       entity.DealEventOfferDeclined,
+			FromStatus: &deal.Status,
+			ToStatus:   status,
+			Note:       reason,
+		})
+	}); err != nil {
+		return fmt.Errorf("decline offer: %w", err)
+	}
+
+	s.log.Info("offer declined", "deal_id", dealID)
+	return nil
+}
+
+// GetOffers returns every offer made on the deal, oldest first, to both the
+// advertiser and the channel's team.
+func (s *svc) GetOffers(ctx context.Context, dealID uuid.UUID) ([]dto.DealOfferItem, error) {
+	deal, err := s.requireParticipant(ctx, dealID)
+	if err != nil {
+		return nil, err
+	}
+
+	offers, err := s.offerRepo.GetByDealID(ctx, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("get offers: %w", err)
+	}
+
+	authors := make(map[uuid.UUID]*entity.User)
+	items := make([]dto.DealOfferItem, len(offers))
+	for i := range offers {
+		o := &offers[i]
+		author, ok := authors[o.AuthorID]
+		if !ok {
+			author, err = s.userRepo.GetByID(ctx, o.AuthorID)
+			if err != nil {
+				return nil, fmt.Errorf("get author: %w", err)
+			}
+			authors[o.AuthorID] = author
+		}
+		party := entity.DealPartyPublisher
+		if o.AuthorID == deal.AdvertiserID {
+			party = entity.DealPartyAdvertiser
+		}
+		items[i] = dto.DealOfferItem{
+			DealOffer:   *o,
+			AuthorName:  author.Name,
+			AuthorParty: party,
+		}
+	}
+
+	return items, nil
+}
+
+// checkCampaignBudget makes sure a negotiated price still fits what is left
+// of the deal's campaign budget once its other deals are paid for. The
+// campaign stays locked until the transaction ends, so two of its deals
+// cannot both take the last of the budget.
+func (s *svc) checkCampaignBudget(ctx context.Context, deal *entity.Deal, price int64) error {
+	if deal.CampaignID == nil {
+		return nil
+	}
+
+	left, err := s.dealRepo.GetCampaignBudgetLeft(ctx, *deal.CampaignID, deal.ID)
+	if err != nil {
+		return fmt.Errorf("get campaign budget: %w", err)
+	}
+	if price > left {
+		return dto.ErrBudgetExceeded.WithDetails(map[string]any{"budget_left_nano_ton": left})
+	}
+
+	return nil
+}
+
+// pendingOfferForUpdate locks a deal under negotiation and returns it with
+// its pending offer, which the user must be able to answer: they are on the
+// deal, but on the other side from the offer's author. The user's side is
+// returned too.
+func (s *svc) pendingOfferForUpdate(
+	ctx context.Context, dealID, userID uuid.UUID,
+) (*entity.Deal, *entity.DealOffer, entity.DealParty, error) {
+	deal, err := s.dealRepo.GetByIDForUpdate(ctx, dealID)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("get deal: %w", err)
+	}
+
+	party, err := s.partyOf(ctx, deal, userID)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	if deal.Status != entity.DealStatusNegotiating {
+		return nil, nil, "", dto.ErrInvalidTransition
+	}
+
+	pending, err := s.offerRepo.GetPending(ctx, dealID)
+	if errors.Is(err, dto.ErrNotFound) {
+		return nil, nil, "", dto.ErrInvalidTransition
+	}
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("get pending offer: %w", err)
+	}
+
+	// nobody answers their own side's offer
+	if (pending.AuthorID == deal.AdvertiserID) == (party == entity.DealPartyAdvertiser) {
+		return nil, nil, "", dto.ErrInvalidTransition
+	}
+
+	return deal, pending, party, nil
+}
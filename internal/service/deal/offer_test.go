@@ -0,0 +1,300 @@
+package deal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+var offerID = uuid.Must(uuid.NewV7())
+
+func negotiatingDeal() *entity.Deal {
+	return &entity.Deal{
+		ID:           dealID,
+		ChannelID:    channelID,
+		AdvertiserID: userID,
+		Status:       entity.DealStatusNegotiating,
+		ScheduledAt:  time.Now().Add(24 * time.Hour),
+		PriceNanoTON: 3000000000,
+	}
+}
+
+func pendingOffer(authorID uuid.UUID) *entity.DealOffer {
+	return &entity.DealOffer{
+		ID:           offerID,
+		DealID:       dealID,
+		AuthorID:     authorID,
+		PriceNanoTON: 4000000000,
+		ScheduledAt:  time.Now().Add(48 * time.Hour),
+		Status:       entity.DealOfferStatusPending,
+	}
+}
+
+func TestCreateDeal_Offer(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+	params := defaultCreateParams()
+	params.PriceNanoTON = 3000000000
+	params.Offer = true
+
+	m.channelRepo.EXPECT().GetByTgChannelID(ctx, params.TgChannelID).Return(defaultChannel(), nil)
+	m.channelRepo.EXPECT().GetAdFormatsByChannelID(ctx, channelID).Return(defaultAdFormats(), nil)
+	m.postRepo.EXPECT().GetByID(ctx, params.TemplatePostID).Return(defaultTemplatePost(), nil)
+	m.userRepo.EXPECT().GetByID(ctx, userID).Return(defaultUser(), nil)
+	m.channelRepo.EXPECT().GetOwnerWalletAddress(ctx, channelID).Return(nil, nil)
+	expectNoPromotion(m, ctx)
+	expectTx(m.tx, ctx)
+	expectFreeSlot(m, ctx)
+	m.dealRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, d *entity.Deal) (*entity.Deal, error) {
+			assert.Equal(t, entity.DealStatusNegotiating, d.Status)
+			assert.Equal(t, int64(3000000000), d.PriceNanoTON)
+			assert.Nil(t, d.EscrowWalletAddress)
+			assert.Nil(t, d.EscrowMemo)
+			assert.Nil(t, d.PaymentExpiresAt)
+			d.ID = dealID
+			return d, nil
+		},
+	)
+	m.postRepo.EXPECT().CopyAsAd(ctx, params.TemplatePostID, dealID, 1).Return(nil, nil)
+	m.revisionRepo.EXPECT().Create(ctx, gomock.Any()).Return(&entity.AdRevision{}, nil)
+	m.offerRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, o *entity.DealOffer) (*entity.DealOffer, error) {
+			assert.Equal(t, dealID, o.DealID)
+			assert.Equal(t, userID, o.AuthorID)
+			assert.Equal(t, int64(3000000000), o.PriceNanoTON)
+			assert.Equal(t, params.ScheduledAt, o.ScheduledAt)
+			return o, nil
+		},
+	)
+	ev := expectEvent(t, m, ctx, entity.DealEventCreated)
+
+	deal, _, err := s.CreateDeal(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealStatusNegotiating, deal.Status)
+	assert.Equal(t, entity.DealStatusNegotiating, ev.ToStatus)
+}
+
+func TestCounterOffer_Publisher(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(publisherID, 999)
+	params := OfferParams{PriceNanoTON: 4500000000, ScheduledAt: time.Now().Add(72 * time.Hour)}
+
+	m.userRepo.EXPECT().
+		GetByID(ctx, publisherID).
+		Return(&entity.User{ID: publisherID, Name: "Bob"}, nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(negotiatingDeal(), nil)
+	expectPublisher(m, ctx)
+	m.offerRepo.EXPECT().GetPending(ctx, dealID).Return(pendingOffer(userID), nil)
+	expectFreeSlot(m, ctx)
+	m.offerRepo.EXPECT().Respond(ctx, offerID, entity.DealOfferStatusCountered).Return(nil)
+	m.offerRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, o *entity.DealOffer) (*entity.DealOffer, error) {
+			assert.Equal(t, publisherID, o.AuthorID)
+			assert.Equal(t, params.PriceNanoTON, o.PriceNanoTON)
+			assert.Equal(t, params.ScheduledAt, o.ScheduledAt)
+			return o, nil
+		},
+	)
+	ev := expectEvent(t, m, ctx, entity.DealEventOfferMade)
+
+	item, err := s.CounterOffer(ctx, dealID, params)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealPartyPublisher, item.AuthorParty)
+	assert.Equal(t, "Bob", item.AuthorName)
+	assert.Equal(t, entity.DealStatusNegotiating, ev.ToStatus)
+	assert.Equal(t, params.PriceNanoTON, ev.Metadata["price_nano_ton"])
+}
+
+func TestCounterOffer_OwnOffer(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+	params := OfferParams{PriceNanoTON: 4500000000, ScheduledAt: time.Now().Add(72 * time.Hour)}
+
+	m.userRepo.EXPECT().GetByID(ctx, userID).Return(defaultUser(), nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(negotiatingDeal(), nil)
+	m.offerRepo.EXPECT().GetPending(ctx, dealID).Return(pendingOffer(userID), nil)
+
+	_, err := s.CounterOffer(ctx, dealID, params)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, dto.ErrInvalidTransition))
+}
+
+func TestCounterOffer_NotNegotiating(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(publisherID, 999)
+	params := OfferParams{PriceNanoTON: 4500000000, ScheduledAt: time.Now().Add(72 * time.Hour)}
+
+	deal := negotiatingDeal()
+	deal.Status = entity.DealStatusPendingPayment
+	m.userRepo.EXPECT().GetByID(ctx, publisherID).Return(&entity.User{ID: publisherID}, nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(deal, nil)
+	expectPublisher(m, ctx)
+
+	_, err := s.CounterOffer(ctx, dealID, params)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, dto.ErrInvalidTransition))
+}
+
+func TestCounterOffer_TooSoon(t *testing.T) {
+	s, _ := newTestService(t)
+	ctx := ctxWithUser(publisherID, 999)
+	params := OfferParams{PriceNanoTON: 4500000000, ScheduledAt: time.Now().Add(time.Minute)}
+
+	_, err := s.CounterOffer(ctx, dealID, params)
+	requireAPIError(t, err, "invalid_request")
+}
+
+func TestAcceptOffer_FreezesTerms(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+	offer := pendingOffer(publisherID)
+	deposit := &dto.EscrowDeposit{Address: "EQBescrow", Memo: "ABCDEFGHIJ"}
+
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(negotiatingDeal(), nil)
+	m.offerRepo.EXPECT().GetPending(ctx, dealID).Return(offer, nil)
+	expectFreeSlot(m, ctx)
+	m.escrow.EXPECT().Provision(ctx).Return(deposit, nil)
+	m.offerRepo.EXPECT().Respond(ctx, offerID, entity.DealOfferStatusAccepted).Return(nil)
+	m.dealRepo.EXPECT().
+		AcceptTerms(ctx, dealID, offer.PriceNanoTON, offer.ScheduledAt, deposit, gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context, _ uuid.UUID, price int64, at time.Time,
+			_ *dto.EscrowDeposit, expiresAt time.Time,
+		) (*entity.Deal, error) {
+			assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, time.Minute)
+			return &entity.Deal{
+				ID: dealID, Status: entity.DealStatusPendingPayment,
+				PriceNanoTON: price, ScheduledAt: at,
+			}, nil
+		})
+	ev := expectEvent(t, m, ctx, entity.DealEventOfferAccepted)
+
+	require.NoError(t, s.AcceptOffer(ctx, dealID))
+	assert.Equal(t, entity.DealStatusNegotiating, *ev.FromStatus)
+	assert.Equal(t, entity.DealStatusPendingPayment, ev.ToStatus)
+	assert.Equal(t, offer.PriceNanoTON, ev.Metadata["price_nano_ton"])
+}
+
+func TestAcceptOffer_SlotTooClose(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+	offer := pendingOffer(publisherID)
+	offer.ScheduledAt = time.Now().Add(30 * time.Minute)
+
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(negotiatingDeal(), nil)
+	m.offerRepo.EXPECT().GetPending(ctx, dealID).Return(offer, nil)
+
+	err := s.AcceptOffer(ctx, dealID)
+	requireAPIError(t, err, "invalid_request")
+}
+
+func TestAcceptOffer_WithinCampaignBudget(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+	campaignID := uuid.Must(uuid.NewV7())
+	deal := negotiatingDeal()
+	deal.CampaignID = &campaignID
+	offer := pendingOffer(publisherID)
+	deposit := &dto.EscrowDeposit{Address: "EQBescrow", Memo: "ABCDEFGHIJ"}
+
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(deal, nil)
+	m.offerRepo.EXPECT().GetPending(ctx, dealID).Return(offer, nil)
+	expectFreeSlot(m, ctx)
+	m.dealRepo.EXPECT().
+		GetCampaignBudgetLeft(ctx, campaignID, dealID).
+		Return(offer.PriceNanoTON, nil)
+	m.escrow.EXPECT().Provision(ctx).Return(deposit, nil)
+	m.offerRepo.EXPECT().Respond(ctx, offerID, entity.DealOfferStatusAccepted).Return(nil)
+	m.dealRepo.EXPECT().
+		AcceptTerms(ctx, dealID, offer.PriceNanoTON, offer.ScheduledAt, deposit, gomock.Any()).
+		Return(&entity.Deal{ID: dealID, Status: entity.DealStatusPendingPayment}, nil)
+	expectEvent(t, m, ctx, entity.DealEventOfferAccepted)
+
+	require.NoError(t, s.AcceptOffer(ctx, dealID))
+}
+
+func TestAcceptOffer_CampaignBudgetExceeded(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+	campaignID := uuid.Must(uuid.NewV7())
+	deal := negotiatingDeal()
+	deal.CampaignID = &campaignID
+	offer := pendingOffer(publisherID)
+
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(deal, nil)
+	m.offerRepo.EXPECT().GetPending(ctx, dealID).Return(offer, nil)
+	expectFreeSlot(m, ctx)
+	m.dealRepo.EXPECT().
+		GetCampaignBudgetLeft(ctx, campaignID, dealID).
+		Return(offer.PriceNanoTON-1, nil)
+
+	err := s.AcceptOffer(ctx, dealID)
+	requireAPIError(t, err, "budget_exceeded")
+}
+
+func TestAcceptOffer_Stranger(t *testing.T) {
+	s, m := newTestService(t)
+	otherUser := uuid.Must(uuid.NewV7())
+	ctx := ctxWithUser(otherUser, 999)
+
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(negotiatingDeal(), nil)
+	m.channelRepo.EXPECT().GetRole(ctx, channelID, otherUser).Return(nil, dto.ErrNotFound)
+
+	err := s.AcceptOffer(ctx, dealID)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, dto.ErrForbidden))
+}
+
+func TestDeclineOffer(t *testing.T) {
+	tests := []struct {
+		name   string
+		user   uuid.UUID
+		author uuid.UUID
+		status entity.DealStatus
+	}{
+		{"publisher rejects", publisherID, userID, entity.DealStatusRejected},
+		{"advertiser cancels", userID, publisherID, entity.DealStatusCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newTestService(t)
+			ctx := ctxWithUser(tt.user, 999)
+			reason := "too expensive"
+
+			expectTx(m.tx, ctx)
+			m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(negotiatingDeal(), nil)
+			if tt.user == publisherID {
+				expectPublisher(m, ctx)
+			}
+			m.offerRepo.EXPECT().GetPending(ctx, dealID).Return(pendingOffer(tt.author), nil)
+			m.offerRepo.EXPECT().Respond(ctx, offerID, entity.DealOfferStatusDeclined).Return(nil)
+			m.dealRepo.EXPECT().
+				UpdateStatus(ctx, dealID, entity.DealStatusNegotiating, tt.status, &reason).
+				Return(nil)
+			ev := expectEvent(t, m, ctx, entity.DealEventOfferDeclined)
+
+			require.NoError(t, s.DeclineOffer(ctx, dealID, &reason))
+			assert.Equal(t, tt.status, ev.ToStatus)
+			assert.Equal(t, &reason, ev.Note)
+		})
+	}
+}
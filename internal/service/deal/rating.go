@@ -0,0 +1,112 @@
+package deal
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+// statuses in which a deal is over and its sides can rate each other
+var ratableStatuses = []entity.DealStatus{
+	entity.DealStatusCompleted,
+	entity.DealStatusResolved,
+}
+
+type RatingParams struct {
+	Rating  int
+	Comment *string
+}
+
+// RateDeal leaves the user's rating of the other side of a finished deal.
+// Each side rates once; the advertiser's rating counts towards the channel's
+// rating on the marketplace.
+func (s *svc) RateDeal(
+	ctx context.Context, dealID uuid.UUID, params RatingParams,
+) (*dto.DealRatingItem, error) {
+	user, ok := dto.UserFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("rate deal: %w", dto.ErrForbidden)
+	}
+
+	if params.Rating < 1 || params.Rating > 5 {
+		return nil, fmt.Errorf("rate deal: %w", dto.ErrValidation.WithDetails(
+			map[string]any{"rating": "must be between 1 and 5"},
+		))
+	}
+
+	author, err := s.userRepo.GetByID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get author: %w", err)
+	}
+
+	var created *entity.DealRating
+	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		deal, err := s.dealRepo.GetByIDForUpdate(txCtx, dealID)
+		if err != nil {
+			return fmt.Errorf("get deal: %w", err)
+		}
+
+		party, err := s.partyOf(txCtx, deal, user.ID)
+		if err != nil {
+			return err
+		}
+
+		if !slices.Contains(ratableStatuses, deal.Status) {
+			return dto.ErrInvalidTransition
+		}
+
+		created, err = s.ratingRepo.Create(txCtx, &entity.DealRating{
+			DealID:   dealID,
+			AuthorID: user.ID,
+			Party:    party,
+			Rating:   params.Rating,
+			Comment:  params.Comment,
+		})
+		if err != nil {
+			return fmt.Errorf("create rating: %w", err)
+		}
+
+		return s.record(txCtx, &entity.DealEvent{
+			DealID:     dealID,
+			Type:       entity.DealEventRated,
+			FromStatus: &deal.Status,
+			ToStatus:   deal.Status,
+			Note:       params.Comment,
+			Metadata:   map[string]any{"rating": params.Rating},
+		})
+	}); err != nil {
+		return nil, fmt.Errorf("rate deal: %w", err)
+	}
+
+	s.log.Info("deal rated", "deal_id", dealID, "party", created.Party, "rating", created.Rating)
+	return &dto.DealRatingItem{DealRating: *created, AuthorName: author.Name}, nil
+}
+
+// GetRatings returns the ratings left on the deal to both the advertiser and
+// the channel's team.
+func (s *svc) GetRatings(ctx context.Context, dealID uuid.UUID) ([]dto.DealRatingItem, error) {
+	if _, err := s.requireParticipant(ctx, dealID); err != nil {
+		return nil, err
+	}
+
+	ratings, err := s.ratingRepo.GetByDealID(ctx, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("get ratings: %w", err)
+	}
+
+	items := make([]dto.DealRatingItem, len(ratings))
+	for i := range ratings {
+		author, err := s.userRepo.GetByID(ctx, ratings[i].AuthorID)
+		if err != nil {
+			return nil, fmt.Errorf("get author: %w", err)
+		}
+		items[i] = dto.DealRatingItem{DealRating: ratings[i], AuthorName: author.Name}
+	}
+
+	return items, nil
+}
@@ -0,0 +1,101 @@
+package deal
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+func finishedDeal(status entity.DealStatus) *entity.Deal {
+	return &entity.Deal{
+		ID:           dealID,
+		ChannelID:    channelID,
+		AdvertiserID: userID,
+		Status:       status,
+	}
+}
+
+func TestRateDeal(t *testing.T) {
+	comment := "posted on time, good reach"
+	tests := []struct {
+		name   string
+		status entity.DealStatus
+	}{
+		{"completed", entity.DealStatusCompleted},
+		{"resolved", entity.DealStatusResolved},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newTestService(t)
+			ctx := ctxWithUser(userID, 123456)
+
+			m.userRepo.EXPECT().GetByID(ctx, userID).Return(defaultUser(), nil)
+			expectTx(m.tx, ctx)
+			m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(finishedDeal(tt.status), nil)
+			m.ratingRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+				func(_ context.Context, rt *entity.DealRating) (*entity.DealRating, error) {
+					assert.Equal(t, entity.DealPartyAdvertiser, rt.Party)
+					assert.Equal(t, 5, rt.Rating)
+					assert.Equal(t, &comment, rt.Comment)
+					rt.ID = uuid.Must(uuid.NewV7())
+					return rt, nil
+				},
+			)
+			ev := expectEvent(t, m, ctx, entity.DealEventRated)
+
+			item, err := s.RateDeal(ctx, dealID, RatingParams{Rating: 5, Comment: &comment})
+			require.NoError(t, err)
+			assert.Equal(t, entity.DealPartyAdvertiser, item.Party)
+			assert.Equal(t, tt.status, ev.ToStatus)
+			assert.Equal(t, 5, ev.Metadata["rating"])
+		})
+	}
+}
+
+func TestRateDeal_NotFinished(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+
+	m.userRepo.EXPECT().GetByID(ctx, userID).Return(defaultUser(), nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().
+		GetByIDForUpdate(ctx, dealID).
+		Return(finishedDeal(entity.DealStatusPosted), nil)
+
+	_, err := s.RateDeal(ctx, dealID, RatingParams{Rating: 4})
+	assert.True(t, errors.Is(err, dto.ErrInvalidTransition))
+}
+
+func TestRateDeal_AlreadyRated(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(publisherID, 999)
+
+	m.userRepo.EXPECT().GetByID(ctx, publisherID).Return(&entity.User{ID: publisherID}, nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().
+		GetByIDForUpdate(ctx, dealID).
+		Return(finishedDeal(entity.DealStatusCompleted), nil)
+	m.channelRepo.EXPECT().
+		GetRole(ctx, channelID, publisherID).
+		Return(&entity.ChannelRole{Role: entity.ChannelRoleTypeOwner}, nil)
+	m.ratingRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil, dto.ErrAlreadyRated)
+
+	_, err := s.RateDeal(ctx, dealID, RatingParams{Rating: 2})
+	assert.True(t, errors.Is(err, dto.ErrAlreadyRated))
+}
+
+func TestRateDeal_OutOfRange(t *testing.T) {
+	s, _ := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+
+	_, err := s.RateDeal(ctx, dealID, RatingParams{Rating: 6})
+	requireAPIError(t, err, "invalid_request")
+}
@@ -0,0 +1,86 @@
+package deal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+// GetReport returns how the deal's ad has performed since it was posted, to
+// both the advertiser and the channel's team. The views curve is built from
+// the snapshots taken while the ad was checked during its feed window.
+func (s *svc) GetReport(ctx context.Context, dealID uuid.UUID) (*dto.DealReportResponse, error) {
+	deal, err := s.requireParticipant(ctx, dealID)
+	if err != nil {
+		return nil, err
+	}
+
+	if deal.PostedAt == nil {
+		return nil, fmt.Errorf("get report: %w", dto.ErrDealNotPosted)
+	}
+
+	snaps, err := s.snapshotRepo.GetByDealID(ctx, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("get snapshots: %w", err)
+	}
+
+	var avgViews *int
+	listing, err := s.channelRepo.GetMarketplaceChannel(ctx, deal.ChannelID)
+	switch {
+	case err == nil:
+		avgViews = listing.AvgDailyViews7d
+	case !errors.Is(err, dto.ErrNotFound):
+		return nil, fmt.Errorf("get marketplace channel: %w", err)
+	}
+
+	return buildReport(deal, snaps, avgViews), nil
+}
+
+func buildReport(
+	deal *entity.Deal, snaps []entity.DealViewSnapshot, avgViews *int,
+) *dto.DealReportResponse {
+	report := &dto.DealReportResponse{
+		DealID:                 deal.ID.String(),
+		PostedAt:               *deal.PostedAt,
+		FeedEndsAt:             deal.PostedAt.Add(time.Duration(deal.FeedHours) * time.Hour),
+		PriceNanoTON:           deal.PriceNanoTON,
+		ChannelAvgDailyViews7d: avgViews,
+		Curve:                  make([]dto.DealViewPoint, len(snaps)),
+	}
+
+	for i, snap := range snaps {
+		report.Curve[i] = dto.DealViewPoint{
+			TakenAt:   snap.TakenAt,
+			Views:     snap.Views,
+			Forwards:  snap.Forwards,
+			Reactions: snap.Reactions,
+		}
+	}
+
+	if len(snaps) > 0 {
+		last := snaps[len(snaps)-1]
+		report.Views = last.Views
+		report.Forwards = last.Forwards
+		report.Reactions = last.Reactions
+	}
+
+	if report.Views > 0 {
+		// in floats: price times a thousand may not fit in an int64
+		cpm := int64(math.Round(float64(deal.PriceNanoTON) * 1000 / float64(report.Views)))
+		report.CPMNanoTON = &cpm
+	}
+
+	if avgViews != nil && *avgViews > 0 {
+		ratio := float64(report.Views) / float64(*avgViews)
+		report.ViewsToChannelAvg = &ratio
+	}
+
+	return report
+}
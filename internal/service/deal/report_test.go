@@ -0,0 +1,94 @@
+package deal
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+func postedDeal(postedAt time.Time) *entity.Deal {
+	return &entity.Deal{
+		ID:           dealID,
+		ChannelID:    channelID,
+		AdvertiserID: userID,
+		Status:       entity.DealStatusCompleted,
+		PriceNanoTON: 2_000_000_000,
+		FeedHours:    24,
+		PostedAt:     &postedAt,
+	}
+}
+
+func TestGetReport_Success(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+	postedAt := time.Now().Add(-25 * time.Hour)
+	avgViews := 8000
+
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(postedDeal(postedAt), nil)
+	m.snapshotRepo.EXPECT().GetByDealID(ctx, dealID).Return([]entity.DealViewSnapshot{
+		{DealID: dealID, TakenAt: postedAt.Add(time.Hour), Views: 1500, Forwards: 3},
+		{DealID: dealID, TakenAt: postedAt.Add(24 * time.Hour), Views: 4000, Forwards: 9,
+			Reactions: 25},
+	}, nil)
+	m.channelRepo.EXPECT().
+		GetMarketplaceChannel(ctx, channelID).
+		Return(&entity.MVChannel{ChannelID: channelID, AvgDailyViews7d: &avgViews}, nil)
+
+	report, err := s.GetReport(ctx, dealID)
+	require.NoError(t, err)
+	assert.Equal(t, postedAt.Add(24*time.Hour), report.FeedEndsAt)
+	assert.Len(t, report.Curve, 2)
+	assert.Equal(t, int64(4000), report.Views)
+	assert.Equal(t, int64(9), report.Forwards)
+	assert.Equal(t, int64(25), report.Reactions)
+	require.NotNil(t, report.CPMNanoTON)
+	assert.Equal(t, int64(500_000_000), *report.CPMNanoTON)
+	require.NotNil(t, report.ViewsToChannelAvg)
+	assert.InDelta(t, 0.5, *report.ViewsToChannelAvg, 1e-9)
+}
+
+func TestGetReport_NoViewsYet(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(postedDeal(time.Now()), nil)
+	m.snapshotRepo.EXPECT().GetByDealID(ctx, dealID).Return(nil, nil)
+	m.channelRepo.EXPECT().GetMarketplaceChannel(ctx, channelID).Return(nil, dto.ErrNotFound)
+
+	report, err := s.GetReport(ctx, dealID)
+	require.NoError(t, err)
+	assert.Empty(t, report.Curve)
+	assert.Nil(t, report.CPMNanoTON)
+	assert.Nil(t, report.ChannelAvgDailyViews7d)
+	assert.Nil(t, report.ViewsToChannelAvg)
+}
+
+func TestGetReport_NotPosted(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+
+	deal := postedDeal(time.Now())
+	deal.Status = entity.DealStatusApproved
+	deal.PostedAt = nil
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(deal, nil)
+
+	_, err := s.GetReport(ctx, dealID)
+	assert.True(t, errors.Is(err, dto.ErrDealNotPosted))
+}
+
+func TestGetReport_Stranger(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(publisherID, 999)
+
+	m.dealRepo.EXPECT().GetByID(ctx, dealID).Return(postedDeal(time.Now()), nil)
+	m.channelRepo.EXPECT().GetRole(ctx, channelID, publisherID).Return(nil, dto.ErrNotFound)
+
+	_, err := s.GetReport(ctx, dealID)
+	assert.True(t, errors.Is(err, dto.ErrForbidden))
+}
@@ -0,0 +1,277 @@
+package deal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+// statuses in which a deal's posting time can still be moved
+var reschedulableStatuses = []entity.DealStatus{
+	entity.DealStatusPendingPayment,
+	entity.DealStatusPendingReview,
+	entity.DealStatusChangesRequested,
+	entity.DealStatusApproved,
+}
+
+// RequestReschedule proposes a new posting time to the other side of the
+// deal. A proposal still pending from either side is superseded.
+func (s *svc) RequestReschedule(
+	ctx context.Context, dealID uuid.UUID, scheduledAt time.Time,
+) (*dto.DealRescheduleItem, error) {
+	user, ok := dto.UserFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("request reschedule: %w", dto.ErrForbidden)
+	}
+
+	if err := s.checkSchedule(scheduledAt); err != nil {
+		return nil, fmt.Errorf("request reschedule: %w", err)
+	}
+
+	author, err := s.userRepo.GetByID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get author: %w", err)
+	}
+
+	var created *entity.DealReschedule
+	var party entity.DealParty
+	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		deal, err := s.dealRepo.GetByIDForUpdate(txCtx, dealID)
+		if err != nil {
+			return fmt.Errorf("get deal: %w", err)
+		}
+
+		party, err = s.partyOf(txCtx, deal, user.ID)
+		if err != nil {
+			return err
+		}
+
+		if !canReschedule(deal) {
+			return dto.ErrInvalidTransition
+		}
+		if scheduledAt.Equal(deal.ScheduledAt) {
+			return dto.ErrValidation.WithDetails(
+				map[string]any{"scheduled_at": "deal is already scheduled at this time"},
+			)
+		}
+		if err := s.checkSlot(txCtx, deal, scheduledAt); err != nil {
+			return err
+		}
+
+		pending, err := s.rescheduleRepo.GetPending(txCtx, dealID)
+		if err != nil && !errors.Is(err, dto.ErrNotFound) {
+			return fmt.Errorf("get pending reschedule: %w", err)
+		}
+		if pending != nil {
+			if err := s.rescheduleRepo.Respond(
+				txCtx, pending.ID, entity.DealRescheduleStatusSuperseded,
+			); err != nil {
+				return fmt.Errorf("supersede pending reschedule: %w", err)
+			}
+		}
+
+		created, err = s.rescheduleRepo.Create(txCtx, &entity.DealReschedule{
+			DealID:      dealID,
+			AuthorID:    user.ID,
+			ScheduledAt: scheduledAt,
+		})
+		if err != nil {
+			return fmt.Errorf("create reschedule: %w", err)
+		}
+
+		return s.record(txCtx, &entity.DealEvent{
+			DealID:     dealID,
+			Type:       entity.DealEventRescheduleRequested,
+			FromStatus: &deal.Status,
+			ToStatus:   deal.Status,
+			Metadata:   map[string]any{"scheduled_at": scheduledAt},
+		})
+	}); err != nil {
+		return nil, fmt.Errorf("request reschedule: %w", err)
+	}
+
+	s.log.Info("reschedule requested", "deal_id", dealID, "reschedule_id", created.ID)
+	return &dto.DealRescheduleItem{
+		DealReschedule: *created,
+		AuthorName:     author.Name,
+		AuthorParty:    party,
+	}, nil
+}
+
+// AcceptReschedule moves the deal to the time the other side proposed. The
+// time is validated again, as it was when the deal was created, and must not
+// clash with another deal booked on the channel.
+func (s *svc) AcceptReschedule(ctx context.Context, dealID uuid.UUID) error {
+	user, ok := dto.UserFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("accept reschedule: %w", dto.ErrForbidden)
+	}
+
+	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		deal, pending, err := s.pendingRescheduleForUpdate(txCtx, dealID, user.ID)
+		if err != nil {
+			return err
+		}
+
+		if !canReschedule(deal) {
+			return dto.ErrInvalidTransition
+		}
+		// the proposal may have been made long ago
+		if err := s.checkSchedule(pending.ScheduledAt); err != nil {
+			return err
+		}
+		if err := s.checkSlot(txCtx, deal, pending.ScheduledAt); err != nil {
+			return err
+		}
+
+		if err := s.rescheduleRepo.Respond(
+			txCtx, pending.ID, entity.DealRescheduleStatusAccepted,
+		); err != nil {
+			return fmt.Errorf("accept pending reschedule: %w", err)
+		}
+		if _, err := s.dealRepo.Reschedule(
+			txCtx, dealID, deal.Status, pending.ScheduledAt,
+		); err != nil {
+			return fmt.Errorf("reschedule deal: %w", err)
+		}
+
+		return s.record(txCtx, &entity.DealEvent{
+			DealID:     dealID,
+			Type:       entity.DealEventRescheduled,
+			FromStatus: &deal.Status,
+			ToStatus:   deal.Status,
+			Metadata: map[string]any{
+				"previous_scheduled_at": deal.ScheduledAt,
+				"scheduled_at":          pending.ScheduledAt,
+			},
+		})
+	}); err != nil {
+		return fmt.Errorf("accept reschedule: %w", err)
+	}
+
+	s.log.Info("deal rescheduled", "deal_id", dealID)
+	return nil
+}
+
+// DeclineReschedule turns down the other side's proposal. The deal keeps
+// its posting time.
+func (s *svc) DeclineReschedule(ctx context.Context, dealID uuid.UUID, reason *string) error {
+	user, ok := dto.UserFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("decline reschedule: %w", dto.ErrForbidden)
+	}
+
+	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		deal, pending, err := s.pendingRescheduleForUpdate(txCtx, dealID, user.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.rescheduleRepo.Respond(
+			txCtx, pending.ID, entity.DealRescheduleStatusDeclined,
+		); err != nil {
+			return fmt.Errorf("decline pending reschedule: %w", err)
+		}
+
+		return s.record(txCtx, &entity.DealEvent{
+			DealID:     dealID,
+			Type:       entity.DealEventRescheduleDeclined,
+			FromStatus: &deal.Status,
+			ToStatus:   deal.Status,
+			Note:       reason,
+			Metadata:   map[string]any{"scheduled_at": pending.ScheduledAt},
+		})
+	}); err != nil {
+		return fmt.Errorf("decline reschedule: %w", err)
+	}
+
+	s.log.Info("reschedule declined", "deal_id", dealID)
+	return nil
+}
+
+// GetReschedules returns every posting time proposed on the deal, oldest
+// first, to both the advertiser and the channel's team.
+func (s *svc) GetReschedules(
+	ctx context.Context, dealID uuid.UUID,
+) ([]dto.DealRescheduleItem, error) {
+	deal, err := s.requireParticipant(ctx, dealID)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.rescheduleRepo.GetByDealID(ctx, dealID)
+	if err != nil {
+		return nil, fmt.Errorf("get reschedules: %w", err)
+	}
+
+	authors := make(map[uuid.UUID]*entity.User)
+	items := make([]dto.DealRescheduleItem, len(list))
+	for i := range list {
+		rs := &list[i]
+		author, ok := authors[rs.AuthorID]
+		if !ok {
+			author, err = s.userRepo.GetByID(ctx, rs.AuthorID)
+			if err != nil {
+				return nil, fmt.Errorf("get author: %w", err)
+			}
+			authors[rs.AuthorID] = author
+		}
+		party := entity.DealPartyPublisher
+		if rs.AuthorID == deal.AdvertiserID {
+			party = entity.DealPartyAdvertiser
+		}
+		items[i] = dto.DealRescheduleItem{
+			DealReschedule: *rs,
+			AuthorName:     author.Name,
+			AuthorParty:    party,
+		}
+	}
+
+	return items, nil
+}
+
+// pendingRescheduleForUpdate locks the deal and returns it with its pending
+// proposal, which the user must be able to answer: they are on the deal, but
+// on the other side from the proposal's author.
+func (s *svc) pendingRescheduleForUpdate(
+	ctx context.Context, dealID, userID uuid.UUID,
+) (*entity.Deal, *entity.DealReschedule, error) {
+	deal, err := s.dealRepo.GetByIDForUpdate(ctx, dealID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get deal: %w", err)
+	}
+
+	party, err := s.partyOf(ctx, deal, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pending, err := s.rescheduleRepo.GetPending(ctx, dealID)
+	if errors.Is(err, dto.ErrNotFound) {
+		return nil, nil, dto.ErrInvalidTransition
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("get pending reschedule: %w", err)
+	}
+
+	// nobody answers their own side's proposal
+	if (pending.AuthorID == deal.AdvertiserID) == (party == entity.DealPartyAdvertiser) {
+		return nil, nil, dto.ErrInvalidTransition
+	}
+
+	return deal, pending, nil
+}
+
+// canReschedule reports whether the deal is yet to be posted and its current
+// slot has not come up, so the publishing job will not pick it up meanwhile.
+func canReschedule(deal *entity.Deal) bool {
+	return slices.Contains(reschedulableStatuses, deal.Status) &&
+		time.Now().Before(deal.ScheduledAt)
+}
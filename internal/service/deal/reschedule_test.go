@@ -0,0 +1,218 @@
+package deal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+var rescheduleID = uuid.Must(uuid.NewV7())
+
+func approvedDeal() *entity.Deal {
+	return &entity.Deal{
+		ID:           dealID,
+		ChannelID:    channelID,
+		AdvertiserID: userID,
+		Status:       entity.DealStatusApproved,
+		ScheduledAt:  time.Now().Add(24 * time.Hour),
+		TopHours:     4,
+	}
+}
+
+func pendingReschedule(authorID uuid.UUID) *entity.DealReschedule {
+	return &entity.DealReschedule{
+		ID:          rescheduleID,
+		DealID:      dealID,
+		AuthorID:    authorID,
+		ScheduledAt: time.Now().Add(48 * time.Hour),
+		Status:      entity.DealRescheduleStatusPending,
+	}
+}
+
+func TestRequestReschedule_SupersedesPending(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+	at := time.Now().Add(72 * time.Hour)
+
+	m.userRepo.EXPECT().GetByID(ctx, userID).Return(defaultUser(), nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(approvedDeal(), nil)
+	m.channelRepo.EXPECT().
+		GetInventoryForUpdate(ctx, channelID).
+		Return(&entity.ChannelInventory{ChannelID: channelID}, nil)
+	m.dealRepo.EXPECT().
+		HasSlotConflict(ctx, channelID, dealID, at, at.Add(4*time.Hour)).
+		Return(false, nil)
+	m.rescheduleRepo.EXPECT().GetPending(ctx, dealID).Return(pendingReschedule(publisherID), nil)
+	m.rescheduleRepo.EXPECT().
+		Respond(ctx, rescheduleID, entity.DealRescheduleStatusSuperseded).
+		Return(nil)
+	m.rescheduleRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, rs *entity.DealReschedule) (*entity.DealReschedule, error) {
+			assert.Equal(t, userID, rs.AuthorID)
+			assert.Equal(t, at, rs.ScheduledAt)
+			return rs, nil
+		},
+	)
+	ev := expectEvent(t, m, ctx, entity.DealEventRescheduleRequested)
+
+	item, err := s.RequestReschedule(ctx, dealID, at)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DealPartyAdvertiser, item.AuthorParty)
+	assert.Equal(t, entity.DealStatusApproved, ev.ToStatus)
+	assert.Equal(t, at, ev.Metadata["scheduled_at"])
+}
+
+func TestRequestReschedule_InPast(t *testing.T) {
+	s, _ := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+
+	_, err := s.RequestReschedule(ctx, dealID, time.Now().Add(-time.Hour))
+	requireAPIError(t, err, "invalid_request")
+}
+
+func TestRequestReschedule_NotReschedulable(t *testing.T) {
+	tests := []struct {
+		name string
+		deal func(d *entity.Deal)
+	}{
+		{"posted", func(d *entity.Deal) { d.Status = entity.DealStatusPosted }},
+		{"negotiating", func(d *entity.Deal) { d.Status = entity.DealStatusNegotiating }},
+		{"slot already due", func(d *entity.Deal) { d.ScheduledAt = time.Now().Add(-time.Minute) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newTestService(t)
+			ctx := ctxWithUser(userID, 123456)
+			deal := approvedDeal()
+			tt.deal(deal)
+
+			m.userRepo.EXPECT().GetByID(ctx, userID).Return(defaultUser(), nil)
+			expectTx(m.tx, ctx)
+			m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(deal, nil)
+
+			_, err := s.RequestReschedule(ctx, dealID, time.Now().Add(72*time.Hour))
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, dto.ErrInvalidTransition))
+		})
+	}
+}
+
+func TestRequestReschedule_SlotTaken(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+	at := time.Now().Add(72 * time.Hour)
+
+	m.userRepo.EXPECT().GetByID(ctx, userID).Return(defaultUser(), nil)
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(approvedDeal(), nil)
+	m.channelRepo.EXPECT().
+		GetInventoryForUpdate(ctx, channelID).
+		Return(&entity.ChannelInventory{ChannelID: channelID}, nil)
+	m.dealRepo.EXPECT().
+		HasSlotConflict(ctx, channelID, dealID, at, at.Add(4*time.Hour)).
+		Return(true, nil)
+
+	_, err := s.RequestReschedule(ctx, dealID, at)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, dto.ErrSlotTaken))
+}
+
+func TestAcceptReschedule_MovesDeal(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(publisherID, 999)
+	deal := approvedDeal()
+	rs := pendingReschedule(userID)
+
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(deal, nil)
+	expectPublisher(m, ctx)
+	m.rescheduleRepo.EXPECT().GetPending(ctx, dealID).Return(rs, nil)
+	m.channelRepo.EXPECT().
+		GetInventoryForUpdate(ctx, channelID).
+		Return(&entity.ChannelInventory{ChannelID: channelID}, nil)
+	m.dealRepo.EXPECT().
+		HasSlotConflict(ctx, channelID, dealID, rs.ScheduledAt, rs.ScheduledAt.Add(4*time.Hour)).
+		Return(false, nil)
+	m.rescheduleRepo.EXPECT().
+		Respond(ctx, rescheduleID, entity.DealRescheduleStatusAccepted).
+		Return(nil)
+	m.dealRepo.EXPECT().
+		Reschedule(ctx, dealID, entity.DealStatusApproved, rs.ScheduledAt).
+		Return(&entity.Deal{ID: dealID, Status: entity.DealStatusApproved}, nil)
+	ev := expectEvent(t, m, ctx, entity.DealEventRescheduled)
+
+	require.NoError(t, s.AcceptReschedule(ctx, dealID))
+	assert.Equal(t, deal.ScheduledAt, ev.Metadata["previous_scheduled_at"])
+	assert.Equal(t, rs.ScheduledAt, ev.Metadata["scheduled_at"])
+}
+
+func TestAcceptReschedule_OwnProposal(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(approvedDeal(), nil)
+	m.rescheduleRepo.EXPECT().GetPending(ctx, dealID).Return(pendingReschedule(userID), nil)
+
+	err := s.AcceptReschedule(ctx, dealID)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, dto.ErrInvalidTransition))
+}
+
+func TestAcceptReschedule_ProposalExpired(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(publisherID, 999)
+	rs := pendingReschedule(userID)
+	rs.ScheduledAt = time.Now().Add(10 * time.Minute)
+
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(approvedDeal(), nil)
+	expectPublisher(m, ctx)
+	m.rescheduleRepo.EXPECT().GetPending(ctx, dealID).Return(rs, nil)
+
+	err := s.AcceptReschedule(ctx, dealID)
+	requireAPIError(t, err, "invalid_request")
+}
+
+func TestAcceptReschedule_NoPending(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(publisherID, 999)
+
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(approvedDeal(), nil)
+	expectPublisher(m, ctx)
+	m.rescheduleRepo.EXPECT().GetPending(ctx, dealID).Return(nil, dto.ErrNotFound)
+
+	err := s.AcceptReschedule(ctx, dealID)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, dto.ErrInvalidTransition))
+}
+
+func TestDeclineReschedule_KeepsTime(t *testing.T) {
+	s, m := newTestService(t)
+	ctx := ctxWithUser(userID, 123456)
+	reason := "can't make it"
+
+	expectTx(m.tx, ctx)
+	m.dealRepo.EXPECT().GetByIDForUpdate(ctx, dealID).Return(approvedDeal(), nil)
+	m.rescheduleRepo.EXPECT().GetPending(ctx, dealID).Return(pendingReschedule(publisherID), nil)
+	m.rescheduleRepo.EXPECT().
+		Respond(ctx, rescheduleID, entity.DealRescheduleStatusDeclined).
+		Return(nil)
+	ev := expectEvent(t, m, ctx, entity.DealEventRescheduleDeclined)
+
+	require.NoError(t, s.DeclineReschedule(ctx, dealID, &reason))
+	assert.Equal(t, entity.DealStatusApproved, ev.ToStatus)
+	assert.Equal(t, &reason, ev.Note)
+}
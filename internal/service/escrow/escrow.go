@@ -0,0 +1,517 @@
+package escrow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bpva/ad-marketplace/internal/config"
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+	"github.com/bpva/ad-marketplace/internal/logx"
+)
+
+// transactionsLimit is the page size used when walking an address's history.
+const transactionsLimit = 100
+
+// minReturnNanoTON is the smallest amount sent back to a payer; returning
+// less would cost the escrow wallet more in fees than it gives back.
+const minReturnNanoTON = 10000000
+
+type DealRepository interface {
+	GetByStatus(ctx context.Context, status entity.DealStatus) ([]entity.Deal, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Deal, error)
+	GetByEscrowMemo(ctx context.Context, memo string) (*entity.Deal, error)
+	GetAwaitingPayout(ctx context.Context) ([]entity.Deal, error)
+	SetReleaseTxHash(ctx context.Context, id uuid.UUID, txHash string) error
+	SetRefundTxHash(ctx context.Context, id uuid.UUID, txHash string) error
+	SetPayoutWallet(ctx context.Context, id uuid.UUID, address string) error
+	RecordPayoutFailure(ctx context.Context, id uuid.UUID, reason string) error
+}
+
+type ChannelRepository interface {
+	GetOwnerWalletAddress(ctx context.Context, channelID uuid.UUID) (*string, error)
+	GetOwnerID(ctx context.Context, channelID uuid.UUID) (uuid.UUID, error)
+}
+
+type OutboxRepository interface {
+	GetUnprocessed(
+		ctx context.Context,
+		event entity.OutboxEvent,
+		limit int,
+	) ([]entity.OutboxMessage, error)
+	MarkProcessed(ctx context.Context, id uuid.UUID) error
+}
+
+type CursorRepository interface {
+	Get(ctx context.Context, address string) (*entity.EscrowCursor, error)
+	Save(ctx context.Context, address, lt, hash string) error
+}
+
+type TransferRepository interface {
+	Create(ctx context.Context, t *entity.Transfer) error
+	CreateSettled(ctx context.Context, t *entity.Transfer) error
+	GetByStatus(
+		ctx context.Context,
+		status entity.TransferStatus,
+		limit int,
+	) ([]entity.Transfer, error)
+	MarkSent(ctx context.Context, id uuid.UUID, seqno int64, validUntil time.Time) error
+	MarkConfirmed(ctx context.Context, id uuid.UUID, txHash string) error
+	MarkFailed(ctx context.Context, id uuid.UUID, reason string) error
+	Reset(ctx context.Context, id uuid.UUID, reason string) error
+}
+
+type LedgerRepository interface {
+	Post(ctx context.Context, legs []entity.LedgerEntry) error
+	GetDiscrepancies(ctx context.Context) ([]entity.LedgerDiscrepancy, error)
+}
+
+type DealService interface {
+	ConfirmPayment(
+		ctx context.Context,
+		dealID uuid.UUID,
+		txHash, payer string,
+		paidAt time.Time,
+	) error
+	RefundLatePayment(
+		ctx context.Context,
+		dealID uuid.UUID,
+		txHash, payer string,
+		paidAt time.Time,
+	) error
+	ExpirePayment(ctx context.Context, dealID uuid.UUID) error
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, userID uuid.UUID, text string) error
+}
+
+type TONProvider interface {
+	GetTransactions(
+		ctx context.Context,
+		address string,
+		limit int,
+		before *dto.TONTxID,
+	) ([]dto.TONTransaction, error)
+	GetSeqno(ctx context.Context, address string) (int64, error)
+	SendTransfers(
+		ctx context.Context,
+		seqno int64,
+		validUntil time.Time,
+		transfers []dto.TONTransfer,
+	) error
+}
+
+type Transactor interface {
+	WithTx(ctx context.Context, f func(ctx context.Context) error) error
+}
+
+type svc struct {
+	cfg          config.TON
+	dealRepo     DealRepository
+	channelRepo  ChannelRepository
+	transferRepo TransferRepository
+	outboxRepo   OutboxRepository
+	cursorRepo   CursorRepository
+	ledgerRepo   LedgerRepository
+	deals        DealService
+	notifier     Notifier
+	ton          TONProvider
+	tx           Transactor
+	log          *slog.Logger
+}
+
+func New(
+	cfg config.TON,
+	dealRepo DealRepository,
+	channelRepo ChannelRepository,
+	transferRepo TransferRepository,
+	outboxRepo OutboxRepository,
+	cursorRepo CursorRepository,
+	ledgerRepo LedgerRepository,
+	deals DealService,
+	notifier Notifier,
+	ton TONProvider,
+	tx Transactor,
+	log *slog.Logger,
+) *svc {
+	log = log.With(logx.Service("EscrowService"))
+	return &svc{
+		cfg:          cfg,
+		dealRepo:     dealRepo,
+		channelRepo:  channelRepo,
+		transferRepo: transferRepo,
+		outboxRepo:   outboxRepo,
+		cursorRepo:   cursorRepo,
+		ledgerRepo:   ledgerRepo,
+		deals:        deals,
+		notifier:     notifier,
+		ton:          ton,
+		tx:           tx,
+		log:          log,
+	}
+}
+
+// CheckPayments reads the transfers that reached escrow addresses since the
+// previous poll, confirms the deals they pay for, and then expires deals
+// whose payment deadline has passed.
+func (s *svc) CheckPayments(ctx context.Context) error {
+	deals, err := s.dealRepo.GetByStatus(ctx, entity.DealStatusPendingPayment)
+	if err != nil {
+		return fmt.Errorf("get pending payment deals: %w", err)
+	}
+
+	// deals provisioned on the platform wallet share an address,
+	// so its history is read once per poll
+	pending := make(map[string][]*entity.Deal)
+	if s.cfg.EscrowWalletAddress != "" {
+		pending[s.cfg.EscrowWalletAddress] = nil
+	}
+	for i := range deals {
+		if address := deals[i].EscrowWalletAddress; address != nil {
+			pending[*address] = append(pending[*address], &deals[i])
+		}
+	}
+
+	for address, deals := range pending {
+		paid, err := s.scanPayments(ctx, address, deals)
+		if err != nil {
+			// a deal may have been paid in the unread part of the history
+			s.log.Error("scan payments failed", "address", address, "error", err)
+			continue
+		}
+
+		for _, deal := range deals {
+			if paid[deal.ID] {
+				continue
+			}
+			if err := s.expireIfOverdue(ctx, deal); err != nil {
+				s.log.Error("expire payment failed", "deal_id", deal.ID, "error", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// scanPayments handles the transactions of address that follow its cursor,
+// oldest first, moving the cursor past each one, and returns the pending
+// deals confirmed on the way. A transaction that fails to be handled stops
+// the scan and is retried on the next poll.
+func (s *svc) scanPayments(
+	ctx context.Context,
+	address string,
+	pending []*entity.Deal,
+) (map[uuid.UUID]bool, error) {
+	cursor, err := s.cursorRepo.Get(ctx, address)
+	if err != nil && !errors.Is(err, dto.ErrNotFound) {
+		return nil, fmt.Errorf("get cursor: %w", err)
+	}
+
+	// an address seen for the first time is read back to its oldest
+	// pending deal; earlier transfers cannot pay for anything
+	since := time.Now()
+	for _, deal := range pending {
+		if deal.CreatedAt.Before(since) {
+			since = deal.CreatedAt
+		}
+	}
+	since = since.Truncate(time.Second)
+
+	var head *dto.TONTransaction
+	var fresh []dto.TONTransaction
+	if err := s.scanTransactions(ctx, address, func(tx *dto.TONTransaction) bool {
+		if head == nil {
+			head = tx
+		}
+		if cursor != nil {
+			if !ltAfter(tx.LT, cursor.LT) {
+				return false
+			}
+		} else if tx.Time.Before(since) {
+			return false
+		}
+		fresh = append(fresh, *tx)
+		return true
+	}); err != nil {
+		return nil, fmt.Errorf("get transactions: %w", err)
+	}
+
+	paid := make(map[uuid.UUID]bool)
+	for i := len(fresh) - 1; i >= 0; i-- {
+		tx := &fresh[i]
+		if err := s.handlePayment(ctx, address, tx, pending, paid); err != nil {
+			return nil, fmt.Errorf("handle transaction %s: %w", tx.Hash, err)
+		}
+		if err := s.cursorRepo.Save(ctx, address, tx.LT, tx.Hash); err != nil {
+			return nil, fmt.Errorf("save cursor: %w", err)
+		}
+	}
+
+	if cursor == nil && len(fresh) == 0 && head != nil {
+		if err := s.cursorRepo.Save(ctx, address, head.LT, head.Hash); err != nil {
+			return nil, fmt.Errorf("save cursor: %w", err)
+		}
+	}
+
+	return paid, nil
+}
+
+// handlePayment confirms the deal an incoming transfer pays for, if any. A
+// transfer that names a deal by its memo but does not pay for it is returned
+// to its sender, as is whatever a payment carries over the price.
+func (s *svc) handlePayment(
+	ctx context.Context,
+	address string,
+	tx *dto.TONTransaction,
+	pending []*entity.Deal,
+	paid map[uuid.UUID]bool,
+) error {
+	if tx.In == nil {
+		return nil
+	}
+
+	deal, err := s.findPaidDeal(ctx, address, tx, pending, paid)
+	if err != nil || deal == nil {
+		return err
+	}
+
+	if !pays(tx, deal) {
+		text := fmt.Sprintf(
+			"A transfer for deal %s did not cover its price. "+
+				"It will be returned to the wallet it was sent from.",
+			deal.ID,
+		)
+		return s.returnPayment(ctx, deal, tx, tx.In.ValueNanoTON, "underpaid", text)
+	}
+
+	err = s.deals.ConfirmPayment(ctx, deal.ID, tx.Hash, tx.In.Source, tx.Time)
+	if errors.Is(err, dto.ErrInvalidTransition) {
+		return s.refundLatePayment(ctx, deal, tx)
+	}
+	if err != nil {
+		return fmt.Errorf("confirm payment: %w", err)
+	}
+	paid[deal.ID] = true
+
+	return s.returnExcess(ctx, deal, tx)
+}
+
+// returnExcess returns what a payment carried over the deal's price.
+func (s *svc) returnExcess(ctx context.Context, deal *entity.Deal, tx *dto.TONTransaction) error {
+	excess := tx.In.ValueNanoTON - deal.PriceNanoTON
+	if excess <= 0 {
+		return nil
+	}
+
+	text := fmt.Sprintf(
+		"Your payment for deal %s was more than its price. "+
+			"The difference will be returned to the wallet it was sent from.",
+		deal.ID,
+	)
+	return s.returnPayment(ctx, deal, tx, excess, "overpaid", text)
+}
+
+// returnPayment queues a transfer of amount back to the sender of tx. The
+// transfer's comment names tx, so handling tx again queues nothing new.
+func (s *svc) returnPayment(
+	ctx context.Context,
+	deal *entity.Deal,
+	tx *dto.TONTransaction,
+	amount int64,
+	reason, text string,
+) error {
+	if amount < minReturnNanoTON || tx.In.Source == "" {
+		s.log.Error("payment cannot be returned, manual handling required",
+			"deal_id", deal.ID,
+			"reason", reason,
+			"tx_hash", tx.Hash,
+			"source", tx.In.Source,
+			"amount", amount)
+		return nil
+	}
+
+	if err := s.transferRepo.Create(ctx, &entity.Transfer{
+		DealID:        deal.ID,
+		Kind:          entity.TransferKindReturn,
+		Destination:   tx.In.Source,
+		AmountNanoTON: amount,
+		Comment:       fmt.Sprintf("Return of %s for deal %s", tx.Hash, deal.ID),
+	}); err != nil {
+		return fmt.Errorf("queue return: %w", err)
+	}
+
+	s.log.Warn("payment returned",
+		"deal_id", deal.ID,
+		"reason", reason,
+		"tx_hash", tx.Hash,
+		"source", tx.In.Source,
+		"amount", amount)
+
+	if err := s.notifier.Notify(ctx, deal.AdvertiserID, text); err != nil {
+		s.log.Warn("failed to notify advertiser", "deal_id", deal.ID, "error", err)
+	}
+
+	return nil
+}
+
+// refundLatePayment returns a payment that reached a deal no longer awaiting
+// it, such as one that was cancelled or expired while the transfer was on
+// its way.
+func (s *svc) refundLatePayment(
+	ctx context.Context,
+	deal *entity.Deal,
+	tx *dto.TONTransaction,
+) error {
+	err := s.deals.RefundLatePayment(ctx, deal.ID, tx.Hash, tx.In.Source, tx.Time)
+	if errors.Is(err, dto.ErrInvalidTransition) {
+		return s.returnSecondPayment(ctx, deal.ID, tx)
+	}
+	if err != nil {
+		return fmt.Errorf("refund late payment: %w", err)
+	}
+
+	text := fmt.Sprintf(
+		"A payment for deal %s arrived after the deal was closed. "+
+			"It will be returned to the wallet it was sent from.",
+		deal.ID,
+	)
+	if err := s.notifier.Notify(ctx, deal.AdvertiserID, text); err != nil {
+		s.log.Warn("failed to notify advertiser", "deal_id", deal.ID, "error", err)
+	}
+
+	// the refund covers the price only
+	return s.returnExcess(ctx, deal, tx)
+}
+
+// returnSecondPayment handles a payment for a deal that was already paid.
+// If tx is the payment on record, it was handled before and only its excess
+// is due; any other payment is returned in full.
+func (s *svc) returnSecondPayment(
+	ctx context.Context,
+	dealID uuid.UUID,
+	tx *dto.TONTransaction,
+) error {
+	deal, err := s.dealRepo.GetByID(ctx, dealID)
+	if err != nil {
+		return fmt.Errorf("get deal: %w", err)
+	}
+	if deal.PaymentTxHash != nil && *deal.PaymentTxHash == tx.Hash {
+		return s.returnExcess(ctx, deal, tx)
+	}
+
+	text := fmt.Sprintf(
+		"Deal %s has already been paid for. "+
+			"Your latest transfer will be returned to the wallet it was sent from.",
+		deal.ID,
+	)
+	return s.returnPayment(ctx, deal, tx, tx.In.ValueNanoTON, "already paid", text)
+}
+
+// findPaidDeal matches a transfer by its memo, or else to the oldest unpaid
+// memo-less deal on the address it pays for. A memo match is returned even if
+// the transfer falls short, so that it can be sent back; a memo-less transfer
+// cannot be told apart from unrelated ones and must cover the full price.
+func (s *svc) findPaidDeal(
+	ctx context.Context,
+	address string,
+	tx *dto.TONTransaction,
+	pending []*entity.Deal,
+	paid map[uuid.UUID]bool,
+) (*entity.Deal, error) {
+	if memo := strings.ToUpper(strings.TrimSpace(tx.In.Comment)); memo != "" {
+		deal, err := s.dealRepo.GetByEscrowMemo(ctx, memo)
+		if err != nil && !errors.Is(err, dto.ErrNotFound) {
+			return nil, fmt.Errorf("get deal by memo: %w", err)
+		}
+		if err == nil && deal.EscrowWalletAddress != nil &&
+			*deal.EscrowWalletAddress == address {
+			return deal, nil
+		}
+	}
+
+	for _, deal := range pending {
+		if deal.EscrowMemo == nil && !paid[deal.ID] && pays(tx, deal) {
+			return deal, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// pays reports whether tx was made after the deal was created and covers its
+// full price.
+func pays(tx *dto.TONTransaction, deal *entity.Deal) bool {
+	return tx.In.ValueNanoTON >= deal.PriceNanoTON &&
+		!tx.Time.Before(deal.CreatedAt.Truncate(time.Second))
+}
+
+// expireIfOverdue drops a deal whose payment deadline has passed. It runs only
+// after the deal's transactions were checked, so a payment that landed before
+// the deadline always wins.
+func (s *svc) expireIfOverdue(ctx context.Context, deal *entity.Deal) error {
+	if deal.PaymentExpiresAt == nil || time.Now().Before(*deal.PaymentExpiresAt) {
+		return nil
+	}
+
+	err := s.deals.ExpirePayment(ctx, deal.ID)
+	if errors.Is(err, dto.ErrInvalidTransition) {
+		// cancelled or paid since it was read
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("expire payment: %w", err)
+	}
+
+	text := fmt.Sprintf(
+		"Your deal %s was cancelled: no payment arrived by %s UTC. "+
+			"Create a new deal to book the slot again.",
+		deal.ID, deal.PaymentExpiresAt.UTC().Format("2006-01-02 15:04"),
+	)
+	if err := s.notifier.Notify(ctx, deal.AdvertiserID, text); err != nil {
+		// the deal is already closed; a lost message must not retry the expiry
+		s.log.Warn("failed to notify advertiser", "deal_id", deal.ID, "error", err)
+	}
+
+	return nil
+}
+
+// ltAfter reports whether logical time a is later than b.
+func ltAfter(a, b string) bool {
+	an, errA := strconv.ParseUint(a, 10, 64)
+	bn, errB := strconv.ParseUint(b, 10, 64)
+	return errA == nil && errB == nil && an > bn
+}
+
+// scanTransactions walks the history of address from the newest transaction
+// back, page by page, until visit returns false or the history ends.
+func (s *svc) scanTransactions(
+	ctx context.Context,
+	address string,
+	visit func(tx *dto.TONTransaction) bool,
+) error {
+	var before *dto.TONTxID
+	for {
+		txs, err := s.ton.GetTransactions(ctx, address, transactionsLimit, before)
+		if err != nil {
+			return err
+		}
+		for i := range txs {
+			if !visit(&txs[i]) {
+				return nil
+			}
+		}
+		if len(txs) < transactionsLimit {
+			return nil
+		}
+		last := &txs[len(txs)-1]
+		before = &dto.TONTxID{LT: last.LT, Hash: last.Hash}
+	}
+}
@@ -0,0 +1,101 @@
+package escrow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+// postPayout moves the publisher's share out of escrow: the transferred amount
+// to the channel owner and the fee withheld from it to the platform.
+func (s *svc) postPayout(ctx context.Context, deal *entity.Deal, t *entity.Transfer) error {
+	// the owner may have left the channel since; the leg is kept unattributed
+	var owner *uuid.UUID
+	ownerID, err := s.channelRepo.GetOwnerID(ctx, deal.ChannelID)
+	if err != nil && !errors.Is(err, dto.ErrNotFound) {
+		return fmt.Errorf("get channel owner: %w", err)
+	}
+	if err == nil {
+		owner = &ownerID
+	}
+
+	if t.AmountNanoTON > 0 {
+		if err := s.ledgerRepo.Post(ctx, escrowLegs(
+			deal.ID, entity.LedgerEntryRelease, entity.LedgerAccountPublisher,
+			owner, t.AmountNanoTON,
+		)); err != nil {
+			return fmt.Errorf("post release: %w", err)
+		}
+	}
+
+	if t.FeeNanoTON > 0 {
+		if err := s.ledgerRepo.Post(ctx, escrowLegs(
+			deal.ID, entity.LedgerEntryFee, entity.LedgerAccountPlatform, nil, t.FeeNanoTON,
+		)); err != nil {
+			return fmt.Errorf("post fee: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// postRefund moves the refunded amount out of escrow back to the advertiser.
+func (s *svc) postRefund(ctx context.Context, deal *entity.Deal, t *entity.Transfer) error {
+	if err := s.ledgerRepo.Post(ctx, escrowLegs(
+		deal.ID, entity.LedgerEntryRefund, entity.LedgerAccountAdvertiser,
+		&deal.AdvertiserID, t.AmountNanoTON,
+	)); err != nil {
+		return fmt.Errorf("post refund: %w", err)
+	}
+	return nil
+}
+
+// escrowLegs is a posting that moves amount out of the deal's escrow into
+// the given account.
+func escrowLegs(
+	dealID uuid.UUID,
+	kind entity.LedgerEntryKind,
+	account entity.LedgerAccount,
+	userID *uuid.UUID,
+	amount int64,
+) []entity.LedgerEntry {
+	return []entity.LedgerEntry{
+		{
+			DealID:        dealID,
+			Kind:          kind,
+			Account:       entity.LedgerAccountEscrow,
+			AmountNanoTON: -amount,
+		},
+		{
+			DealID:        dealID,
+			Kind:          kind,
+			Account:       account,
+			UserID:        userID,
+			AmountNanoTON: amount,
+		},
+	}
+}
+
+// ReconcileLedger checks the escrow balance every deal has in the ledger
+// against its payment and the transfers confirmed on-chain. Mismatches need
+// manual review and are only reported.
+func (s *svc) ReconcileLedger(ctx context.Context) error {
+	discrepancies, err := s.ledgerRepo.GetDiscrepancies(ctx)
+	if err != nil {
+		return fmt.Errorf("get ledger discrepancies: %w", err)
+	}
+
+	for _, d := range discrepancies {
+		s.log.Error("escrow balance does not match the ledger",
+			"deal_id", d.DealID,
+			"ledger_nano_ton", d.LedgerNanoTON,
+			"expected_nano_ton", d.ExpectedNanoTON)
+	}
+
+	return nil
+}
@@ -0,0 +1,382 @@
+package escrow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+const (
+	// wallet v4 accepts at most four outgoing messages per external message
+	transfersPerMessage = 4
+	// how long a broadcast message stays executable on-chain
+	transferTTL = time.Minute
+	// extra wait for the indexer before an unconfirmed transfer is considered lost
+	confirmGrace = 2 * time.Minute
+	// a deal's platform fee is in basis points
+	bpsDenominator = 10000
+	// outbox messages consumed per tick
+	outboxBatch = 50
+)
+
+// ProcessTransfers queues refunds from the outbox and payouts for completed
+// deals, and drives queued transfers to confirmation. It never broadcasts
+// while a previous message is unresolved: every message is bound to a seqno
+// and expires at valid_until, so a transfer is resent only once its earlier
+// attempt provably cannot land.
+func (s *svc) ProcessTransfers(ctx context.Context) error {
+	if err := s.queueRefunds(ctx); err != nil {
+		return fmt.Errorf("queue refunds: %w", err)
+	}
+
+	if err := s.queuePayouts(ctx); err != nil {
+		return fmt.Errorf("queue payouts: %w", err)
+	}
+
+	inFlight, err := s.confirmSent(ctx)
+	if err != nil {
+		return fmt.Errorf("confirm sent transfers: %w", err)
+	}
+	if inFlight {
+		return nil
+	}
+
+	if err := s.sendPending(ctx); err != nil {
+		return fmt.Errorf("send pending transfers: %w", err)
+	}
+
+	return nil
+}
+
+// queueRefunds turns refund events into transfers. The transfer is created and
+// the event acknowledged in one transaction, and a deal has at most one refund,
+// so a crash at any point neither loses nor duplicates a refund.
+func (s *svc) queueRefunds(ctx context.Context) error {
+	msgs, err := s.outboxRepo.GetUnprocessed(ctx, entity.OutboxEventRefund, outboxBatch)
+	if err != nil {
+		return fmt.Errorf("get refund events: %w", err)
+	}
+
+	// a broken event stays unprocessed and is retried, without holding up the rest
+	for i := range msgs {
+		if err := s.queueRefund(ctx, &msgs[i]); err != nil {
+			s.log.Error("queue refund failed",
+				"deal_id", msgs[i].DealID,
+				"event_id", msgs[i].ID,
+				"error", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *svc) queueRefund(ctx context.Context, msg *entity.OutboxMessage) error {
+	deal, err := s.dealRepo.GetByID(ctx, msg.DealID)
+	if err != nil {
+		return fmt.Errorf("get deal: %w", err)
+	}
+	// refund to the wallet the payment came from; the advertiser's linked
+	// wallet is only a fallback for payments recorded without a sender
+	destination := deal.PayerAddress
+	if destination == nil {
+		destination = deal.AdvertiserWalletAddress
+	}
+	if destination == nil {
+		s.log.Warn("refunded deal has no payer or advertiser wallet", "deal_id", deal.ID)
+		return nil
+	}
+
+	// a resolved dispute refunds only what the publisher was not awarded
+	amount := deal.PriceNanoTON
+	if deal.PublisherShareNanoTON != nil {
+		amount -= *deal.PublisherShareNanoTON
+	}
+
+	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		if err := s.transferRepo.Create(txCtx, &entity.Transfer{
+			DealID:        deal.ID,
+			Kind:          entity.TransferKindRefund,
+			Destination:   *destination,
+			AmountNanoTON: amount,
+			Comment:       fmt.Sprintf("Refund for deal %s", deal.ID),
+		}); err != nil {
+			return err
+		}
+		return s.outboxRepo.MarkProcessed(txCtx, msg.ID)
+	}); err != nil {
+		return fmt.Errorf("create refund: %w", err)
+	}
+
+	s.log.Info("refund queued", "deal_id", deal.ID, "status", deal.Status)
+	return nil
+}
+
+func (s *svc) queuePayouts(ctx context.Context) error {
+	deals, err := s.dealRepo.GetAwaitingPayout(ctx)
+	if err != nil {
+		return fmt.Errorf("get deals awaiting payout: %w", err)
+	}
+
+	for i := range deals {
+		if err := s.queuePayout(ctx, &deals[i]); err != nil {
+			s.log.Error("queue payout failed", "deal_id", deals[i].ID, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// queuePayout creates the payout transfer of a completed or resolved deal. A
+// deal booked before the channel owner linked a wallet is paid to the owner's
+// current wallet; until there is one, the deal carries a payout error.
+func (s *svc) queuePayout(ctx context.Context, deal *entity.Deal) error {
+	if deal.PayoutWalletAddress == nil {
+		wallet, err := s.channelRepo.GetOwnerWalletAddress(ctx, deal.ChannelID)
+		if err != nil && !errors.Is(err, dto.ErrNotFound) {
+			return fmt.Errorf("get owner wallet: %w", err)
+		}
+		if wallet == nil {
+			if deal.PayoutError != nil {
+				return nil
+			}
+			s.log.Warn("deal awaiting payout has no payout wallet", "deal_id", deal.ID)
+			return s.dealRepo.RecordPayoutFailure(ctx, deal.ID,
+				"channel owner has not linked a payout wallet")
+		}
+		if err := s.dealRepo.SetPayoutWallet(ctx, deal.ID, *wallet); err != nil {
+			return fmt.Errorf("set payout wallet: %w", err)
+		}
+		deal.PayoutWalletAddress = wallet
+	}
+
+	// a resolved dispute pays out only the publisher's share
+	gross := deal.PriceNanoTON
+	if deal.PublisherShareNanoTON != nil {
+		gross = *deal.PublisherShareNanoTON
+	}
+
+	fee := gross * deal.PlatformFeeBPS / bpsDenominator
+	payout := &entity.Transfer{
+		DealID:        deal.ID,
+		Kind:          entity.TransferKindPayout,
+		Destination:   *deal.PayoutWalletAddress,
+		AmountNanoTON: gross - fee,
+		FeeNanoTON:    fee,
+		Comment:       fmt.Sprintf("Payout for deal %s", deal.ID),
+	}
+	if payout.AmountNanoTON <= 0 {
+		return s.settleFee(ctx, deal, payout)
+	}
+	if err := s.transferRepo.Create(ctx, payout); err != nil {
+		return fmt.Errorf("create payout: %w", err)
+	}
+
+	s.log.Info("payout queued", "deal_id", deal.ID, "fee_nano_ton", fee)
+	return nil
+}
+
+// settleFee closes the payout of a deal whose fee, frozen at 100% before fees
+// were capped, takes all of it. A zero-amount message cannot be sent, so the
+// payout is recorded as settled and only the fee is posted to the ledger.
+func (s *svc) settleFee(ctx context.Context, deal *entity.Deal, payout *entity.Transfer) error {
+	payout.AmountNanoTON = 0
+	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		if err := s.transferRepo.CreateSettled(txCtx, payout); err != nil {
+			return err
+		}
+		return s.postPayout(txCtx, deal, payout)
+	}); err != nil {
+		return fmt.Errorf("settle fee: %w", err)
+	}
+
+	s.log.Info("payout taken by fee, nothing to send",
+		"deal_id", deal.ID,
+		"fee_nano_ton", payout.FeeNanoTON)
+	return nil
+}
+
+// confirmSent reports whether any transfer is still awaiting its transaction.
+func (s *svc) confirmSent(ctx context.Context) (bool, error) {
+	sent, err := s.transferRepo.GetByStatus(ctx, entity.TransferStatusSent, transfersPerMessage)
+	if err != nil {
+		return false, fmt.Errorf("get sent transfers: %w", err)
+	}
+	if len(sent) == 0 {
+		return false, nil
+	}
+
+	// every transfer executes before its valid_until, so history older than
+	// the earliest send (with a margin for clock skew) cannot hold a match
+	since := *sent[0].ValidUntil
+	pending := make(map[string]bool, len(sent))
+	for i := range sent {
+		if sent[i].ValidUntil.Before(since) {
+			since = *sent[i].ValidUntil
+		}
+		pending[sent[i].Comment] = true
+	}
+	since = since.Add(-transferTTL - confirmGrace)
+
+	found := make(map[string]string, len(sent))
+	if err := s.scanTransactions(ctx, s.cfg.EscrowWalletAddress,
+		func(tx *dto.TONTransaction) bool {
+			if tx.Time.Before(since) {
+				return false
+			}
+			for _, m := range tx.Out {
+				if pending[m.Comment] && found[m.Comment] == "" {
+					found[m.Comment] = tx.Hash
+				}
+			}
+			return len(found) < len(pending)
+		},
+	); err != nil {
+		return false, fmt.Errorf("get escrow transactions: %w", err)
+	}
+
+	inFlight := false
+	for i := range sent {
+		t := &sent[i]
+
+		if hash := found[t.Comment]; hash != "" {
+			if err := s.confirm(ctx, t, hash); err != nil {
+				return false, fmt.Errorf("confirm transfer %s: %w", t.ID, err)
+			}
+			continue
+		}
+
+		if time.Now().Before(t.ValidUntil.Add(confirmGrace)) {
+			inFlight = true
+			continue
+		}
+
+		if err := s.expire(ctx, t); err != nil {
+			return false, fmt.Errorf("expire transfer %s: %w", t.ID, err)
+		}
+	}
+
+	return inFlight, nil
+}
+
+// confirm records the transaction of a transfer and posts the funds it moved
+// out of escrow to the ledger.
+func (s *svc) confirm(ctx context.Context, t *entity.Transfer, txHash string) error {
+	deal, err := s.dealRepo.GetByID(ctx, t.DealID)
+	if err != nil {
+		return fmt.Errorf("get deal: %w", err)
+	}
+
+	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		if err := s.transferRepo.MarkConfirmed(txCtx, t.ID, txHash); err != nil {
+			return err
+		}
+		switch t.Kind {
+		case entity.TransferKindPayout:
+			if err := s.dealRepo.SetReleaseTxHash(txCtx, t.DealID, txHash); err != nil {
+				return err
+			}
+			return s.postPayout(txCtx, deal, t)
+		case entity.TransferKindRefund:
+			if err := s.dealRepo.SetRefundTxHash(txCtx, t.DealID, txHash); err != nil {
+				return err
+			}
+			return s.postRefund(txCtx, deal, t)
+		case entity.TransferKindReturn:
+			// returned funds never reached the deal's escrow balance
+			return nil
+		default:
+			return fmt.Errorf("unknown transfer kind %q", t.Kind)
+		}
+	}); err != nil {
+		return err
+	}
+
+	s.log.Info("transfer confirmed",
+		"deal_id", t.DealID,
+		"kind", t.Kind,
+		"tx_hash", txHash)
+	return nil
+}
+
+// expire handles a transfer whose message can no longer execute. If the
+// wallet seqno has not moved, the message was never applied and the transfer
+// is requeued. If it moved but no matching transaction is visible, the outcome
+// is unknown and the transfer is parked for manual reconciliation.
+func (s *svc) expire(ctx context.Context, t *entity.Transfer) error {
+	seqno, err := s.ton.GetSeqno(ctx, s.cfg.EscrowWalletAddress)
+	if err != nil {
+		return fmt.Errorf("get seqno: %w", err)
+	}
+
+	if seqno <= *t.Seqno {
+		s.log.Warn("transfer expired, requeueing", "deal_id", t.DealID, "kind", t.Kind)
+		return s.transferRepo.Reset(ctx, t.ID, "message expired before execution")
+	}
+
+	s.log.Error("transfer outcome unknown, manual reconciliation required",
+		"deal_id", t.DealID,
+		"kind", t.Kind,
+		"seqno", *t.Seqno)
+	return s.transferRepo.MarkFailed(ctx, t.ID,
+		"seqno consumed but no matching transaction found")
+}
+
+func (s *svc) sendPending(ctx context.Context) error {
+	pending, err := s.transferRepo.GetByStatus(
+		ctx, entity.TransferStatusPending, transfersPerMessage,
+	)
+	if err != nil {
+		return fmt.Errorf("get pending transfers: %w", err)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	seqno, err := s.ton.GetSeqno(ctx, s.cfg.EscrowWalletAddress)
+	if err != nil {
+		return fmt.Errorf("get seqno: %w", err)
+	}
+	validUntil := time.Now().Add(transferTTL).Truncate(time.Second)
+
+	transfers := make([]dto.TONTransfer, len(pending))
+	for i := range pending {
+		transfers[i] = dto.TONTransfer{
+			Destination:   pending[i].Destination,
+			AmountNanoTON: pending[i].AmountNanoTON,
+			Comment:       pending[i].Comment,
+		}
+	}
+
+	// persist the attempt before broadcasting, so a crash in between leaves
+	// the transfers in sent state and they are not broadcast twice
+	if err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		for i := range pending {
+			err := s.transferRepo.MarkSent(txCtx, pending[i].ID, seqno, validUntil)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("mark sent: %w", err)
+	}
+
+	if err := s.ton.SendTransfers(ctx, seqno, validUntil, transfers); err != nil {
+		// the message may still have been accepted; confirmSent settles it
+		return fmt.Errorf("send transfers: %w", err)
+	}
+
+	for i := range pending {
+		s.log.Info("transfer sent",
+			"deal_id", pending[i].DealID,
+			"kind", pending[i].Kind,
+			"amount_nano_ton", pending[i].AmountNanoTON,
+			"seqno", seqno)
+	}
+
+	return nil
+}
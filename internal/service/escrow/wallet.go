@@ -0,0 +1,43 @@
+package escrow
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+)
+
+// memoBytes gives 10-character memos; uniqueness is enforced by the database.
+const memoBytes = 6
+
+var memoEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// wallet provisions deposits on the shared platform escrow wallet. Deals are
+// told apart by a unique transfer memo, so no per-deal key material is stored:
+// the only secret is the platform wallet key, which lives in the environment.
+type wallet struct {
+	address string
+}
+
+func NewWallet(address string) *wallet {
+	return &wallet{address: address}
+}
+
+func (w *wallet) Provision(_ context.Context) (*dto.EscrowDeposit, error) {
+	if w.address == "" {
+		return nil, errors.New("escrow wallet address is not configured")
+	}
+
+	b := make([]byte, memoBytes)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("generate memo: %w", err)
+	}
+
+	return &dto.EscrowDeposit{
+		Address: w.address,
+		Memo:    memoEncoding.EncodeToString(b),
+	}, nil
+}
@@ -0,0 +1,205 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	tele "gopkg.in/telebot.v4"
+
+	"github.com/bpva/ad-marketplace/internal/config"
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+	"github.com/bpva/ad-marketplace/internal/logx"
+)
+
+// messages relayed per tick
+const relayBatch = 50
+
+type MessageRepository interface {
+	GetUnrelayed(ctx context.Context, limit int) ([]entity.DealMessage, error)
+	CreateRelay(ctx context.Context, relay *entity.DealMessageRelay) error
+	MarkRelayed(ctx context.Context, id uuid.UUID) error
+}
+
+type DealRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Deal, error)
+}
+
+type ChannelRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Channel, error)
+	GetRolesByChannelID(ctx context.Context, channelID uuid.UUID) ([]entity.ChannelRole, error)
+}
+
+type UserRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error)
+}
+
+type SettingsRepository interface {
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*entity.UserSettings, error)
+}
+
+type Sender interface {
+	Send(to tele.Recipient, what any, opts ...any) (*tele.Message, error)
+}
+
+type svc struct {
+	cfg          config.Telegram
+	messageRepo  MessageRepository
+	dealRepo     DealRepository
+	channelRepo  ChannelRepository
+	userRepo     UserRepository
+	settingsRepo SettingsRepository
+	bot          Sender
+	log          *slog.Logger
+}
+
+func New(
+	cfg config.Telegram,
+	messageRepo MessageRepository,
+	dealRepo DealRepository,
+	channelRepo ChannelRepository,
+	userRepo UserRepository,
+	settingsRepo SettingsRepository,
+	bot Sender,
+	log *slog.Logger,
+) *svc {
+	log = log.With(logx.Service("MessagingService"))
+	return &svc{
+		cfg:          cfg,
+		messageRepo:  messageRepo,
+		dealRepo:     dealRepo,
+		channelRepo:  channelRepo,
+		userRepo:     userRepo,
+		settingsRepo: settingsRepo,
+		bot:          bot,
+		log:          log,
+	}
+}
+
+// RelayMessages forwards new deal messages to the other side of each deal:
+// the advertiser, or everyone on the channel's team.
+func (s *svc) RelayMessages(ctx context.Context) error {
+	msgs, err := s.messageRepo.GetUnrelayed(ctx, relayBatch)
+	if err != nil {
+		return fmt.Errorf("get unrelayed messages: %w", err)
+	}
+
+	for i := range msgs {
+		msg := &msgs[i]
+		if err := s.relay(ctx, msg); err != nil {
+			s.log.Error("failed to relay message",
+				"deal_id", msg.DealID,
+				"message_id", msg.ID,
+				"error", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *svc) relay(ctx context.Context, msg *entity.DealMessage) error {
+	deal, err := s.dealRepo.GetByID(ctx, msg.DealID)
+	if err != nil {
+		return fmt.Errorf("get deal: %w", err)
+	}
+
+	channel, err := s.channelRepo.GetByID(ctx, deal.ChannelID)
+	if err != nil {
+		return fmt.Errorf("get channel: %w", err)
+	}
+
+	recipients, err := s.recipients(ctx, deal, msg.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	author, err := s.userRepo.GetByID(ctx, msg.AuthorID)
+	if err != nil {
+		return fmt.Errorf("get author: %w", err)
+	}
+
+	text := fmt.Sprintf(
+		"%s wrote about the ad in %s:\n\n%s\n\nReply to this message to answer.",
+		author.Name, channel.Title, msg.Text,
+	)
+	menu := &tele.ReplyMarkup{}
+	menu.Inline(menu.Row(menu.WebApp("Open deal", &tele.WebApp{URL: s.dealURL(deal.ID)})))
+
+	for _, userID := range recipients {
+		if err := s.send(ctx, msg, userID, text, menu); err != nil {
+			// one unreachable recipient must not resend the message to the rest
+			s.log.Warn("failed to forward message",
+				"message_id", msg.ID,
+				"user_id", userID,
+				"error", err)
+		}
+	}
+
+	if err := s.messageRepo.MarkRelayed(ctx, msg.ID); err != nil {
+		return fmt.Errorf("mark relayed: %w", err)
+	}
+
+	return nil
+}
+
+// recipients returns the users on the side of the deal the author is not on.
+func (s *svc) recipients(
+	ctx context.Context, deal *entity.Deal, authorID uuid.UUID,
+) ([]uuid.UUID, error) {
+	if authorID != deal.AdvertiserID {
+		return []uuid.UUID{deal.AdvertiserID}, nil
+	}
+
+	roles, err := s.channelRepo.GetRolesByChannelID(ctx, deal.ChannelID)
+	if err != nil {
+		return nil, fmt.Errorf("get channel roles: %w", err)
+	}
+
+	ids := make([]uuid.UUID, len(roles))
+	for i := range roles {
+		ids[i] = roles[i].UserID
+	}
+	return ids, nil
+}
+
+// send forwards the message to the user unless they turned notifications off,
+// and remembers the bot message so replies to it reach the deal.
+func (s *svc) send(
+	ctx context.Context,
+	msg *entity.DealMessage,
+	userID uuid.UUID,
+	text string,
+	menu *tele.ReplyMarkup,
+) error {
+	settings, err := s.settingsRepo.GetByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, dto.ErrNotFound) {
+		return fmt.Errorf("get settings: %w", err)
+	}
+	if settings != nil && !settings.ReceiveNotifications {
+		return nil
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+
+	sent, err := s.bot.Send(tele.ChatID(user.TgID), text, menu)
+	if err != nil {
+		return fmt.Errorf("send message: %w", err)
+	}
+
+	return s.messageRepo.CreateRelay(ctx, &entity.DealMessageRelay{
+		ChatID:      user.TgID,
+		TgMessageID: sent.ID,
+		MessageID:   msg.ID,
+	})
+}
+
+func (s *svc) dealURL(dealID uuid.UUID) string {
+	return fmt.Sprintf("%s/deals/%s", strings.TrimSuffix(s.cfg.MiniAppURL, "/"), dealID)
+}
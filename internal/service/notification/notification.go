@@ -0,0 +1,72 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	tele "gopkg.in/telebot.v4"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+	"github.com/bpva/ad-marketplace/internal/logx"
+)
+
+type UserRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error)
+}
+
+type SettingsRepository interface {
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*entity.UserSettings, error)
+}
+
+type Sender interface {
+	Send(to tele.Recipient, what any, opts ...any) (*tele.Message, error)
+}
+
+type svc struct {
+	userRepo     UserRepository
+	settingsRepo SettingsRepository
+	bot          Sender
+	log          *slog.Logger
+}
+
+func New(
+	userRepo UserRepository,
+	settingsRepo SettingsRepository,
+	bot Sender,
+	log *slog.Logger,
+) *svc {
+	log = log.With(logx.Service("NotificationService"))
+	return &svc{
+		userRepo:     userRepo,
+		settingsRepo: settingsRepo,
+		bot:          bot,
+		log:          log,
+	}
+}
+
+// Notify sends a bot message to the user unless they turned notifications
+// off. Users who never opened the settings get notified.
+func (s *svc) Notify(ctx context.Context, userID uuid.UUID, text string) error {
+	settings, err := s.settingsRepo.GetByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, dto.ErrNotFound) {
+		return fmt.Errorf("get settings: %w", err)
+	}
+	if settings != nil && !settings.ReceiveNotifications {
+		return nil
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+
+	if _, err := s.bot.Send(tele.ChatID(user.TgID), text); err != nil {
+		return fmt.Errorf("send notification: %w", err)
+	}
+
+	return nil
+}
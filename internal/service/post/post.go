@@ -98,56 +98,97 @@ func (s *svc) SendPreview(ctx context.Context, postID uuid.UUID) error {
 		return fmt.Errorf("send preview: %w", dto.ErrForbidden)
 	}
 
-	recipient := tele.ChatID(user.TgID)
+	posts := []entity.Post{*post}
+	if post.MediaGroupID != nil {
+		posts, err = s.postRepo.GetByMediaGroupID(ctx, *post.MediaGroupID)
+		if err != nil {
+			return fmt.Errorf("get album posts: %w", err)
+		}
+	}
+
+	if _, err := s.send(tele.ChatID(user.TgID), posts); err != nil {
+		return fmt.Errorf("send preview: %w", err)
+	}
+	return nil
+}
+
+// PublishAd posts an ad version to the channel and returns the ids of the
+// sent messages. There is no user in context; the caller owns authorization.
+func (s *svc) PublishAd(
+	ctx context.Context,
+	tgChannelID int64,
+	posts []entity.Post,
+) ([]int64, error) {
+	msgs, err := s.send(tele.ChatID(tgChannelID), posts)
+	if err != nil {
+		return nil, fmt.Errorf("publish ad: %w", err)
+	}
+
+	ids := make([]int64, len(msgs))
+	for i := range msgs {
+		ids[i] = int64(msgs[i].ID)
+	}
+	return ids, nil
+}
+
+// send delivers a single post or, if the posts share a media group, an album.
+func (s *svc) send(to tele.Recipient, posts []entity.Post) ([]tele.Message, error) {
+	if len(posts) == 0 {
+		return nil, dto.ErrNotFound
+	}
 
+	post := &posts[0]
 	if post.MediaGroupID != nil {
-		return s.sendAlbumPreview(ctx, post, recipient)
+		return s.sendAlbum(posts, to)
 	}
+
+	var msg *tele.Message
+	var err error
 	if post.MediaType != nil {
-		return s.sendMediaPreview(post, recipient)
+		msg, err = s.sendMedia(post, to)
+	} else {
+		msg, err = s.sendText(post, to)
+	}
+	if err != nil {
+		return nil, err
 	}
-	return s.sendTextPreview(post, recipient)
+	return []tele.Message{*msg}, nil
 }
 
-func (s *svc) sendTextPreview(post *entity.Post, to tele.Recipient) error {
+func (s *svc) sendText(post *entity.Post, to tele.Recipient) (*tele.Message, error) {
 	if post.Text == nil {
-		return fmt.Errorf("send text preview: %w", dto.ErrNotFound)
+		return nil, fmt.Errorf("send text: %w", dto.ErrNotFound)
 	}
 
 	var opts []any
 	if sendOpts := buildSendOptions(post.Entities); sendOpts != nil {
 		opts = append(opts, sendOpts)
 	}
-	_, err := s.bot.Send(to, *post.Text, opts...)
+	msg, err := s.bot.Send(to, *post.Text, opts...)
 	if err != nil {
-		return fmt.Errorf("send text preview: %w", err)
+		return nil, fmt.Errorf("send text: %w", err)
 	}
-	return nil
+	return msg, nil
 }
 
-func (s *svc) sendMediaPreview(post *entity.Post, to tele.Recipient) error {
+func (s *svc) sendMedia(post *entity.Post, to tele.Recipient) (*tele.Message, error) {
 	media := buildSendable(post)
 	if media == nil {
-		return fmt.Errorf("send media preview: %w", dto.ErrNotFound)
+		return nil, fmt.Errorf("send media: %w", dto.ErrNotFound)
 	}
 
 	var opts []any
 	if sendOpts := buildSendOptions(post.Entities); sendOpts != nil {
 		opts = append(opts, sendOpts)
 	}
-	_, err := s.bot.Send(to, media, opts...)
+	msg, err := s.bot.Send(to, media, opts...)
 	if err != nil {
-		return fmt.Errorf("send media preview: %w", err)
+		return nil, fmt.Errorf("send media: %w", err)
 	}
-	return nil
+	return msg, nil
 }
 
-func (s *svc) sendAlbumPreview(ctx context.Context, post *entity.Post, to tele.Recipient) error {
-	posts, err := s.postRepo.GetByMediaGroupID(ctx, *post.MediaGroupID)
-	if err != nil {
-		return fmt.Errorf("get album posts: %w", err)
-	}
-
+func (s *svc) sendAlbum(posts []entity.Post, to tele.Recipient) ([]tele.Message, error) {
 	var caption string
 	var captionEntities []tele.MessageEntity
 	for _, p := range posts {
@@ -175,18 +216,18 @@ func (s *svc) sendAlbumPreview(ctx context.Context, post *entity.Post, to tele.R
 	}
 
 	if len(album) == 0 {
-		return fmt.Errorf("send album preview: %w", dto.ErrNotFound)
+		return nil, fmt.Errorf("send album: %w", dto.ErrNotFound)
 	}
 
 	var opts []any
 	if len(captionEntities) > 0 {
 		opts = append(opts, &tele.SendOptions{Entities: captionEntities})
 	}
-	_, err = s.bot.SendAlbum(to, album, opts...)
+	msgs, err := s.bot.SendAlbum(to, album, opts...)
 	if err != nil {
-		return fmt.Errorf("send album preview: %w", err)
+		return nil, fmt.Errorf("send album: %w", err)
 	}
-	return nil
+	return msgs, nil
 }
 
 func buildSendOptions(rawEntities []byte) *tele.SendOptions {
@@ -0,0 +1,57 @@
+package publisher
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+// DeleteExpired removes auto-delete ads from their channels once the feed
+// window is over. Only completed deals are picked up: the verifier has
+// already settled them, so the deletion cannot be mistaken for a violation.
+func (s *svc) DeleteExpired(ctx context.Context) error {
+	deals, err := s.dealRepo.GetAwaitingDeletion(ctx)
+	if err != nil {
+		return fmt.Errorf("get deals awaiting deletion: %w", err)
+	}
+
+	for i := range deals {
+		deal := &deals[i]
+		err := s.delete(ctx, deal)
+		if err == nil {
+			continue
+		}
+
+		if !isPermanent(err) {
+			s.log.Warn("failed to delete ad, will retry", "deal_id", deal.ID, "error", err)
+			continue
+		}
+
+		s.log.Error("failed to delete ad, giving up", "deal_id", deal.ID, "error", err)
+		if err := s.dealRepo.RecordDeleteFailure(ctx, deal.ID, err.Error()); err != nil {
+			return fmt.Errorf("record delete failure of deal %s: %w", deal.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *svc) delete(ctx context.Context, deal *entity.Deal) error {
+	channel, err := s.channelRepo.GetByID(ctx, deal.ChannelID)
+	if err != nil {
+		return fmt.Errorf("get channel: %w", err)
+	}
+
+	if err := s.deleter.DeleteMessages(channel.TgChannelID, deal.PostedMessageIDs); err != nil {
+		return err
+	}
+
+	if err := s.dealRepo.SetDeletedAt(ctx, deal.ID, time.Now()); err != nil {
+		return fmt.Errorf("set deleted at: %w", err)
+	}
+
+	s.log.Info("ad deleted", "deal_id", deal.ID, "feed_hours", deal.FeedHours)
+	return nil
+}
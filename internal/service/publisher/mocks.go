@@ -0,0 +1,106 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/bpva/ad-marketplace/internal/service/publisher (interfaces: Pinner,Deleter)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks.go -package=publisher . Pinner,Deleter
+//
+
+// Package publisher is a generated GoMock package.
+package publisher
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPinner is a mock of Pinner interface.
+type MockPinner struct {
+	ctrl     *gomock.Controller
+	recorder *MockPinnerMockRecorder
+	isgomock struct{}
+}
+
+// MockPinnerMockRecorder is the mock recorder for MockPinner.
+type MockPinnerMockRecorder struct {
+	mock *MockPinner
+}
+
+// NewMockPinner creates a new mock instance.
+func NewMockPinner(ctrl *gomock.Controller) *MockPinner {
+	mock := &MockPinner{ctrl: ctrl}
+	mock.recorder = &MockPinnerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPinner) EXPECT() *MockPinnerMockRecorder {
+	return m.recorder
+}
+
+// Pin mocks base method.
+func (m *MockPinner) Pin(chatID int64, messageID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pin", chatID, messageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Pin indicates an expected call of Pin.
+func (mr *MockPinnerMockRecorder) Pin(chatID, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pin", reflect.TypeOf((*MockPinner)(nil).Pin), chatID, messageID)
+}
+
+// Unpin mocks base method.
+func (m *MockPinner) Unpin(chatID int64, messageID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unpin", chatID, messageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unpin indicates an expected call of Unpin.
+func (mr *MockPinnerMockRecorder) Unpin(chatID, messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unpin", reflect.TypeOf((*MockPinner)(nil).Unpin), chatID, messageID)
+}
+
+// MockDeleter is a mock of Deleter interface.
+type MockDeleter struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeleterMockRecorder
+	isgomock struct{}
+}
+
+// MockDeleterMockRecorder is the mock recorder for MockDeleter.
+type MockDeleterMockRecorder struct {
+	mock *MockDeleter
+}
+
+// NewMockDeleter creates a new mock instance.
+func NewMockDeleter(ctrl *gomock.Controller) *MockDeleter {
+	mock := &MockDeleter{ctrl: ctrl}
+	mock.recorder = &MockDeleterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeleter) EXPECT() *MockDeleterMockRecorder {
+	return m.recorder
+}
+
+// DeleteMessages mocks base method.
+func (m *MockDeleter) DeleteMessages(chatID int64, messageIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMessages", chatID, messageIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMessages indicates an expected call of DeleteMessages.
+func (mr *MockDeleterMockRecorder) DeleteMessages(chatID, messageIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessages", reflect.TypeOf((*MockDeleter)(nil).DeleteMessages), chatID, messageIDs)
+}
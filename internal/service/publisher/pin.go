@@ -0,0 +1,110 @@
+package publisher
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bpva/ad-marketplace/internal/entity"
+)
+
+// UpdatePins pins freshly posted ads for their top hours and unpins those
+// whose top hours are over. Pin and unpin times are recorded on the deal, so
+// the verifier can tell an early unpin by the publisher from our own.
+func (s *svc) UpdatePins(ctx context.Context) error {
+	if err := s.pinPosted(ctx); err != nil {
+		return fmt.Errorf("pin posted ads: %w", err)
+	}
+
+	if err := s.unpinExpired(ctx); err != nil {
+		return fmt.Errorf("unpin expired ads: %w", err)
+	}
+
+	return nil
+}
+
+func (s *svc) pinPosted(ctx context.Context) error {
+	deals, err := s.dealRepo.GetAwaitingPin(ctx)
+	if err != nil {
+		return fmt.Errorf("get deals awaiting pin: %w", err)
+	}
+
+	for i := range deals {
+		deal := &deals[i]
+		err := s.pin(ctx, deal)
+		if err == nil {
+			continue
+		}
+
+		if !isPermanent(err) {
+			s.log.Warn("failed to pin ad, will retry", "deal_id", deal.ID, "error", err)
+			continue
+		}
+
+		s.log.Error("failed to pin ad", "deal_id", deal.ID, "error", err)
+		reason := fmt.Sprintf("ad could not be pinned: %v", err)
+		if err := s.deals.OpenDispute(ctx, deal.ID, reason); err != nil {
+			return fmt.Errorf("open dispute for deal %s: %w", deal.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *svc) pin(ctx context.Context, deal *entity.Deal) error {
+	channel, err := s.channelRepo.GetByID(ctx, deal.ChannelID)
+	if err != nil {
+		return fmt.Errorf("get channel: %w", err)
+	}
+
+	// an album is pinned by its first message
+	if err := s.pinner.Pin(channel.TgChannelID, int(deal.PostedMessageIDs[0])); err != nil {
+		return err
+	}
+
+	if err := s.dealRepo.SetPinnedAt(ctx, deal.ID, time.Now()); err != nil {
+		return fmt.Errorf("set pinned at: %w", err)
+	}
+
+	s.log.Info("ad pinned", "deal_id", deal.ID, "top_hours", deal.TopHours)
+	return nil
+}
+
+func (s *svc) unpinExpired(ctx context.Context) error {
+	deals, err := s.dealRepo.GetAwaitingUnpin(ctx)
+	if err != nil {
+		return fmt.Errorf("get deals awaiting unpin: %w", err)
+	}
+
+	for i := range deals {
+		deal := &deals[i]
+		if err := s.unpin(ctx, deal); err != nil {
+			s.log.Warn("failed to unpin ad", "deal_id", deal.ID, "error", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *svc) unpin(ctx context.Context, deal *entity.Deal) error {
+	channel, err := s.channelRepo.GetByID(ctx, deal.ChannelID)
+	if err != nil {
+		return fmt.Errorf("get channel: %w", err)
+	}
+
+	err = s.pinner.Unpin(channel.TgChannelID, int(deal.PostedMessageIDs[0]))
+	if err != nil && !isPermanent(err) {
+		return err
+	}
+	if err != nil {
+		// the message is gone or already unpinned; nothing is left to undo
+		s.log.Warn("ad could not be unpinned", "deal_id", deal.ID, "error", err)
+	}
+
+	if err := s.dealRepo.SetUnpinnedAt(ctx, deal.ID, time.Now()); err != nil {
+		return fmt.Errorf("set unpinned at: %w", err)
+	}
+
+	s.log.Info("ad unpinned", "deal_id", deal.ID)
+	return nil
+}
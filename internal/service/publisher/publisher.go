@@ -0,0 +1,245 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	tele "gopkg.in/telebot.v4"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+	"github.com/bpva/ad-marketplace/internal/logx"
+)
+
+// transient failures (network, flood control) are retried on every tick
+// until a deal runs out of attempts; then the deal is failed and refunded
+const maxPublishAttempts = 5
+
+// a claimed deal that is neither posted nor released after this long belongs
+// to a worker that died mid-send; it is retried, at the risk of a double post
+const publishClaimTimeout = 10 * time.Minute
+
+type DealRepository interface {
+	ClaimDueForPosting(
+		ctx context.Context, maxAttempts int, staleAfter time.Duration, skip []uuid.UUID,
+	) (*entity.Deal, error)
+	RecordPublishFailure(ctx context.Context, id uuid.UUID, reason string, attempts int) error
+	GetAwaitingPin(ctx context.Context) ([]entity.Deal, error)
+	GetAwaitingUnpin(ctx context.Context) ([]entity.Deal, error)
+	SetPinnedAt(ctx context.Context, id uuid.UUID, pinnedAt time.Time) error
+	SetUnpinnedAt(ctx context.Context, id uuid.UUID, unpinnedAt time.Time) error
+	GetAwaitingDeletion(ctx context.Context) ([]entity.Deal, error)
+	SetDeletedAt(ctx context.Context, id uuid.UUID, deletedAt time.Time) error
+	RecordDeleteFailure(ctx context.Context, id uuid.UUID, reason string) error
+}
+
+type ChannelRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Channel, error)
+}
+
+type PostRepository interface {
+	GetLatestAd(ctx context.Context, dealID uuid.UUID) ([]entity.Post, error)
+}
+
+type AdPublisher interface {
+	PublishAd(ctx context.Context, tgChannelID int64, posts []entity.Post) ([]int64, error)
+}
+
+//go:generate mockgen -destination=mocks.go -package=publisher . Pinner,Deleter
+type Pinner interface {
+	Pin(chatID int64, messageID int) error
+	Unpin(chatID int64, messageID int) error
+}
+
+type Deleter interface {
+	DeleteMessages(chatID int64, messageIDs []int64) error
+}
+
+type DealService interface {
+	MarkPosted(
+		ctx context.Context,
+		dealID uuid.UUID,
+		messageIDs []int64,
+		postedAt time.Time,
+	) error
+	OpenDispute(ctx context.Context, dealID uuid.UUID, reason string) error
+	FailPublish(ctx context.Context, dealID uuid.UUID, reason string) error
+}
+
+type Transactor interface {
+	WithTx(ctx context.Context, f func(ctx context.Context) error) error
+}
+
+type svc struct {
+	dealRepo    DealRepository
+	channelRepo ChannelRepository
+	postRepo    PostRepository
+	ads         AdPublisher
+	pinner      Pinner
+	deleter     Deleter
+	deals       DealService
+	tx          Transactor
+	log         *slog.Logger
+}
+
+func New(
+	dealRepo DealRepository,
+	channelRepo ChannelRepository,
+	postRepo PostRepository,
+	ads AdPublisher,
+	pinner Pinner,
+	deleter Deleter,
+	deals DealService,
+	tx Transactor,
+	log *slog.Logger,
+) *svc {
+	log = log.With(logx.Service("PublisherService"))
+	return &svc{
+		dealRepo:    dealRepo,
+		channelRepo: channelRepo,
+		postRepo:    postRepo,
+		ads:         ads,
+		pinner:      pinner,
+		deleter:     deleter,
+		deals:       deals,
+		tx:          tx,
+		log:         log,
+	}
+}
+
+// PublishDue posts the latest ad version of every approved deal whose
+// scheduled time has arrived, one deal at a time. Each deal is claimed in its
+// own short transaction before its ad is sent and marked posted right after,
+// so no row lock is held across Telegram calls and a crash or a failing deal
+// cannot undo the deals already posted. A failing deal does not block the
+// others; its error is stored on the deal so both parties can see why the ad
+// is not live.
+func (s *svc) PublishDue(ctx context.Context) error {
+	// every deal is attempted at most once per run
+	var tried []uuid.UUID
+	for ctx.Err() == nil {
+		deal, err := s.dealRepo.ClaimDueForPosting(
+			ctx, maxPublishAttempts, publishClaimTimeout, tried,
+		)
+		if errors.Is(err, dto.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("claim deal due for posting: %w", err)
+		}
+		tried = append(tried, deal.ID)
+
+		if err := s.publish(ctx, deal); err != nil {
+			// the outcome must be stored even if the run is being shut down
+			err := s.recordFailure(context.WithoutCancel(ctx), deal, err)
+			if err != nil {
+				s.log.Error("failed to record publish failure",
+					"deal_id", deal.ID,
+					"error", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *svc) publish(ctx context.Context, deal *entity.Deal) error {
+	channel, err := s.channelRepo.GetByID(ctx, deal.ChannelID)
+	if err != nil {
+		return fmt.Errorf("get channel: %w", err)
+	}
+
+	posts, err := s.postRepo.GetLatestAd(ctx, deal.ID)
+	if err != nil {
+		return fmt.Errorf("get latest ad: %w", err)
+	}
+
+	messageIDs, err := s.ads.PublishAd(ctx, channel.TgChannelID, posts)
+	if err != nil {
+		return err
+	}
+
+	// record the post even if the run is being shut down
+	err = s.deals.MarkPosted(context.WithoutCancel(ctx), deal.ID, messageIDs, time.Now())
+	if err != nil {
+		// the ad is already live; retrying would post it a second time
+		return &postedError{messageIDs: messageIDs, err: err}
+	}
+
+	return nil
+}
+
+// recordFailure stores the publish error on the deal. Once retrying cannot
+// help, the deal is failed and refunded, unless the ad already went live: that
+// one stays approved with its error for an operator to reconcile.
+func (s *svc) recordFailure(ctx context.Context, deal *entity.Deal, err error) error {
+	attempts := deal.PublishAttempts + 1
+	if isPermanent(err) {
+		attempts = maxPublishAttempts
+	}
+
+	return s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		reason := err.Error()
+		if err := s.dealRepo.RecordPublishFailure(txCtx, deal.ID, reason, attempts); err != nil {
+			return err
+		}
+
+		if attempts < maxPublishAttempts {
+			s.log.Warn("failed to publish ad, will retry",
+				"deal_id", deal.ID,
+				"attempt", attempts,
+				"error", err)
+			return nil
+		}
+
+		var posted *postedError
+		if errors.As(err, &posted) {
+			s.log.Error("ad posted but deal not updated",
+				"deal_id", deal.ID,
+				"message_ids", posted.messageIDs,
+				"error", err)
+			return nil
+		}
+
+		s.log.Error("failed to publish ad, giving up",
+			"deal_id", deal.ID,
+			"attempt", attempts,
+			"error", err)
+		return s.deals.FailPublish(txCtx, deal.ID, reason)
+	})
+}
+
+type postedError struct {
+	messageIDs []int64
+	err        error
+}
+
+func (e *postedError) Error() string {
+	return fmt.Sprintf("ad posted as messages %v but deal not updated: %v", e.messageIDs, e.err)
+}
+
+func (e *postedError) Unwrap() error {
+	return e.err
+}
+
+// isPermanent reports whether retrying cannot help: the ad is already posted,
+// the deal or its content is gone, or Telegram refused the request itself
+// (e.g. the bot lost the right to post or was removed from the channel).
+func isPermanent(err error) bool {
+	var posted *postedError
+	if errors.As(err, &posted) || errors.Is(err, dto.ErrNotFound) {
+		return true
+	}
+
+	var tgErr *tele.Error
+	if errors.As(err, &tgErr) {
+		return tgErr.Code == http.StatusBadRequest || tgErr.Code == http.StatusForbidden
+	}
+
+	return false
+}
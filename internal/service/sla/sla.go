@@ -0,0 +1,137 @@
+package sla
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bpva/ad-marketplace/internal/config"
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+	"github.com/bpva/ad-marketplace/internal/logx"
+)
+
+type DealRepository interface {
+	GetReviewOverdue(
+		ctx context.Context,
+		defaultTimeout, cutoff time.Duration,
+	) ([]entity.Deal, error)
+}
+
+type RevisionRepository interface {
+	GetLatest(ctx context.Context, dealID uuid.UUID) (*entity.AdRevision, error)
+}
+
+type DealService interface {
+	ExpireReview(ctx context.Context, dealID uuid.UUID, reason string) error
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, userID uuid.UUID, text string) error
+}
+
+type svc struct {
+	cfg          config.Deal
+	dealRepo     DealRepository
+	revisionRepo RevisionRepository
+	deals        DealService
+	notifier     Notifier
+	log          *slog.Logger
+}
+
+func New(
+	cfg config.Deal,
+	dealRepo DealRepository,
+	revisionRepo RevisionRepository,
+	deals DealService,
+	notifier Notifier,
+	log *slog.Logger,
+) *svc {
+	log = log.With(logx.Service("SLAService"))
+	return &svc{
+		cfg:          cfg,
+		dealRepo:     dealRepo,
+		revisionRepo: revisionRepo,
+		deals:        deals,
+		notifier:     notifier,
+		log:          log,
+	}
+}
+
+// ExpireReviews cancels deals that waited in review past their channel's
+// timeout or got too close to their slot, so the advertiser is refunded
+// instead of waiting on a side that never answers.
+func (s *svc) ExpireReviews(ctx context.Context) error {
+	deals, err := s.dealRepo.GetReviewOverdue(ctx, s.cfg.ReviewTimeout, s.cfg.ReviewCutoff)
+	if err != nil {
+		return fmt.Errorf("get overdue reviews: %w", err)
+	}
+
+	now := time.Now()
+	for i := range deals {
+		deal := &deals[i]
+		if err := s.expire(ctx, deal, now); err != nil {
+			s.log.Error("failed to expire review", "deal_id", deal.ID, "error", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *svc) expire(ctx context.Context, deal *entity.Deal, now time.Time) error {
+	reason, err := s.expiryReason(ctx, deal, now.Add(s.cfg.ReviewCutoff))
+	if err != nil {
+		return err
+	}
+
+	err = s.deals.ExpireReview(ctx, deal.ID, reason)
+	if errors.Is(err, dto.ErrInvalidTransition) {
+		// the review finished between the query and now
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("expire review: %w", err)
+	}
+
+	text := fmt.Sprintf(
+		"Your deal %s was cancelled: %s. Any payment will be refunded to your wallet.",
+		deal.ID, reason,
+	)
+	if err := s.notifier.Notify(ctx, deal.AdvertiserID, text); err != nil {
+		// the deal is already closed; a lost message must not retry the expiry
+		s.log.Warn("failed to notify advertiser", "deal_id", deal.ID, "error", err)
+	}
+
+	return nil
+}
+
+// expiryReason explains which deadline was missed; it ends up in the
+// publisher note, so it must make sense to both parties. A deal under review
+// may be waiting on either side: the publisher to review the ad, or the
+// advertiser to confirm the publisher's edit.
+func (s *svc) expiryReason(
+	ctx context.Context,
+	deal *entity.Deal,
+	cutoff time.Time,
+) (string, error) {
+	if !deal.ScheduledAt.After(cutoff) {
+		return "review was not finished before the scheduled time", nil
+	}
+	if deal.Status == entity.DealStatusChangesRequested {
+		return "requested changes were not submitted in time", nil
+	}
+
+	// a deal without revisions only has the advertiser's original ad
+	latest, err := s.revisionRepo.GetLatest(ctx, deal.ID)
+	if err != nil && !errors.Is(err, dto.ErrNotFound) {
+		return "", fmt.Errorf("get latest revision: %w", err)
+	}
+	if err == nil && latest.ApprovedBy(entity.DealPartyPublisher) {
+		return "advertiser did not confirm the publisher's changes in time", nil
+	}
+	return "publisher did not review the ad in time", nil
+}
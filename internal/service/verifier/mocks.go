@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/bpva/ad-marketplace/internal/service/verifier (interfaces: TelegramClient)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks.go -package=verifier . TelegramClient
+//
+
+// Package verifier is a generated GoMock package.
+package verifier
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/bpva/ad-marketplace/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTelegramClient is a mock of TelegramClient interface.
+type MockTelegramClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockTelegramClientMockRecorder
+	isgomock struct{}
+}
+
+// MockTelegramClientMockRecorder is the mock recorder for MockTelegramClient.
+type MockTelegramClientMockRecorder struct {
+	mock *MockTelegramClient
+}
+
+// NewMockTelegramClient creates a new mock instance.
+func NewMockTelegramClient(ctrl *gomock.Controller) *MockTelegramClient {
+	mock := &MockTelegramClient{ctrl: ctrl}
+	mock.recorder = &MockTelegramClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTelegramClient) EXPECT() *MockTelegramClientMockRecorder {
+	return m.recorder
+}
+
+// GetChannelMessages mocks base method.
+func (m *MockTelegramClient) GetChannelMessages(ctx context.Context, channelID int64, ids []int64) ([]dto.ChannelMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelMessages", ctx, channelID, ids)
+	ret0, _ := ret[0].([]dto.ChannelMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelMessages indicates an expected call of GetChannelMessages.
+func (mr *MockTelegramClientMockRecorder) GetChannelMessages(ctx, channelID, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelMessages", reflect.TypeOf((*MockTelegramClient)(nil).GetChannelMessages), ctx, channelID, ids)
+}
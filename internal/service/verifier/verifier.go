@@ -0,0 +1,184 @@
+package verifier
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bpva/ad-marketplace/internal/dto"
+	"github.com/bpva/ad-marketplace/internal/entity"
+	"github.com/bpva/ad-marketplace/internal/gateway/mtproto"
+	"github.com/bpva/ad-marketplace/internal/logx"
+)
+
+type DealRepository interface {
+	GetByStatus(ctx context.Context, status entity.DealStatus) ([]entity.Deal, error)
+}
+
+type ChannelRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Channel, error)
+}
+
+type SnapshotRepository interface {
+	Create(ctx context.Context, snap *entity.DealViewSnapshot) error
+}
+
+//go:generate mockgen -destination=mocks.go -package=verifier . TelegramClient
+type TelegramClient interface {
+	GetChannelMessages(
+		ctx context.Context,
+		channelID int64,
+		ids []int64,
+	) ([]dto.ChannelMessage, error)
+}
+
+type DealService interface {
+	Complete(ctx context.Context, dealID uuid.UUID) error
+	OpenDispute(ctx context.Context, dealID uuid.UUID, reason string) error
+}
+
+type svc struct {
+	dealRepo     DealRepository
+	channelRepo  ChannelRepository
+	snapshotRepo SnapshotRepository
+	tg           TelegramClient
+	deals        DealService
+	log          *slog.Logger
+}
+
+func New(
+	dealRepo DealRepository,
+	channelRepo ChannelRepository,
+	snapshotRepo SnapshotRepository,
+	tg TelegramClient,
+	deals DealService,
+	log *slog.Logger,
+) *svc {
+	log = log.With(logx.Service("VerifierService"))
+	return &svc{
+		dealRepo:     dealRepo,
+		channelRepo:  channelRepo,
+		snapshotRepo: snapshotRepo,
+		tg:           tg,
+		deals:        deals,
+		log:          log,
+	}
+}
+
+// VerifyPosted enforces the feed window of posted deals: an ad that was
+// deleted, edited or unpinned early sends the deal to dispute, one that
+// survived the whole window completes it. Each check also records how many
+// views the ad has gathered so far.
+func (s *svc) VerifyPosted(ctx context.Context) error {
+	deals, err := s.dealRepo.GetByStatus(ctx, entity.DealStatusPosted)
+	if err != nil {
+		return fmt.Errorf("get posted deals: %w", err)
+	}
+
+	for i := range deals {
+		deal := &deals[i]
+		if err := s.verify(ctx, deal); err != nil {
+			// a channel we cannot read must not block the rest
+			s.log.Error("failed to verify deal", "deal_id", deal.ID, "error", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *svc) verify(ctx context.Context, deal *entity.Deal) error {
+	if deal.PostedAt == nil || len(deal.PostedMessageIDs) == 0 {
+		return fmt.Errorf("posted deal has no messages")
+	}
+
+	channel, err := s.channelRepo.GetByID(ctx, deal.ChannelID)
+	if err != nil {
+		return fmt.Errorf("get channel: %w", err)
+	}
+
+	// taken before the check, so a window that ends while we wait for
+	// telegram is not counted as passed
+	now := time.Now()
+
+	msgs, err := s.tg.GetChannelMessages(
+		ctx, mtproto.BotAPIToMTProto(channel.TgChannelID), deal.PostedMessageIDs,
+	)
+	if err != nil {
+		return fmt.Errorf("get channel messages: %w", err)
+	}
+
+	if snap, ok := snapshot(deal.ID, msgs); ok {
+		if err := s.snapshotRepo.Create(ctx, snap); err != nil {
+			// a missing point on the views curve must not hold up the deal
+			s.log.Warn("failed to store view snapshot", "deal_id", deal.ID, "error", err)
+		}
+	}
+
+	windowEnd := deal.PostedAt.Add(time.Duration(deal.FeedHours) * time.Hour)
+	if reason := violation(deal, msgs, now, windowEnd); reason != "" {
+		return s.deals.OpenDispute(ctx, deal.ID, reason)
+	}
+
+	if now.Before(windowEnd) {
+		return nil
+	}
+
+	return s.deals.Complete(ctx, deal.ID)
+}
+
+// violation describes the first way the posted ad no longer matches what
+// was published, or returns an empty string. A freshly sent message carries
+// no edit date, so an edit date inside the feed window means the ad was
+// changed. A deletion cannot be dated, so once the window is over a missing
+// message is taken as removed after it. While the ad is pinned by us and its
+// top hours are not over, it must stay pinned.
+func violation(
+	deal *entity.Deal,
+	msgs []dto.ChannelMessage,
+	now time.Time,
+	windowEnd time.Time,
+) string {
+	windowOver := !now.Before(windowEnd)
+	for _, m := range msgs {
+		if !m.Exists {
+			if windowOver {
+				continue
+			}
+			return fmt.Sprintf("message %d was deleted", m.ID)
+		}
+		if m.EditedAt != nil && m.EditedAt.Before(windowEnd) {
+			return fmt.Sprintf("message %d was edited at %s",
+				m.ID, m.EditedAt.UTC().Format(time.RFC3339))
+		}
+	}
+
+	if deal.PinnedAt == nil || deal.UnpinnedAt != nil || len(msgs) == 0 {
+		return ""
+	}
+	topEnd := deal.PinnedAt.Add(time.Duration(deal.TopHours) * time.Hour)
+	if now.Before(topEnd) && !msgs[0].Pinned {
+		return fmt.Sprintf("message %d was unpinned before its top hours ended", msgs[0].ID)
+	}
+	return ""
+}
+
+// snapshot sums up the ad's messages that are still in the channel. All of
+// them are seen by the same readers, so views are those of the most seen one
+// rather than a sum.
+func snapshot(dealID uuid.UUID, msgs []dto.ChannelMessage) (*entity.DealViewSnapshot, bool) {
+	snap := &entity.DealViewSnapshot{DealID: dealID}
+	found := false
+	for _, m := range msgs {
+		if !m.Exists {
+			continue
+		}
+		found = true
+		snap.Views = max(snap.Views, m.Views)
+		snap.Forwards += m.Forwards
+		snap.Reactions += m.Reactions
+	}
+	return snap, found
+}
@@ -58,8 +58,19 @@ func (d *db) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandT
 	return d.pool.Exec(ctx, sql, args...)
 }
 
+// WithTx runs f in a transaction. Called with a context that already carries
+// one, f runs in a savepoint of it, so a failing inner call rolls back only
+// its own work and the outer transaction can go on.
 func (d *db) WithTx(ctx context.Context, f func(ctx context.Context) error) error {
-	tx, err := d.pool.Begin(ctx)
+	var (
+		tx  pgx.Tx
+		err error
+	)
+	if outer, ok := ctx.Value(txCtxKey{}).(pgx.Tx); ok {
+		tx, err = outer.Begin(ctx)
+	} else {
+		tx, err = d.pool.Begin(ctx)
+	}
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,76 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/bpva/ad-marketplace/internal/logx"
+)
+
+type job struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+}
+
+type worker struct {
+	jobs []job
+	log  *slog.Logger
+}
+
+func New(log *slog.Logger) *worker {
+	return &worker{log: log.With(logx.Service("Worker"))}
+}
+
+// Every registers a job that runs once on start and then on every interval tick.
+func (w *worker) Every(name string, interval time.Duration, run func(ctx context.Context) error) {
+	w.jobs = append(w.jobs, job{name: name, interval: interval, run: run})
+}
+
+// Run blocks until ctx is cancelled and every in-flight job has returned.
+func (w *worker) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, j := range w.jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.loop(ctx, j)
+		}()
+	}
+	wg.Wait()
+}
+
+func (w *worker) loop(ctx context.Context, j job) {
+	log := w.log.With("job", j.name)
+	log.Info("job scheduled", "interval", j.interval)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		w.tick(ctx, log, j)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *worker) tick(ctx context.Context, log *slog.Logger, j job) {
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("job panic", "panic", r, "stack", string(debug.Stack()))
+		}
+	}()
+
+	if err := j.run(ctx); err != nil {
+		log.Error("job failed", "duration", time.Since(start), "error", err)
+		return
+	}
+	log.Debug("job finished", "duration", time.Since(start))
+}
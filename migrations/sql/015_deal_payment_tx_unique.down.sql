@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_deals_payment_tx_hash;
@@ -0,0 +1,2 @@
+CREATE UNIQUE INDEX idx_deals_payment_tx_hash ON deals(payment_tx_hash)
+    WHERE payment_tx_hash IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE deals DROP COLUMN escrow_memo;
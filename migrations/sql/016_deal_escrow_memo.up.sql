@@ -0,0 +1,3 @@
+ALTER TABLE deals ADD COLUMN escrow_memo TEXT;
+
+CREATE UNIQUE INDEX idx_deals_escrow_memo ON deals(escrow_memo) WHERE escrow_memo IS NOT NULL;
@@ -0,0 +1,20 @@
+CREATE TABLE transfers (
+    id UUID PRIMARY KEY,
+    deal_id UUID NOT NULL REFERENCES deals(id),
+    kind TEXT NOT NULL,
+    destination TEXT NOT NULL,
+    amount_nano_ton BIGINT NOT NULL,
+    fee_nano_ton BIGINT NOT NULL DEFAULT 0,
+    comment TEXT NOT NULL UNIQUE,
+    status TEXT NOT NULL DEFAULT 'pending',
+    seqno BIGINT,
+    valid_until TIMESTAMPTZ,
+    tx_hash TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (deal_id, kind)
+);
+
+CREATE INDEX idx_transfers_status ON transfers(status);
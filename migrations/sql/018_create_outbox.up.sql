@@ -0,0 +1,10 @@
+CREATE TABLE outbox (
+    id UUID PRIMARY KEY,
+    deal_id UUID NOT NULL REFERENCES deals(id),
+    event TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    processed_at TIMESTAMPTZ,
+    UNIQUE (deal_id, event)
+);
+
+CREATE INDEX idx_outbox_unprocessed ON outbox(event, created_at) WHERE processed_at IS NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_deals_approved_scheduled;
+
+ALTER TABLE deals
+    DROP COLUMN publish_error,
+    DROP COLUMN publish_attempts;
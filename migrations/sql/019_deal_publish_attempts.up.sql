@@ -0,0 +1,5 @@
+ALTER TABLE deals
+    ADD COLUMN publish_attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN publish_error TEXT;
+
+CREATE INDEX idx_deals_approved_scheduled ON deals(scheduled_at) WHERE status = 'approved';
@@ -0,0 +1,3 @@
+ALTER TABLE deals
+    DROP COLUMN unpinned_at,
+    DROP COLUMN pinned_at;
@@ -0,0 +1,3 @@
+ALTER TABLE deals
+    ADD COLUMN pinned_at TIMESTAMPTZ,
+    ADD COLUMN unpinned_at TIMESTAMPTZ;
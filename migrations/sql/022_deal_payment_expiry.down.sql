@@ -0,0 +1 @@
+ALTER TABLE deals DROP COLUMN payment_expires_at;
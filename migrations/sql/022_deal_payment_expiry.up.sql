@@ -0,0 +1 @@
+ALTER TABLE deals ADD COLUMN payment_expires_at TIMESTAMPTZ;
@@ -0,0 +1,5 @@
+ALTER TABLE channels DROP COLUMN review_timeout_hours;
+
+DROP INDEX idx_deals_under_review;
+
+ALTER TABLE deals DROP COLUMN status_changed_at;
@@ -0,0 +1,8 @@
+ALTER TABLE deals ADD COLUMN status_changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+UPDATE deals SET status_changed_at = updated_at;
+
+CREATE INDEX idx_deals_under_review ON deals(status_changed_at)
+    WHERE status IN ('pending_review', 'changes_requested');
+
+ALTER TABLE channels ADD COLUMN review_timeout_hours INT CHECK (review_timeout_hours > 0);
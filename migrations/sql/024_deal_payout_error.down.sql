@@ -0,0 +1 @@
+ALTER TABLE deals DROP COLUMN payout_error;
@@ -0,0 +1 @@
+ALTER TABLE deals ADD COLUMN payout_error TEXT;
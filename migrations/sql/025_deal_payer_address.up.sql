@@ -0,0 +1 @@
+ALTER TABLE deals ADD COLUMN payer_address TEXT;
@@ -0,0 +1 @@
+DROP TABLE escrow_cursors;
@@ -0,0 +1,6 @@
+CREATE TABLE escrow_cursors (
+    address TEXT PRIMARY KEY,
+    lt TEXT NOT NULL,
+    hash TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,15 @@
+CREATE TABLE ad_revisions (
+    deal_id UUID NOT NULL REFERENCES deals(id),
+    version INT NOT NULL,
+    author_id UUID NOT NULL REFERENCES users(id),
+    publisher_note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (deal_id, version)
+);
+
+INSERT INTO ad_revisions (deal_id, version, author_id, created_at)
+SELECT p.external_id, p.version, d.advertiser_id, MIN(p.created_at)
+FROM posts p
+JOIN deals d ON d.id = p.external_id
+WHERE p.type = 'ad' AND p.version IS NOT NULL
+GROUP BY p.external_id, p.version, d.advertiser_id;
@@ -0,0 +1,3 @@
+ALTER TABLE ad_revisions
+    DROP COLUMN advertiser_approved_at,
+    DROP COLUMN publisher_approved_at;
@@ -0,0 +1,14 @@
+ALTER TABLE ad_revisions
+    ADD COLUMN advertiser_approved_at TIMESTAMPTZ,
+    ADD COLUMN publisher_approved_at TIMESTAMPTZ;
+
+-- so far only advertisers wrote revisions, and an approved deal was approved
+-- on its latest one
+UPDATE ad_revisions SET advertiser_approved_at = created_at;
+
+UPDATE ad_revisions r
+SET publisher_approved_at = d.status_changed_at
+FROM deals d
+WHERE d.id = r.deal_id
+  AND d.status IN ('approved', 'publish_failed', 'posted', 'completed', 'dispute')
+  AND r.version = (SELECT MAX(version) FROM ad_revisions WHERE deal_id = r.deal_id);
@@ -0,0 +1 @@
+DROP TABLE deal_events;
@@ -0,0 +1,18 @@
+CREATE TABLE deal_events (
+    id UUID PRIMARY KEY,
+    deal_id UUID NOT NULL REFERENCES deals(id),
+    type TEXT NOT NULL,
+    actor_id UUID REFERENCES users(id),
+    from_status TEXT,
+    to_status TEXT NOT NULL,
+    note TEXT,
+    metadata JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_deal_events_deal_id ON deal_events(deal_id, created_at);
+
+-- earlier history is lost; start every existing timeline with its creation
+INSERT INTO deal_events (id, deal_id, type, actor_id, to_status, created_at)
+SELECT gen_random_uuid(), id, 'created', advertiser_id, 'pending_payment', created_at
+FROM deals;
@@ -0,0 +1,2 @@
+DROP TABLE deal_message_relays;
+DROP TABLE deal_messages;
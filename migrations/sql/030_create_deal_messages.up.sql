@@ -0,0 +1,19 @@
+CREATE TABLE deal_messages (
+    id UUID PRIMARY KEY,
+    deal_id UUID NOT NULL REFERENCES deals(id),
+    author_id UUID NOT NULL REFERENCES users(id),
+    text TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    relayed_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_deal_messages_deal_id ON deal_messages(deal_id, created_at);
+CREATE INDEX idx_deal_messages_unrelayed ON deal_messages(created_at) WHERE relayed_at IS NULL;
+
+-- bot messages that forwarded a deal message, so replies find their thread
+CREATE TABLE deal_message_relays (
+    chat_id BIGINT NOT NULL,
+    tg_message_id BIGINT NOT NULL,
+    message_id UUID NOT NULL REFERENCES deal_messages(id),
+    PRIMARY KEY (chat_id, tg_message_id)
+);
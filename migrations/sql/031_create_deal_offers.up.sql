@@ -0,0 +1,13 @@
+CREATE TABLE deal_offers (
+    id UUID PRIMARY KEY,
+    deal_id UUID NOT NULL REFERENCES deals(id),
+    author_id UUID NOT NULL REFERENCES users(id),
+    price_nano_ton BIGINT NOT NULL,
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    responded_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_deal_offers_deal_id ON deal_offers(deal_id, created_at);
+CREATE UNIQUE INDEX idx_deal_offers_pending ON deal_offers(deal_id) WHERE status = 'pending';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_deals_channel_scheduled_at;
+
+DROP TABLE deal_reschedules;
@@ -0,0 +1,14 @@
+CREATE TABLE deal_reschedules (
+    id UUID PRIMARY KEY,
+    deal_id UUID NOT NULL REFERENCES deals(id),
+    author_id UUID NOT NULL REFERENCES users(id),
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    responded_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_deal_reschedules_deal_id ON deal_reschedules(deal_id, created_at);
+CREATE UNIQUE INDEX idx_deal_reschedules_pending ON deal_reschedules(deal_id)
+    WHERE status = 'pending';
+CREATE INDEX idx_deals_channel_scheduled_at ON deals(channel_id, scheduled_at);
@@ -0,0 +1,7 @@
+CREATE TABLE channel_inventory (
+    channel_id UUID PRIMARY KEY REFERENCES channels(id),
+    max_ads_per_day INT CHECK (max_ads_per_day > 0),
+    posting_windows JSONB NOT NULL DEFAULT '[]',
+    blackout_dates DATE[] NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,3 @@
+DROP INDEX idx_deals_campaign_id;
+ALTER TABLE deals DROP COLUMN campaign_id;
+DROP TABLE campaigns;
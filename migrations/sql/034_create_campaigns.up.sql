@@ -0,0 +1,14 @@
+CREATE TABLE campaigns (
+    id UUID PRIMARY KEY,
+    advertiser_id UUID NOT NULL REFERENCES users(id),
+    name TEXT NOT NULL,
+    template_post_id UUID NOT NULL REFERENCES posts(id),
+    budget_nano_ton BIGINT NOT NULL CHECK (budget_nano_ton > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_campaigns_advertiser_id ON campaigns(advertiser_id, created_at);
+
+ALTER TABLE deals ADD COLUMN campaign_id UUID REFERENCES campaigns(id);
+
+CREATE INDEX idx_deals_campaign_id ON deals(campaign_id) WHERE campaign_id IS NOT NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE deals
+    DROP CONSTRAINT deals_publisher_share_check,
+    DROP COLUMN publisher_share_nano_ton;
+DROP TABLE dispute_evidence;
+DROP TABLE deal_disputes;
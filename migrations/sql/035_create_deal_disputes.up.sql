@@ -0,0 +1,38 @@
+CREATE TABLE deal_disputes (
+    id UUID PRIMARY KEY,
+    deal_id UUID NOT NULL UNIQUE REFERENCES deals(id),
+    reason TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'open',
+    outcome TEXT,
+    arbiter_id UUID REFERENCES users(id),
+    resolution_note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_deal_disputes_open ON deal_disputes(created_at) WHERE status = 'open';
+
+CREATE TABLE dispute_evidence (
+    id UUID PRIMARY KEY,
+    dispute_id UUID NOT NULL REFERENCES deal_disputes(id),
+    author_id UUID NOT NULL REFERENCES users(id),
+    text TEXT,
+    file_id TEXT,
+    message_link TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (text IS NOT NULL OR file_id IS NOT NULL OR message_link IS NOT NULL)
+);
+
+CREATE INDEX idx_dispute_evidence_dispute_id ON dispute_evidence(dispute_id, created_at);
+
+ALTER TABLE deals
+    ADD COLUMN publisher_share_nano_ton BIGINT,
+    ADD CONSTRAINT deals_publisher_share_check
+        CHECK (publisher_share_nano_ton BETWEEN 0 AND price_nano_ton);
+
+-- deals sent to dispute before disputes were tracked
+INSERT INTO deal_disputes (id, deal_id, reason, created_at)
+SELECT gen_random_uuid(), id, COALESCE(publisher_note, 'ad was removed or modified'),
+    status_changed_at
+FROM deals
+WHERE status = 'dispute';
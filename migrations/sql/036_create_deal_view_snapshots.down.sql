@@ -0,0 +1 @@
+DROP TABLE deal_view_snapshots;
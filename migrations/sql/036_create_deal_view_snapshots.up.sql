@@ -0,0 +1,8 @@
+CREATE TABLE deal_view_snapshots (
+    deal_id UUID NOT NULL REFERENCES deals(id),
+    taken_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    views BIGINT NOT NULL,
+    forwards BIGINT NOT NULL,
+    reactions BIGINT NOT NULL,
+    PRIMARY KEY (deal_id, taken_at)
+);
@@ -0,0 +1,225 @@
+CREATE TABLE deal_ratings (
+    id UUID PRIMARY KEY,
+    deal_id UUID NOT NULL REFERENCES deals(id),
+    author_id UUID NOT NULL REFERENCES users(id),
+    party TEXT NOT NULL,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    comment TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (deal_id, party)
+);
+
+DROP MATERIALIZED VIEW channel_marketplace;
+
+CREATE MATERIALIZED VIEW channel_marketplace AS
+SELECT
+    c.id AS channel_id,
+    c.telegram_channel_id,
+    c.title,
+    c.username,
+    c.photo_small_file_id,
+    c.photo_big_file_id,
+    COALESCE(ci.about, '') AS about,
+    ci.subscribers,
+    ci.linked_chat_id,
+    ci.languages,
+    ci.top_hours,
+    ci.reactions_by_emotion,
+    ci.story_reactions_by_emotion,
+    ci.recent_posts,
+    (
+        SELECT jsonb_agg(jsonb_build_object(
+            'id', caf.id,
+            'channel_id', caf.channel_id,
+            'format_type', caf.format_type,
+            'is_native', caf.is_native,
+            'feed_hours', caf.feed_hours,
+            'top_hours', caf.top_hours,
+            'auto_delete', caf.auto_delete,
+            'price_nano_ton', caf.price_nano_ton,
+            'created_at', caf.created_at
+        ) ORDER BY caf.created_at)
+        FROM channel_ad_formats caf
+        WHERE caf.channel_id = c.id
+    ) AS ad_formats,
+    (
+        SELECT jsonb_agg(jsonb_build_object(
+            'id', cat.id,
+            'slug', cat.slug,
+            'display_name', cat.display_name
+        ) ORDER BY cat.id)
+        FROM channel_categories cc
+        JOIN categories cat ON cat.id = cc.category_id
+        WHERE cc.channel_id = c.id
+    ) AS categories,
+    (
+        SELECT CASE WHEN COUNT(*) >= 1
+            THEN (SUM(vbs.val::bigint) / COUNT(*))::int
+            ELSE NULL END
+        FROM channel_historical_stats chs,
+            jsonb_each_text(chs.data->'views_by_source') AS vbs(key, val)
+        WHERE chs.channel_id = c.id
+            AND chs.date = CURRENT_DATE - INTERVAL '1 day'
+    ) AS avg_daily_views_1d,
+    (
+        SELECT CASE WHEN COUNT(DISTINCT chs.date) >= 7
+            THEN (SUM(vbs.val::bigint) / COUNT(DISTINCT chs.date))::int
+            ELSE NULL END
+        FROM channel_historical_stats chs,
+            jsonb_each_text(chs.data->'views_by_source') AS vbs(key, val)
+        WHERE chs.channel_id = c.id
+            AND chs.date >= CURRENT_DATE - INTERVAL '7 days'
+    ) AS avg_daily_views_7d,
+    (
+        SELECT CASE WHEN COUNT(DISTINCT chs.date) >= 7
+            THEN (SUM(vbs.val::bigint) / COUNT(DISTINCT chs.date))::int
+            ELSE NULL END
+        FROM channel_historical_stats chs,
+            jsonb_each_text(chs.data->'views_by_source') AS vbs(key, val)
+        WHERE chs.channel_id = c.id
+            AND chs.date >= CURRENT_DATE - INTERVAL '30 days'
+    ) AS avg_daily_views_30d,
+    (
+        SELECT CASE WHEN COUNT(DISTINCT chs.date) >= 7
+            THEN SUM(vbs.val::bigint)::int
+            ELSE NULL END
+        FROM channel_historical_stats chs,
+            jsonb_each_text(chs.data->'views_by_source') AS vbs(key, val)
+        WHERE chs.channel_id = c.id
+            AND chs.date >= CURRENT_DATE - INTERVAL '7 days'
+    ) AS total_views_7d,
+    (
+        SELECT CASE WHEN COUNT(DISTINCT chs.date) >= 7
+            THEN SUM(vbs.val::bigint)::int
+            ELSE NULL END
+        FROM channel_historical_stats chs,
+            jsonb_each_text(chs.data->'views_by_source') AS vbs(key, val)
+        WHERE chs.channel_id = c.id
+            AND chs.date >= CURRENT_DATE - INTERVAL '30 days'
+    ) AS total_views_30d,
+    (
+        SELECT CASE WHEN COUNT(*) >= 2
+            THEN (
+                (SELECT (chs2.data->>'subscribers')::int
+                 FROM channel_historical_stats chs2
+                 WHERE chs2.channel_id = c.id
+                     AND chs2.date >= CURRENT_DATE - INTERVAL '7 days'
+                 ORDER BY chs2.date DESC LIMIT 1)
+                -
+                (SELECT (chs3.data->>'subscribers')::int
+                 FROM channel_historical_stats chs3
+                 WHERE chs3.channel_id = c.id
+                     AND chs3.date >= CURRENT_DATE - INTERVAL '7 days'
+                 ORDER BY chs3.date ASC LIMIT 1)
+            )
+            ELSE NULL END
+        FROM channel_historical_stats chs
+        WHERE chs.channel_id = c.id
+            AND chs.date >= CURRENT_DATE - INTERVAL '7 days'
+    ) AS sub_growth_7d,
+    (
+        SELECT CASE WHEN COUNT(*) >= 2
+            THEN (
+                (SELECT (chs2.data->>'subscribers')::int
+                 FROM channel_historical_stats chs2
+                 WHERE chs2.channel_id = c.id
+                     AND chs2.date >= CURRENT_DATE - INTERVAL '30 days'
+                 ORDER BY chs2.date DESC LIMIT 1)
+                -
+                (SELECT (chs3.data->>'subscribers')::int
+                 FROM channel_historical_stats chs3
+                 WHERE chs3.channel_id = c.id
+                     AND chs3.date >= CURRENT_DATE - INTERVAL '30 days'
+                 ORDER BY chs3.date ASC LIMIT 1)
+            )
+            ELSE NULL END
+        FROM channel_historical_stats chs
+        WHERE chs.channel_id = c.id
+            AND chs.date >= CURRENT_DATE - INTERVAL '30 days'
+    ) AS sub_growth_30d,
+    (
+        SELECT CASE WHEN COUNT(DISTINCT chs.date) >= 7
+            THEN (SUM((chs.data->>'interactions')::bigint) / COUNT(DISTINCT chs.date))::int
+            ELSE NULL END
+        FROM channel_historical_stats chs
+        WHERE chs.channel_id = c.id
+            AND chs.date >= CURRENT_DATE - INTERVAL '7 days'
+            AND chs.data->>'interactions' IS NOT NULL
+    ) AS avg_interactions_7d,
+    (
+        SELECT CASE WHEN COUNT(DISTINCT chs.date) >= 7
+            THEN (SUM((chs.data->>'interactions')::bigint) / COUNT(DISTINCT chs.date))::int
+            ELSE NULL END
+        FROM channel_historical_stats chs
+        WHERE chs.channel_id = c.id
+            AND chs.date >= CURRENT_DATE - INTERVAL '30 days'
+            AND chs.data->>'interactions' IS NOT NULL
+    ) AS avg_interactions_30d,
+    (
+        SELECT CASE WHEN total_views > 0
+            THEN total_interactions::float / total_views
+            ELSE NULL END
+        FROM (
+            SELECT
+                SUM((chs.data->>'interactions')::bigint) AS total_interactions,
+                SUM(vbs.val::bigint) AS total_views
+            FROM channel_historical_stats chs,
+                jsonb_each_text(chs.data->'views_by_source') AS vbs(key, val)
+            WHERE chs.channel_id = c.id
+                AND chs.date >= CURRENT_DATE - INTERVAL '7 days'
+                AND chs.data->>'interactions' IS NOT NULL
+        ) sub
+        WHERE (
+            SELECT COUNT(DISTINCT chs2.date)
+            FROM channel_historical_stats chs2
+            WHERE chs2.channel_id = c.id
+                AND chs2.date >= CURRENT_DATE - INTERVAL '7 days'
+        ) >= 7
+    ) AS engagement_rate_7d,
+    (
+        SELECT CASE WHEN total_views > 0
+            THEN total_interactions::float / total_views
+            ELSE NULL END
+        FROM (
+            SELECT
+                SUM((chs.data->>'interactions')::bigint) AS total_interactions,
+                SUM(vbs.val::bigint) AS total_views
+            FROM channel_historical_stats chs,
+                jsonb_each_text(chs.data->'views_by_source') AS vbs(key, val)
+            WHERE chs.channel_id = c.id
+                AND chs.date >= CURRENT_DATE - INTERVAL '30 days'
+                AND chs.data->>'interactions' IS NOT NULL
+        ) sub
+        WHERE (
+            SELECT COUNT(DISTINCT chs2.date)
+            FROM channel_historical_stats chs2
+            WHERE chs2.channel_id = c.id
+                AND chs2.date >= CURRENT_DATE - INTERVAL '30 days'
+        ) >= 7
+    ) AS engagement_rate_30d,
+    (
+        SELECT ROUND(AVG(r.rating), 2)::float
+        FROM deal_ratings r
+        JOIN deals d ON d.id = r.deal_id
+        WHERE d.channel_id = c.id AND r.party = 'advertiser'
+    ) AS rating,
+    (
+        SELECT COUNT(*)::int
+        FROM deal_ratings r
+        JOIN deals d ON d.id = r.deal_id
+        WHERE d.channel_id = c.id AND r.party = 'advertiser'
+    ) AS rating_count,
+    (
+        SELECT COUNT(*)::int
+        FROM deals d
+        WHERE d.channel_id = c.id AND d.status = 'completed'
+    ) AS completed_deals
+FROM channels c
+LEFT JOIN channel_info ci ON ci.channel_id = c.id
+WHERE c.deleted_at IS NULL AND c.is_listed = true;
+
+CREATE UNIQUE INDEX idx_channel_marketplace_channel_id ON channel_marketplace(channel_id);
+CREATE INDEX idx_channel_marketplace_subscribers ON channel_marketplace(subscribers DESC NULLS LAST);
+CREATE INDEX idx_channel_marketplace_avg_views_7d ON channel_marketplace(avg_daily_views_7d DESC NULLS LAST);
+CREATE INDEX idx_channel_marketplace_rating ON channel_marketplace(rating DESC NULLS LAST);
+CREATE INDEX idx_channel_marketplace_completed_deals ON channel_marketplace(completed_deals DESC);
@@ -0,0 +1,4 @@
+DROP TABLE ledger_entries;
+DROP TABLE fee_promotions;
+ALTER TABLE deals DROP COLUMN platform_fee_bps;
+ALTER TABLE channels DROP COLUMN platform_fee_bps;
@@ -0,0 +1,82 @@
+ALTER TABLE channels ADD COLUMN platform_fee_bps INT
+    CHECK (platform_fee_bps BETWEEN 0 AND 10000);
+
+-- the fee is frozen when the deal is created; deals made before fees were
+-- tracked per deal take no fee
+ALTER TABLE deals ADD COLUMN platform_fee_bps INT NOT NULL DEFAULT 0
+    CHECK (platform_fee_bps BETWEEN 0 AND 10000);
+
+CREATE TABLE fee_promotions (
+    id UUID PRIMARY KEY,
+    channel_id UUID REFERENCES channels(id),
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    note TEXT,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_fee_promotions_ends_at ON fee_promotions(ends_at);
+
+CREATE TABLE ledger_entries (
+    id UUID PRIMARY KEY,
+    txn_id UUID NOT NULL,
+    deal_id UUID NOT NULL REFERENCES deals(id),
+    kind TEXT NOT NULL,
+    account TEXT NOT NULL,
+    user_id UUID REFERENCES users(id),
+    amount_nano_ton BIGINT NOT NULL CHECK (amount_nano_ton <> 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (deal_id, kind, account)
+);
+
+CREATE INDEX idx_ledger_entries_txn_id ON ledger_entries(txn_id);
+CREATE INDEX idx_ledger_entries_user_id ON ledger_entries(user_id) WHERE user_id IS NOT NULL;
+
+-- payments received before the ledger existed
+WITH paid AS (
+    SELECT gen_random_uuid() AS txn_id, id, advertiser_id, price_nano_ton,
+        COALESCE(paid_at, updated_at) AS at
+    FROM deals
+    WHERE payment_tx_hash IS NOT NULL
+)
+INSERT INTO ledger_entries
+    (id, txn_id, deal_id, kind, account, user_id, amount_nano_ton, created_at)
+SELECT gen_random_uuid(), paid.txn_id, paid.id, 'hold', e.account, e.user_id, e.amount, paid.at
+FROM paid
+CROSS JOIN LATERAL (VALUES
+    ('advertiser', paid.advertiser_id, -paid.price_nano_ton),
+    ('escrow', NULL::UUID, paid.price_nano_ton)
+) AS e(account, user_id, amount);
+
+-- and the transfers that already left escrow
+WITH sent AS (
+    SELECT gen_random_uuid() AS txn_id, gen_random_uuid() AS fee_txn_id,
+        t.deal_id, t.kind, t.amount_nano_ton, t.fee_nano_ton, t.updated_at AS at,
+        d.advertiser_id, cr.user_id AS owner_id
+    FROM transfers t
+    JOIN deals d ON d.id = t.deal_id
+    LEFT JOIN channel_roles cr ON cr.channel_id = d.channel_id AND cr.role = 'owner'
+    WHERE t.status = 'confirmed'
+)
+INSERT INTO ledger_entries
+    (id, txn_id, deal_id, kind, account, user_id, amount_nano_ton, created_at)
+SELECT gen_random_uuid(), e.txn_id, sent.deal_id, e.kind, e.account, e.user_id, e.amount,
+    sent.at
+FROM sent
+CROSS JOIN LATERAL (VALUES
+    (sent.txn_id, 'release', 'escrow', NULL::UUID, -sent.amount_nano_ton,
+        sent.kind = 'payout'),
+    (sent.txn_id, 'release', 'publisher', sent.owner_id, sent.amount_nano_ton,
+        sent.kind = 'payout'),
+    (sent.fee_txn_id, 'fee', 'escrow', NULL::UUID, -sent.fee_nano_ton,
+        sent.kind = 'payout' AND sent.fee_nano_ton > 0),
+    (sent.fee_txn_id, 'fee', 'platform', NULL::UUID, sent.fee_nano_ton,
+        sent.kind = 'payout' AND sent.fee_nano_ton > 0),
+    (sent.txn_id, 'refund', 'escrow', NULL::UUID, -sent.amount_nano_ton,
+        sent.kind = 'refund'),
+    (sent.txn_id, 'refund', 'advertiser', sent.advertiser_id, sent.amount_nano_ton,
+        sent.kind = 'refund')
+) AS e(txn_id, kind, account, user_id, amount, applies)
+WHERE e.applies;
@@ -0,0 +1,5 @@
+CREATE INDEX idx_deals_channel_id ON deals(channel_id);
+CREATE INDEX idx_deals_advertiser_id ON deals(advertiser_id);
+
+DROP INDEX idx_deals_channel_created;
+DROP INDEX idx_deals_advertiser_created;
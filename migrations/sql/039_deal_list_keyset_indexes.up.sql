@@ -0,0 +1,6 @@
+-- deal lists page by (created_at, id) within one advertiser or one channel
+CREATE INDEX idx_deals_advertiser_created ON deals(advertiser_id, created_at, id);
+CREATE INDEX idx_deals_channel_created ON deals(channel_id, created_at, id);
+
+DROP INDEX idx_deals_advertiser_id;
+DROP INDEX idx_deals_channel_id;
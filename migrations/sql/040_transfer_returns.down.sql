@@ -0,0 +1,5 @@
+DELETE FROM transfers WHERE kind = 'return';
+
+DROP INDEX idx_transfers_deal_kind;
+
+ALTER TABLE transfers ADD CONSTRAINT transfers_deal_id_kind_key UNIQUE (deal_id, kind);
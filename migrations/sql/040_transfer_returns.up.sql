@@ -0,0 +1,6 @@
+-- a deal can have any number of returns, one per incoming transfer; they are
+-- kept apart by their comment, which names the returned transaction
+ALTER TABLE transfers DROP CONSTRAINT transfers_deal_id_kind_key;
+
+CREATE UNIQUE INDEX idx_transfers_deal_kind ON transfers(deal_id, kind)
+    WHERE kind <> 'return';
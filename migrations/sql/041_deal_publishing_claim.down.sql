@@ -0,0 +1 @@
+ALTER TABLE deals DROP COLUMN publishing_at;
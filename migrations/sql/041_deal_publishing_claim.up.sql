@@ -0,0 +1,3 @@
+-- set when a worker claims an approved deal to post its ad; the claim is
+-- committed before the ad is sent so the deal is never picked up twice
+ALTER TABLE deals ADD COLUMN publishing_at TIMESTAMPTZ;
@@ -0,0 +1,3 @@
+ALTER TABLE channels DROP CONSTRAINT channels_platform_fee_bps_check;
+ALTER TABLE channels ADD CONSTRAINT channels_platform_fee_bps_check
+    CHECK (platform_fee_bps BETWEEN 0 AND 10000);
@@ -0,0 +1,7 @@
+-- a fee of the whole payout leaves nothing to transfer; deals already frozen
+-- at 100% are settled without a transfer
+UPDATE channels SET platform_fee_bps = 9999 WHERE platform_fee_bps > 9999;
+
+ALTER TABLE channels DROP CONSTRAINT channels_platform_fee_bps_check;
+ALTER TABLE channels ADD CONSTRAINT channels_platform_fee_bps_check
+    CHECK (platform_fee_bps BETWEEN 0 AND 9999);
@@ -0,0 +1,4 @@
+DROP INDEX idx_deals_channel_price;
+DROP INDEX idx_deals_advertiser_price;
+DROP INDEX idx_deals_channel_scheduled;
+DROP INDEX idx_deals_advertiser_scheduled;
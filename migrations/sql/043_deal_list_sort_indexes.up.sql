@@ -0,0 +1,6 @@
+-- deal lists can also be sorted by slot or price; each sort pages by
+-- (column, id) within one advertiser or one channel
+CREATE INDEX idx_deals_advertiser_scheduled ON deals(advertiser_id, scheduled_at, id);
+CREATE INDEX idx_deals_channel_scheduled ON deals(channel_id, scheduled_at, id);
+CREATE INDEX idx_deals_advertiser_price ON deals(advertiser_id, price_nano_ton, id);
+CREATE INDEX idx_deals_channel_price ON deals(channel_id, price_nano_ton, id);
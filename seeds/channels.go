@@ -108,7 +108,7 @@ func (s *Seeder) seedChannels(ctx context.Context, users []seedUser) ([]seedChan
 
 		for _, f := range d.formats {
 			if _, err := channels.CreateAdFormat(
-				ctx, ch.ID, f.typ, f.native, f.feed, f.top, f.price,
+				ctx, ch.ID, f.typ, f.native, f.feed, f.top, false, f.price,
 			); err != nil {
 				return nil, fmt.Errorf("create ad format for %s: %w", d.title, err)
 			}